@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"cfstream/internal/alias"
+	"cfstream/internal/output"
+)
+
+var aliasCmd = &cobra.Command{
+	Use:   "alias",
+	Short: "Manage local video ID aliases",
+	Long: `Manage local names for video IDs.
+
+Every command that accepts a video ID also accepts an alias, so you can use
+'cfstream link signed intro-video' instead of the 32-character UID.`,
+}
+
+var aliasSetCmd = &cobra.Command{
+	Use:   "set <name> <video-id>",
+	Short: "Create or update an alias",
+	Long:  `Create or update an alias that refers to a video ID.`,
+	Args:  cobra.ExactArgs(2),
+	RunE:  runAliasSet,
+}
+
+var aliasListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List aliases",
+	Long:  `List all local video ID aliases.`,
+	Args:  cobra.NoArgs,
+	RunE:  runAliasList,
+}
+
+var aliasRemoveCmd = &cobra.Command{
+	Use:     "remove <name>",
+	Aliases: []string{"rm"},
+	Short:   "Remove an alias",
+	Long:    `Remove a local video ID alias.`,
+	Args:    cobra.ExactArgs(1),
+	RunE:    runAliasRemove,
+}
+
+// aliasEntry is a single alias row for output.
+type aliasEntry struct {
+	Name string `json:"name" yaml:"name"`
+	UID  string `json:"uid" yaml:"uid"`
+}
+
+func init() {
+	rootCmd.AddCommand(aliasCmd)
+	aliasCmd.AddCommand(aliasSetCmd)
+	aliasCmd.AddCommand(aliasListCmd)
+	aliasCmd.AddCommand(aliasRemoveCmd)
+}
+
+func runAliasSet(cmd *cobra.Command, args []string) error {
+	name, videoID := args[0], args[1]
+
+	if err := alias.ValidateName(name); err != nil {
+		return err
+	}
+
+	aliases, err := alias.Load()
+	if err != nil {
+		return err
+	}
+
+	// Allow pointing an alias at another alias
+	aliases[name] = aliases.Resolve(videoID)
+
+	if err := alias.Save(aliases); err != nil {
+		return err
+	}
+
+	if !quiet {
+		fmt.Printf("Alias %s -> %s saved\n", name, aliases[name])
+	}
+
+	return nil
+}
+
+func runAliasList(cmd *cobra.Command, args []string) error {
+	aliases, err := alias.Load()
+	if err != nil {
+		return err
+	}
+
+	if len(aliases) == 0 {
+		if !quiet {
+			fmt.Println("No aliases defined")
+		}
+		return nil
+	}
+
+	entries := make([]aliasEntry, 0, len(aliases))
+	for _, name := range aliases.Names() {
+		entries = append(entries, aliasEntry{Name: name, UID: aliases[name]})
+	}
+
+	formatter, err := output.NewFormatter(outputFormat)
+	if err != nil {
+		return err
+	}
+
+	headers := []string{"Name", "UID"}
+	if err := formatter.FormatList(os.Stdout, headers, entries); err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+
+	return nil
+}
+
+func runAliasRemove(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	aliases, err := alias.Load()
+	if err != nil {
+		return err
+	}
+
+	if _, ok := aliases[name]; !ok {
+		return fmt.Errorf("alias not found: %s", name)
+	}
+	delete(aliases, name)
+
+	if err := alias.Save(aliases); err != nil {
+		return err
+	}
+
+	if !quiet {
+		fmt.Printf("Alias %s removed\n", name)
+	}
+
+	return nil
+}
+
+// resolveVideoID returns the video UID for an alias, or the argument unchanged
+// if it is not an alias.
+func resolveVideoID(id string) (string, error) {
+	aliases, err := alias.Load()
+	if err != nil {
+		return "", err
+	}
+	return aliases.Resolve(id), nil
+}
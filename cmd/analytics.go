@@ -0,0 +1,330 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"cfstream/internal/api"
+	"cfstream/internal/chart"
+	"cfstream/internal/output"
+	"cfstream/internal/reltime"
+)
+
+var analyticsCmd = &cobra.Command{
+	Use:   "analytics",
+	Short: "Query viewership analytics",
+	Long:  `Query viewership analytics from the Cloudflare GraphQL Analytics API.`,
+}
+
+var analyticsTopCmd = &cobra.Command{
+	Use:   "top",
+	Short: "Rank videos by views or minutes watched",
+	Long: `Rank videos by views or minutes watched over a time range and join in their
+names from the library. Use --output csv for a spreadsheet-friendly report.`,
+	Args: cobra.NoArgs,
+	RunE: runAnalyticsTop,
+}
+
+var analyticsSeriesCmd = &cobra.Command{
+	Use:   "series <video-id>",
+	Short: "Show views of a video over time",
+	Long: `Show views and minutes watched of a video per hour, day, or week, with an
+inline bar chart and sparkline of the --metric in table output.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAnalyticsSeries,
+}
+
+var analyticsViewsCmd = &cobra.Command{
+	Use:   "views <video-id>",
+	Short: "Show views of a video",
+	Long: `Show the total views and minutes watched of a video since --since, e.g.
+
+  cfstream analytics views VIDEO_ID --since 7d
+
+Use --by country to break the totals down by viewer country, most viewed
+first, limited to --limit countries.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAnalyticsViews,
+}
+
+// viewsSummary is the output of analytics views without a breakdown.
+type viewsSummary struct {
+	UID           string  `json:"uid"`
+	Name          string  `json:"name"`
+	Since         string  `json:"since"`
+	Views         int64   `json:"views"`
+	MinutesViewed float64 `json:"minutesViewed"`
+	Countries     int     `json:"countries"`
+}
+
+// seriesRow is one interval of the analytics series output.
+type seriesRow struct {
+	Time          string  `json:"time"`
+	Views         int64   `json:"views"`
+	MinutesViewed float64 `json:"minutesViewed"`
+	Chart         string  `json:"-" yaml:"-"`
+}
+
+var (
+	analyticsSince    string
+	analyticsLimit    int
+	analyticsBy       string
+	analyticsInterval string
+	analyticsMetric   string
+
+	// The views command has its own defaults, so it can't share these with top
+	analyticsViewsSince string
+	analyticsViewsBy    string
+)
+
+func init() {
+	rootCmd.AddCommand(analyticsCmd)
+	analyticsCmd.AddCommand(analyticsTopCmd)
+	analyticsCmd.AddCommand(analyticsSeriesCmd)
+	analyticsCmd.AddCommand(analyticsViewsCmd)
+
+	// Top command flags
+	analyticsTopCmd.Flags().StringVar(&analyticsSince, "since", "30d", "start of the range as a duration (e.g., 30d, 12h) or date (2006-01-02)")
+	analyticsTopCmd.Flags().IntVar(&analyticsLimit, "limit", 20, "number of videos to show")
+	analyticsTopCmd.Flags().StringVar(&analyticsBy, "by", api.OrderByViews, "rank by views or minutes")
+
+	// Series command flags
+	analyticsSeriesCmd.Flags().StringVar(&analyticsSince, "since", "30d", "start of the range as a duration (e.g., 30d, 12h) or date (2006-01-02)")
+	analyticsSeriesCmd.Flags().StringVar(&analyticsInterval, "interval", api.IntervalDay, "interval (hour, day, week)")
+	analyticsSeriesCmd.Flags().StringVar(&analyticsMetric, "metric", api.OrderByViews, "metric to chart (views or minutes)")
+
+	// Views command flags
+	analyticsViewsCmd.Flags().StringVar(&analyticsViewsSince, "since", "7d", "start of the range as a duration (e.g., 7d, 12h) or date (2006-01-02)")
+	analyticsViewsCmd.Flags().StringVar(&analyticsViewsBy, "by", "", "break down the totals by country")
+	analyticsViewsCmd.Flags().IntVar(&analyticsLimit, "limit", 20, "number of countries to show with --by country")
+}
+
+func runAnalyticsTop(cmd *cobra.Command, args []string) error {
+	if analyticsBy != api.OrderByViews && analyticsBy != api.OrderByMinutes {
+		return fmt.Errorf("invalid --by value: %s (use views or minutes)", analyticsBy)
+	}
+	if analyticsLimit <= 0 {
+		return fmt.Errorf("--limit must be positive")
+	}
+
+	since, err := parseSince(analyticsSince, time.Now())
+	if err != nil {
+		return err
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	stats, err := client.TopVideos(ctx, &api.AnalyticsOptions{
+		Since:   since,
+		Limit:   analyticsLimit,
+		OrderBy: analyticsBy,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to query analytics: %w", err)
+	}
+
+	if len(stats) == 0 {
+		if !quiet {
+			fmt.Println("No views in this time range")
+		}
+		return nil
+	}
+
+	// Analytics only knows UIDs; names come from the library
+	videos, err := listAllVideos(ctx, client, &api.ListOptions{})
+	if err != nil {
+		return err
+	}
+	names := make(map[string]string, len(videos))
+	for _, v := range videos {
+		names[v.UID] = v.Name
+	}
+	for i := range stats {
+		stats[i].Name = names[stats[i].UID]
+	}
+
+	formatter, err := output.NewFormatter(outputFormat)
+	if err != nil {
+		return err
+	}
+
+	headers := []string{"UID", "Name", "Views", "MinutesViewed"}
+	if err := formatter.FormatList(os.Stdout, headers, stats); err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+
+	return nil
+}
+
+func runAnalyticsSeries(cmd *cobra.Command, args []string) error {
+	videoID, err := resolveVideoID(args[0])
+	if err != nil {
+		return err
+	}
+
+	switch analyticsInterval {
+	case api.IntervalHour, api.IntervalDay, api.IntervalWeek:
+	default:
+		return fmt.Errorf("invalid interval: %s (use hour, day, or week)", analyticsInterval)
+	}
+	if analyticsMetric != api.OrderByViews && analyticsMetric != api.OrderByMinutes {
+		return fmt.Errorf("invalid --metric value: %s (use views or minutes)", analyticsMetric)
+	}
+
+	since, err := parseSince(analyticsSince, time.Now())
+	if err != nil {
+		return err
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	series, err := client.ViewSeries(ctx, videoID, &api.AnalyticsOptions{
+		Since:    since,
+		Interval: analyticsInterval,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to query analytics: %w", err)
+	}
+
+	values := make([]float64, len(series))
+	peak := 0.0
+	for i, p := range series {
+		values[i] = float64(p.Views)
+		if analyticsMetric == api.OrderByMinutes {
+			values[i] = p.MinutesViewed
+		}
+		peak = max(peak, values[i])
+	}
+
+	layout := "2006-01-02"
+	if analyticsInterval == api.IntervalHour {
+		layout = "2006-01-02 15:04"
+	}
+	rows := make([]seriesRow, len(series))
+	for i, p := range series {
+		rows[i] = seriesRow{
+			Time:          p.Time.Format(layout),
+			Views:         p.Views,
+			MinutesViewed: p.MinutesViewed,
+			Chart:         chart.Bar(values[i], peak, 40),
+		}
+	}
+
+	formatter, err := output.NewFormatter(outputFormat)
+	if err != nil {
+		return err
+	}
+
+	headers := []string{"Time", "Views", "MinutesViewed"}
+	if outputFormat == outputFormatTable {
+		headers = append(headers, "Chart")
+	}
+	if err := formatter.FormatList(os.Stdout, headers, rows); err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+
+	if outputFormat == outputFormatTable && !quiet {
+		fmt.Printf("%s per %s: %s\n", analyticsMetric, analyticsInterval, chart.Sparkline(values))
+	}
+
+	return nil
+}
+
+func runAnalyticsViews(cmd *cobra.Command, args []string) error {
+	if analyticsViewsBy != "" && analyticsViewsBy != "country" {
+		return fmt.Errorf("invalid --by value: %s (use country)", analyticsViewsBy)
+	}
+	if analyticsLimit <= 0 {
+		return fmt.Errorf("--limit must be positive")
+	}
+
+	videoID, err := resolveVideoID(args[0])
+	if err != nil {
+		return err
+	}
+
+	since, err := parseSince(analyticsViewsSince, time.Now())
+	if err != nil {
+		return err
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	countries, err := client.CountryViews(ctx, videoID, &api.AnalyticsOptions{Since: since})
+	if err != nil {
+		return fmt.Errorf("failed to query analytics: %w", err)
+	}
+
+	formatter, err := output.NewFormatter(outputFormat)
+	if err != nil {
+		return err
+	}
+
+	if analyticsViewsBy == "country" {
+		if len(countries) == 0 {
+			if !quiet {
+				fmt.Println("No views in this time range")
+			}
+			return nil
+		}
+		if len(countries) > analyticsLimit {
+			countries = countries[:analyticsLimit]
+		}
+		if err := formatter.FormatList(os.Stdout, []string{"Country", "Views", "MinutesViewed"}, countries); err != nil {
+			return fmt.Errorf("failed to format output: %w", err)
+		}
+		return nil
+	}
+
+	summary := viewsSummary{UID: videoID, Name: videoID, Since: since.Format("2006-01-02 15:04"), Countries: len(countries)}
+	for _, c := range countries {
+		summary.Views += c.Views
+		summary.MinutesViewed += c.MinutesViewed
+	}
+	if video, err := client.GetVideo(ctx, videoID); err == nil {
+		summary.Name = video.Name
+	}
+
+	if err := formatter.FormatSingle(os.Stdout, summary); err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+	return nil
+}
+
+// parseSince parses the start of a time range relative to now. It accepts a
+// day count such as 30d, a Go duration such as 12h, or a date (2006-01-02).
+func parseSince(value string, now time.Time) (time.Time, error) {
+	return parseTimeAgo("--since", value, now)
+}
+
+// parseTimeAgo parses the value of flag as a time before now, given as a day
+// count such as 30d, a Go duration such as 12h, or a date (2006-01-02).
+func parseTimeAgo(flag, value string, now time.Time) (time.Time, error) {
+	t, err := reltime.Parse(value, now, reltime.Past)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s value: %w", flag, err)
+	}
+	return t, nil
+}
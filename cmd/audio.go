@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"cfstream/internal/api"
+	"cfstream/internal/output"
+)
+
+var audioCmd = &cobra.Command{
+	Use:   "audio",
+	Short: "Manage additional audio tracks",
+	Long:  `Manage the additional audio tracks of videos, such as dubbed languages.`,
+}
+
+var audioListCmd = &cobra.Command{
+	Use:   "list <video-id>",
+	Short: "List the audio tracks of a video",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runAudioList,
+}
+
+var audioSetDefaultCmd = &cobra.Command{
+	Use:   "set-default <video-id> <track>",
+	Short: "Choose the audio track the player uses by default",
+	Long: `Make an audio track the one the embedded player plays by default, e.g.
+
+  cfstream audio set-default VIDEO_ID Deutsch
+
+The track is given by its UID or its label, ignoring case.`,
+	Args: cobra.ExactArgs(2),
+	RunE: runAudioSetDefault,
+}
+
+func init() {
+	rootCmd.AddCommand(audioCmd)
+	audioCmd.AddCommand(audioListCmd)
+	audioCmd.AddCommand(audioSetDefaultCmd)
+}
+
+func runAudioList(cmd *cobra.Command, args []string) error {
+	videoID, err := resolveVideoID(args[0])
+	if err != nil {
+		return err
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	tracks, err := client.ListAudioTracks(ctx, videoID)
+	if err != nil {
+		return fmt.Errorf("failed to list audio tracks: %w", err)
+	}
+
+	if len(tracks) == 0 {
+		if !quiet {
+			fmt.Println("No additional audio tracks found")
+		}
+		return nil
+	}
+
+	formatter, err := output.NewFormatter(outputFormat)
+	if err != nil {
+		return err
+	}
+	if err := formatter.FormatList(os.Stdout, []string{"UID", "Label", "Default", "Status"}, tracks); err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+	return nil
+}
+
+func runAudioSetDefault(cmd *cobra.Command, args []string) error {
+	videoID, err := resolveVideoID(args[0])
+	if err != nil {
+		return err
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	tracks, err := client.ListAudioTracks(ctx, videoID)
+	if err != nil {
+		return fmt.Errorf("failed to list audio tracks: %w", err)
+	}
+	track, err := findAudioTrack(tracks, args[1])
+	if err != nil {
+		return err
+	}
+	if track.Default {
+		if !quiet {
+			fmt.Printf("%s is already the default audio track\n", track.Label)
+		}
+		return nil
+	}
+
+	if _, err := client.SetDefaultAudioTrack(ctx, videoID, track.UID); err != nil {
+		if isDryRun(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to set default audio track: %w", err)
+	}
+
+	if !quiet {
+		fmt.Printf("%s (%s) is now the default audio track\n", track.Label, track.UID)
+	}
+	return nil
+}
+
+// findAudioTrack returns the track whose UID or label matches value.
+func findAudioTrack(tracks []api.AudioTrack, value string) (*api.AudioTrack, error) {
+	for i := range tracks {
+		if tracks[i].UID == value {
+			return &tracks[i], nil
+		}
+	}
+
+	var match *api.AudioTrack
+	for i := range tracks {
+		if strings.EqualFold(tracks[i].Label, value) {
+			if match != nil {
+				return nil, fmt.Errorf("more than one audio track is labeled %q; use its UID", value)
+			}
+			match = &tracks[i]
+		}
+	}
+	if match == nil {
+		return nil, fmt.Errorf("audio track not found: %s (see 'cfstream audio list')", value)
+	}
+	return match, nil
+}
@@ -0,0 +1,211 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"cfstream/internal/api"
+	"cfstream/internal/batch"
+	"cfstream/internal/filter"
+	"cfstream/internal/output"
+	"cfstream/internal/progress"
+)
+
+// batchRetries is how often a rate-limited batch item is retried.
+const batchRetries = 5
+
+// batchFailure is one row of the failure summary of a batch command.
+type batchFailure struct {
+	ID    string
+	Error string
+}
+
+// deleteVideos deletes videoIDs concurrently after a single confirmation and
+// prints a summary of deleted and failed items. Protected videos must be
+// confirmed separately; see guardProtected. With a checkpoint path,
+// deleted IDs are recorded there and skipped on rerun.
+func deleteVideos(videoIDs []string, concurrency int, checkpointPath string) error {
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	if err := guardProtected(client, videoIDs); err != nil {
+		return err
+	}
+
+	ok, err := confirm(fmt.Sprintf("Are you sure you want to delete %d videos?", len(videoIDs)))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		fmt.Println("Deletion cancelled")
+		return nil
+	}
+
+	// Dry-run plans are printed sequentially so they don't interleave
+	if dryRun {
+		concurrency = 1
+	}
+	if concurrency < 1 {
+		return fmt.Errorf("--concurrency must be at least 1")
+	}
+
+	// Dry runs don't record anything in the checkpoint
+	var checkpoint *batch.Checkpoint
+	if checkpointPath != "" && !dryRun {
+		checkpoint, err = openCheckpoint(checkpointPath)
+		if err != nil {
+			return err
+		}
+		defer checkpoint.Close()
+	}
+
+	bar := batchProgress(len(videoIDs), "Deleting")
+	results := batch.Run(context.Background(), videoIDs, batch.Options{
+		Concurrency: concurrency,
+		Retries:     batchRetries,
+		Backoff:     time.Second,
+		Checkpoint:  checkpoint,
+		OnThrottle:  reportThrottle(bar),
+		OnDone: func(r batch.Result) {
+			bar.Item(r.ID, r.Err)
+		},
+	}, func(ctx context.Context, id string) error {
+		ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		defer cancel()
+
+		err := client.DeleteVideo(ctx, id)
+		if isDryRun(err) {
+			return nil
+		}
+		return err
+	})
+	bar.Finish()
+
+	if dryRun {
+		return nil
+	}
+	return reportBatch(results, "deleted")
+}
+
+// selectVideos lists the library and returns the videos matching the filter
+// expression. Bulk commands require either a filter or all, so an empty
+// filter can't select the whole library by accident.
+func selectVideos(client api.Client, expr string, all bool) ([]api.Video, error) {
+	if expr == "" && !all {
+		return nil, fmt.Errorf("either --filter or --all is required")
+	}
+	if expr != "" && all {
+		return nil, fmt.Errorf("--filter and --all cannot be combined")
+	}
+
+	f, err := filter.Parse(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	videos, err := listAllVideos(ctx, client, &api.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return f.Apply(videos), nil
+}
+
+// listAllVideos lists every video matching opts, fetching as many pages as
+// the library needs.
+func listAllVideos(ctx context.Context, client api.Client, opts *api.ListOptions) ([]api.Video, error) {
+	var videos []api.Video
+	pager := client.ListVideosPager(opts)
+	for pager.More() {
+		page, err := pager.Next(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list videos: %w", err)
+		}
+		videos = append(videos, page...)
+	}
+	return videos, nil
+}
+
+// reportBatch prints a summary line and a table of failed items, and returns
+// an error when any item failed.
+func reportBatch(results []batch.Result, verb string) error {
+	failed := batch.Failed(results)
+	skipped := batch.Skipped(results)
+
+	if !quiet {
+		fmt.Printf("%d %s, %d failed", len(results)-len(failed)-skipped, verb, len(failed))
+		if skipped > 0 {
+			fmt.Printf(", %d skipped (already in checkpoint)", skipped)
+		}
+		fmt.Println()
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+
+	rows := make([]batchFailure, len(failed))
+	for i, r := range failed {
+		rows[i] = batchFailure{ID: r.ID, Error: r.Err.Error()}
+	}
+
+	formatter, err := output.NewFormatter(outputFormat)
+	if err != nil {
+		return err
+	}
+	if err := formatter.FormatList(os.Stdout, []string{"ID", "Error"}, rows); err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+
+	return fmt.Errorf("%d of %d items failed", len(failed), len(results))
+}
+
+// reportThrottle returns a batch OnThrottle callback that tells the user a
+// batch paused because of a rate limit. The warning is shown in verbose mode
+// and always included in the JSON event stream.
+func reportThrottle(bar progress.Reporter) func(time.Duration, int) {
+	return func(wait time.Duration, concurrency int) {
+		if verbose || progressMode == progress.ModeJSON {
+			bar.Warn(fmt.Sprintf("rate limited: pausing for %s, concurrency reduced to %d", wait.Round(time.Second), concurrency))
+		}
+	}
+}
+
+// openCheckpoint opens the checkpoint file at path and reports how many
+// items an earlier run already completed.
+func openCheckpoint(path string) (*batch.Checkpoint, error) {
+	checkpoint, err := batch.OpenCheckpoint(path)
+	if err != nil {
+		return nil, err
+	}
+	if n := checkpoint.Len(); n > 0 && !quiet {
+		fmt.Fprintf(os.Stderr, "Resuming from checkpoint: %d items already done\n", n)
+	}
+	return checkpoint, nil
+}
+
+// readIDs reads whitespace-separated IDs from r, skipping blank lines and
+// lines starting with #.
+func readIDs(r io.Reader) ([]string, error) {
+	var ids []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		ids = append(ids, strings.Fields(line)...)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read IDs: %w", err)
+	}
+	return ids, nil
+}
@@ -0,0 +1,239 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"cfstream/internal/api"
+	"cfstream/internal/batch"
+	"cfstream/internal/output"
+	"cfstream/internal/upload"
+)
+
+var uploadBatchCmd = &cobra.Command{
+	Use:   "batch <manifest.csv>",
+	Short: "Upload the files and URLs listed in a CSV manifest",
+	Long: `Upload every entry of a CSV manifest with a header row, e.g.
+
+  source,name,meta.course,creator,require_signed_urls
+  videos/intro.mp4,Introduction,go101,alice,
+  https://example.com/outro.mp4,Outro,go101,alice,false
+
+Columns:
+
+  source               local file path or http(s) URL (also path or url)
+  name                 video name; defaults to the file name
+  metadata             JSON object of metadata
+  meta.<key>           a single metadata value
+  creator              creator ID
+  require_signed_urls  true or false; defaults to true
+
+Relative paths are resolved against the manifest's directory. Entries are
+uploaded concurrently, and a report of every entry is printed at the end.
+The first failure stops the batch: entries not started yet are skipped.
+With --continue-on-error, every entry is attempted.`,
+	Args: cobra.ExactArgs(1),
+	RunE: notifyOnFinish("Upload", runUploadBatch),
+}
+
+// batchUploadResult is one row of the upload batch report.
+type batchUploadResult struct {
+	Line   int
+	Source string
+	Name   string
+	UID    string
+	Result string
+	Error  string
+}
+
+// errBatchStopped marks entries skipped after an earlier failure.
+var errBatchStopped = errors.New("skipped after an earlier failure")
+
+var (
+	batchUploadConcurrency int
+	batchContinueOnError   bool
+)
+
+func init() {
+	uploadCmd.AddCommand(uploadBatchCmd)
+
+	uploadBatchCmd.Flags().IntVar(&batchUploadConcurrency, "concurrency", 3, "number of entries uploaded at once")
+	uploadBatchCmd.Flags().BoolVar(&batchContinueOnError, "continue-on-error", false, "upload the remaining entries after a failure")
+	uploadBatchCmd.Flags().BoolVar(&uploadValidate, "validate", false, "check duration, codec, and size of files against Stream limits before uploading")
+}
+
+func runUploadBatch(cmd *cobra.Command, args []string) error {
+	if err := checkUploadFlags(); err != nil {
+		return err
+	}
+
+	entries, err := upload.LoadManifest(args[0])
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		if !quiet {
+			fmt.Println("Manifest lists no entries")
+		}
+		return nil
+	}
+	for _, e := range entries {
+		if e.IsURL() {
+			continue
+		}
+		if _, err := os.Stat(e.Source); err != nil {
+			return fmt.Errorf("line %d: file not found: %s", e.Line, e.Source)
+		}
+	}
+
+	// Dry-run plans are printed sequentially so they don't interleave
+	concurrency := batchUploadConcurrency
+	if dryRun {
+		concurrency = 1
+	}
+	if concurrency < 1 {
+		return fmt.Errorf("--concurrency must be at least 1")
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+	var files []string
+	for _, e := range entries {
+		if !e.IsURL() {
+			files = append(files, e.Source)
+		}
+	}
+	minutes, err := validateUploads(files)
+	if err != nil {
+		return err
+	}
+	if err := checkQuota(context.Background(), client, minutes); err != nil {
+		return err
+	}
+
+	// Entries are identified by index, as a manifest may list a source twice
+	ids := make([]string, len(entries))
+	for i := range entries {
+		ids[i] = strconv.Itoa(i)
+	}
+	uploaded := make([]*api.Video, len(entries))
+	var stopped atomic.Bool
+
+	bar := batchProgress(len(entries), "Uploading")
+	results := batch.Run(context.Background(), ids, batch.Options{
+		Concurrency: concurrency,
+		Retries:     batchRetries,
+		Backoff:     time.Second,
+		OnThrottle:  reportThrottle(bar),
+		OnDone: func(r batch.Result) {
+			i, _ := strconv.Atoi(r.ID) //nolint:errcheck // IDs are indexes
+			bar.Item(entries[i].Source, r.Err)
+		},
+	}, func(ctx context.Context, id string) error {
+		if stopped.Load() {
+			return errBatchStopped
+		}
+		i, _ := strconv.Atoi(id) //nolint:errcheck // IDs are indexes
+		video, err := uploadEntry(ctx, client, &entries[i])
+		if isDryRun(err) {
+			return nil
+		}
+		if err != nil {
+			if !batchContinueOnError {
+				stopped.Store(true)
+			}
+			return err
+		}
+		uploaded[i] = video
+		return nil
+	})
+	bar.Finish()
+
+	if dryRun {
+		var files []fileUpload
+		for _, e := range entries {
+			if !e.IsURL() {
+				files = append(files, fileUpload{Path: e.Source})
+			}
+		}
+		planUploadSummary(files)
+		fmt.Printf("Dry run: %d URLs would be copied\n", len(entries)-len(files))
+		return nil
+	}
+
+	rows := make([]batchUploadResult, len(entries))
+	failed, skipped := 0, 0
+	for i, r := range results {
+		row := batchUploadResult{Line: entries[i].Line, Source: entries[i].Source, Name: entries[i].Name}
+		switch {
+		case errors.Is(r.Err, errBatchStopped):
+			row.Result = "skipped"
+			skipped++
+		case r.Err != nil:
+			row.Result = "failed"
+			row.Error = r.Err.Error()
+			failed++
+		default:
+			row.Result = "uploaded"
+			row.UID = uploaded[i].UID
+			row.Name = uploaded[i].Name
+		}
+		rows[i] = row
+	}
+
+	formatter, err := output.NewFormatter(outputFormat)
+	if err != nil {
+		return err
+	}
+	if err := formatter.FormatList(os.Stdout, []string{"Line", "Source", "Name", "UID", "Result", "Error"}, rows); err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+	if !quiet {
+		fmt.Printf("%d uploaded, %d failed, %d skipped\n", len(entries)-failed-skipped, failed, skipped)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d entries failed", failed, len(entries))
+	}
+	return nil
+}
+
+// uploadEntry uploads the file or copies the URL of a manifest entry.
+func uploadEntry(ctx context.Context, client api.Client, e *upload.Entry) (*api.Video, error) {
+	opts := &api.UploadOptions{
+		Name:                  e.Name,
+		Metadata:              e.Meta,
+		RequireSignedURLs:     e.RequireSignedURLs,
+		Watermark:             uploadWatermark,
+		ThumbnailTimestampPct: uploadThumbnailPct,
+		Creator:               e.Creator,
+	}
+
+	if e.IsURL() {
+		opts.Metadata = withMeta(e.Meta, api.MetaSourceURL, e.Source)
+		ctx, cancel := context.WithTimeout(ctx, time.Minute)
+		defer cancel()
+		return client.UploadFromURL(ctx, e.Source, opts)
+	}
+
+	recorder := &resumeRecorder{path: e.Source, name: e.Name}
+	opts.OnResumable = recorder.record
+	video, err := client.UploadFile(ctx, e.Source, opts, nil)
+	if err != nil {
+		if recorder.uid != "" {
+			return nil, fmt.Errorf("%w (resume with: cfstream upload resume %s)", err, recorder.uid)
+		}
+		return nil, err
+	}
+	recorder.finish()
+	return video, nil
+}
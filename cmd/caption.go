@@ -0,0 +1,649 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"cfstream/internal/api"
+	"cfstream/internal/batch"
+	"cfstream/internal/caption"
+	"cfstream/internal/output"
+)
+
+var captionCmd = &cobra.Command{
+	Use:   "caption",
+	Short: "Manage captions and subtitles",
+	Long:  `Manage caption and subtitle tracks (WebVTT) for videos.`,
+}
+
+var captionListCmd = &cobra.Command{
+	Use:   "list <video-id>",
+	Short: "List the caption tracks of a video",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runCaptionList,
+}
+
+var captionUploadCmd = &cobra.Command{
+	Use:   "upload <video-id> <file.vtt>",
+	Short: "Upload a caption file",
+	Long: `Upload a WebVTT caption file for one language of a video, replacing any
+existing track in that language, e.g.
+
+  cfstream caption upload VIDEO_ID subs/intro.de.vtt --lang de
+
+To upload a directory of files at once, use 'cfstream caption upload-batch'.`,
+	Args: cobra.ExactArgs(2),
+	RunE: runCaptionUpload,
+}
+
+var captionDeleteCmd = &cobra.Command{
+	Use:   "delete <video-id>",
+	Short: "Delete a caption track",
+	Long:  `Delete the caption track of one language from a video.`,
+	Args:  cobra.ExactArgs(1),
+	RunE:  runCaptionDelete,
+}
+
+var captionGenerateCmd = &cobra.Command{
+	Use:   "generate <video-id>",
+	Short: "Generate captions from the video's audio",
+	Long: `Have Stream transcribe the video's audio into captions for a language and
+wait until the track is ready, e.g.
+
+  cfstream caption generate VIDEO_ID --lang en
+
+Generated tracks can be downloaded, edited, and uploaded again like any other.`,
+	Args: cobra.ExactArgs(1),
+	RunE: notifyOnFinish("Caption generation", runCaptionGenerate),
+}
+
+var captionUploadBatchCmd = &cobra.Command{
+	Use:   "upload-batch <dir>",
+	Short: "Upload a directory of caption files",
+	Long: `Upload every caption file in a directory, deriving the video and language
+from each file name using --pattern, or from a CSV file given with --map.
+
+The pattern uses {uid} and {lang} placeholders and is matched against paths
+relative to the directory, e.g. '{uid}.{lang}.vtt' or '{lang}/{uid}.vtt'.
+{uid} may also be a video alias.
+
+The mapping CSV needs a header row with file, uid, and lang columns; relative
+file paths are resolved against the directory.
+
+Languages must be BCP 47 tags such as en, pt-BR, or zh-Hant. They are checked
+before anything is uploaded, with suggestions for common mistakes like en_US.
+
+With --checkpoint, uploaded files are recorded in a file and skipped when the
+command is rerun, so an interrupted batch resumes where it stopped.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runCaptionUploadBatch,
+}
+
+var captionDownloadCmd = &cobra.Command{
+	Use:   "download <video-id>",
+	Short: "Download caption tracks of a video",
+	Long: `Download caption tracks of a video as WebVTT files named <uid>.<lang>.vtt.
+Use --all to save every available language, or --lang for specific ones.
+
+The file names match the default upload-batch pattern, so edited files can be
+uploaded again with 'cfstream caption upload-batch'.
+
+For a single language, --out names the file instead, or - for stdout:
+
+  cfstream caption download VIDEO_ID --lang en --out intro.en.vtt`,
+	Args: cobra.ExactArgs(1),
+	RunE: runCaptionDownload,
+}
+
+// captionDownloadResult is one row of the download report.
+type captionDownloadResult struct {
+	Language string
+	Label    string
+	File     string
+}
+
+// captionUploadResult is one row of the upload-batch report.
+type captionUploadResult struct {
+	File     string
+	UID      string
+	Language string
+	Status   string
+	Error    string
+}
+
+var (
+	captionPattern     string
+	captionMap         string
+	captionConcurrency int
+	captionAll         bool
+	captionLangs       []string
+	captionOutDir      string
+	captionLang        string
+	captionTimeout     time.Duration
+	captionOut         string
+	captionCheckpoint  string
+)
+
+func init() {
+	rootCmd.AddCommand(captionCmd)
+	captionCmd.AddCommand(captionListCmd)
+	captionCmd.AddCommand(captionUploadCmd)
+	captionCmd.AddCommand(captionDeleteCmd)
+	captionCmd.AddCommand(captionGenerateCmd)
+	captionCmd.AddCommand(captionUploadBatchCmd)
+	captionCmd.AddCommand(captionDownloadCmd)
+
+	captionUploadCmd.Flags().StringVar(&captionLang, "lang", "", "BCP 47 language tag of the captions, e.g. en or pt-BR (required)")
+	_ = captionUploadCmd.MarkFlagRequired("lang") //nolint:errcheck // Flag is registered above
+
+	captionDeleteCmd.Flags().StringVar(&captionLang, "lang", "", "language of the track to delete (required)")
+	_ = captionDeleteCmd.MarkFlagRequired("lang") //nolint:errcheck // Flag is registered above
+
+	captionGenerateCmd.Flags().StringVar(&captionLang, "lang", "", "language to transcribe, e.g. en (required)")
+	captionGenerateCmd.Flags().DurationVar(&captionTimeout, "timeout", 30*time.Minute, "maximum time to wait for the captions")
+	_ = captionGenerateCmd.MarkFlagRequired("lang") //nolint:errcheck // Flag is registered above
+
+	// --language is accepted as a synonym of --lang on every caption command
+	captionCmd.SetGlobalNormalizationFunc(func(f *pflag.FlagSet, name string) pflag.NormalizedName {
+		if name == "language" {
+			name = "lang"
+		}
+		return pflag.NormalizedName(name)
+	})
+
+	captionUploadBatchCmd.Flags().StringVar(&captionPattern, "pattern", "{uid}.{lang}.vtt", "file name pattern with {uid} and {lang} placeholders")
+	captionUploadBatchCmd.Flags().StringVar(&captionMap, "map", "", "CSV file mapping file,uid,lang (overrides --pattern)")
+	captionUploadBatchCmd.Flags().IntVar(&captionConcurrency, "concurrency", 4, "number of concurrent uploads")
+	captionUploadBatchCmd.Flags().StringVar(&captionCheckpoint, "checkpoint", "", "file recording uploaded files; completed files are skipped on rerun")
+
+	addSortFlags(captionListCmd, captionSortKeys)
+
+	captionDownloadCmd.Flags().BoolVar(&captionAll, "all", false, "download every available language")
+	captionDownloadCmd.Flags().StringSliceVar(&captionLangs, "lang", nil, "language to download (repeatable)")
+	captionDownloadCmd.Flags().StringVar(&captionOutDir, "out-dir", ".", "directory to save caption files in")
+	captionDownloadCmd.Flags().StringVar(&captionOut, "out", "", "file to save a single language to, or - for stdout")
+}
+
+func runCaptionList(cmd *cobra.Command, args []string) error {
+	videoID, err := resolveVideoID(args[0])
+	if err != nil {
+		return err
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	captions, err := client.ListCaptions(ctx, videoID)
+	if err != nil {
+		return fmt.Errorf("failed to list captions: %w", err)
+	}
+
+	if len(captions) == 0 {
+		if !quiet {
+			fmt.Println("No captions found")
+		}
+		return nil
+	}
+
+	formatter, err := output.NewFormatter(outputFormat)
+	if err != nil {
+		return err
+	}
+	if err := sortList(captions, captionSortKeys); err != nil {
+		return err
+	}
+	if err := formatter.FormatList(os.Stdout, []string{"Language", "Label", "Generated", "Status"}, captions); err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+	return nil
+}
+
+func runCaptionUpload(cmd *cobra.Command, args []string) error {
+	if err := caption.ValidateLanguage(captionLang); err != nil {
+		return err
+	}
+
+	videoID, err := resolveVideoID(args[0])
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(args[1]); err != nil {
+		return fmt.Errorf("failed to read caption file: %w", err)
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	uploaded, err := client.UploadCaption(ctx, videoID, captionLang, args[1])
+	if err != nil {
+		if isDryRun(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to upload captions: %w", err)
+	}
+
+	if !quiet {
+		fmt.Printf("Uploaded %s captions (%s) to video %s\n", uploaded.Language, uploaded.Label, videoID)
+	}
+	return nil
+}
+
+func runCaptionDelete(cmd *cobra.Command, args []string) error {
+	videoID, err := resolveVideoID(args[0])
+	if err != nil {
+		return err
+	}
+
+	ok, err := confirm(fmt.Sprintf("Are you sure you want to delete the %s captions of video %s?", captionLang, videoID))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		fmt.Println("Deletion cancelled")
+		return nil
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := client.DeleteCaption(ctx, videoID, captionLang); err != nil {
+		if isDryRun(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to delete captions: %w", err)
+	}
+
+	if !quiet {
+		fmt.Printf("Deleted %s captions of video %s\n", captionLang, videoID)
+	}
+	return nil
+}
+
+func runCaptionGenerate(cmd *cobra.Command, args []string) error {
+	if err := caption.ValidateLanguage(captionLang); err != nil {
+		return err
+	}
+
+	videoID, err := resolveVideoID(args[0])
+	if err != nil {
+		return err
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), captionTimeout)
+	defer cancel()
+
+	if _, err := client.GenerateCaption(ctx, videoID, captionLang); err != nil {
+		if isDryRun(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to start caption generation: %w", err)
+	}
+
+	generated, err := waitForCaption(ctx, client, videoID, captionLang, 10*time.Second)
+	if err != nil {
+		return err
+	}
+
+	if !quiet {
+		fmt.Printf("Generated %s captions (%s) for video %s\n", generated.Language, generated.Label, videoID)
+	}
+	return nil
+}
+
+// waitForCaption polls the caption tracks of a video until the track of
+// language is ready, fails, or ctx is done. Status changes are printed on stderr.
+func waitForCaption(ctx context.Context, client api.Client, videoID, language string, interval time.Duration) (*api.Caption, error) {
+	lastStatus := ""
+	for {
+		captions, err := client.ListCaptions(ctx, videoID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, fmt.Errorf("timed out waiting for %s captions", language)
+			}
+			return nil, fmt.Errorf("failed to list captions: %w", err)
+		}
+
+		for i := range captions {
+			c := &captions[i]
+			if c.Language != language {
+				continue
+			}
+			switch c.Status {
+			case "ready":
+				return c, nil
+			case "error":
+				return nil, fmt.Errorf("caption generation failed for %s", language)
+			}
+			if c.Status != lastStatus && !quiet {
+				fmt.Fprintf(os.Stderr, "Status: %s\n", c.Status)
+				lastStatus = c.Status
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("timed out waiting for %s captions", language)
+		case <-time.After(interval):
+		}
+	}
+}
+
+func runCaptionUploadBatch(cmd *cobra.Command, args []string) error {
+	dir := args[0]
+	if captionConcurrency < 1 {
+		return fmt.Errorf("--concurrency must be at least 1")
+	}
+
+	var files []caption.File
+	var err error
+	if captionMap != "" {
+		files, err = caption.LoadMapping(captionMap, dir)
+	} else {
+		var pattern *caption.Pattern
+		pattern, err = caption.ParsePattern(captionPattern)
+		if err == nil {
+			files, err = caption.Scan(dir, pattern)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(files) == 0 {
+		if !quiet {
+			fmt.Println("No caption files found")
+		}
+		return nil
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	// Check every language before uploading anything so a bad mapping row
+	// doesn't leave the batch half applied
+	if err := validateCaptionFiles(client, files); err != nil {
+		return err
+	}
+
+	// Batch items and checkpoint entries are keyed by file path
+	byPath := make(map[string]caption.File, len(files))
+	paths := make([]string, 0, len(files))
+	for _, f := range files {
+		if _, ok := byPath[f.Path]; ok {
+			return fmt.Errorf("%s is listed more than once", f.Path)
+		}
+		byPath[f.Path] = f
+		paths = append(paths, f.Path)
+	}
+
+	// Dry-run plans are printed sequentially so they don't interleave
+	concurrency := captionConcurrency
+	if dryRun {
+		concurrency = 1
+	}
+
+	// Dry runs don't record anything in the checkpoint
+	var checkpoint *batch.Checkpoint
+	if captionCheckpoint != "" && !dryRun {
+		checkpoint, err = openCheckpoint(captionCheckpoint)
+		if err != nil {
+			return err
+		}
+		defer checkpoint.Close()
+	}
+
+	// Resolved video IDs for the report, written by concurrent uploads
+	var mu sync.Mutex
+	resolved := make(map[string]string, len(files))
+
+	bar := batchProgress(len(files), "Uploading captions")
+	batchResults := batch.Run(context.Background(), paths, batch.Options{
+		Concurrency: concurrency,
+		Retries:     batchRetries,
+		Backoff:     time.Second,
+		Checkpoint:  checkpoint,
+		OnThrottle:  reportThrottle(bar),
+		OnDone: func(r batch.Result) {
+			bar.Item(r.ID, r.Err)
+		},
+	}, func(ctx context.Context, path string) error {
+		videoID, err := uploadCaptionFile(ctx, client, byPath[path])
+		if videoID != "" {
+			mu.Lock()
+			resolved[path] = videoID
+			mu.Unlock()
+		}
+		return err
+	})
+	bar.Finish()
+
+	if dryRun {
+		return nil
+	}
+
+	formatter, err := output.NewFormatter(outputFormat)
+	if err != nil {
+		return err
+	}
+	results := make([]captionUploadResult, len(batchResults))
+	for i, r := range batchResults {
+		f := byPath[r.ID]
+		result := captionUploadResult{File: f.Path, UID: f.VideoID, Language: f.Language, Status: "uploaded"}
+		if uid, ok := resolved[r.ID]; ok {
+			result.UID = uid
+		}
+		switch {
+		case r.Skipped:
+			result.Status = "skipped"
+		case r.Err != nil:
+			result.Status, result.Error = "failed", r.Err.Error()
+		}
+		results[i] = result
+	}
+	if err := formatter.FormatList(os.Stdout, []string{"File", "UID", "Language", "Status", "Error"}, results); err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+
+	if failed := len(batch.Failed(batchResults)); failed > 0 {
+		return fmt.Errorf("%d of %d caption uploads failed", failed, len(results))
+	}
+	return nil
+}
+
+func runCaptionDownload(cmd *cobra.Command, args []string) error {
+	if !captionAll && len(captionLangs) == 0 {
+		return fmt.Errorf("specify --all or at least one --lang")
+	}
+	if captionOut != "" && (captionAll || len(captionLangs) != 1) {
+		return fmt.Errorf("--out saves a single language; use --out-dir with --all or several --lang")
+	}
+	for _, lang := range captionLangs {
+		if err := caption.ValidateLanguage(lang); err != nil {
+			return err
+		}
+	}
+
+	videoID, err := resolveVideoID(args[0])
+	if err != nil {
+		return err
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	captions, err := client.ListCaptions(ctx, videoID)
+	if err != nil {
+		return fmt.Errorf("failed to list captions: %w", err)
+	}
+
+	if !captionAll {
+		available := make(map[string]api.Caption, len(captions))
+		for _, c := range captions {
+			available[c.Language] = c
+		}
+		selected := make([]api.Caption, 0, len(captionLangs))
+		for _, lang := range captionLangs {
+			c, ok := available[lang]
+			if !ok {
+				return fmt.Errorf("video %s has no %s captions%s", videoID, lang, presentLanguages(captions))
+			}
+			selected = append(selected, c)
+		}
+		captions = selected
+	}
+
+	if len(captions) == 0 {
+		if !quiet {
+			fmt.Println("No captions found")
+		}
+		return nil
+	}
+
+	if captionOut != "" {
+		return saveCaption(ctx, client, videoID, captions[0].Language, captionOut)
+	}
+
+	if err := os.MkdirAll(captionOutDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	bar := batchProgress(len(captions), "Downloading captions")
+	results := make([]captionDownloadResult, 0, len(captions))
+	for _, c := range captions {
+		vtt, err := client.DownloadCaption(ctx, videoID, c.Language)
+		if err != nil {
+			return fmt.Errorf("failed to download %s captions: %w", c.Language, err)
+		}
+
+		path := filepath.Join(captionOutDir, fmt.Sprintf("%s.%s.vtt", videoID, c.Language))
+		if err := os.WriteFile(path, vtt, 0o644); err != nil { //nolint:gosec // Caption files are not sensitive
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		results = append(results, captionDownloadResult{Language: c.Language, Label: c.Label, File: path})
+		bar.Item(c.Language, nil)
+	}
+	bar.Finish()
+
+	formatter, err := output.NewFormatter(outputFormat)
+	if err != nil {
+		return err
+	}
+	if err := formatter.FormatList(os.Stdout, []string{"Language", "Label", "File"}, results); err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+
+	return nil
+}
+
+// saveCaption downloads one caption track to path, or to stdout when path is -.
+func saveCaption(ctx context.Context, client api.Client, videoID, language, path string) error {
+	vtt, err := client.DownloadCaption(ctx, videoID, language)
+	if err != nil {
+		return fmt.Errorf("failed to download %s captions: %w", language, err)
+	}
+
+	if path == "-" {
+		_, err := os.Stdout.Write(vtt)
+		return err
+	}
+	if err := os.WriteFile(path, vtt, 0o644); err != nil { //nolint:gosec // Caption files are not sensitive
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if !quiet {
+		fmt.Printf("Saved %s captions to %s\n", language, path)
+	}
+	return nil
+}
+
+// uploadCaptionFile uploads one caption file of a batch and returns the
+// resolved ID of its video.
+func uploadCaptionFile(ctx context.Context, client api.Client, f caption.File) (string, error) {
+	videoID, err := resolveVideoID(f.VideoID)
+	if err != nil {
+		return "", err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	defer cancel()
+
+	if _, err := client.UploadCaption(ctx, videoID, f.Language, f.Path); err != nil && !isDryRun(err) {
+		return videoID, err
+	}
+	return videoID, nil
+}
+
+// validateCaptionFiles checks the language tag of every file. Errors list the
+// languages already present on the video to help spot the intended tag.
+func validateCaptionFiles(client api.Client, files []caption.File) error {
+	var problems []string
+	for _, f := range files {
+		err := caption.ValidateLanguage(f.Language)
+		if err == nil {
+			continue
+		}
+
+		existing := ""
+		if videoID, resolveErr := resolveVideoID(f.VideoID); resolveErr == nil {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			captions, listErr := client.ListCaptions(ctx, videoID)
+			cancel()
+			if listErr == nil {
+				existing = presentLanguages(captions)
+			}
+		}
+		problems = append(problems, fmt.Sprintf("%s: %v%s", f.Path, err, existing))
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid caption languages, nothing was uploaded:\n  %s", strings.Join(problems, "\n  "))
+	}
+	return nil
+}
+
+// presentLanguages describes the languages of captions for use in error
+// messages, e.g. " (video has: en, pt-BR)".
+func presentLanguages(captions []api.Caption) string {
+	if len(captions) == 0 {
+		return " (video has no captions)"
+	}
+	languages := make([]string, len(captions))
+	for i, c := range captions {
+		languages[i] = c.Language
+	}
+	return fmt.Sprintf(" (video has: %s)", strings.Join(languages, ", "))
+}
@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"cfstream/internal/api"
+	"cfstream/internal/output"
+)
+
+var clipCmd = &cobra.Command{
+	Use:   "clip",
+	Short: "Create clips from videos",
+	Long:  `Create new videos from part of an existing video.`,
+}
+
+var clipCreateCmd = &cobra.Command{
+	Use:   "create <video-id>",
+	Short: "Create a clip from part of a video",
+	Long: `Create a new video from the part of a video between --start and --end, e.g.
+
+  cfstream clip create VIDEO_ID --start 30s --end 90s --name "Keynote highlight"
+  cfstream clip create VIDEO_ID --start 1:02:00 --end 1:05:30 --wait
+
+Times are Go durations (1m30s), seconds (90), or [h:]mm:ss, and are
+rounded to whole seconds by the API. The clip keeps the source video's
+signed URL requirement. Use --wait to poll the clip until it is ready.`,
+	Args: cobra.ExactArgs(1),
+	RunE: notifyOnFinish("Clip", runClipCreate),
+}
+
+var (
+	clipStart    string
+	clipEnd      string
+	clipName     string
+	clipMetadata string
+	clipWait     bool
+	clipTimeout  time.Duration
+)
+
+func init() {
+	rootCmd.AddCommand(clipCmd)
+	clipCmd.AddCommand(clipCreateCmd)
+
+	clipCreateCmd.Flags().StringVar(&clipStart, "start", "", "start of the clip, e.g. 30s or 1:30 (required)")
+	clipCreateCmd.Flags().StringVar(&clipEnd, "end", "", "end of the clip, e.g. 90s or 2:00 (required)")
+	clipCreateCmd.Flags().StringVar(&clipName, "name", "", "clip name (default: source name with the clipped range)")
+	clipCreateCmd.Flags().StringVar(&clipMetadata, "metadata", "", "clip metadata as JSON")
+	clipCreateCmd.Flags().BoolVar(&clipWait, "wait", false, "wait until the clip is ready to stream")
+	clipCreateCmd.Flags().DurationVar(&clipTimeout, "timeout", 30*time.Minute, "maximum time to wait with --wait")
+	_ = clipCreateCmd.MarkFlagRequired("start") //nolint:errcheck // Flag is registered above
+	_ = clipCreateCmd.MarkFlagRequired("end")   //nolint:errcheck // Flag is registered above
+}
+
+func runClipCreate(cmd *cobra.Command, args []string) error {
+	start, err := parseClipTime("--start", clipStart)
+	if err != nil {
+		return err
+	}
+	end, err := parseClipTime("--end", clipEnd)
+	if err != nil {
+		return err
+	}
+	if end <= start {
+		return fmt.Errorf("--end must be after --start")
+	}
+
+	var metadata map[string]interface{}
+	if clipMetadata != "" {
+		if err := json.Unmarshal([]byte(clipMetadata), &metadata); err != nil {
+			return fmt.Errorf("invalid metadata JSON: %w", err)
+		}
+	}
+
+	videoID, err := resolveVideoID(args[0])
+	if err != nil {
+		return err
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	source, err := client.GetVideo(ctx, videoID)
+	cancel()
+	if err != nil {
+		return fmt.Errorf("failed to get video: %w", err)
+	}
+	if source.Duration > 0 && float64(end) > source.Duration {
+		return fmt.Errorf("--end %s is past the end of the video (%s)", clipEnd, formatClipTime(int64(source.Duration)))
+	}
+
+	opts := &api.ClipOptions{
+		Start:             start,
+		End:               end,
+		Name:              firstNonEmpty(clipName, fmt.Sprintf("%s (%s-%s)", source.Name, formatClipTime(start), formatClipTime(end))),
+		Metadata:          metadata,
+		RequireSignedURLs: source.RequireSignedURLs,
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 30*time.Second)
+	clip, err := client.CreateClip(ctx, videoID, opts)
+	cancel()
+	if isDryRun(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create clip: %w", err)
+	}
+
+	if clipWait {
+		ctx, cancel := context.WithTimeout(context.Background(), clipTimeout)
+		defer cancel()
+
+		clip, err = waitForVideo(ctx, client, clip.UID, 5*time.Second)
+		if err != nil {
+			return err
+		}
+	}
+
+	formatter, err := output.NewFormatter(outputFormat)
+	if err != nil {
+		return err
+	}
+	if err := formatter.FormatSingle(os.Stdout, clip); err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+	return nil
+}
+
+// parseClipTime parses a position in a video given as a Go duration such
+// as 1m30s, a number of seconds, or [h:]mm:ss, and returns it in seconds.
+func parseClipTime(flag, value string) (int64, error) {
+	value = strings.TrimSpace(value)
+
+	if d, err := time.ParseDuration(value); err == nil && d >= 0 {
+		return int64(d.Round(time.Second) / time.Second), nil
+	}
+	if n, err := strconv.ParseFloat(value, 64); err == nil && n >= 0 {
+		return int64(n + 0.5), nil
+	}
+
+	parts := strings.Split(value, ":")
+	if len(parts) == 2 || len(parts) == 3 {
+		var seconds int64
+		for _, part := range parts {
+			n, err := strconv.ParseInt(part, 10, 64)
+			if err != nil || n < 0 {
+				return 0, fmt.Errorf("invalid %s value: %s (e.g., 90s, 90, or 1:30)", flag, value)
+			}
+			seconds = seconds*60 + n
+		}
+		return seconds, nil
+	}
+
+	return 0, fmt.Errorf("invalid %s value: %s (e.g., 90s, 90, or 1:30)", flag, value)
+}
+
+// formatClipTime formats seconds as m:ss, or h:mm:ss from an hour on.
+func formatClipTime(seconds int64) string {
+	if seconds >= 3600 {
+		return fmt.Sprintf("%d:%02d:%02d", seconds/3600, seconds/60%60, seconds%60)
+	}
+	return fmt.Sprintf("%d:%02d", seconds/60, seconds%60)
+}
@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"context"
 	"fmt"
+	"net/url"
 	"os"
 	"strings"
 	"syscall"
@@ -131,9 +132,9 @@ func runConfigInit(cmd *cobra.Command, args []string) error {
 }
 
 func runConfigShow(cmd *cobra.Command, args []string) error {
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	if err != nil {
-		return fmt.Errorf("failed to load configuration: %w", err)
+		return err
 	}
 
 	// Check which values come from environment
@@ -143,6 +144,10 @@ func runConfigShow(cmd *cobra.Command, args []string) error {
 
 	fmt.Println("Configuration:")
 
+	if profileName != "" {
+		fmt.Printf("  Profile:    %s\n", profileName)
+	}
+
 	// Display Account ID
 	accountIDSource := ""
 	if envAccountID != "" {
@@ -168,6 +173,16 @@ func runConfigShow(cmd *cobra.Command, args []string) error {
 	// Display duration
 	fmt.Printf("  Duration:   %s\n", cfg.DefaultSignedDuration)
 
+	// Display local signing key
+	if cfg.SigningKeyID != "" {
+		fmt.Printf("  Signing:    key %s (%s)\n", cfg.SigningKeyID, cfg.SigningKeyPEMPath)
+	}
+
+	// Display notification webhook
+	if cfg.Notifications.WebhookURL != "" {
+		fmt.Printf("  Webhook:    %s\n", maskURL(cfg.Notifications.WebhookURL))
+	}
+
 	fmt.Println()
 	fmt.Printf("Config file: %s\n", config.Path())
 
@@ -186,3 +201,12 @@ func maskToken(token string) string {
 
 	return token[:8] + strings.Repeat("*", len(token)-8)
 }
+
+// maskURL hides the path of a URL, which often embeds a secret token.
+func maskURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return maskToken(rawURL)
+	}
+	return u.Scheme + "://" + u.Host + "/****"
+}
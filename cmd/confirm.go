@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+
+	"cfstream/internal/api"
+)
+
+// stdinReader is shared so that successive prompts don't lose buffered input.
+var stdinReader = bufio.NewReader(os.Stdin)
+
+// confirm asks a yes/no question and reports whether the user answered yes.
+// With --yes or --dry-run it returns true without prompting. When stdin is not a terminal
+// it refuses to prompt, so unattended runs fail fast instead of hanging or
+// consuming piped data.
+func confirm(prompt string) (bool, error) {
+	if assumeYes || dryRun {
+		return true, nil
+	}
+
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return false, fmt.Errorf("confirmation required but stdin is not a terminal (use --yes to proceed)")
+	}
+
+	fmt.Fprintf(os.Stderr, "%s (y/N): ", prompt)
+	response, err := stdinReader.ReadString('\n')
+	if err != nil {
+		return false, fmt.Errorf("failed to read confirmation: %w", err)
+	}
+
+	response = strings.TrimSpace(strings.ToLower(response))
+	return response == "y" || response == "yes", nil
+}
+
+// isDryRun reports whether err means a request was skipped because of --dry-run.
+// Commands treat this as success since the planned calls were already printed.
+func isDryRun(err error) bool {
+	return errors.Is(err, api.ErrDryRun)
+}
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"cfstream/internal/api"
+	"cfstream/internal/manifest"
+	"cfstream/internal/output"
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <manifest>",
+	Short: "Compare a manifest with the live account",
+	Long: `Compare a manifest file with the videos in the account and list the
+differences. Nothing is changed.
+
+The manifest is YAML or JSON: a list of videos, or a mapping with a videos key.
+A previous "cfstream video list -o json" export works as a manifest.
+
+  videos:
+    - uid: abc123
+      name: Intro
+      requireSignedURLs: true
+      allowedOrigins: [example.com]
+      meta:
+        category: training
+
+Only the fields a video sets in the manifest are compared. Videos only in the
+account are reported as added, videos only in the manifest as removed. Use
+--exit-code to exit non-zero when there are differences, e.g. in CI.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDiff,
+}
+
+var diffExitCode bool
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+
+	diffCmd.Flags().BoolVar(&diffExitCode, "exit-code", false, "exit non-zero when the account differs from the manifest")
+}
+
+func runDiff(cmd *cobra.Command, args []string) error {
+	entries, err := manifest.Load(args[0])
+	if err != nil {
+		return err
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	videos, err := listAllVideos(ctx, client, &api.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	changes := manifest.Diff(entries, videos)
+	if len(changes) == 0 {
+		if !quiet {
+			fmt.Println("No differences")
+		}
+		return nil
+	}
+
+	formatter, err := output.NewFormatter(outputFormat)
+	if err != nil {
+		return err
+	}
+	headers := []string{"Kind", "UID", "Name", "Field", "Manifest", "Account"}
+	if err := formatter.FormatList(os.Stdout, headers, changes); err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+
+	if !quiet {
+		added, removed, changed := 0, 0, 0
+		for _, c := range changes {
+			switch c.Kind {
+			case manifest.Added:
+				added++
+			case manifest.Removed:
+				removed++
+			default:
+				changed++
+			}
+		}
+		fmt.Printf("%d added, %d removed, %d fields changed\n", added, removed, changed)
+	}
+
+	if diffExitCode {
+		return fmt.Errorf("account differs from %s", args[0])
+	}
+	return nil
+}
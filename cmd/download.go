@@ -0,0 +1,621 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"cfstream/internal/api"
+	"cfstream/internal/batch"
+	"cfstream/internal/fetch"
+	"cfstream/internal/filter"
+	"cfstream/internal/output"
+	"cfstream/internal/progress"
+)
+
+var downloadCmd = &cobra.Command{
+	Use:   "download",
+	Short: "Manage MP4 downloads",
+	Long:  `Manage the MP4 downloads that can be generated for videos.`,
+}
+
+var downloadEnableCmd = &cobra.Command{
+	Use:   "enable [video-id]",
+	Short: "Enable MP4 downloads for a video or many videos",
+	Long: `Enable the MP4 download of a video, wait until it is ready, and print its
+download URL, e.g.
+
+  cfstream download enable VIDEO_ID
+
+Without a video ID, downloads are enabled for every video matching --filter
+(or every video with --all), and the UID, name, and download URL of each
+video are listed. Use --out to save the list as CSV for handing to others, e.g.
+
+  cfstream download enable --filter 'meta.category=training' --out downloads.csv
+
+With --checkpoint, videos whose downloads are ready are recorded in a file and
+skipped when the command is rerun, so an interrupted batch resumes where it
+stopped; skipped videos are not listed again.
+
+Private videos get URLs signed with a downloadable token valid for --duration.
+Downloads that are already enabled are only waited for. Use --no-wait to
+return as soon as generation has started.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: notifyOnFinish("Download enablement", runDownloadEnable),
+}
+
+var downloadGetCmd = &cobra.Command{
+	Use:   "get <video-id>",
+	Short: "Download the MP4 of a video",
+	Long: `Download the MP4 of a video to disk, enabling the download first if needed
+and waiting until it is ready, e.g.
+
+  cfstream download get VIDEO_ID --out video.mp4
+
+The file is written to --out (default <video-id>.mp4) through a .part file
+that is renamed when complete. If the transfer is interrupted, rerunning the
+command resumes it with an HTTP range request.`,
+	Args: cobra.ExactArgs(1),
+	RunE: notifyOnFinish("Download", runDownloadGet),
+}
+
+var downloadAllCmd = &cobra.Command{
+	Use:   "all",
+	Short: "Download the MP4s of many videos",
+	Long: `Download the MP4 of every video matching --search and --filter into
+--out-dir, enabling downloads where needed, e.g.
+
+  cfstream download all --search standup --out-dir ./exports --concurrency 4
+  cfstream download all --filter 'meta.team=sales' --out-dir ./exports --template '{meta.team}/{created}-{name}.mp4'
+
+Without --search or --filter, the whole library is downloaded. Files are
+named by --template, whose placeholders are {uid}, {name} (without its
+extension), {created} (YYYY-MM-DD), and {meta.KEY}; it may contain / to
+create subdirectories. Videos whose file already exists are skipped, so
+rerunning the command archives only new videos and resumes interrupted ones.`,
+	Args: cobra.NoArgs,
+	RunE: notifyOnFinish("Bulk download", runDownloadAll),
+}
+
+var downloadStatusCmd = &cobra.Command{
+	Use:   "status <video-id>",
+	Short: "Show the MP4 download status of a video",
+	Long: `Show whether the MP4 download of a video is enabled, how far its generation
+has progressed, and its URL once ready. Use --wait to poll until it is ready.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDownloadStatus,
+}
+
+// downloadRow is one row of the download enable output.
+type downloadRow struct {
+	UID  string
+	Name string
+	URL  string
+}
+
+// downloadStatus is the output of download status.
+type downloadStatus struct {
+	UID             string
+	Status          string
+	PercentComplete float64
+	URL             string
+}
+
+var (
+	downloadFilter      string
+	downloadAll         bool
+	downloadConcurrency int
+	downloadTimeout     time.Duration
+	downloadOut         string
+	downloadNoWait      bool
+	downloadWait        bool
+	downloadSearch      string
+	downloadOutDir      string
+	downloadTemplate    string
+	downloadCheckpoint  string
+)
+
+func init() {
+	rootCmd.AddCommand(downloadCmd)
+	downloadCmd.AddCommand(downloadEnableCmd)
+	downloadCmd.AddCommand(downloadGetCmd)
+	downloadCmd.AddCommand(downloadAllCmd)
+	downloadCmd.AddCommand(downloadStatusCmd)
+
+	downloadEnableCmd.Flags().StringVar(&downloadFilter, "filter", "", "select videos, e.g. 'meta.category=training'")
+	downloadEnableCmd.Flags().BoolVar(&downloadAll, "all", false, "enable downloads for every video in the library")
+	downloadEnableCmd.Flags().IntVar(&downloadConcurrency, "concurrency", 4, "number of videos processed concurrently")
+	downloadEnableCmd.Flags().DurationVar(&downloadTimeout, "timeout", 30*time.Minute, "maximum time to wait for downloads to become ready")
+	downloadEnableCmd.Flags().StringVar(&downloadOut, "out", "", "write the download URLs to this CSV file instead of stdout")
+	downloadEnableCmd.Flags().StringVar(&signedDuration, "duration", "", "token duration for private videos (e.g., 24h, 168h)")
+	downloadEnableCmd.Flags().BoolVar(&downloadNoWait, "no-wait", false, "don't wait for a single video's download to become ready")
+	downloadEnableCmd.Flags().StringVar(&downloadCheckpoint, "checkpoint", "", "file recording IDs with ready downloads; completed IDs are skipped on rerun")
+
+	downloadGetCmd.Flags().StringVar(&downloadOut, "out", "", "file to write (default <video-id>.mp4)")
+	downloadGetCmd.Flags().DurationVar(&downloadTimeout, "timeout", 30*time.Minute, "maximum time to wait for the download to become ready")
+	downloadGetCmd.Flags().StringVar(&signedDuration, "duration", "", "token duration for private videos (e.g., 1h)")
+
+	downloadAllCmd.Flags().StringVar(&downloadSearch, "search", "", "only videos whose name matches")
+	downloadAllCmd.Flags().StringVar(&downloadFilter, "filter", "", "select videos, e.g. 'meta.category=training'")
+	downloadAllCmd.Flags().StringVar(&downloadOutDir, "out-dir", ".", "directory to write the files to")
+	downloadAllCmd.Flags().StringVar(&downloadTemplate, "template", fetch.DefaultTemplate, "file name template")
+	downloadAllCmd.Flags().IntVar(&downloadConcurrency, "concurrency", 4, "number of concurrent downloads")
+	downloadAllCmd.Flags().DurationVar(&downloadTimeout, "timeout", 30*time.Minute, "maximum time to wait for each download to become ready")
+	downloadAllCmd.Flags().StringVar(&signedDuration, "duration", "", "token duration for private videos (e.g., 24h)")
+
+	downloadStatusCmd.Flags().BoolVar(&downloadWait, "wait", false, "poll until the download is ready")
+	downloadStatusCmd.Flags().DurationVar(&downloadTimeout, "timeout", 30*time.Minute, "maximum time to wait with --wait")
+}
+
+func runDownloadEnable(cmd *cobra.Command, args []string) error {
+	if downloadConcurrency < 1 {
+		return fmt.Errorf("--concurrency must be at least 1")
+	}
+	if _, err := parseTokenDuration(signedDuration); err != nil {
+		return err
+	}
+	if len(args) == 1 {
+		if downloadFilter != "" || downloadAll || downloadCheckpoint != "" {
+			return fmt.Errorf("a video ID cannot be combined with --filter, --all, or --checkpoint")
+		}
+		return enableVideoDownload(args[0])
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	videos, err := selectVideos(client, downloadFilter, downloadAll)
+	if err != nil {
+		return err
+	}
+	if len(videos) == 0 {
+		if !quiet {
+			fmt.Println("No videos match")
+		}
+		return nil
+	}
+
+	byID := make(map[string]api.Video, len(videos))
+	videoIDs := make([]string, len(videos))
+	for i, video := range videos {
+		byID[video.UID] = video
+		videoIDs[i] = video.UID
+	}
+
+	// Dry-run plans are printed sequentially so they don't interleave
+	concurrency := downloadConcurrency
+	if dryRun {
+		concurrency = 1
+	}
+
+	// Dry runs don't record anything in the checkpoint
+	var checkpoint *batch.Checkpoint
+	if downloadCheckpoint != "" && !dryRun {
+		checkpoint, err = openCheckpoint(downloadCheckpoint)
+		if err != nil {
+			return err
+		}
+		defer checkpoint.Close()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), downloadTimeout)
+	defer cancel()
+
+	var mu sync.Mutex
+	urls := make(map[string]string, len(videoIDs))
+
+	bar := batchProgress(len(videoIDs), "Enabling downloads")
+	results := batch.Run(ctx, videoIDs, batch.Options{
+		Concurrency: concurrency,
+		Retries:     batchRetries,
+		Backoff:     time.Second,
+		Checkpoint:  checkpoint,
+		OnThrottle:  reportThrottle(bar),
+		OnDone: func(r batch.Result) {
+			bar.Item(r.ID, r.Err)
+		},
+	}, func(ctx context.Context, id string) error {
+		downloadURL, err := enableDownload(ctx, client, byID[id])
+		if isDryRun(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		mu.Lock()
+		urls[id] = downloadURL
+		mu.Unlock()
+		return nil
+	})
+	bar.Finish()
+
+	if dryRun {
+		return nil
+	}
+
+	// Rows keep the library order rather than the order downloads became ready
+	rows := make([]downloadRow, 0, len(urls))
+	for _, id := range videoIDs {
+		if downloadURL, ok := urls[id]; ok {
+			rows = append(rows, downloadRow{UID: id, Name: byID[id].Name, URL: downloadURL})
+		}
+	}
+	if len(rows) > 0 {
+		if err := writeDownloadRows(rows); err != nil {
+			return err
+		}
+	}
+
+	return reportBatch(results, "ready")
+}
+
+// enableVideoDownload enables the MP4 download of one video and, unless
+// --no-wait is given, waits for it with a progress bar and prints its URL.
+func enableVideoDownload(arg string) error {
+	videoID, err := resolveVideoID(arg)
+	if err != nil {
+		return err
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), downloadTimeout)
+	defer cancel()
+
+	video, err := client.GetVideo(ctx, videoID)
+	if err != nil {
+		return fmt.Errorf("failed to get video: %w", err)
+	}
+
+	downloads, err := client.EnableDownloads(ctx, videoID)
+	if isDryRun(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to enable downloads: %w", err)
+	}
+
+	if downloadNoWait {
+		return printDownloadStatus(videoID, downloads)
+	}
+
+	downloads, err = waitForDownload(ctx, client, videoID, downloads, downloadBar(videoID))
+	if err != nil {
+		return err
+	}
+	downloadURL, err := signedDownloadURL(ctx, client, video, downloads.Default.URL)
+	if err != nil {
+		return err
+	}
+	return writeDownloadRows([]downloadRow{{UID: videoID, Name: video.Name, URL: downloadURL}})
+}
+
+func runDownloadGet(cmd *cobra.Command, args []string) error {
+	if _, err := parseTokenDuration(signedDuration); err != nil {
+		return err
+	}
+
+	videoID, err := resolveVideoID(args[0])
+	if err != nil {
+		return err
+	}
+	path := firstNonEmpty(downloadOut, videoID+".mp4")
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), downloadTimeout)
+	video, err := client.GetVideo(ctx, videoID)
+	if err != nil {
+		cancel()
+		return fmt.Errorf("failed to get video: %w", err)
+	}
+
+	downloadURL, err := readyDownloadURL(ctx, client, video, downloadBar(videoID))
+	cancel()
+	if isDryRun(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// The transfer itself isn't limited by --timeout; large files take as long as they take
+	var bar progress.Reporter = progress.None()
+	size, err := fetch.File(context.Background(), downloadURL, path, fetch.Options{
+		Retries: 3,
+		OnStart: func(total int64) {
+			bar = newProgress(progress.Options{Total: total, Description: "Downloading " + path, Bytes: true, Phase: "downloading"})
+		},
+		OnProgress: func(written int64) {
+			bar.Set(written)
+		},
+	})
+	bar.Finish()
+	if err != nil {
+		return fmt.Errorf("failed to download %s: %w", path, err)
+	}
+
+	if !quiet {
+		fmt.Printf("Saved %s (%s)\n", path, progress.FormatBytes(size))
+	}
+	return nil
+}
+
+func runDownloadAll(cmd *cobra.Command, args []string) error {
+	if downloadConcurrency < 1 {
+		return fmt.Errorf("--concurrency must be at least 1")
+	}
+	if _, err := parseTokenDuration(signedDuration); err != nil {
+		return err
+	}
+	f, err := filter.Parse(downloadFilter)
+	if err != nil {
+		return err
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	videos, err := listAllVideos(ctx, client, &api.ListOptions{Search: downloadSearch})
+	cancel()
+	if err != nil {
+		return err
+	}
+	videos = f.Apply(videos)
+
+	// Names are checked up front so a bad template or a collision doesn't
+	// surface halfway through an archive run
+	byID := make(map[string]*api.Video, len(videos))
+	paths := make(map[string]string, len(videos))
+	owners := make(map[string]string, len(videos))
+	var videoIDs []string
+	for i := range videos {
+		video := &videos[i]
+		name, err := fetch.Name(downloadTemplate, video)
+		if err != nil {
+			return err
+		}
+		path := filepath.Join(downloadOutDir, filepath.FromSlash(name))
+		if other, ok := owners[path]; ok {
+			return fmt.Errorf("videos %s and %s would both be saved as %s; add {uid} to --template", other, video.UID, path)
+		}
+		owners[path] = video.UID
+
+		if _, err := os.Stat(path); err == nil {
+			continue
+		}
+		byID[video.UID] = video
+		paths[video.UID] = path
+		videoIDs = append(videoIDs, video.UID)
+	}
+
+	if !quiet && len(videos) > len(videoIDs) {
+		fmt.Printf("%d of %d matching videos are already downloaded\n", len(videos)-len(videoIDs), len(videos))
+	}
+	if len(videoIDs) == 0 {
+		if !quiet {
+			fmt.Println("No videos to download")
+		}
+		return nil
+	}
+
+	if dryRun {
+		for _, id := range videoIDs {
+			fmt.Printf("Would download %s to %s\n", id, paths[id])
+		}
+		return nil
+	}
+
+	bar := batchProgress(len(videoIDs), "Downloading")
+	results := batch.Run(context.Background(), videoIDs, batch.Options{
+		Concurrency: downloadConcurrency,
+		Retries:     batchRetries,
+		Backoff:     time.Second,
+		OnThrottle:  reportThrottle(bar),
+		OnDone: func(r batch.Result) {
+			bar.Item(r.ID, r.Err)
+		},
+	}, func(ctx context.Context, id string) error {
+		waitCtx, cancel := context.WithTimeout(ctx, downloadTimeout)
+		downloadURL, err := readyDownloadURL(waitCtx, client, byID[id], progress.None())
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		path := paths[id]
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil { //nolint:gosec // Exports are ordinary user files
+			return fmt.Errorf("failed to create directory: %w", err)
+		}
+		_, err = fetch.File(ctx, downloadURL, path, fetch.Options{Retries: 3})
+		return err
+	})
+	bar.Finish()
+
+	return reportBatch(results, "downloaded")
+}
+
+// readyDownloadURL enables the MP4 download of a video if needed, waits
+// until it is ready, and returns its URL, signed for private videos.
+func readyDownloadURL(ctx context.Context, client api.Client, video *api.Video, bar progress.Reporter) (string, error) {
+	downloads, err := client.GetDownloads(ctx, video.UID)
+	if err == nil && downloads.Default == nil {
+		downloads, err = client.EnableDownloads(ctx, video.UID)
+	}
+	if err != nil {
+		bar.Finish()
+		return "", err
+	}
+
+	downloads, err = waitForDownload(ctx, client, video.UID, downloads, bar)
+	if err != nil {
+		return "", err
+	}
+	return signedDownloadURL(ctx, client, video, downloads.Default.URL)
+}
+
+func runDownloadStatus(cmd *cobra.Command, args []string) error {
+	videoID, err := resolveVideoID(args[0])
+	if err != nil {
+		return err
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), downloadTimeout)
+	defer cancel()
+
+	downloads, err := client.GetDownloads(ctx, videoID)
+	if err != nil {
+		return fmt.Errorf("failed to get downloads: %w", err)
+	}
+
+	if downloadWait {
+		if downloads.Default == nil {
+			return fmt.Errorf("downloads are not enabled for %s; run 'cfstream download enable %s'", videoID, videoID)
+		}
+		downloads, err = waitForDownload(ctx, client, videoID, downloads, downloadBar(videoID))
+		if err != nil {
+			return err
+		}
+	}
+
+	return printDownloadStatus(videoID, downloads)
+}
+
+// printDownloadStatus prints the state of a video's default download.
+func printDownloadStatus(videoID string, downloads *api.Downloads) error {
+	status := downloadStatus{UID: videoID, Status: "not enabled"}
+	if d := downloads.Default; d != nil {
+		status.Status = d.Status
+		status.PercentComplete = d.PercentComplete
+		status.URL = d.URL
+	}
+
+	formatter, err := output.NewFormatter(outputFormat)
+	if err != nil {
+		return err
+	}
+	if err := formatter.FormatSingle(os.Stdout, status); err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+	return nil
+}
+
+// downloadBar returns a Reporter for the generation percentage of a download.
+func downloadBar(videoID string) progress.Reporter {
+	return newProgress(progress.Options{Total: 100, Description: "Generating MP4 " + videoID, Phase: "processing"})
+}
+
+// enableDownload enables the MP4 download of a video, waits until it is
+// ready, and returns its URL, signed for private videos.
+func enableDownload(ctx context.Context, client api.Client, video api.Video) (string, error) {
+	downloads, err := client.EnableDownloads(ctx, video.UID)
+	if err != nil {
+		return "", err
+	}
+
+	downloads, err = waitForDownload(ctx, client, video.UID, downloads, progress.None())
+	if err != nil {
+		return "", err
+	}
+	return signedDownloadURL(ctx, client, &video, downloads.Default.URL)
+}
+
+// waitForDownload polls the downloads of a video until the default download
+// is ready, fails, or ctx is done, reporting its percentComplete to bar.
+func waitForDownload(ctx context.Context, client api.Client, videoID string, downloads *api.Downloads, bar progress.Reporter) (*api.Downloads, error) {
+	defer bar.Finish()
+
+	for downloads.Default == nil || downloads.Default.Status != "ready" {
+		if downloads.Default != nil {
+			if downloads.Default.Status == "error" {
+				return nil, fmt.Errorf("download generation failed")
+			}
+			bar.Set(int64(downloads.Default.PercentComplete))
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("timed out waiting for download")
+		case <-time.After(5 * time.Second):
+		}
+
+		var err error
+		downloads, err = client.GetDownloads(ctx, videoID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	bar.Set(100)
+	return downloads, nil
+}
+
+// signedDownloadURL returns the download URL of a video, signed with a
+// downloadable token when the video requires signed URLs.
+func signedDownloadURL(ctx context.Context, client api.Client, video *api.Video, downloadURL string) (string, error) {
+	if !video.RequireSignedURLs {
+		return downloadURL, nil
+	}
+	token, err := signedTokenFor(ctx, client, video.UID, &api.TokenOptions{Downloadable: true})
+	if err != nil {
+		return "", err
+	}
+	return withToken(downloadURL, token), nil
+}
+
+// writeDownloadRows writes the download list as CSV to --out, or in the
+// selected output format to stdout.
+func writeDownloadRows(rows []downloadRow) error {
+	headers := []string{"UID", "Name", "URL"}
+
+	if downloadOut == "" {
+		formatter, err := output.NewFormatter(outputFormat)
+		if err != nil {
+			return err
+		}
+		if err := formatter.FormatList(os.Stdout, headers, rows); err != nil {
+			return fmt.Errorf("failed to format output: %w", err)
+		}
+		return nil
+	}
+
+	f, err := os.Create(downloadOut)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", downloadOut, err)
+	}
+	defer f.Close()
+
+	formatter, err := output.NewFormatter(outputFormatCSV)
+	if err != nil {
+		return err
+	}
+	if err := formatter.FormatList(f, headers, rows); err != nil {
+		return fmt.Errorf("failed to write %s: %w", downloadOut, err)
+	}
+	if !quiet {
+		fmt.Printf("Wrote %d download URLs to %s\n", len(rows), downloadOut)
+	}
+	return f.Close()
+}
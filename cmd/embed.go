@@ -5,12 +5,15 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 
 	"cfstream/internal/api"
 	"cfstream/internal/config"
+	"cfstream/internal/embed"
 )
 
 var embedCmd = &cobra.Command{
@@ -22,9 +25,18 @@ var embedCmd = &cobra.Command{
 var embedCodeCmd = &cobra.Command{
 	Use:   "code <video-id>",
 	Short: "Get HTML embed code",
-	Long:  `Get HTML iframe embed code for a video.`,
-	Args:  cobra.ExactArgs(1),
-	RunE:  runEmbedCode,
+	Long: `Get embed code for a video.
+
+By default an HTML iframe is printed. Use --target for other snippets:
+  stream-element  <stream> web component with the Stream SDK script tag
+  react           React component using @cloudflare/stream-react
+  hls-js          plain <video> element with HLS.js against the manifest URL
+
+--self-hosted is shorthand for --target hls-js: the snippet plays the HLS
+manifest in a native <video> element (HLS.js where HLS isn't supported
+natively) instead of the Cloudflare iframe, so the page controls the player.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runEmbedCode,
 }
 
 var (
@@ -34,6 +46,18 @@ var (
 	embedLoop       bool
 	embedControls   bool
 	embedDuration   string
+	embedCopy       bool
+
+	embedWidth            int
+	embedHeight           int
+	embedPoster           string
+	embedPreload          string
+	embedPrimaryColor     string
+	embedLetterboxColor   string
+	embedStartTime        string
+	embedDefaultTextTrack string
+	embedTarget           string
+	embedSelfHosted       bool
 )
 
 func init() {
@@ -47,19 +71,54 @@ func init() {
 	embedCodeCmd.Flags().BoolVar(&embedLoop, "loop", false, "loop video")
 	embedCodeCmd.Flags().BoolVar(&embedControls, "controls", true, "show controls")
 	embedCodeCmd.Flags().StringVar(&embedDuration, "duration", "", "signed URL duration (e.g., 1h, 24h) - required for private videos")
+	embedCodeCmd.Flags().BoolVar(&embedCopy, "copy", false, "copy the embed code to the clipboard")
+	embedCodeCmd.Flags().IntVar(&embedWidth, "width", 0, "player width in pixels (default 1280)")
+	embedCodeCmd.Flags().IntVar(&embedHeight, "height", 0, "player height in pixels (default 720)")
+	embedCodeCmd.Flags().StringVar(&embedPoster, "poster", "", "poster image URL shown before playback")
+	embedCodeCmd.Flags().StringVar(&embedPreload, "preload", "", "preload behavior (none, metadata, auto)")
+	embedCodeCmd.Flags().StringVar(&embedPrimaryColor, "primary-color", "", "player accent color (e.g., #f48120)")
+	embedCodeCmd.Flags().StringVar(&embedLetterboxColor, "letterbox-color", "", "letterbox color (e.g., transparent)")
+	embedCodeCmd.Flags().StringVar(&embedStartTime, "start-time", "", "initial playback position (e.g., 1m30s)")
+	embedCodeCmd.Flags().StringVar(&embedTarget, "target", "iframe", "snippet type: "+strings.Join(embed.Targets, ", "))
+	embedCodeCmd.Flags().BoolVar(&embedSelfHosted, "self-hosted", false, "emit a native <video>/HLS.js player instead of the Stream iframe (same as --target hls-js)")
+	embedCodeCmd.Flags().StringVar(&embedDefaultTextTrack, "default-text-track", "", "language code of captions to show by default (e.g., en)")
+	addAccessRuleFlags(embedCodeCmd)
 }
 
 func runEmbedCode(cmd *cobra.Command, args []string) error {
-	videoID := args[0]
+	videoID, err := resolveVideoID(args[0])
+	if err != nil {
+		return err
+	}
+
+	target := embedTarget
+	if embedSelfHosted {
+		if cmd.Flags().Changed("target") && target != "hls-js" {
+			return fmt.Errorf("--self-hosted cannot be combined with --target %s", target)
+		}
+		target = "hls-js"
+	}
+	if !slices.Contains(embed.Targets, target) {
+		return fmt.Errorf("invalid target: %s (use %s)", target, strings.Join(embed.Targets, ", "))
+	}
+
+	switch embedPreload {
+	case "", "none", "metadata", "auto":
+	default:
+		return fmt.Errorf("invalid preload value: %s (use none, metadata, or auto)", embedPreload)
+	}
+	if embedWidth < 0 || embedHeight < 0 {
+		return fmt.Errorf("--width and --height must be positive")
+	}
 
 	cfg, err := config.Load()
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w\nRun 'cfstream config init' to configure credentials", err)
 	}
 
-	client, err := api.NewClient(cfg.AccountID, cfg.APIToken)
+	client, err := createClient()
 	if err != nil {
-		return fmt.Errorf("failed to create API client: %w", err)
+		return err
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -94,7 +153,7 @@ func runEmbedCode(cmd *cobra.Command, args []string) error {
 
 		// Generate signed token (calculate absolute expiration timestamp)
 		expirationTime := time.Now().Unix() + int64(d.Seconds())
-		token, err := client.GetSignedToken(ctx, videoID, expirationTime)
+		token, err := mintToken(ctx, client, videoID, &api.TokenOptions{Expires: expirationTime})
 		if err != nil {
 			return fmt.Errorf("failed to generate signed token: %w", err)
 		}
@@ -109,12 +168,37 @@ func runEmbedCode(cmd *cobra.Command, args []string) error {
 		Loop:        embedLoop,
 		Controls:    embedControls,
 		SignedToken: signedToken,
+
+		Width:            embedWidth,
+		Height:           embedHeight,
+		Poster:           embedPoster,
+		Preload:          embedPreload,
+		PrimaryColor:     embedPrimaryColor,
+		LetterboxColor:   embedLetterboxColor,
+		StartTime:        embedStartTime,
+		DefaultTextTrack: embedDefaultTextTrack,
 	}
 
 	// Get embed code
-	embedCode, err := client.GetEmbedCode(ctx, videoID, opts)
-	if err != nil {
-		return fmt.Errorf("failed to get embed code: %w", err)
+	var embedCode string
+	if target == "iframe" {
+		embedCode, err = client.GetEmbedCode(ctx, videoID, opts)
+		if err != nil {
+			return fmt.Errorf("failed to get embed code: %w", err)
+		}
+	} else {
+		customerCode, err := extractCustomerCodeFromURL(video.Preview)
+		if err != nil {
+			return fmt.Errorf("failed to extract customer code: %w", err)
+		}
+		embedCode, err = embed.Render(target, embed.Video{CustomerCode: customerCode, UID: videoID}, opts)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := copyToClipboard(embedCopy, embedCode); err != nil {
+		return err
 	}
 
 	if outputFormat == outputFormatJSON {
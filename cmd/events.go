@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"cfstream/internal/api"
+	"cfstream/internal/changes"
+	"cfstream/internal/config"
+)
+
+var eventsCmd = &cobra.Command{
+	Use:   "events",
+	Short: "Follow changes to the library",
+	Long:  `Follow changes to the video library for accounts that can't receive webhooks.`,
+}
+
+var eventsTailCmd = &cobra.Command{
+	Use:   "tail",
+	Short: "Poll the library and print change events",
+	Long: `Poll the library every --interval and print one JSON object per line for
+each change since the previous poll:
+
+  {"event":"ready","time":"2024-06-01T12:00:30Z","uid":"abc123","name":"Intro","status":"ready"}
+
+Events are created, ready, errored (with the error in "error"), and deleted.
+State is kept per profile in the cfstream state directory
+($XDG_STATE_HOME/cfstream/events.json), so a restarted tail reports what
+changed while it was stopped. When there is no state yet, the first poll only
+records the current library. Stop with Ctrl-C.`,
+	Args: cobra.NoArgs,
+	RunE: runEventsTail,
+}
+
+var eventsInterval time.Duration
+
+func init() {
+	rootCmd.AddCommand(eventsCmd)
+	eventsCmd.AddCommand(eventsTailCmd)
+
+	eventsTailCmd.Flags().DurationVar(&eventsInterval, "interval", 30*time.Second, "time between polls")
+}
+
+func runEventsTail(cmd *cobra.Command, args []string) error {
+	if eventsInterval < time.Second {
+		return fmt.Errorf("--interval must be at least 1s")
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	profile := profileName
+	if profile == config.DefaultProfile {
+		profile = ""
+	}
+	state, err := changes.LoadState(profile)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	enc := json.NewEncoder(os.Stdout)
+	baseline := state.Updated.IsZero()
+
+	for {
+		if err := pollEvents(ctx, client, profile, state, enc, baseline); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			// A failed poll is retried on the next tick; the state is unchanged
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		} else {
+			baseline = false
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(eventsInterval):
+		}
+	}
+}
+
+// pollEvents lists the whole library, prints the changes since the previous
+// poll, and saves the new state of profile. A baseline poll only records the
+// state. Deletions are derived from missing videos, so the listing must not
+// stop at the first page.
+func pollEvents(ctx context.Context, client api.Client, profile string, state *changes.State, enc *json.Encoder, baseline bool) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+	defer cancel()
+
+	videos, err := listAllVideos(ctx, client, &api.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	events := changes.Diff(state.Videos, videos, time.Now().UTC())
+	if baseline {
+		if !quiet {
+			fmt.Fprintf(os.Stderr, "Recorded %d videos; watching for changes\n", len(videos))
+		}
+		events = nil
+	}
+
+	for _, e := range events {
+		if err := enc.Encode(e); err != nil {
+			return fmt.Errorf("failed to write event: %w", err)
+		}
+	}
+	state.Record(videos)
+
+	return changes.SaveState(profile, state)
+}
@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"cfstream/internal/api"
+	"cfstream/internal/batch"
+	"cfstream/internal/export"
+	"cfstream/internal/output"
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the video catalog with its metadata",
+	Long: `Export every video with its metadata, signed URL and origin settings, and
+caption inventory, e.g. to keep the catalog under version control:
+
+  cfstream export --out videos.json
+  cfstream export --out videos.csv
+
+The format follows the file extension (.json, .yaml, .csv) unless --format
+is given, and defaults to JSON on stdout. Videos are sorted by UID so that
+exports of an unchanged library are identical. A JSON or YAML export can be
+compared with the account later using 'cfstream diff'.`,
+	Args: cobra.NoArgs,
+	RunE: runExport,
+}
+
+var (
+	exportOut         string
+	exportFormat      string
+	exportConcurrency int
+)
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+
+	exportCmd.Flags().StringVar(&exportOut, "out", "-", "file to write, or - for stdout")
+	exportCmd.Flags().StringVar(&exportFormat, "format", "", "json, yaml, or csv (default: from the --out extension, else json)")
+	exportCmd.Flags().IntVar(&exportConcurrency, "concurrency", 4, "number of concurrent caption requests")
+}
+
+func runExport(cmd *cobra.Command, args []string) error {
+	format := exportFormat
+	if format == "" {
+		format = exportFormatFor(exportOut)
+	}
+	if format != outputFormatJSON && format != outputFormatYAML && format != outputFormatCSV {
+		return fmt.Errorf("invalid --format value: %s (json, yaml, or csv)", format)
+	}
+	if exportConcurrency < 1 {
+		return fmt.Errorf("--concurrency must be at least 1")
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	defer cancel()
+
+	videos, err := listAllVideos(ctx, client, &api.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	captions, err := captionInventory(ctx, client, videos)
+	if err != nil {
+		return err
+	}
+	catalog := export.New(videos, captions)
+
+	formatter, err := output.NewFormatter(format)
+	if err != nil {
+		return err
+	}
+
+	// The export is formatted in full first, so a failure doesn't leave a
+	// truncated backup behind
+	var buf bytes.Buffer
+	if format == outputFormatCSV {
+		rows, err := catalog.Rows()
+		if err != nil {
+			return err
+		}
+		if err := formatter.FormatList(&buf, export.RowHeaders, rows); err != nil {
+			return fmt.Errorf("failed to format export: %w", err)
+		}
+	} else if err := formatter.FormatSingle(&buf, catalog); err != nil {
+		return fmt.Errorf("failed to format export: %w", err)
+	}
+
+	if exportOut == "-" {
+		_, err := os.Stdout.Write(buf.Bytes())
+		return err
+	}
+	if err := os.WriteFile(exportOut, buf.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("failed to write export: %w", err)
+	}
+	if !quiet {
+		fmt.Printf("Exported %d videos to %s\n", len(catalog.Videos), exportOut)
+	}
+	return nil
+}
+
+// exportFormatFor returns the export format implied by the extension of path.
+func exportFormatFor(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return outputFormatCSV
+	case ".yaml", ".yml":
+		return outputFormatYAML
+	default:
+		return outputFormatJSON
+	}
+}
+
+// captionInventory lists the caption tracks of every video concurrently,
+// keyed by video UID. Any failure fails the export, so a backup is never
+// silently missing captions.
+func captionInventory(ctx context.Context, client api.Client, videos []api.Video) (map[string][]api.Caption, error) {
+	ids := make([]string, len(videos))
+	for i, v := range videos {
+		ids[i] = v.UID
+	}
+
+	var mu sync.Mutex
+	captions := make(map[string][]api.Caption, len(videos))
+
+	bar := batchProgress(len(ids), "Listing captions")
+	results := batch.Run(ctx, ids, batch.Options{
+		Concurrency: exportConcurrency,
+		Retries:     batchRetries,
+		Backoff:     time.Second,
+		OnThrottle:  reportThrottle(bar),
+		OnDone: func(r batch.Result) {
+			bar.Item(r.ID, r.Err)
+		},
+	}, func(ctx context.Context, id string) error {
+		ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		defer cancel()
+
+		tracks, err := client.ListCaptions(ctx, id)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		captions[id] = tracks
+		mu.Unlock()
+		return nil
+	})
+	bar.Finish()
+
+	if failed := batch.Failed(results); len(failed) > 0 {
+		return nil, fmt.Errorf("failed to list captions of %d videos (first: %s: %w)", len(failed), failed[0].ID, failed[0].Err)
+	}
+	return captions, nil
+}
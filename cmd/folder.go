@@ -0,0 +1,467 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/cobra"
+
+	"cfstream/internal/api"
+	"cfstream/internal/batch"
+	"cfstream/internal/tags"
+	"cfstream/internal/upload"
+)
+
+// Ways upload dir recognizes files that were uploaded before.
+const (
+	skipByName     = "name"
+	skipByChecksum = "checksum"
+	skipNone       = "none"
+)
+
+var uploadDirCmd = &cobra.Command{
+	Use:   "dir <directory>",
+	Short: "Upload the video files of a directory",
+	Long: `Upload the files of a directory whose names match --glob, e.g.
+
+  cfstream upload dir ./videos --glob '*.mp4' --recursive --tag webinar
+
+Videos are named after the file's path relative to the directory. Hidden
+files and directories are skipped. Files uploaded before are skipped too,
+recognized by --skip-existing:
+
+  name      a video with the same name exists (default)
+  checksum  a video has the file's SHA-256 in its sha256 metadata key; the
+            checksum is recorded on upload, so renamed files are recognized
+  none      upload every matching file
+
+--metadata and --tag apply to every uploaded video. Files are uploaded
+concurrently like 'upload file' with several files.`,
+	Args: cobra.ExactArgs(1),
+	RunE: notifyOnFinish("Upload", runUploadDir),
+}
+
+var uploadWatchCmd = &cobra.Command{
+	Use:   "watch <directory>",
+	Short: "Upload files as they are added to a directory",
+	Long: `Watch a directory and upload each new file whose name matches --glob,
+e.g.
+
+  cfstream upload watch ./incoming --glob '*.mp4' --move-to done
+
+Files already in the directory are uploaded at startup. A file is uploaded
+once it has not changed for --settle, so files still being copied are not
+uploaded half-written. With --move-to, uploaded files are moved into that
+subdirectory.
+
+Up to --concurrency files are uploaded at once while the directory keeps
+being watched. Uploaded files are recorded by name, size, and modification
+time in --state-file (default <directory>/.cfstream-uploaded), so a restarted
+watch does not upload them again, while a new recording that reuses a name
+is still uploaded. Failed uploads are reported and retried when the file
+changes or the watch restarts. Subdirectories and hidden files are ignored.
+Stop with Ctrl-C.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runUploadWatch,
+}
+
+var (
+	dirGlob         string
+	dirRecursive    bool
+	dirSkipExisting string
+	dirTags         []string
+	dirConcurrency  int
+
+	folderMoveTo      string
+	folderStateFile   string
+	folderSettle      time.Duration
+	folderConcurrency int
+)
+
+func init() {
+	uploadCmd.AddCommand(uploadDirCmd)
+
+	uploadDirCmd.Flags().StringVar(&dirGlob, "glob", "*", "only upload files whose names match this pattern, e.g. '*.mp4'")
+	uploadDirCmd.Flags().BoolVar(&dirRecursive, "recursive", false, "also upload files in subdirectories")
+	uploadDirCmd.Flags().StringVar(&dirSkipExisting, "skip-existing", skipByName, "recognize uploaded files by name, checksum, or none")
+	uploadDirCmd.Flags().StringArrayVar(&dirTags, "tag", nil, "tag every uploaded video (repeatable)")
+	uploadDirCmd.Flags().StringVar(&uploadMetadata, "metadata", "", "video metadata as JSON, applied to every video")
+	uploadDirCmd.Flags().IntVar(&dirConcurrency, "concurrency", 3, "number of files uploaded at once")
+	uploadDirCmd.Flags().BoolVar(&uploadValidate, "validate", false, "check duration, codec, and size against Stream limits before uploading")
+
+	uploadCmd.AddCommand(uploadWatchCmd)
+
+	uploadWatchCmd.Flags().StringVar(&dirGlob, "glob", "*", "only upload files whose names match this pattern, e.g. '*.mp4'")
+	uploadWatchCmd.Flags().StringArrayVar(&dirTags, "tag", nil, "tag every uploaded video (repeatable)")
+	uploadWatchCmd.Flags().StringVar(&uploadMetadata, "metadata", "", "video metadata as JSON, applied to every video")
+	uploadWatchCmd.Flags().StringVar(&folderMoveTo, "move-to", "", "move uploaded files into this subdirectory, e.g. done")
+	uploadWatchCmd.Flags().StringVar(&folderStateFile, "state-file", "", "file recording uploaded files (default <directory>/.cfstream-uploaded)")
+	uploadWatchCmd.Flags().DurationVar(&folderSettle, "settle", 5*time.Second, "upload a file once it has not changed for this long")
+	uploadWatchCmd.Flags().IntVar(&folderConcurrency, "concurrency", 2, "number of files uploaded at once")
+	uploadWatchCmd.Flags().BoolVar(&uploadValidate, "validate", false, "check duration, codec, and size against Stream limits before uploading")
+}
+
+func runUploadDir(cmd *cobra.Command, args []string) error {
+	if err := checkUploadFlags(); err != nil {
+		return err
+	}
+
+	dir := args[0]
+	switch dirSkipExisting {
+	case skipByName, skipByChecksum, skipNone:
+	default:
+		return fmt.Errorf("invalid --skip-existing value: %s (name, checksum, or none)", dirSkipExisting)
+	}
+	metadata, err := dirMetadata()
+	if err != nil {
+		return err
+	}
+
+	paths, err := upload.Find(dir, dirGlob, dirRecursive)
+	if err != nil {
+		return err
+	}
+	if len(paths) == 0 {
+		if !quiet {
+			fmt.Printf("No files matching %s in %s\n", dirGlob, dir)
+		}
+		return nil
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	var existing []api.Video
+	if dirSkipExisting != skipNone {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		existing, err = listAllVideos(ctx, client, &api.ListOptions{})
+		cancel()
+		if err != nil {
+			return err
+		}
+	}
+
+	files, skipped, err := dirUploads(dir, paths, metadata, existing)
+	if err != nil {
+		return err
+	}
+	if skipped > 0 && !quiet {
+		fmt.Printf("Skipping %d files uploaded before\n", skipped)
+	}
+	if len(files) == 0 {
+		if !quiet {
+			fmt.Println("All files are uploaded; nothing to do")
+		}
+		return nil
+	}
+
+	toUpload := make([]string, len(files))
+	for i, f := range files {
+		toUpload[i] = f.Path
+	}
+	minutes, err := validateUploads(toUpload)
+	if err != nil {
+		return err
+	}
+	if err := checkQuota(context.Background(), client, minutes); err != nil {
+		return err
+	}
+	return uploadFiles(client, files, dirConcurrency, nil, nil)
+}
+
+// dirMetadata returns the metadata shared by the videos of a directory:
+// --metadata with the --tag tags added.
+func dirMetadata() (map[string]interface{}, error) {
+	for _, tag := range dirTags {
+		if err := tags.Validate(tag); err != nil {
+			return nil, err
+		}
+	}
+
+	var metadata map[string]interface{}
+	if uploadMetadata != "" {
+		if err := json.Unmarshal([]byte(uploadMetadata), &metadata); err != nil {
+			return nil, fmt.Errorf("invalid metadata JSON: %w", err)
+		}
+	}
+	if len(dirTags) > 0 {
+		current, _ := tags.Add(tags.Get(metadata), dirTags...)
+		metadata = tags.Set(metadata, current)
+	}
+	return metadata, nil
+}
+
+// dirUploads returns the uploads of the files at paths in dir that are not
+// among the existing videos, and how many files were skipped.
+func dirUploads(dir string, paths []string, metadata map[string]interface{}, existing []api.Video) ([]fileUpload, int, error) {
+	names := make(map[string]bool, len(existing))
+	checksums := make(map[string]bool, len(existing))
+	for i := range existing {
+		names[existing[i].Name] = true
+		if sum, ok := existing[i].Meta[upload.ChecksumKey].(string); ok {
+			checksums[sum] = true
+		}
+	}
+
+	var files []fileUpload
+	skipped := 0
+	for _, path := range paths {
+		name, err := filepath.Rel(dir, path)
+		if err != nil {
+			name = filepath.Base(path)
+		}
+		name = filepath.ToSlash(name)
+
+		meta := metadata
+		switch dirSkipExisting {
+		case skipByName:
+			if names[name] {
+				skipped++
+				continue
+			}
+		case skipByChecksum:
+			sum, err := upload.Checksum(path)
+			if err != nil {
+				return nil, 0, err
+			}
+			if checksums[sum] {
+				skipped++
+				continue
+			}
+			meta = withMeta(metadata, upload.ChecksumKey, sum)
+		}
+
+		files = append(files, fileUpload{Path: path, Name: name, Meta: meta})
+	}
+	return files, skipped, nil
+}
+
+func runUploadWatch(cmd *cobra.Command, args []string) error {
+	if err := checkUploadFlags(); err != nil {
+		return err
+	}
+
+	dir := args[0]
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return fmt.Errorf("not a directory: %s", dir)
+	}
+	if _, err := filepath.Match(dirGlob, ""); err != nil {
+		return fmt.Errorf("invalid glob %q: %w", dirGlob, err)
+	}
+	if folderSettle <= 0 {
+		return fmt.Errorf("--settle must be positive")
+	}
+	if folderConcurrency < 1 {
+		return fmt.Errorf("--concurrency must be at least 1")
+	}
+
+	metadata, err := dirMetadata()
+	if err != nil {
+		return err
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	statePath := folderStateFile
+	if statePath == "" {
+		statePath = filepath.Join(dir, ".cfstream-uploaded")
+	}
+	state, err := batch.OpenCheckpoint(statePath)
+	if err != nil {
+		return err
+	}
+	defer state.Close()
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to watch directory: %w", err)
+	}
+	defer watcher.Close()
+	if err := watcher.Add(dir); err != nil {
+		return fmt.Errorf("failed to watch directory: %w", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := checkQuota(ctx, client, 0); err != nil {
+		return err
+	}
+
+	// Files are uploaded once no change was seen for --settle; existing
+	// files wait too, as they may still be being copied
+	changed := make(map[string]time.Time)
+	existing, err := upload.Find(dir, dirGlob, false)
+	if err != nil {
+		return err
+	}
+	for _, path := range existing {
+		changed[path] = time.Now()
+	}
+	if !quiet {
+		fmt.Fprintf(os.Stderr, "Watching %s for %s; %d files already uploaded\n", dir, dirGlob, state.Len())
+	}
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	// Uploads run in the background so events keep being read while large
+	// files upload; a file is not queued again while its upload runs.
+	// Dry-run plans are printed one at a time so they don't interleave
+	concurrency := folderConcurrency
+	if dryRun {
+		concurrency = 1
+	}
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		uploading = make(map[string]bool)
+		slots     = make(chan struct{}, concurrency)
+	)
+	defer wg.Wait()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if !watchedFile(event.Name) {
+				continue
+			}
+			if event.Has(fsnotify.Remove) || event.Has(fsnotify.Rename) {
+				delete(changed, event.Name)
+			} else if event.Has(fsnotify.Create) || event.Has(fsnotify.Write) {
+				changed[event.Name] = time.Now()
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		case <-ticker.C:
+			for path, at := range changed {
+				if time.Since(at) < folderSettle {
+					continue
+				}
+				mu.Lock()
+				busy := uploading[path]
+				uploading[path] = true
+				mu.Unlock()
+				if busy {
+					// Changed during its upload; queued again once that finishes
+					continue
+				}
+				delete(changed, path)
+
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					defer func() {
+						mu.Lock()
+						delete(uploading, path)
+						mu.Unlock()
+					}()
+					select {
+					case slots <- struct{}{}:
+					case <-ctx.Done():
+						return
+					}
+					defer func() { <-slots }()
+					uploadWatchedFile(ctx, client, state, dir, path, metadata)
+				}()
+			}
+		}
+	}
+}
+
+// watchStateKey identifies an uploaded file in the upload watch state by
+// name, size, and modification time, so a new file reusing the name of an
+// uploaded one is not mistaken for it.
+func watchStateKey(name string, info os.FileInfo) string {
+	return fmt.Sprintf("%s %d %d", name, info.Size(), info.ModTime().UnixNano())
+}
+
+// watchedFile reports whether path is a file upload watch uploads.
+func watchedFile(path string) bool {
+	name := filepath.Base(path)
+	if strings.HasPrefix(name, ".") {
+		return false
+	}
+	ok, _ := filepath.Match(dirGlob, name) //nolint:errcheck // Pattern is validated by runUploadWatch
+	return ok
+}
+
+// uploadWatchedFile uploads a settled file of a watched directory, records
+// it in the state, and moves it with --move-to. Failures are reported, not
+// returned, so the watch keeps running. It is safe for concurrent use.
+func uploadWatchedFile(ctx context.Context, client api.Client, state *batch.Checkpoint, dir, path string, metadata map[string]interface{}) {
+	name := filepath.Base(path)
+	info, err := os.Stat(path)
+	if err != nil || !info.Mode().IsRegular() {
+		return
+	}
+	key := watchStateKey(name, info)
+	if state.Done(key) {
+		if !quiet {
+			fmt.Fprintf(os.Stderr, "Skipping %s: uploaded before\n", name)
+		}
+		return
+	}
+
+	if _, err := validateUploads([]string{path}); err != nil {
+		fmt.Fprintf(os.Stderr, "Skipping %s: %v\n", name, err)
+		return
+	}
+
+	recorder := &resumeRecorder{path: path, name: name}
+	video, err := client.UploadFile(ctx, path, &api.UploadOptions{
+		Name:                  name,
+		Metadata:              metadata,
+		RequireSignedURLs:     true,
+		Watermark:             uploadWatermark,
+		ThumbnailTimestampPct: uploadThumbnailPct,
+		OnResumable:           recorder.record,
+	}, nil)
+	if isDryRun(err) {
+		return
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to upload %s: %v\n", name, err)
+		return
+	}
+	recorder.finish()
+
+	if err := state.Record(key); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	fmt.Printf("Uploaded %s as %s\n", name, video.UID)
+
+	if folderMoveTo == "" {
+		return
+	}
+	doneDir := filepath.Join(dir, folderMoveTo)
+	if err := os.MkdirAll(doneDir, 0o755); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to create %s: %v\n", doneDir, err)
+		return
+	}
+	if err := os.Rename(path, filepath.Join(doneDir, name)); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to move %s: %v\n", name, err)
+	}
+}
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/cobra/doc"
+)
+
+var genCmd = &cobra.Command{
+	Use:    "gen",
+	Short:  "Generate man pages, docs, and shell completions",
+	Long:   `Generate man pages, markdown docs, and shell completion scripts for packaging.`,
+	Hidden: true,
+}
+
+var genManCmd = &cobra.Command{
+	Use:   "man",
+	Short: "Generate man pages",
+	Long:  `Generate one troff man page per command into the output directory.`,
+	Args:  cobra.NoArgs,
+	RunE:  runGenMan,
+}
+
+var genMarkdownCmd = &cobra.Command{
+	Use:   "markdown",
+	Short: "Generate markdown docs",
+	Long:  `Generate one markdown reference page per command into the output directory.`,
+	Args:  cobra.NoArgs,
+	RunE:  runGenMarkdown,
+}
+
+var genCompletionCmd = &cobra.Command{
+	Use:       "completion <bash|zsh|fish|powershell>",
+	Short:     "Generate a shell completion script",
+	Long:      `Generate a shell completion script and write it to stdout or --out.`,
+	Args:      cobra.ExactArgs(1),
+	ValidArgs: []string{"bash", "zsh", "fish", "powershell"},
+	RunE:      runGenCompletion,
+}
+
+var (
+	genManDir      string
+	genMarkdownDir string
+	genOut         string
+)
+
+func init() {
+	rootCmd.AddCommand(genCmd)
+	genCmd.AddCommand(genManCmd)
+	genCmd.AddCommand(genMarkdownCmd)
+	genCmd.AddCommand(genCompletionCmd)
+
+	genManCmd.Flags().StringVar(&genManDir, "dir", "man", "output directory")
+	genMarkdownCmd.Flags().StringVar(&genMarkdownDir, "dir", "docs", "output directory")
+	genCompletionCmd.Flags().StringVar(&genOut, "out", "", "output file (defaults to stdout)")
+}
+
+func runGenMan(cmd *cobra.Command, args []string) error {
+	date := buildDate()
+	header := &doc.GenManHeader{
+		Section: "1",
+		Source:  "cfstream " + version,
+		Manual:  "cfstream Manual",
+		Date:    &date,
+	}
+
+	if err := os.MkdirAll(genManDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	if err := doc.GenManTree(rootCmd, header, genManDir); err != nil {
+		return fmt.Errorf("failed to generate man pages: %w", err)
+	}
+
+	if !quiet {
+		fmt.Printf("Man pages written to %s\n", genManDir)
+	}
+	return nil
+}
+
+func runGenMarkdown(cmd *cobra.Command, args []string) error {
+	if err := os.MkdirAll(genMarkdownDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	if err := doc.GenMarkdownTree(rootCmd, genMarkdownDir); err != nil {
+		return fmt.Errorf("failed to generate markdown docs: %w", err)
+	}
+
+	if !quiet {
+		fmt.Printf("Markdown docs written to %s\n", genMarkdownDir)
+	}
+	return nil
+}
+
+func runGenCompletion(cmd *cobra.Command, args []string) error {
+	var w io.Writer = os.Stdout
+	if genOut != "" {
+		f, err := os.Create(genOut)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	var err error
+	switch args[0] {
+	case "bash":
+		err = rootCmd.GenBashCompletionV2(w, true)
+	case "zsh":
+		err = rootCmd.GenZshCompletion(w)
+	case "fish":
+		err = rootCmd.GenFishCompletion(w, true)
+	case "powershell":
+		err = rootCmd.GenPowerShellCompletionWithDesc(w)
+	default:
+		return fmt.Errorf("unsupported shell: %s (supported: bash, zsh, fish, powershell)", args[0])
+	}
+	if err != nil {
+		return fmt.Errorf("failed to generate completion: %w", err)
+	}
+
+	return nil
+}
+
+// buildDate returns SOURCE_DATE_EPOCH when set, for reproducible package builds,
+// and the current time otherwise.
+func buildDate() time.Time {
+	if epoch := os.Getenv("SOURCE_DATE_EPOCH"); epoch != "" {
+		if seconds, err := strconv.ParseInt(epoch, 10, 64); err == nil {
+			return time.Unix(seconds, 0).UTC()
+		}
+	}
+	return time.Now()
+}
@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"cfstream/internal/api"
+	"cfstream/internal/batch"
+	"cfstream/internal/manifest"
+	"cfstream/internal/output"
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Restore video settings from an export",
+	Long: `Restore the names, metadata, requireSignedURLs, and allowedOrigins of
+existing videos from a file written by 'cfstream export', or any manifest
+accepted by 'cfstream diff'.
+
+The differing fields are listed first, then the videos are updated after
+confirmation. Only fields present in the file are restored, and a video's
+metadata is replaced with the file's. Videos in the file that no longer exist
+are reported and skipped; nothing is uploaded or deleted. Use --dry-run to
+only list the changes, and --checkpoint to skip the videos an interrupted run
+already updated.`,
+	Args: cobra.ExactArgs(1),
+	RunE: notifyOnFinish("Import", runImport),
+}
+
+var (
+	importConcurrency int
+	importCheckpoint  string
+)
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+
+	importCmd.Flags().IntVar(&importConcurrency, "concurrency", 4, "number of concurrent updates")
+	importCmd.Flags().StringVar(&importCheckpoint, "checkpoint", "", "file recording updated IDs; completed IDs are skipped on rerun")
+}
+
+func runImport(cmd *cobra.Command, args []string) error {
+	entries, err := manifest.Load(args[0])
+	if err != nil {
+		return err
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	videos, err := listAllVideos(ctx, client, &api.ListOptions{})
+	cancel()
+	if err != nil {
+		return err
+	}
+
+	live := make(map[string]*api.Video, len(videos))
+	for i := range videos {
+		live[videos[i].UID] = &videos[i]
+	}
+
+	// Only changed fields and missing videos matter; videos absent from the
+	// file are left alone
+	var changes []manifest.Change
+	missing := 0
+	for _, c := range manifest.Diff(entries, videos) {
+		switch c.Kind {
+		case manifest.Changed:
+			changes = append(changes, c)
+		case manifest.Removed:
+			c.Kind = "missing"
+			changes = append(changes, c)
+			missing++
+		}
+	}
+
+	updates := make(map[string]*api.UpdateOptions)
+	var videoIDs []string
+	for i := range entries {
+		video, ok := live[entries[i].UID]
+		if !ok {
+			continue
+		}
+		if opts, changed := manifest.Update(&entries[i], video); changed {
+			updates[video.UID] = opts
+			videoIDs = append(videoIDs, video.UID)
+		}
+	}
+
+	if len(changes) > 0 {
+		formatter, err := output.NewFormatter(outputFormat)
+		if err != nil {
+			return err
+		}
+		headers := []string{"Kind", "UID", "Name", "Field", "Manifest", "Account"}
+		if err := formatter.FormatList(os.Stdout, headers, changes); err != nil {
+			return fmt.Errorf("failed to format output: %w", err)
+		}
+	}
+	if missing > 0 && !quiet {
+		fmt.Printf("%d videos in %s no longer exist and are skipped\n", missing, args[0])
+	}
+
+	if len(videoIDs) == 0 {
+		if !quiet {
+			fmt.Println("All videos match; nothing to import")
+		}
+		return nil
+	}
+
+	ok, err := confirm(fmt.Sprintf("Update %d videos?", len(videoIDs)))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		fmt.Println("Import cancelled")
+		return nil
+	}
+
+	// Dry-run plans are printed sequentially so they don't interleave
+	concurrency := importConcurrency
+	if dryRun {
+		concurrency = 1
+	}
+	if concurrency < 1 {
+		return fmt.Errorf("--concurrency must be at least 1")
+	}
+
+	// Dry runs don't record anything in the checkpoint
+	var checkpoint *batch.Checkpoint
+	if importCheckpoint != "" && !dryRun {
+		checkpoint, err = openCheckpoint(importCheckpoint)
+		if err != nil {
+			return err
+		}
+		defer checkpoint.Close()
+	}
+
+	bar := batchProgress(len(videoIDs), "Updating")
+	results := batch.Run(context.Background(), videoIDs, batch.Options{
+		Concurrency: concurrency,
+		Retries:     batchRetries,
+		Backoff:     time.Second,
+		Checkpoint:  checkpoint,
+		OnThrottle:  reportThrottle(bar),
+		OnDone: func(r batch.Result) {
+			bar.Item(r.ID, r.Err)
+		},
+	}, func(ctx context.Context, id string) error {
+		ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		defer cancel()
+
+		_, err := client.UpdateVideo(ctx, id, updates[id])
+		if isDryRun(err) {
+			return nil
+		}
+		return err
+	})
+	bar.Finish()
+
+	if dryRun {
+		return nil
+	}
+	return reportBatch(results, "updated")
+}
@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"cfstream/internal/presign"
+)
+
+var uploadR2Cmd = &cobra.Command{
+	Use:   "r2 <bucket/key>",
+	Short: "Upload a video stored in R2",
+	Long: `Upload a video that is already stored in Cloudflare R2 without downloading it.
+
+A presigned GET URL is created for the object with the given R2 API token
+credentials and handed to Stream, which fetches the object itself. The
+credentials default to R2_ACCESS_KEY_ID and R2_SECRET_ACCESS_KEY, then
+AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY, and AWS_SESSION_TOKEN. The endpoint
+defaults to the R2 endpoint of the configured account.`,
+	Args: cobra.ExactArgs(1),
+	RunE: notifyOnFinish("Upload", func(cmd *cobra.Command, args []string) error {
+		return uploadFromObject("r2", args[0])
+	}),
+}
+
+var uploadS3Cmd = &cobra.Command{
+	Use:   "s3 <bucket/key>",
+	Short: "Upload a video stored in S3",
+	Long: `Upload a video that is already stored in Amazon S3 (or another S3-compatible
+store given with --endpoint) without downloading it.
+
+A presigned GET URL is created for the object and handed to Stream, which
+fetches the object itself. Credentials and region default to AWS_ACCESS_KEY_ID,
+AWS_SECRET_ACCESS_KEY, AWS_SESSION_TOKEN, and AWS_REGION. AWS_SESSION_TOKEN is
+only used together with the AWS_* keys; pass --session-token for temporary
+credentials given on the command line.`,
+	Args: cobra.ExactArgs(1),
+	RunE: notifyOnFinish("Upload", func(cmd *cobra.Command, args []string) error {
+		return uploadFromObject("s3", args[0])
+	}),
+}
+
+var (
+	objectAccessKeyID     string
+	objectSecretAccessKey string
+	objectSessionToken    string
+	objectRegion          string
+	objectEndpoint        string
+	objectExpires         time.Duration
+)
+
+func init() {
+	uploadCmd.AddCommand(uploadR2Cmd)
+	uploadCmd.AddCommand(uploadS3Cmd)
+
+	for _, c := range []*cobra.Command{uploadR2Cmd, uploadS3Cmd} {
+		c.Flags().StringVar(&uploadName, "name", "", "video name (defaults to the object name)")
+		c.Flags().StringVar(&uploadMetadata, "metadata", "", "video metadata as JSON")
+		c.Flags().BoolVar(&urlWait, "wait", false, "wait until the video is ready to stream, printing status changes")
+		c.Flags().DurationVar(&urlWaitTimeout, "timeout", 30*time.Minute, "maximum time to wait with --wait")
+		c.Flags().StringVar(&objectAccessKeyID, "access-key-id", "", "access key ID (defaults to the environment)")
+		c.Flags().StringVar(&objectSecretAccessKey, "secret-access-key", "", "secret access key (defaults to the environment)")
+		c.Flags().StringVar(&objectSessionToken, "session-token", "", "session token of temporary --access-key-id credentials")
+		c.Flags().StringVar(&objectEndpoint, "endpoint", "", "S3-compatible endpoint URL")
+		c.Flags().DurationVar(&objectExpires, "expires", time.Hour, "how long the presigned URL stays valid")
+	}
+	uploadS3Cmd.Flags().StringVar(&objectRegion, "region", "", "bucket region (defaults to AWS_REGION or us-east-1)")
+}
+
+// uploadFromObject presigns a GET URL for bucket/key in R2 or S3 and uploads
+// the object from it.
+func uploadFromObject(provider, location string) error {
+	bucket, key, ok := strings.Cut(strings.TrimPrefix(location, provider+"://"), "/")
+	if !ok || bucket == "" || key == "" {
+		return fmt.Errorf("invalid object location: %s (use bucket/key)", location)
+	}
+
+	creds, err := objectCredentials(provider)
+	if err != nil {
+		return err
+	}
+	if creds.AccessKeyID == "" || creds.SecretAccessKey == "" {
+		return fmt.Errorf("%s credentials not found (use --access-key-id and --secret-access-key)", strings.ToUpper(provider))
+	}
+
+	objectURL, region, err := objectLocation(provider, bucket, key)
+	if err != nil {
+		return err
+	}
+
+	signedURL, err := presign.GetURL(objectURL, region, creds, objectExpires, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to presign object URL: %w", err)
+	}
+
+	return uploadFromURL(signedURL, fmt.Sprintf("%s://%s/%s", provider, bucket, key), path.Base(key), false)
+}
+
+// objectCredentials returns the credentials given by the flags, or else those
+// from the environment. A session token belongs to its keys, so the
+// AWS_SESSION_TOKEN variable is only used with the AWS_* keys.
+func objectCredentials(provider string) (presign.Credentials, error) {
+	if objectAccessKeyID != "" || objectSecretAccessKey != "" {
+		if objectAccessKeyID == "" || objectSecretAccessKey == "" {
+			return presign.Credentials{}, fmt.Errorf("--access-key-id and --secret-access-key must be used together")
+		}
+		return presign.Credentials{
+			AccessKeyID:     objectAccessKeyID,
+			SecretAccessKey: objectSecretAccessKey,
+			SessionToken:    objectSessionToken,
+		}, nil
+	}
+	if objectSessionToken != "" {
+		return presign.Credentials{}, fmt.Errorf("--session-token requires --access-key-id and --secret-access-key")
+	}
+
+	if provider == "r2" && (os.Getenv("R2_ACCESS_KEY_ID") != "" || os.Getenv("R2_SECRET_ACCESS_KEY") != "") {
+		return presign.Credentials{
+			AccessKeyID:     os.Getenv("R2_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("R2_SECRET_ACCESS_KEY"),
+		}, nil
+	}
+	return presign.Credentials{
+		AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+	}, nil
+}
+
+// objectLocation returns the URL and signing region of an object.
+func objectLocation(provider, bucket, key string) (string, string, error) {
+	region := "auto"
+	if provider == "s3" {
+		region = firstNonEmpty(objectRegion, os.Getenv("AWS_REGION"), "us-east-1")
+	}
+
+	if objectEndpoint != "" {
+		endpoint, err := url.Parse(objectEndpoint)
+		if err != nil || endpoint.Host == "" {
+			return "", "", fmt.Errorf("invalid --endpoint: %s", objectEndpoint)
+		}
+		endpoint.Path = path.Join("/", endpoint.Path, bucket, key)
+		return endpoint.String(), region, nil
+	}
+
+	if provider == "r2" {
+		cfg, err := loadConfig()
+		if err != nil {
+			return "", "", err
+		}
+		if cfg.AccountID == "" {
+			return "", "", fmt.Errorf("account ID not configured (run 'cfstream config init' or use --endpoint)")
+		}
+		u := url.URL{Scheme: "https", Host: cfg.AccountID + ".r2.cloudflarestorage.com", Path: "/" + bucket + "/" + key}
+		return u.String(), region, nil
+	}
+
+	u := url.URL{Scheme: "https", Host: fmt.Sprintf("%s.s3.%s.amazonaws.com", bucket, region), Path: "/" + key}
+	return u.String(), region, nil
+}
+
+// firstNonEmpty returns the first of values that is not empty.
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
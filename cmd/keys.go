@@ -0,0 +1,235 @@
+package cmd
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"cfstream/internal/config"
+	"cfstream/internal/output"
+)
+
+var keysCmd = &cobra.Command{
+	Use:   "keys",
+	Short: "Manage signing keys for local token signing",
+	Long: `Manage the Stream signing keys used to sign tokens locally, without a
+token API request per signed URL.`,
+}
+
+var keysCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a signing key and store it locally",
+	Long: `Create a signing key, save its private key as PEM and JWK in the keys
+directory next to the config file, and make it the signing key used for
+local token signing. The private key can't be retrieved again later.
+
+Use --no-activate to store the key without changing the configured one.`,
+	Args: cobra.NoArgs,
+	RunE: runKeysCreate,
+}
+
+var keysListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List signing keys",
+	Long:  `List the account's signing keys, whether each is stored locally, and which one is active.`,
+	Args:  cobra.NoArgs,
+	RunE:  runKeysList,
+}
+
+var keysDeleteCmd = &cobra.Command{
+	Use:   "delete <key-id>",
+	Short: "Delete a signing key",
+	Long: `Delete a signing key from the account and its local copy. Tokens signed
+with the key stop working. If it is the active key, local signing is turned off.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runKeysDelete,
+}
+
+// keyRow is one row of the keys list output.
+type keyRow struct {
+	ID      string
+	Created string
+	Local   bool
+	Active  bool
+}
+
+var keysNoActivate bool
+
+func init() {
+	rootCmd.AddCommand(keysCmd)
+	keysCmd.AddCommand(keysCreateCmd)
+	keysCmd.AddCommand(keysListCmd)
+	keysCmd.AddCommand(keysDeleteCmd)
+
+	keysCreateCmd.Flags().BoolVar(&keysNoActivate, "no-activate", false, "don't make the new key the configured signing key")
+}
+
+func runKeysCreate(cmd *cobra.Command, args []string) error {
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	key, err := client.CreateSigningKey(ctx)
+	if err != nil {
+		if isDryRun(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to create signing key: %w", err)
+	}
+
+	// The key exists now, so it is saved before anything else can fail
+	pemPath, jwkPath := keyPaths(key.ID)
+	if err := os.MkdirAll(config.KeysDir(), 0o700); err != nil {
+		return fmt.Errorf("failed to create keys directory: %w", err)
+	}
+	if err := os.WriteFile(pemPath, decodeKey(key.PEM), 0o600); err != nil {
+		return fmt.Errorf("failed to save signing key %s: %w", key.ID, err)
+	}
+	if err := os.WriteFile(jwkPath, decodeKey(key.JWK), 0o600); err != nil {
+		return fmt.Errorf("failed to save signing key %s: %w", key.ID, err)
+	}
+
+	if !keysNoActivate {
+		if err := config.SetSigningKey(profileName, key.ID, pemPath); err != nil {
+			return err
+		}
+	}
+
+	if !quiet {
+		fmt.Printf("Signing key %s created\n", key.ID)
+		fmt.Printf("PEM: %s\n", pemPath)
+		fmt.Printf("JWK: %s\n", jwkPath)
+		if !keysNoActivate {
+			fmt.Printf("Signed URLs are now signed locally; the key is set in %s\n", config.Path())
+		}
+	}
+	return nil
+}
+
+func runKeysList(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	keys, err := client.ListSigningKeys(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list signing keys: %w", err)
+	}
+
+	if len(keys) == 0 {
+		if !quiet {
+			fmt.Println("No signing keys found")
+		}
+		return nil
+	}
+
+	rows := make([]keyRow, len(keys))
+	for i, key := range keys {
+		pemPath, _ := keyPaths(key.ID)
+		_, statErr := os.Stat(pemPath)
+		rows[i] = keyRow{
+			ID:      key.ID,
+			Created: key.Created.Format("2006-01-02 15:04"),
+			Local:   statErr == nil || key.ID == cfg.SigningKeyID,
+			Active:  key.ID == cfg.SigningKeyID,
+		}
+	}
+
+	formatter, err := output.NewFormatter(outputFormat)
+	if err != nil {
+		return err
+	}
+	if err := formatter.FormatList(os.Stdout, []string{"ID", "Created", "Local", "Active"}, rows); err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+	return nil
+}
+
+func runKeysDelete(cmd *cobra.Command, args []string) error {
+	keyID := args[0]
+
+	ok, err := confirm(fmt.Sprintf("Delete signing key %s? Tokens signed with it will stop working.", keyID))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		fmt.Println("Deletion cancelled")
+		return nil
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := client.DeleteSigningKey(ctx, keyID); err != nil {
+		if isDryRun(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to delete signing key: %w", err)
+	}
+
+	pemPath, jwkPath := keyPaths(keyID)
+	for _, path := range []string{pemPath, jwkPath} {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to remove %s: %w", path, err)
+		}
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	if cfg.SigningKeyID == keyID {
+		if err := config.SetSigningKey(profileName, "", ""); err != nil {
+			return err
+		}
+		if !quiet {
+			fmt.Println("The deleted key was active; signed URLs now use the token API")
+		}
+	}
+
+	if !quiet {
+		fmt.Printf("Signing key %s deleted\n", keyID)
+	}
+	return nil
+}
+
+// keyPaths returns where the PEM and JWK of a signing key are stored.
+func keyPaths(keyID string) (pemPath, jwkPath string) {
+	base := filepath.Join(config.KeysDir(), filepath.Base(keyID))
+	return base + ".pem", base + ".jwk"
+}
+
+// decodeKey decodes a base64 encoded key as returned by the signing keys
+// API, keeping the value as is when it isn't base64.
+func decodeKey(encoded string) []byte {
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return []byte(encoded)
+	}
+	return decoded
+}
@@ -0,0 +1,191 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"cfstream/internal/api"
+	"cfstream/internal/config"
+	"cfstream/internal/lifecycle"
+	"cfstream/internal/output"
+)
+
+var lifecycleCmd = &cobra.Command{
+	Use:   "lifecycle",
+	Short: "Apply retention rules to the library",
+	Long: `Evaluate the lifecycle rules from the configuration file against the library.
+
+Rules live in the lifecycle section of the config file:
+
+  lifecycle:
+    rules:
+      - name: temp-uploads
+        filter: meta.temp=true
+        older_than: 30d
+        action: delete
+      - name: executive
+        filter: creator=ceo
+        action: protect
+
+A delete rule removes the videos matching its filter once they are older than
+older_than. A protect rule keeps the videos it matches from being deleted by
+any rule, wherever it appears in the list. Videos in the protected_videos list
+of the config file are kept as well.`,
+}
+
+var lifecyclePlanCmd = &cobra.Command{
+	Use:   "plan",
+	Short: "Show what the lifecycle rules would change",
+	Long:  `List the videos the lifecycle rules would delete and the ones protect rules keep, without changing anything.`,
+	Args:  cobra.NoArgs,
+	RunE:  runLifecyclePlan,
+}
+
+var lifecycleRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Apply the lifecycle rules",
+	Long: `Show the lifecycle plan, ask for confirmation, and delete the planned videos.
+Use --yes to run unattended, e.g. from cron, and --checkpoint so a run
+interrupted by a rate limit or network failure resumes where it stopped.`,
+	Args: cobra.NoArgs,
+	RunE: notifyOnFinish("Lifecycle run", runLifecycleRun),
+}
+
+// lifecycleRow is one row of the lifecycle plan.
+type lifecycleRow struct {
+	UID     string
+	Name    string
+	Created string
+	Action  string
+	Rule    string
+}
+
+var (
+	lifecycleConcurrency int
+	lifecycleCheckpoint  string
+)
+
+func init() {
+	rootCmd.AddCommand(lifecycleCmd)
+	lifecycleCmd.AddCommand(lifecyclePlanCmd)
+	lifecycleCmd.AddCommand(lifecycleRunCmd)
+
+	lifecycleRunCmd.Flags().IntVar(&lifecycleConcurrency, "concurrency", 4, "number of concurrent deletions")
+	lifecycleRunCmd.Flags().StringVar(&lifecycleCheckpoint, "checkpoint", "", "file recording deleted IDs; completed IDs are skipped on rerun")
+}
+
+func runLifecyclePlan(cmd *cobra.Command, args []string) error {
+	decisions, err := planLifecycle()
+	if err != nil {
+		return err
+	}
+	return printLifecyclePlan(decisions)
+}
+
+func runLifecycleRun(cmd *cobra.Command, args []string) error {
+	decisions, err := planLifecycle()
+	if err != nil {
+		return err
+	}
+	if err := printLifecyclePlan(decisions); err != nil {
+		return err
+	}
+
+	var videoIDs []string
+	for _, d := range decisions {
+		if d.Action == lifecycle.ActionDelete {
+			videoIDs = append(videoIDs, d.Video.UID)
+		}
+	}
+	if len(videoIDs) == 0 {
+		return nil
+	}
+
+	return deleteVideos(videoIDs, lifecycleConcurrency, lifecycleCheckpoint)
+}
+
+// planLifecycle evaluates the configured lifecycle rules against the library.
+func planLifecycle() ([]lifecycle.Decision, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if len(cfg.Lifecycle.Rules) == 0 {
+		return nil, fmt.Errorf("no lifecycle rules configured; add a lifecycle section to %s", config.Path())
+	}
+
+	rules, err := lifecycle.Compile(cfg.Lifecycle.Rules)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	videos, err := listAllVideos(ctx, client, &api.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	protected, err := loadProtection()
+	if err != nil {
+		return nil, err
+	}
+
+	// Videos in protected_videos are kept like those matched by a protect rule
+	decisions := lifecycle.Plan(rules, videos, time.Now())
+	for i := range decisions {
+		if decisions[i].Action == lifecycle.ActionDelete && protected.Protected(&decisions[i].Video) {
+			decisions[i].Action = lifecycle.ActionKeep
+			decisions[i].Rule = "protected_videos"
+		}
+	}
+	return decisions, nil
+}
+
+// printLifecyclePlan prints the planned decisions and a summary line.
+func printLifecyclePlan(decisions []lifecycle.Decision) error {
+	if len(decisions) == 0 {
+		if !quiet {
+			fmt.Println("No videos match a delete rule; nothing to do")
+		}
+		return nil
+	}
+
+	rows := make([]lifecycleRow, len(decisions))
+	deletes := 0
+	for i, d := range decisions {
+		rows[i] = lifecycleRow{
+			UID:     d.Video.UID,
+			Name:    d.Video.Name,
+			Created: d.Video.Created.Format("2006-01-02"),
+			Action:  d.Action,
+			Rule:    d.Rule,
+		}
+		if d.Action == lifecycle.ActionDelete {
+			deletes++
+		}
+	}
+
+	formatter, err := output.NewFormatter(outputFormat)
+	if err != nil {
+		return err
+	}
+	if err := formatter.FormatList(os.Stdout, []string{"UID", "Name", "Created", "Action", "Rule"}, rows); err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+
+	if !quiet {
+		fmt.Printf("%d to delete, %d protected\n", deletes, len(decisions)-deletes)
+	}
+	return nil
+}
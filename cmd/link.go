@@ -4,13 +4,18 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"os"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 
+	"cfstream/internal/api"
+	"cfstream/internal/clipboard"
 	"cfstream/internal/config"
+	"cfstream/internal/output"
+	"cfstream/internal/signing"
 )
 
 var linkCmd = &cobra.Command{
@@ -30,9 +35,21 @@ var linkPreviewCmd = &cobra.Command{
 var linkSignedCmd = &cobra.Command{
 	Use:   "signed <video-id>",
 	Short: "Get signed URL",
-	Long:  `Generate a signed (short-lived) URL for a video.`,
-	Args:  cobra.ExactArgs(1),
-	RunE:  runLinkSigned,
+	Long: `Generate a signed (short-lived) URL for a video.
+
+Use --rule (repeatable) or --rules-file to restrict where the token plays,
+e.g. --rule allow:country=US,CA --rule block:any. A rule is ACTION:TYPE
+with ACTION allow or block and TYPE ip=CIDRs, country=codes, or any. Rules
+are checked in order and the first match decides; rules from --rules-file
+come first. --downloadable also allows MP4 downloads with the token.
+
+Tokens come from the token API unless a signing key is configured. With
+--local the token is always signed locally with a stored signing key (see
+'cfstream keys create'), and --key-id picks a key stored in the keys
+directory. Together with --customer-code, which skips looking up the video,
+no API request is made at all.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runLinkSigned,
 }
 
 var linkThumbnailCmd = &cobra.Command{
@@ -59,9 +76,62 @@ var linkDASHCmd = &cobra.Command{
 	RunE:  runLinkDASH,
 }
 
+var linkAllCmd = &cobra.Command{
+	Use:   "all <video-id>",
+	Short: "Get every URL for a video",
+	Long: `Print every relevant URL for a video in one shot: HLS, DASH, watch page,
+iframe src, thumbnail, animated thumbnail, and MP4 download (if enabled).
+
+Private videos get signed variants using a token valid for --duration.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runLinkAll,
+}
+
+var linkDownloadCmd = &cobra.Command{
+	Use:   "download <video-id>",
+	Short: "Get MP4 download URL",
+	Long: `Get the MP4 download URL for a video with downloads enabled.
+Private videos get a signed token that permits downloading.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runLinkDownload,
+}
+
+var linkCheckCmd = &cobra.Command{
+	Use:   "check <video-id>",
+	Short: "Check that playback URLs are reachable",
+	Long: `Send HEAD requests to the HLS and DASH manifests and the thumbnail of a video
+and report response codes and latency. Private videos are checked with a
+short-lived signed token. Exits non-zero if any URL is unreachable.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runLinkCheck,
+}
+
+// linkCheckResult is one row of the link check output.
+type linkCheckResult struct {
+	Type    string
+	URL     string
+	Status  int
+	Latency time.Duration
+	OK      bool
+	Error   string
+}
+
+// linkEntry is one row of the link all output.
+type linkEntry struct {
+	Type string
+	URL  string
+}
+
 var (
-	signedDuration string
-	thumbnailTime  string
+	linkCopy           bool
+	signedDuration     string
+	thumbnailTime      string
+	signLocal          bool
+	signKeyID          string
+	signedCustomerCode string
+	tokenRules         []string
+	tokenRulesFile     string
+	tokenDownloadable  bool
 )
 
 func init() {
@@ -71,16 +141,36 @@ func init() {
 	linkCmd.AddCommand(linkThumbnailCmd)
 	linkCmd.AddCommand(linkHLSCmd)
 	linkCmd.AddCommand(linkDASHCmd)
+	linkCmd.AddCommand(linkAllCmd)
+	linkCmd.AddCommand(linkDownloadCmd)
+	linkCmd.AddCommand(linkCheckCmd)
+
+	// Flags shared by all link commands
+	linkCmd.PersistentFlags().BoolVar(&linkCopy, "copy", false, "copy the URL to the clipboard")
 
 	// Signed command flags
 	linkSignedCmd.Flags().StringVar(&signedDuration, "duration", "", "token duration (e.g., 1h, 30m, 2h30m)")
-
-	// Thumbnail command flags
+	linkSignedCmd.Flags().BoolVar(&signLocal, "local", false, "sign the token locally with a stored signing key")
+	linkSignedCmd.Flags().StringVar(&signKeyID, "key-id", "", "stored signing key to sign with (implies --local)")
+	linkSignedCmd.Flags().StringVar(&signedCustomerCode, "customer-code", "", "customer subdomain code; skips looking up the video")
+	linkSignedCmd.Flags().BoolVar(&tokenDownloadable, "downloadable", false, "allow MP4 downloads with the token")
+	addAccessRuleFlags(linkSignedCmd)
+	addAccessRuleFlags(linkAllCmd)
+
+	// All command flags
+	linkAllCmd.Flags().StringVar(&signedDuration, "duration", "", "token duration for private videos (e.g., 1h, 30m)")
+
+	// Thumbnail and download command flags
+	linkThumbnailCmd.Flags().StringVar(&signedDuration, "duration", "", "token duration for private videos (e.g., 1h, 30m)")
+	linkDownloadCmd.Flags().StringVar(&signedDuration, "duration", "", "token duration for private videos (e.g., 1h, 30m)")
 	linkThumbnailCmd.Flags().StringVar(&thumbnailTime, "time", "", "timestamp for thumbnail (e.g., 10s, 1m30s)")
 }
 
 func runLinkPreview(cmd *cobra.Command, args []string) error {
-	videoID := args[0]
+	videoID, err := resolveVideoID(args[0])
+	if err != nil {
+		return err
+	}
 
 	client, err := createClient()
 	if err != nil {
@@ -100,6 +190,10 @@ func runLinkPreview(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("this video is private and requires a signed URL\n\nUse: cfstream link signed %s --duration 24h", videoID)
 	}
 
+	if err := copyToClipboard(linkCopy, video.Preview); err != nil {
+		return err
+	}
+
 	if outputFormat == outputFormatJSON {
 		result := map[string]string{
 			"url": video.Preview,
@@ -114,28 +208,16 @@ func runLinkPreview(cmd *cobra.Command, args []string) error {
 }
 
 func runLinkSigned(cmd *cobra.Command, args []string) error {
-	videoID := args[0]
+	videoID, err := resolveVideoID(args[0])
+	if err != nil {
+		return err
+	}
 
-	// Parse duration
-	var durationSeconds int64
-	if signedDuration != "" {
-		duration, err := time.ParseDuration(signedDuration)
-		if err != nil {
-			return fmt.Errorf("invalid duration format: %w", err)
-		}
-		durationSeconds = time.Now().Unix() + int64(duration.Seconds())
-	} else {
-		// Use default duration from config
-		cfg, err := config.Load()
-		if err != nil {
-			return fmt.Errorf("failed to load configuration: %w", err)
-		}
-		duration, err := time.ParseDuration(cfg.DefaultSignedDuration)
-		if err != nil {
-			return fmt.Errorf("invalid default duration in config: %w", err)
-		}
-		durationSeconds = time.Now().Unix() + int64(duration.Seconds())
+	duration, err := parseTokenDuration(signedDuration)
+	if err != nil {
+		return err
 	}
+	durationSeconds := time.Now().Add(duration).Unix()
 
 	client, err := createClient()
 	if err != nil {
@@ -146,26 +228,31 @@ func runLinkSigned(cmd *cobra.Command, args []string) error {
 	defer cancel()
 
 	// Get video to extract customer code
-	video, err := client.GetVideo(ctx, videoID)
-	if err != nil {
-		return fmt.Errorf("failed to get video: %w", err)
+	customerCode := signedCustomerCode
+	if customerCode == "" {
+		video, err := client.GetVideo(ctx, videoID)
+		if err != nil {
+			return fmt.Errorf("failed to get video: %w", err)
+		}
+		customerCode, err = extractCustomerCodeFromURL(video.Preview)
+		if err != nil {
+			return fmt.Errorf("failed to extract customer code: %w", err)
+		}
 	}
 
 	// Generate signed token
-	token, err := client.GetSignedToken(ctx, videoID, durationSeconds)
+	token, err := mintToken(ctx, client, videoID, &api.TokenOptions{Expires: durationSeconds})
 	if err != nil {
 		return fmt.Errorf("failed to generate signed token: %w", err)
 	}
 
-	// Extract customer code from preview URL
-	customerCode, err := extractCustomerCodeFromURL(video.Preview)
-	if err != nil {
-		return fmt.Errorf("failed to extract customer code: %w", err)
-	}
-
 	// Construct signed URL
 	signedURL := fmt.Sprintf("https://customer-%s.cloudflarestream.com/%s/watch?token=%s", customerCode, videoID, token)
 
+	if err := copyToClipboard(linkCopy, signedURL); err != nil {
+		return err
+	}
+
 	if outputFormat == outputFormatJSON {
 		result := map[string]string{
 			"url":   signedURL,
@@ -181,7 +268,10 @@ func runLinkSigned(cmd *cobra.Command, args []string) error {
 }
 
 func runLinkThumbnail(cmd *cobra.Command, args []string) error {
-	videoID := args[0]
+	videoID, err := resolveVideoID(args[0])
+	if err != nil {
+		return err
+	}
 
 	client, err := createClient()
 	if err != nil {
@@ -219,6 +309,19 @@ func runLinkThumbnail(cmd *cobra.Command, args []string) error {
 		thumbnailURL = fmt.Sprintf("https://customer-%s.cloudflarestream.com/%s/thumbnails/thumbnail.jpg?time=%.0fs", customerCode, videoID, seconds)
 	}
 
+	// Private videos need a signed token to serve thumbnails
+	if video.RequireSignedURLs {
+		token, err := signedTokenFor(ctx, client, videoID, &api.TokenOptions{})
+		if err != nil {
+			return err
+		}
+		thumbnailURL = withToken(thumbnailURL, token)
+	}
+
+	if err := copyToClipboard(linkCopy, thumbnailURL); err != nil {
+		return err
+	}
+
 	if outputFormat == outputFormatJSON {
 		result := map[string]string{
 			"url": thumbnailURL,
@@ -233,7 +336,10 @@ func runLinkThumbnail(cmd *cobra.Command, args []string) error {
 }
 
 func runLinkDASH(cmd *cobra.Command, args []string) error {
-	videoID := args[0]
+	videoID, err := resolveVideoID(args[0])
+	if err != nil {
+		return err
+	}
 
 	client, err := createClient()
 	if err != nil {
@@ -262,6 +368,10 @@ func runLinkDASH(cmd *cobra.Command, args []string) error {
 	// Construct DASH URL
 	dashURL := fmt.Sprintf("https://customer-%s.cloudflarestream.com/%s/manifest/video.mpd", customerCode, videoID)
 
+	if err := copyToClipboard(linkCopy, dashURL); err != nil {
+		return err
+	}
+
 	if outputFormat == outputFormatJSON {
 		result := map[string]string{
 			"url": dashURL,
@@ -275,6 +385,383 @@ func runLinkDASH(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runLinkAll(cmd *cobra.Command, args []string) error {
+	videoID, err := resolveVideoID(args[0])
+	if err != nil {
+		return err
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	duration, err := parseTokenDuration(signedDuration)
+	if err != nil {
+		return err
+	}
+
+	video, err := client.GetVideo(ctx, videoID)
+	if err != nil {
+		return fmt.Errorf("failed to get video: %w", err)
+	}
+
+	entries, token, err := videoLinks(ctx, client, video, duration)
+	if err != nil {
+		return err
+	}
+
+	downloads, err := client.GetDownloads(ctx, videoID)
+	if err != nil {
+		return fmt.Errorf("failed to get downloads: %w", err)
+	}
+	if downloads.Default != nil && downloads.Default.Status == "ready" {
+		// Download URLs need a token carrying the downloadable claim
+		if token != "" {
+			token, err = signedTokenFor(ctx, client, videoID, &api.TokenOptions{Downloadable: true})
+			if err != nil {
+				return err
+			}
+		}
+		entries = append(entries, linkEntry{Type: "download", URL: withToken(downloads.Default.URL, token)})
+	}
+
+	// --copy takes the watch page, the most shareable of the links
+	if err := copyToClipboard(linkCopy, entries[2].URL); err != nil {
+		return err
+	}
+
+	formatter, err := output.NewFormatter(outputFormat)
+	if err != nil {
+		return err
+	}
+
+	if outputFormat != outputFormatTable {
+		result := make(map[string]string, len(entries))
+		for _, e := range entries {
+			result[e.Type] = e.URL
+		}
+		return formatter.FormatSingle(os.Stdout, result)
+	}
+
+	return formatter.FormatList(os.Stdout, []string{"Type", "URL"}, entries)
+}
+
+func runLinkDownload(cmd *cobra.Command, args []string) error {
+	videoID, err := resolveVideoID(args[0])
+	if err != nil {
+		return err
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	video, err := client.GetVideo(ctx, videoID)
+	if err != nil {
+		return fmt.Errorf("failed to get video: %w", err)
+	}
+
+	downloads, err := client.GetDownloads(ctx, videoID)
+	if err != nil {
+		return fmt.Errorf("failed to get downloads: %w", err)
+	}
+	if downloads.Default == nil {
+		return fmt.Errorf("downloads are not enabled for video %s", videoID)
+	}
+	if downloads.Default.Status != "ready" {
+		return fmt.Errorf("download for video %s is not ready yet (status: %s, %.0f%% complete)",
+			videoID, downloads.Default.Status, downloads.Default.PercentComplete)
+	}
+
+	downloadURL := downloads.Default.URL
+	if video.RequireSignedURLs {
+		token, err := signedTokenFor(ctx, client, videoID, &api.TokenOptions{Downloadable: true})
+		if err != nil {
+			return err
+		}
+		downloadURL = withToken(downloadURL, token)
+	}
+
+	if err := copyToClipboard(linkCopy, downloadURL); err != nil {
+		return err
+	}
+
+	if outputFormat == outputFormatJSON {
+		result := map[string]string{
+			"url": downloadURL,
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(result)
+	}
+
+	fmt.Println(downloadURL)
+	return nil
+}
+
+func runLinkCheck(cmd *cobra.Command, args []string) error {
+	videoID, err := resolveVideoID(args[0])
+	if err != nil {
+		return err
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	video, err := client.GetVideo(ctx, videoID)
+	if err != nil {
+		return fmt.Errorf("failed to get video: %w", err)
+	}
+
+	entries, _, err := videoLinks(ctx, client, video, 5*time.Minute)
+	if err != nil {
+		return err
+	}
+
+	httpClient := &http.Client{Timeout: 10 * time.Second}
+	var results []linkCheckResult
+	failed := 0
+	for _, e := range entries {
+		if e.Type != "hls" && e.Type != "dash" && e.Type != "thumbnail" {
+			continue
+		}
+		result := checkURL(ctx, httpClient, e)
+		if !result.OK {
+			failed++
+		}
+		results = append(results, result)
+	}
+
+	formatter, err := output.NewFormatter(outputFormat)
+	if err != nil {
+		return err
+	}
+	if err := formatter.FormatList(os.Stdout, []string{"Type", "Status", "Latency", "OK", "Error"}, results); err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d playback checks failed", failed, len(results))
+	}
+	return nil
+}
+
+// checkURL sends a HEAD request for e and records the outcome.
+func checkURL(ctx context.Context, httpClient *http.Client, e linkEntry) linkCheckResult {
+	result := linkCheckResult{Type: e.Type, URL: e.URL}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, e.URL, nil)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	start := time.Now()
+	resp, err := httpClient.Do(req)
+	result.Latency = time.Since(start).Round(time.Millisecond)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	resp.Body.Close()
+
+	result.Status = resp.StatusCode
+	result.OK = resp.StatusCode >= 200 && resp.StatusCode < 400
+	if !result.OK {
+		result.Error = http.StatusText(resp.StatusCode)
+	}
+	return result
+}
+
+// videoLinks returns the playback URLs of video. Private videos get a signed
+// token valid for duration appended to every URL; the token is also returned.
+func videoLinks(ctx context.Context, client api.Client, video *api.Video, duration time.Duration) ([]linkEntry, string, error) {
+	customerCode, err := extractCustomerCodeFromURL(video.Preview)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to extract customer code: %w", err)
+	}
+	base := fmt.Sprintf("https://customer-%s.cloudflarestream.com/%s", customerCode, video.UID)
+
+	token := ""
+	if video.RequireSignedURLs {
+		token, err = mintToken(ctx, client, video.UID, &api.TokenOptions{Expires: time.Now().Add(duration).Unix()})
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to generate signed token: %w", err)
+		}
+	}
+
+	return []linkEntry{
+		{Type: "hls", URL: withToken(base+"/manifest/video.m3u8", token)},
+		{Type: "dash", URL: withToken(base+"/manifest/video.mpd", token)},
+		{Type: "watch", URL: withToken(base+"/watch", token)},
+		{Type: "iframe", URL: withToken(base+"/iframe", token)},
+		{Type: "thumbnail", URL: withToken(base+"/thumbnails/thumbnail.jpg", token)},
+		{Type: "animated_thumbnail", URL: withToken(base+"/thumbnails/thumbnail.gif", token)},
+	}, token, nil
+}
+
+// signedTokenFor mints a token for videoID valid for --duration (or the configured default).
+func signedTokenFor(ctx context.Context, client api.Client, videoID string, opts *api.TokenOptions) (string, error) {
+	duration, err := parseTokenDuration(signedDuration)
+	if err != nil {
+		return "", err
+	}
+	opts.Expires = time.Now().Add(duration).Unix()
+
+	token, err := mintToken(ctx, client, videoID, opts)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate signed token: %w", err)
+	}
+	return token, nil
+}
+
+// mintToken returns a signed token for videoID. When a signing key is
+// configured, or --local or --key-id is given, the token is signed locally
+// without an API request.
+func mintToken(ctx context.Context, client api.Client, videoID string, opts *api.TokenOptions) (string, error) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return "", err
+	}
+	if opts.AccessRules == nil {
+		opts.AccessRules, err = tokenAccessRules()
+		if err != nil {
+			return "", err
+		}
+	}
+	if tokenDownloadable {
+		opts.Downloadable = true
+	}
+
+	if cfg.SigningKeyID == "" && !signLocal && signKeyID == "" {
+		return client.GetSignedTokenWithOptions(ctx, videoID, opts)
+	}
+
+	signer, err := localSigner(cfg, signKeyID)
+	if err != nil {
+		return "", err
+	}
+
+	// Tokens from the API default to one hour
+	exp := time.Now().Add(time.Hour)
+	if opts.Expires > 0 {
+		exp = time.Unix(opts.Expires, 0)
+	}
+	claims := map[string]interface{}{}
+	if opts.Downloadable {
+		claims["downloadable"] = true
+	}
+	if len(opts.AccessRules) > 0 {
+		claims["accessRules"] = opts.AccessRules
+	}
+	return signer.SignWithClaims(videoID, exp, claims)
+}
+
+// addAccessRuleFlags registers --rule and --rules-file on a command that
+// signs tokens.
+func addAccessRuleFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArrayVar(&tokenRules, "rule", nil, "access rule as ACTION:TYPE[=VALUES], e.g. allow:country=US,CA, block:ip=203.0.113.0/24, block:any (repeatable)")
+	cmd.Flags().StringVar(&tokenRulesFile, "rules-file", "", "JSON file of access rules in the API format")
+}
+
+// tokenAccessRules returns the access rules from --rules-file followed by
+// those given with --rule.
+func tokenAccessRules() ([]api.AccessRule, error) {
+	var rules []api.AccessRule
+	if tokenRulesFile != "" {
+		loaded, err := signing.LoadRules(tokenRulesFile)
+		if err != nil {
+			return nil, err
+		}
+		rules = loaded
+	}
+	for _, value := range tokenRules {
+		rule, err := signing.ParseRule(value)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// localSigner loads the signing key keyID from the keys directory, or else
+// the configured signing key when keyID is empty.
+func localSigner(cfg *config.Config, keyID string) (*signing.Signer, error) {
+	if keyID != "" {
+		pemPath, _ := keyPaths(keyID)
+		if _, err := os.Stat(pemPath); err != nil {
+			return nil, fmt.Errorf("signing key %s is not stored in %s; create one with 'cfstream keys create'", keyID, config.KeysDir())
+		}
+		return signing.LoadSigner(keyID, pemPath)
+	}
+	if cfg.SigningKeyID == "" {
+		return nil, fmt.Errorf("no signing key configured for local signing; create one with 'cfstream keys create'")
+	}
+	return signing.LoadSigner(cfg.SigningKeyID, cfg.SigningKeyPEMPath)
+}
+
+// withToken appends a signed token query parameter to url when token is non-empty.
+func withToken(url, token string) string {
+	if token == "" {
+		return url
+	}
+	if strings.Contains(url, "?") {
+		return url + "&token=" + token
+	}
+	return url + "?token=" + token
+}
+
+// copyToClipboard copies text to the system clipboard when enabled is true.
+func copyToClipboard(enabled bool, text string) error {
+	if !enabled {
+		return nil
+	}
+
+	if err := clipboard.Copy(text); err != nil {
+		return err
+	}
+
+	if !quiet {
+		fmt.Fprintln(os.Stderr, "Copied to clipboard")
+	}
+
+	return nil
+}
+
+// parseTokenDuration parses value, falling back to the configured default signed duration.
+func parseTokenDuration(value string) (time.Duration, error) {
+	if value == "" {
+		cfg, err := config.Load()
+		if err != nil {
+			return 0, fmt.Errorf("failed to load configuration: %w", err)
+		}
+		value = cfg.DefaultSignedDuration
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration format: %w", err)
+	}
+	if duration <= 0 {
+		return 0, fmt.Errorf("duration must be positive")
+	}
+	return duration, nil
+}
+
 // extractCustomerCodeFromURL extracts the customer code from a Cloudflare Stream URL.
 func extractCustomerCodeFromURL(url string) (string, error) {
 	if url == "" {
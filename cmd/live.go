@@ -0,0 +1,537 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"cfstream/internal/api"
+	"cfstream/internal/index"
+	"cfstream/internal/output"
+)
+
+var liveCmd = &cobra.Command{
+	Use:   "live",
+	Short: "Manage live inputs",
+	Long:  `Create, update, and inspect Cloudflare Stream live inputs and their recordings.`,
+}
+
+var liveGetCmd = &cobra.Command{
+	Use:   "get <input-id>",
+	Short: "Get live input details",
+	Long: `Get details for a live input, including its RTMPS and SRT ingest URLs and the
+WebRTC WHIP publish and WHEP playback URLs where available.
+
+The RTMPS stream key, SRT passphrase, and the secret part of the WHIP URL are
+hidden unless --show-keys is given, so the output can be shared safely.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runLiveGet,
+}
+
+var liveCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a live input",
+	Long: `Create a live input and print its ingest endpoints.
+
+Use --delete-recording-after-days to remove broadcast recordings automatically.
+Cloudflare requires a retention of at least 30 days.`,
+	Args: cobra.NoArgs,
+	RunE: runLiveCreate,
+}
+
+var liveUpdateCmd = &cobra.Command{
+	Use:   "update <input-id>",
+	Short: "Update a live input",
+	Long: `Update the settings of a live input. Only the flags given are changed.
+
+Pass --delete-recording-after-days 0 to keep recordings forever.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runLiveUpdate,
+}
+
+var liveLinkCmd = &cobra.Command{
+	Use:   "link <input-id>",
+	Short: "Get live playback URLs",
+	Long: `Print the HLS and DASH manifests, watch page, and iframe src for a live input.
+
+Inputs that require signed URLs get signed variants using a token valid for --duration.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runLiveLink,
+}
+
+var liveEmbedCmd = &cobra.Command{
+	Use:   "embed <input-id>",
+	Short: "Get live player embed code",
+	Long: `Get HTML iframe embed code for a live input.
+
+Inputs that require signed URLs get a token valid for --duration.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runLiveEmbed,
+}
+
+var liveRecordingsCmd = &cobra.Command{
+	Use:   "recordings <input-id>",
+	Short: "List recordings of a live input",
+	Long: `List the videos recorded from a live input. Recording UIDs are regular video
+IDs and work with the video, link, and embed commands.
+
+Use --latest to print only the most recent recording, e.g.:
+  cfstream link signed $(cfstream live recordings INPUT_ID --latest)`,
+	Args: cobra.ExactArgs(1),
+	RunE: runLiveRecordings,
+}
+
+// liveField is one row of the live get table output.
+type liveField struct {
+	Field string
+	Value string
+}
+
+var (
+	liveLatest   bool
+	liveShowKeys bool
+
+	// Create and update flags.
+	liveName            string
+	liveRecordingMode   string
+	liveRequireSigned   bool
+	liveDeleteAfterDays int
+)
+
+func init() {
+	rootCmd.AddCommand(liveCmd)
+	liveCmd.AddCommand(liveGetCmd)
+	liveCmd.AddCommand(liveCreateCmd)
+	liveCmd.AddCommand(liveUpdateCmd)
+	liveCmd.AddCommand(liveLinkCmd)
+	liveCmd.AddCommand(liveEmbedCmd)
+	liveCmd.AddCommand(liveRecordingsCmd)
+
+	// Create and update command flags
+	for _, c := range []*cobra.Command{liveCreateCmd, liveUpdateCmd} {
+		c.Flags().StringVar(&liveName, "name", "", "name of the live input")
+		c.Flags().StringVar(&liveRecordingMode, "recording", "", "recording mode (automatic, off)")
+		c.Flags().BoolVar(&liveRequireSigned, "require-signed", false, "require signed URLs for playback")
+		c.Flags().IntVar(&liveDeleteAfterDays, "delete-recording-after-days", 0, "delete recordings after this many days (minimum 30)")
+	}
+
+	for _, c := range []*cobra.Command{liveGetCmd, liveUpdateCmd} {
+		c.Flags().BoolVar(&liveShowKeys, "show-keys", false, "reveal stream keys and passphrases")
+	}
+
+	// Recordings command flags
+	liveRecordingsCmd.Flags().BoolVar(&liveLatest, "latest", false, "print only the most recent recording")
+	addSortFlags(liveRecordingsCmd, videoSortKeys)
+
+	// Link command flags
+	liveLinkCmd.Flags().StringVar(&signedDuration, "duration", "", "token duration for signed inputs (e.g., 1h, 30m)")
+	liveLinkCmd.Flags().BoolVar(&linkCopy, "copy", false, "copy the watch URL to the clipboard")
+
+	// Embed command flags
+	liveEmbedCmd.Flags().StringVar(&signedDuration, "duration", "", "token duration for signed inputs (e.g., 1h, 30m)")
+	liveEmbedCmd.Flags().BoolVar(&embedResponsive, "responsive", false, "make iframe responsive")
+	liveEmbedCmd.Flags().BoolVar(&embedAutoplay, "autoplay", false, "enable autoplay")
+	liveEmbedCmd.Flags().BoolVar(&embedMuted, "muted", false, "start muted")
+	liveEmbedCmd.Flags().BoolVar(&embedControls, "controls", true, "show controls")
+	liveEmbedCmd.Flags().BoolVar(&embedCopy, "copy", false, "copy the embed code to the clipboard")
+}
+
+func runLiveGet(cmd *cobra.Command, args []string) error {
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	input, err := client.GetLiveInput(ctx, args[0])
+	if err != nil {
+		return fmt.Errorf("failed to get live input: %w", err)
+	}
+
+	if !liveShowKeys {
+		input = redactLiveInput(input)
+	}
+	return printLiveInput(input)
+}
+
+func runLiveCreate(cmd *cobra.Command, args []string) error {
+	opts, err := liveInputOptions(cmd)
+	if err != nil {
+		return err
+	}
+	if opts.RecordingMode == "" {
+		opts.RecordingMode = "automatic"
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	input, err := client.CreateLiveInput(ctx, opts)
+	if err != nil {
+		if isDryRun(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to create live input: %w", err)
+	}
+
+	if !quiet {
+		fmt.Println("Live input created successfully")
+	}
+	return printLiveInput(input)
+}
+
+func runLiveUpdate(cmd *cobra.Command, args []string) error {
+	opts, err := liveInputOptions(cmd)
+	if err != nil {
+		return err
+	}
+	if *opts == (api.LiveInputOptions{}) {
+		return fmt.Errorf("at least one of --name, --recording, --require-signed, or --delete-recording-after-days must be provided")
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	input, err := client.UpdateLiveInput(ctx, args[0], opts)
+	if err != nil {
+		if isDryRun(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to update live input: %w", err)
+	}
+
+	if !quiet {
+		fmt.Println("Live input updated successfully")
+	}
+	if !liveShowKeys {
+		input = redactLiveInput(input)
+	}
+	return printLiveInput(input)
+}
+
+// liveInputOptions builds live input options from the flags set on cmd.
+func liveInputOptions(cmd *cobra.Command) (*api.LiveInputOptions, error) {
+	opts := &api.LiveInputOptions{Name: liveName}
+
+	switch liveRecordingMode {
+	case "", "automatic", "off":
+		opts.RecordingMode = liveRecordingMode
+	default:
+		return nil, fmt.Errorf("invalid recording mode: %s (use automatic or off)", liveRecordingMode)
+	}
+
+	if cmd.Flags().Changed("require-signed") {
+		opts.RequireSignedURLs = &liveRequireSigned
+	}
+
+	if cmd.Flags().Changed("delete-recording-after-days") {
+		if liveDeleteAfterDays != 0 && liveDeleteAfterDays < 30 {
+			return nil, fmt.Errorf("--delete-recording-after-days must be at least 30 (or 0 to disable)")
+		}
+		opts.DeleteRecordingAfterDays = &liveDeleteAfterDays
+	}
+
+	return opts, nil
+}
+
+// printLiveInput prints a live input as a field table or in the requested format.
+func printLiveInput(input *api.LiveInput) error {
+	formatter, err := output.NewFormatter(outputFormat)
+	if err != nil {
+		return err
+	}
+
+	if outputFormat != outputFormatTable {
+		return formatter.FormatSingle(os.Stdout, input)
+	}
+
+	if err := formatter.FormatList(os.Stdout, []string{"Field", "Value"}, liveInputFields(input)); err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+	return nil
+}
+
+// liveInputFields flattens a live input into table rows, skipping endpoints
+// that Cloudflare did not return.
+func liveInputFields(input *api.LiveInput) []liveField {
+	fields := []liveField{
+		{"UID", input.UID},
+		{"Name", input.Name()},
+		{"Created", input.Created.Format(time.RFC3339)},
+		{"Recording", input.Recording.Mode},
+		{"Signed URLs", fmt.Sprintf("%t", input.Recording.RequireSignedURLs)},
+		{"Delete Recordings After", retentionDays(input.DeleteRecordingAfterDays)},
+		{"RTMPS URL", input.RTMPS.URL},
+		{"RTMPS Key", input.RTMPS.StreamKey},
+		{"SRT URL", input.SRT.URL},
+		{"SRT Stream ID", input.SRT.StreamID},
+		{"SRT Passphrase", input.SRT.Passphrase},
+		{"WHIP URL", input.WebRTC.URL},
+		{"RTMPS Playback", input.RTMPSPlayback.URL},
+		{"SRT Playback", input.SRTPlayback.URL},
+		{"WHEP URL", input.WebRTCPlayback.URL},
+	}
+
+	result := make([]liveField, 0, len(fields))
+	for _, f := range fields {
+		if f.Value != "" {
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
+// hiddenSecret replaces secrets in live input output without --show-keys.
+const hiddenSecret = "<hidden>"
+
+// redactLiveInput returns a copy of input with the stream key, SRT
+// passphrase, and WHIP URL key hidden.
+func redactLiveInput(input *api.LiveInput) *api.LiveInput {
+	redacted := *input
+	if redacted.RTMPS.StreamKey != "" {
+		redacted.RTMPS.StreamKey = hiddenSecret
+	}
+	if redacted.SRT.Passphrase != "" {
+		redacted.SRT.Passphrase = hiddenSecret
+	}
+	redacted.WebRTC.URL = redactWHIPURL(redacted.WebRTC.URL)
+	return &redacted
+}
+
+// redactWHIPURL hides the key in a WHIP URL such as
+// https://customer-x.cloudflarestream.com/<key>/webRTC/publish, leaving the
+// host and the trailing webRTC/publish path.
+func redactWHIPURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.Path == "" {
+		return raw
+	}
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(segments) < 3 {
+		return raw
+	}
+	for i := range segments[:len(segments)-2] {
+		segments[i] = hiddenSecret
+	}
+	return u.Scheme + "://" + u.Host + "/" + strings.Join(segments, "/")
+}
+
+// retentionDays describes a recording retention setting.
+func retentionDays(days int) string {
+	if days <= 0 {
+		return "never"
+	}
+	return fmt.Sprintf("%d days", days)
+}
+
+func runLiveLink(cmd *cobra.Command, args []string) error {
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	input, err := client.GetLiveInput(ctx, args[0])
+	if err != nil {
+		return fmt.Errorf("failed to get live input: %w", err)
+	}
+
+	customerCode, err := liveCustomerCode(ctx, client, input)
+	if err != nil {
+		return err
+	}
+
+	token, err := liveToken(ctx, client, input)
+	if err != nil {
+		return err
+	}
+
+	base := fmt.Sprintf("https://customer-%s.cloudflarestream.com/%s", customerCode, input.UID)
+	entries := []linkEntry{
+		{Type: "hls", URL: withToken(base+"/manifest/video.m3u8", token)},
+		{Type: "dash", URL: withToken(base+"/manifest/video.mpd", token)},
+		{Type: "watch", URL: withToken(base+"/watch", token)},
+		{Type: "iframe", URL: withToken(base+"/iframe", token)},
+	}
+
+	if err := copyToClipboard(linkCopy, entries[2].URL); err != nil {
+		return err
+	}
+
+	formatter, err := output.NewFormatter(outputFormat)
+	if err != nil {
+		return err
+	}
+
+	if outputFormat != outputFormatTable {
+		result := make(map[string]string, len(entries))
+		for _, e := range entries {
+			result[e.Type] = e.URL
+		}
+		return formatter.FormatSingle(os.Stdout, result)
+	}
+
+	return formatter.FormatList(os.Stdout, []string{"Type", "URL"}, entries)
+}
+
+func runLiveEmbed(cmd *cobra.Command, args []string) error {
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	input, err := client.GetLiveInput(ctx, args[0])
+	if err != nil {
+		return fmt.Errorf("failed to get live input: %w", err)
+	}
+
+	customerCode, err := liveCustomerCode(ctx, client, input)
+	if err != nil {
+		return err
+	}
+
+	token, err := liveToken(ctx, client, input)
+	if err != nil {
+		return err
+	}
+
+	embedCode := api.EmbedHTML(customerCode, input.UID, &api.EmbedOptions{
+		Responsive:  embedResponsive,
+		Autoplay:    embedAutoplay,
+		Muted:       embedMuted,
+		Controls:    embedControls,
+		SignedToken: token,
+	})
+
+	if err := copyToClipboard(embedCopy, embedCode); err != nil {
+		return err
+	}
+
+	if outputFormat == outputFormatJSON {
+		result := map[string]string{
+			"html": embedCode,
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(result)
+	}
+
+	fmt.Println(embedCode)
+	return nil
+}
+
+func runLiveRecordings(cmd *cobra.Command, args []string) error {
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	videos, err := client.ListLiveInputVideos(ctx, args[0])
+	if err != nil {
+		return fmt.Errorf("failed to list recordings: %w", err)
+	}
+
+	// Remember recordings for completion like any other listed video
+	if err := index.Update(videos); err != nil && verbose {
+		fmt.Fprintf(os.Stderr, "Warning: failed to update video index: %v\n", err)
+	}
+
+	if len(videos) == 0 {
+		if liveLatest {
+			return fmt.Errorf("live input %s has no recordings", args[0])
+		}
+		if !quiet {
+			fmt.Println("No recordings found")
+		}
+		return nil
+	}
+
+	formatter, err := output.NewFormatter(outputFormat)
+	if err != nil {
+		return err
+	}
+
+	if liveLatest {
+		latest := videos[0]
+		for _, v := range videos[1:] {
+			if v.Created.After(latest.Created) {
+				latest = v
+			}
+		}
+
+		// A bare UID composes with other commands in shell substitutions
+		if outputFormat == outputFormatTable {
+			fmt.Println(latest.UID)
+			return nil
+		}
+		return formatter.FormatSingle(os.Stdout, latest)
+	}
+
+	if err := sortList(videos, videoSortKeys); err != nil {
+		return err
+	}
+
+	if err := formatter.FormatList(os.Stdout, videoListHeaders, videos); err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+
+	return nil
+}
+
+// liveToken mints a playback token for inputs that require signed URLs.
+// It returns an empty token for public inputs.
+func liveToken(ctx context.Context, client api.Client, input *api.LiveInput) (string, error) {
+	if !input.Recording.RequireSignedURLs {
+		return "", nil
+	}
+	return signedTokenFor(ctx, client, input.UID, &api.TokenOptions{})
+}
+
+// liveCustomerCode returns the customer subdomain code of the account,
+// taken from the WebRTC URLs of input. Inputs without them fall back to the
+// preview URL of the newest video.
+func liveCustomerCode(ctx context.Context, client api.Client, input *api.LiveInput) (string, error) {
+	for _, u := range []string{input.WebRTCPlayback.URL, input.WebRTC.URL} {
+		if code, err := extractCustomerCodeFromURL(u); err == nil {
+			return code, nil
+		}
+	}
+
+	videos, err := client.ListVideos(ctx, &api.ListOptions{Limit: 1})
+	if err != nil {
+		return "", fmt.Errorf("failed to list videos: %w", err)
+	}
+	if len(videos) > 0 {
+		if code, err := extractCustomerCodeFromURL(videos[0].Preview); err == nil {
+			return code, nil
+		}
+	}
+
+	return "", fmt.Errorf("failed to determine customer code: the live input has no WebRTC URL and the account has no video with a preview URL")
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+
+	"cfstream/internal/mcp"
+)
+
+var mcpCmd = &cobra.Command{
+	Use:   "mcp",
+	Short: "Run a Model Context Protocol server over stdio",
+	Long: `Run a Model Context Protocol (MCP) server on stdin/stdout so AI assistants
+and agents can work with your Stream library through cfstream.
+
+The server offers tools to list and search videos, get video details, build
+playback links (signed for private videos), and create direct upload URLs.
+No tool can delete or modify existing videos.
+
+Example assistant configuration:
+  {"mcpServers": {"cfstream": {"command": "cfstream", "args": ["mcp"]}}}`,
+	Args: cobra.NoArgs,
+	RunE: runMCP,
+}
+
+func init() {
+	rootCmd.AddCommand(mcpCmd)
+}
+
+func runMCP(cmd *cobra.Command, args []string) error {
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	// Signed links use the same local signing key as the other token commands
+	var mint mcp.Minter
+	localMint, _, err := localMinter("", "")
+	if err != nil {
+		return err
+	}
+	if localMint != nil {
+		mint = mcp.Minter(localMint)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	return mcp.NewServer(client, version, mint).Serve(ctx, os.Stdin, os.Stdout)
+}
@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"cfstream/internal/api"
+	"cfstream/internal/batch"
+	"cfstream/internal/migrate"
+	"cfstream/internal/output"
+)
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate <export-file>",
+	Short: "Copy videos listed in an export from another host",
+	Long: `Copy videos listed in an export from another video host (Vimeo, YouTube,
+Brightcove, ...) into Stream. Each row's source URL is ingested with the copy
+endpoint, so nothing is downloaded locally.
+
+--map maps export columns to Stream fields: url, name, or meta.<key>, e.g.
+--map 'title=name,desc=meta.description,download_link=url'. A column named url
+is used as the source when none is mapped to url.
+
+Completed rows are recorded in a state file (default <export-file>.state), so
+rerunning the command after a failure only copies the remaining videos.`,
+	Args: cobra.ExactArgs(1),
+	RunE: notifyOnFinish("Migration", runMigrate),
+}
+
+// migrateResult is one row of the migration report.
+type migrateResult struct {
+	Source string
+	UID    string
+	Name   string
+}
+
+var (
+	migrateFrom        string
+	migrateMap         string
+	migrateState       string
+	migrateConcurrency int
+)
+
+func init() {
+	rootCmd.AddCommand(migrateCmd)
+
+	migrateCmd.Flags().StringVar(&migrateFrom, "from", "csv", "export format (csv)")
+	migrateCmd.Flags().StringVar(&migrateMap, "map", "", "column mapping, e.g. 'title=name,desc=meta.description'")
+	migrateCmd.Flags().StringVar(&migrateState, "state", "", "state file recording completed rows (default <export-file>.state)")
+	migrateCmd.Flags().IntVar(&migrateConcurrency, "concurrency", 4, "number of concurrent copies")
+	addQuotaFlag(migrateCmd.Flags())
+}
+
+func runMigrate(cmd *cobra.Command, args []string) error {
+	exportFile := args[0]
+	if migrateFrom != "csv" {
+		return fmt.Errorf("unsupported export format: %s (supported: csv)", migrateFrom)
+	}
+	if migrateConcurrency < 1 {
+		return fmt.Errorf("--concurrency must be at least 1")
+	}
+
+	mapping, err := migrate.ParseMapping(migrateMap)
+	if err != nil {
+		return err
+	}
+	items, err := migrate.LoadCSV(exportFile, mapping)
+	if err != nil {
+		return err
+	}
+
+	// Rows are tracked by source URL; repeated rows are copied once
+	byURL := make(map[string]migrate.Item, len(items))
+	sources := make([]string, 0, len(items))
+	for _, item := range items {
+		if _, ok := byURL[item.URL]; !ok {
+			sources = append(sources, item.URL)
+		}
+		byURL[item.URL] = item
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+	if err := checkQuota(context.Background(), client, 0); err != nil {
+		return err
+	}
+
+	concurrency := migrateConcurrency
+	var checkpoint *batch.Checkpoint
+	if dryRun {
+		concurrency = 1
+	} else {
+		statePath := migrateState
+		if statePath == "" {
+			statePath = exportFile + ".state"
+		}
+		checkpoint, err = openCheckpoint(statePath)
+		if err != nil {
+			return err
+		}
+		defer checkpoint.Close()
+	}
+
+	var mu sync.Mutex
+	var copied []migrateResult
+
+	bar := batchProgress(len(sources), "Migrating")
+	results := batch.Run(context.Background(), sources, batch.Options{
+		Concurrency: concurrency,
+		Retries:     batchRetries,
+		Backoff:     time.Second,
+		Checkpoint:  checkpoint,
+		OnThrottle:  reportThrottle(bar),
+		OnDone: func(r batch.Result) {
+			bar.Item(r.ID, r.Err)
+		},
+	}, func(ctx context.Context, source string) error {
+		ctx, cancel := context.WithTimeout(ctx, time.Minute)
+		defer cancel()
+
+		item := byURL[source]
+		video, err := client.UploadFromURL(ctx, source, &api.UploadOptions{
+			Name:              item.Name,
+			Metadata:          withMeta(item.Meta, api.MetaSourceURL, source),
+			RequireSignedURLs: true,
+		})
+		if isDryRun(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		mu.Lock()
+		copied = append(copied, migrateResult{Source: source, UID: video.UID, Name: item.Name})
+		mu.Unlock()
+		return nil
+	})
+	bar.Finish()
+
+	if dryRun {
+		return nil
+	}
+
+	if len(copied) > 0 {
+		formatter, err := output.NewFormatter(outputFormat)
+		if err != nil {
+			return err
+		}
+		if err := formatter.FormatList(os.Stdout, []string{"Source", "UID", "Name"}, copied); err != nil {
+			return fmt.Errorf("failed to format output: %w", err)
+		}
+	}
+
+	return reportBatch(results, "copied")
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"cfstream/internal/config"
+	"cfstream/internal/notify"
+)
+
+// notifyOnFinish wraps a command so that notifications are sent when it
+// finishes, successfully or not. Dry runs are never notified.
+func notifyOnFinish(operation string, run func(*cobra.Command, []string) error) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		err := run(cmd, args)
+		if dryRun {
+			return err
+		}
+		notifyCompletion(operation, strings.Join(args, " "), err)
+		return err
+	}
+}
+
+// notifyCompletion reports a finished operation through the configured channels:
+// a desktop notification when --notify is set, and a webhook message when
+// --notify-webhook or notifications.webhook_url is set. Notification failures
+// are reported as warnings only.
+func notifyCompletion(operation, subject string, err error) {
+	webhookURL := notifyWebhookURL
+	if webhookURL == "" {
+		if cfg, cfgErr := config.Load(); cfgErr == nil {
+			webhookURL = cfg.Notifications.WebhookURL
+		}
+	}
+	if !notifyDesktop && webhookURL == "" {
+		return
+	}
+
+	title := fmt.Sprintf("cfstream: %s finished", operation)
+	message := subject
+	if err != nil {
+		title = fmt.Sprintf("cfstream: %s failed", operation)
+		message = err.Error()
+	}
+	if message == "" {
+		message = title
+	}
+
+	if notifyDesktop {
+		if nerr := notify.Desktop(title, message); nerr != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to send notification: %v\n", nerr)
+		}
+	}
+
+	if webhookURL != "" {
+		icon := "✅"
+		if err != nil {
+			icon = "❌"
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+		if nerr := notify.Webhook(ctx, webhookURL, fmt.Sprintf("%s %s: %s", icon, title, message)); nerr != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to send webhook notification: %v\n", nerr)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"cfstream/internal/api"
+	"cfstream/internal/batch"
+)
+
+var videoOriginsCmd = &cobra.Command{
+	Use:   "origins",
+	Short: "Manage which sites may embed videos",
+	Long:  `Manage the allowed origins that restrict which sites may embed videos.`,
+}
+
+var videoOriginsApplyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Set allowed origins on many videos at once",
+	Long: `Set the allowed origins of every video matching --filter, e.g.
+
+  cfstream video origins apply --origins example.com,cdn.example.com --filter 'meta.site=marketing'
+
+Origins are hostnames without a scheme; a leading *. matches subdomains.
+Passing --origins '' removes the restriction so any site may embed the videos.
+Videos that already have exactly these origins are left alone. Use --all
+instead of --filter to update the whole library.`,
+	Args: cobra.NoArgs,
+	RunE: runVideoOriginsApply,
+}
+
+var (
+	originsList        []string
+	originsFilter      string
+	originsAll         bool
+	originsConcurrency int
+	originsCheckpoint  string
+)
+
+func init() {
+	videoCmd.AddCommand(videoOriginsCmd)
+	videoOriginsCmd.AddCommand(videoOriginsApplyCmd)
+
+	videoOriginsApplyCmd.Flags().StringSliceVar(&originsList, "origins", nil, "comma-separated allowed origins; empty allows all sites (required)")
+	videoOriginsApplyCmd.Flags().StringVar(&originsFilter, "filter", "", "select videos, e.g. 'meta.site=marketing'")
+	videoOriginsApplyCmd.Flags().BoolVar(&originsAll, "all", false, "update every video in the library")
+	videoOriginsApplyCmd.Flags().IntVar(&originsConcurrency, "concurrency", 4, "number of concurrent updates")
+	videoOriginsApplyCmd.Flags().StringVar(&originsCheckpoint, "checkpoint", "", "file recording updated IDs; completed IDs are skipped on rerun")
+	_ = videoOriginsApplyCmd.MarkFlagRequired("origins") //nolint:errcheck // Flag is registered above
+}
+
+func runVideoOriginsApply(cmd *cobra.Command, args []string) error {
+	if originsConcurrency < 1 {
+		return fmt.Errorf("--concurrency must be at least 1")
+	}
+
+	origins, err := normalizeOrigins(originsList)
+	if err != nil {
+		return err
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	videos, err := selectVideos(client, originsFilter, originsAll)
+	if err != nil {
+		return err
+	}
+
+	videoIDs := make([]string, 0, len(videos))
+	for _, video := range videos {
+		if !sameOrigins(video.AllowedOrigins, origins) {
+			videoIDs = append(videoIDs, video.UID)
+		}
+	}
+
+	if !quiet && len(videos) > len(videoIDs) {
+		fmt.Printf("%d of %d matching videos already have these origins\n", len(videos)-len(videoIDs), len(videos))
+	}
+	if len(videoIDs) == 0 {
+		if !quiet {
+			fmt.Println("No videos to update")
+		}
+		return nil
+	}
+
+	description := "allow embedding only on " + strings.Join(origins, ", ")
+	if len(origins) == 0 {
+		description = "allow embedding on any site"
+	}
+	ok, err := confirm(fmt.Sprintf("Update %d videos to %s?", len(videoIDs), description))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		fmt.Println("Update cancelled")
+		return nil
+	}
+
+	// Dry-run plans are printed sequentially so they don't interleave
+	concurrency := originsConcurrency
+	if dryRun {
+		concurrency = 1
+	}
+
+	// Dry runs don't record anything in the checkpoint
+	var checkpoint *batch.Checkpoint
+	if originsCheckpoint != "" && !dryRun {
+		checkpoint, err = openCheckpoint(originsCheckpoint)
+		if err != nil {
+			return err
+		}
+		defer checkpoint.Close()
+	}
+
+	bar := batchProgress(len(videoIDs), "Updating")
+	results := batch.Run(context.Background(), videoIDs, batch.Options{
+		Concurrency: concurrency,
+		Retries:     batchRetries,
+		Backoff:     time.Second,
+		Checkpoint:  checkpoint,
+		OnThrottle:  reportThrottle(bar),
+		OnDone: func(r batch.Result) {
+			bar.Item(r.ID, r.Err)
+		},
+	}, func(ctx context.Context, id string) error {
+		ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		defer cancel()
+
+		_, err := client.UpdateVideo(ctx, id, &api.UpdateOptions{AllowedOrigins: origins})
+		if isDryRun(err) {
+			return nil
+		}
+		return err
+	})
+	bar.Finish()
+
+	if dryRun {
+		return nil
+	}
+	return reportBatch(results, "updated")
+}
+
+// normalizeOrigins lowercases and deduplicates origins and rejects values
+// that include a scheme or path, which the API does not accept.
+func normalizeOrigins(values []string) ([]string, error) {
+	origins := make([]string, 0, len(values))
+	for _, value := range values {
+		origin := strings.ToLower(strings.TrimSpace(value))
+		if origin == "" {
+			continue
+		}
+		if strings.Contains(origin, "://") || strings.Contains(origin, "/") {
+			return nil, fmt.Errorf("invalid origin %q: use a hostname such as example.com, without scheme or path", value)
+		}
+		if !slices.Contains(origins, origin) {
+			origins = append(origins, origin)
+		}
+	}
+	return origins, nil
+}
+
+// sameOrigins reports whether two origin lists contain the same origins in any order.
+func sameOrigins(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	sortedA := slices.Clone(a)
+	slices.Sort(sortedA)
+	sortedB := slices.Clone(b)
+	slices.Sort(sortedB)
+	return slices.Equal(sortedA, sortedB)
+}
@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+
+	"github.com/sourcegraph/conc/pool"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"cfstream/internal/config"
+	"cfstream/internal/merge"
+	"cfstream/internal/output"
+)
+
+var foreachProfileCmd = &cobra.Command{
+	Use:   "foreach-profile [flags] -- <command> [args...]",
+	Short: "Run a command against every configured profile",
+	Long: `Run a cfstream command once per configured profile and merge the output into
+one list with a Profile column, e.g.
+
+  cfstream foreach-profile --concurrency 4 -- video list --status ready
+
+Profiles are the accounts listed under profiles in the config file, plus the
+top-level account as "default". Runs are sequential unless --concurrency is
+greater than 1; the merged output keeps profile order either way. Commands
+that ask for confirmation need --yes. Output that isn't a list is printed
+per profile instead of merged.
+
+The global --all-profiles flag does the same for a single command:
+
+  cfstream --all-profiles video list`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAcrossProfiles(args, foreachConcurrency)
+	},
+}
+
+var foreachConcurrency int
+
+// profileRun is the outcome of running a command against one profile.
+type profileRun struct {
+	stdout bytes.Buffer
+	stderr bytes.Buffer
+	err    error
+}
+
+func init() {
+	rootCmd.AddCommand(foreachProfileCmd)
+
+	foreachProfileCmd.Flags().IntVar(&foreachConcurrency, "concurrency", 1, "number of profiles to run concurrently")
+}
+
+// errRanAcrossProfiles stops a command invoked with --all-profiles once it
+// has been run against every profile, so its own RunE is skipped.
+var errRanAcrossProfiles = errors.New("command ran against every profile")
+
+// runAllProfiles reruns the command cobra parsed once per profile instead of
+// running it directly. It returns errRanAcrossProfiles when the runs succeed.
+func runAllProfiles(cmd *cobra.Command, args []string) error {
+	if cmd == foreachProfileCmd {
+		return fmt.Errorf("--all-profiles cannot be combined with foreach-profile")
+	}
+	if err := runAcrossProfiles(commandLine(cmd, args), profileConcurrency); err != nil {
+		return err
+	}
+	return errRanAcrossProfiles
+}
+
+// commandLine rebuilds the arguments of a parsed command: its path, the
+// flags that were set, and the positional arguments after a -- terminator.
+func commandLine(cmd *cobra.Command, args []string) []string {
+	line := strings.Fields(cmd.CommandPath())[1:]
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		if sv, ok := f.Value.(pflag.SliceValue); ok {
+			for _, value := range sv.GetSlice() {
+				line = append(line, "--"+f.Name+"="+value)
+			}
+			return
+		}
+		line = append(line, "--"+f.Name+"="+f.Value.String())
+	})
+	line = append(line, "--")
+	return append(line, args...)
+}
+
+// runAcrossProfiles runs cfstream with args once per profile as a child
+// process and prints the merged output in the selected format.
+func runAcrossProfiles(args []string, concurrency int) error {
+	if concurrency < 1 {
+		return fmt.Errorf("--concurrency must be at least 1")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	names := cfg.ProfileNames()
+	if len(names) == 0 {
+		return fmt.Errorf("no profiles configured; add a profiles section to %s", config.Path())
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate cfstream: %w", err)
+	}
+
+	// Children print machine-readable output for merging; the table view is
+	// built from CSV so it keeps the columns the command chose
+	childFormat := outputFormatCSV
+	if outputFormat == outputFormatJSON || outputFormat == outputFormatNDJSON || outputFormat == outputFormatYAML {
+		childFormat = outputFormatJSON
+	}
+
+	runs := make([]profileRun, len(names))
+	p := pool.New().WithMaxGoroutines(concurrency)
+	for i, name := range names {
+		p.Go(func() {
+			child := exec.CommandContext(context.Background(), executable, profileArgs(args, name, childFormat)...) //nolint:gosec // Reruns this binary
+			child.Stdout = &runs[i].stdout
+			child.Stderr = &runs[i].stderr
+			runs[i].err = child.Run()
+		})
+	}
+	p.Wait()
+
+	outputs := make([]merge.Output, 0, len(names))
+	failed := 0
+	for i, name := range names {
+		scanner := bufio.NewScanner(&runs[i].stderr)
+		for scanner.Scan() {
+			fmt.Fprintf(os.Stderr, "[%s] %s\n", name, scanner.Text())
+		}
+		if runs[i].err != nil {
+			failed++
+			continue
+		}
+		outputs = append(outputs, merge.Output{Profile: name, Data: runs[i].stdout.Bytes()})
+	}
+
+	if err := printMerged(outputs, childFormat); err != nil {
+		// Not a list: show each profile's output as is
+		for _, out := range outputs {
+			fmt.Printf("== %s ==\n%s", out.Profile, out.Data)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d profiles failed", failed, len(names))
+	}
+	return nil
+}
+
+// printMerged merges the outputs of all profiles and prints them in the
+// selected output format.
+func printMerged(outputs []merge.Output, childFormat string) error {
+	formatter, err := output.NewFormatter(outputFormat)
+	if err != nil {
+		return err
+	}
+
+	if childFormat == outputFormatJSON {
+		items, err := merge.JSON(outputs)
+		if err != nil {
+			return err
+		}
+		if items == nil {
+			items = []interface{}{}
+		}
+		return formatter.FormatList(os.Stdout, nil, items)
+	}
+
+	headers, rows, err := merge.CSV(outputs)
+	if err != nil {
+		return err
+	}
+	return formatter.FormatList(os.Stdout, headers, rows)
+}
+
+// profileArgs returns the arguments that rerun a command against one profile.
+// Flags are appended, where they override earlier values, but before a --
+// terminator so they aren't taken as positional arguments.
+func profileArgs(args []string, profile, format string) []string {
+	flags := []string{"--all-profiles=false", "--profile", profile, "--output", format, "--quiet", "--progress", "none"}
+
+	end := slices.Index(args, "--")
+	if end < 0 {
+		end = len(args)
+	}
+	childArgs := slices.Clone(args[:end])
+	childArgs = append(childArgs, flags...)
+	return append(childArgs, args[end:]...)
+}
@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"os"
+
+	"cfstream/internal/progress"
+)
+
+// progressMode is the --progress flag: bar, plain, json, or none.
+var progressMode string
+
+// newProgress returns a Reporter on stderr in the --progress mode. Progress
+// is not reported when only a dry run is printed, nor when output is quiet
+// unless it is the JSON event stream, which --quiet leaves as the only
+// output on stderr for programs wrapping cfstream.
+func newProgress(opts progress.Options) progress.Reporter {
+	if dryRun || (quiet && progressMode != progress.ModeJSON) {
+		return progress.None()
+	}
+	reporter, err := progress.New(os.Stderr, progressMode, opts)
+	if err != nil {
+		// The mode is validated before any command runs
+		return progress.None()
+	}
+	return reporter
+}
+
+// batchProgress returns a Reporter counting total batch items.
+func batchProgress(total int, description string) progress.Reporter {
+	return newProgress(progress.Options{Total: int64(total), Description: description})
+}
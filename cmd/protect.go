@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/term"
+
+	"cfstream/internal/alias"
+	"cfstream/internal/api"
+	"cfstream/internal/config"
+	"cfstream/internal/protection"
+)
+
+// overrideProtection skips the protected_videos check; registered on every
+// command that deletes videos.
+var overrideProtection bool
+
+// loadProtection compiles the protected_videos list from the configuration.
+func loadProtection() (*protection.List, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	aliases, err := alias.Load()
+	if err != nil {
+		return nil, err
+	}
+	return protection.Compile(cfg.ProtectedVideos, aliases.Resolve)
+}
+
+// guardProtected makes sure none of videoIDs is deleted by mistake: each
+// protected video must be confirmed by retyping its name, unless
+// --override-protection is set. Unattended runs fail instead of prompting.
+func guardProtected(client api.Client, videoIDs []string) error {
+	list, err := loadProtection()
+	if err != nil {
+		return err
+	}
+	if list.Empty() {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+	protected, err := list.Find(ctx, client, videoIDs)
+	if err != nil {
+		return err
+	}
+	if len(protected) == 0 {
+		return nil
+	}
+
+	if overrideProtection {
+		if !quiet {
+			fmt.Fprintf(os.Stderr, "Warning: deleting %d protected videos (--override-protection)\n", len(protected))
+		}
+		return nil
+	}
+	if dryRun {
+		for _, v := range protected {
+			fmt.Printf("[dry-run] video %s is protected; deleting it requires retyping its name or --override-protection\n", v.UID)
+		}
+		return nil
+	}
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return fmt.Errorf("%d of the videos are protected (%s); use --override-protection to delete them unattended",
+			len(protected), protectedUIDs(protected))
+	}
+
+	for _, v := range protected {
+		name := v.Name
+		if name == "" {
+			name = v.UID
+		}
+		fmt.Fprintf(os.Stderr, "Video %s (%s) is protected. Type its name to confirm deletion: ", v.UID, name)
+		response, err := stdinReader.ReadString('\n')
+		if err != nil {
+			return fmt.Errorf("failed to read confirmation: %w", err)
+		}
+		if strings.TrimSpace(response) != name {
+			return fmt.Errorf("name does not match; protected video %s was not deleted", v.UID)
+		}
+	}
+	return nil
+}
+
+// protectedUIDs lists the UIDs of videos for messages.
+func protectedUIDs(videos []api.Video) string {
+	uids := make([]string, len(videos))
+	for i, v := range videos {
+		uids[i] = v.UID
+	}
+	return strings.Join(uids, ", ")
+}
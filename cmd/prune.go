@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"cfstream/internal/api"
+	"cfstream/internal/output"
+	"cfstream/internal/tags"
+)
+
+var pruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Delete old videos by a retention policy",
+	Long: `Delete the videos created before --older-than, optionally only those with
+a status, keeping videos with an excluded tag, e.g.
+
+  cfstream prune --older-than 180d --status ready --exclude-tag keep
+
+The candidates are listed and deleted concurrently after confirmation; use
+--dry-run to only list them, or --yes to run unattended, e.g. from cron.
+Videos in the protected_videos list of the config file are always kept. For
+rules kept in the config file, see 'cfstream lifecycle'.`,
+	Args: cobra.NoArgs,
+	RunE: notifyOnFinish("Prune", runPrune),
+}
+
+var (
+	pruneOlderThan   string
+	pruneStatus      string
+	pruneExcludeTags []string
+	pruneConcurrency int
+	pruneCheckpoint  string
+)
+
+func init() {
+	rootCmd.AddCommand(pruneCmd)
+
+	pruneCmd.Flags().StringVar(&pruneOlderThan, "older-than", "", "delete videos created before, e.g. 180d or 2024-01-01 (required)")
+	pruneCmd.Flags().StringVar(&pruneStatus, "status", "", "only delete videos with this status, e.g. ready")
+	pruneCmd.Flags().StringArrayVar(&pruneExcludeTags, "exclude-tag", nil, "keep videos with this tag (repeatable)")
+	pruneCmd.Flags().IntVar(&pruneConcurrency, "concurrency", 4, "number of concurrent deletions")
+	pruneCmd.Flags().StringVar(&pruneCheckpoint, "checkpoint", "", "file recording deleted IDs; completed IDs are skipped on rerun")
+	_ = pruneCmd.MarkFlagRequired("older-than") //nolint:errcheck // Flag is registered above
+}
+
+func runPrune(cmd *cobra.Command, args []string) error {
+	before, err := parseTimeAgo("--older-than", pruneOlderThan, time.Now())
+	if err != nil {
+		return err
+	}
+	for _, tag := range pruneExcludeTags {
+		if err := tags.Validate(tag); err != nil {
+			return err
+		}
+	}
+
+	protected, err := loadProtection()
+	if err != nil {
+		return err
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	videos, err := listAllVideos(ctx, client, &api.ListOptions{Status: pruneStatus, End: &before})
+	cancel()
+	if err != nil {
+		return err
+	}
+
+	var candidates []api.Video
+	tagged, kept := 0, 0
+	for i := range videos {
+		switch {
+		case hasAnyTag(&videos[i], pruneExcludeTags):
+			tagged++
+		case protected.Protected(&videos[i]):
+			kept++
+		default:
+			candidates = append(candidates, videos[i])
+		}
+	}
+
+	if len(candidates) == 0 {
+		if !quiet {
+			fmt.Printf("No videos to prune (%d excluded by tag, %d protected)\n", tagged, kept)
+		}
+		return nil
+	}
+
+	formatter, err := output.NewFormatter(outputFormat)
+	if err != nil {
+		return err
+	}
+	if err := formatter.FormatList(os.Stdout, videoListHeaders, candidates); err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+	if !quiet {
+		fmt.Printf("%d to delete, %d excluded by tag, %d protected\n", len(candidates), tagged, kept)
+	}
+
+	videoIDs := make([]string, len(candidates))
+	for i, v := range candidates {
+		videoIDs[i] = v.UID
+	}
+	return deleteVideos(videoIDs, pruneConcurrency, pruneCheckpoint)
+}
+
+// hasAnyTag reports whether v carries one of the given tags.
+func hasAnyTag(v *api.Video, exclude []string) bool {
+	videoTags := tags.Get(v.Meta)
+	for _, tag := range exclude {
+		if tags.Has(videoTags, tag) {
+			return true
+		}
+	}
+	return false
+}
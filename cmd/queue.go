@@ -0,0 +1,207 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"cfstream/internal/output"
+	"cfstream/internal/upload"
+)
+
+var uploadQueueCmd = &cobra.Command{
+	Use:   "queue",
+	Short: "Stage files and upload them later",
+	Long: `Stage files for upload and upload them later in one run, e.g. stage
+during the day and drain the queue overnight from cron:
+
+  cfstream upload queue add recording-*.mp4 --metadata '{"event":"summit"}'
+  cfstream upload queue list
+  cfstream upload queue run --concurrency 2
+
+The queue is kept in the cfstream state directory
+($XDG_STATE_HOME/cfstream/queue.json). Uploaded files leave the queue; failed
+ones stay with their error and are tried again on the next run.`,
+}
+
+var uploadQueueAddCmd = &cobra.Command{
+	Use:   "add <file>...",
+	Short: "Add files to the upload queue",
+	Long: `Add files to the upload queue. Videos are named after their files
+unless --name is given for a single file. Adding a queued file again replaces
+its name and metadata.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runUploadQueueAdd,
+}
+
+var uploadQueueListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the files in the upload queue",
+	Args:  cobra.NoArgs,
+	RunE:  runUploadQueueList,
+}
+
+var uploadQueueRemoveCmd = &cobra.Command{
+	Use:   "remove <file>...",
+	Short: "Remove files from the upload queue",
+	Args:  cobra.MinimumNArgs(1),
+	RunE:  runUploadQueueRemove,
+}
+
+var uploadQueueRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Upload the files in the queue",
+	Long: `Upload the queued files concurrently like 'upload file' with several
+files. Each uploaded file is removed from the queue as soon as it is done, so
+an interrupted run continues with the remaining files; failed files stay
+queued with their error.`,
+	Args: cobra.NoArgs,
+	RunE: notifyOnFinish("Upload queue", runUploadQueueRun),
+}
+
+var queueConcurrency int
+
+func init() {
+	uploadCmd.AddCommand(uploadQueueCmd)
+	uploadQueueCmd.AddCommand(uploadQueueAddCmd)
+	uploadQueueCmd.AddCommand(uploadQueueListCmd)
+	uploadQueueCmd.AddCommand(uploadQueueRemoveCmd)
+	uploadQueueCmd.AddCommand(uploadQueueRunCmd)
+
+	uploadQueueAddCmd.Flags().StringVar(&uploadName, "name", "", "video name (defaults to filename)")
+	uploadQueueAddCmd.Flags().StringVar(&uploadMetadata, "metadata", "", "video metadata as JSON")
+
+	uploadQueueRunCmd.Flags().IntVar(&queueConcurrency, "concurrency", 2, "number of files uploaded at once")
+	uploadQueueRunCmd.Flags().BoolVar(&uploadValidate, "validate", false, "check duration, codec, and size against Stream limits before uploading")
+}
+
+func runUploadQueueAdd(cmd *cobra.Command, args []string) error {
+	if len(args) > 1 && uploadName != "" {
+		return fmt.Errorf("--name cannot be used with several files; videos are named after their files")
+	}
+
+	var metadata map[string]interface{}
+	if uploadMetadata != "" {
+		if err := json.Unmarshal([]byte(uploadMetadata), &metadata); err != nil {
+			return fmt.Errorf("invalid metadata JSON: %w", err)
+		}
+	}
+
+	files := make([]upload.Queued, 0, len(args))
+	for _, path := range args {
+		name := uploadName
+		if name == "" {
+			name = filepath.Base(path)
+		}
+		f, err := upload.NewQueued(path, name, metadata)
+		if err != nil {
+			return err
+		}
+		files = append(files, f)
+	}
+
+	added, err := upload.Enqueue(files...)
+	if err != nil {
+		return err
+	}
+	if !quiet {
+		fmt.Printf("%d files added to the upload queue", added)
+		if updated := len(files) - added; updated > 0 {
+			fmt.Printf(", %d already queued were updated", updated)
+		}
+		fmt.Println()
+	}
+	return nil
+}
+
+func runUploadQueueList(cmd *cobra.Command, args []string) error {
+	queue, err := upload.LoadQueue()
+	if err != nil {
+		return err
+	}
+	if len(queue.Files) == 0 {
+		if !quiet {
+			fmt.Println("The upload queue is empty")
+		}
+		return nil
+	}
+
+	formatter, err := output.NewFormatter(outputFormat)
+	if err != nil {
+		return err
+	}
+	headers := []string{"Path", "Name", "Size", "Added", "Attempts", "Error"}
+	if err := formatter.FormatList(os.Stdout, headers, queue.Files); err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+	return nil
+}
+
+func runUploadQueueRemove(cmd *cobra.Command, args []string) error {
+	for _, path := range args {
+		found, err := upload.Dequeue(path)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return fmt.Errorf("%s is not in the upload queue", path)
+		}
+		if !quiet {
+			fmt.Printf("Removed %s from the upload queue\n", path)
+		}
+	}
+	return nil
+}
+
+func runUploadQueueRun(cmd *cobra.Command, args []string) error {
+	if err := checkUploadFlags(); err != nil {
+		return err
+	}
+
+	queue, err := upload.LoadQueue()
+	if err != nil {
+		return err
+	}
+	if len(queue.Files) == 0 {
+		if !quiet {
+			fmt.Println("The upload queue is empty; nothing to do")
+		}
+		return nil
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	files := make([]fileUpload, len(queue.Files))
+	paths := make([]string, len(queue.Files))
+	for i, f := range queue.Files {
+		files[i] = fileUpload{Path: f.Path, Name: f.Name, Meta: f.Meta}
+		paths[i] = f.Path
+	}
+
+	minutes, err := validateUploads(paths)
+	if err != nil {
+		return err
+	}
+	if err := checkQuota(context.Background(), client, minutes); err != nil {
+		return err
+	}
+
+	return uploadFiles(client, files, queueConcurrency, nil, func(path string, err error) {
+		var saveErr error
+		if err != nil {
+			saveErr = upload.MarkFailed(path, err)
+		} else {
+			_, saveErr = upload.Dequeue(path)
+		}
+		if saveErr != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to update the upload queue: %v\n", saveErr)
+		}
+	})
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/pflag"
+
+	"cfstream/internal/api"
+)
+
+// quotaLowFraction is the share of remaining storage below which uploads warn.
+const quotaLowFraction = 0.1
+
+var failOnQuota bool
+
+// addQuotaFlag registers --fail-on-quota on flags of a command that calls
+// checkQuota.
+func addQuotaFlag(flags *pflag.FlagSet) {
+	flags.BoolVar(&failOnQuota, "fail-on-quota", false, "fail instead of warning when the upload may exceed the storage quota")
+}
+
+// checkQuota compares the account's remaining storage minutes with the
+// minutes an operation needs, or zero when that is unknown. It warns on
+// stderr, or fails with --fail-on-quota, when the upload would exceed the plan.
+func checkQuota(ctx context.Context, client api.Client, neededMinutes float64) error {
+	// Dry runs upload nothing and make no API calls for the plan
+	if dryRun {
+		return nil
+	}
+
+	usage, err := client.GetStorageUsage(ctx)
+	if err != nil {
+		if failOnQuota {
+			return fmt.Errorf("failed to check storage quota: %w", err)
+		}
+		if verbose {
+			fmt.Fprintf(os.Stderr, "Warning: failed to check storage quota: %v\n", err)
+		}
+		return nil
+	}
+
+	remaining := usage.RemainingMinutes()
+	if remaining < 0 {
+		return nil
+	}
+
+	summary := fmt.Sprintf("%.0f of %.0f storage minutes used, %.0f remaining",
+		usage.TotalStorageMinutes, usage.TotalStorageMinutesLimit, remaining)
+
+	if remaining <= 0 || neededMinutes > remaining {
+		if failOnQuota {
+			return fmt.Errorf("upload would exceed the storage quota: %s", summary)
+		}
+		fmt.Fprintf(os.Stderr, "Warning: upload may exceed the storage quota (%s)\n", summary)
+		return nil
+	}
+
+	if remaining < usage.TotalStorageMinutesLimit*quotaLowFraction && !quiet {
+		fmt.Fprintf(os.Stderr, "Warning: storage quota is running low (%s)\n", summary)
+	}
+	return nil
+}
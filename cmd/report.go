@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"cfstream/internal/output"
+	"cfstream/internal/report"
+)
+
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Summarize the video library",
+	Long:  `Summarize the video library for usage and chargeback reports.`,
+}
+
+var reportCreatorsCmd = &cobra.Command{
+	Use:   "creators",
+	Short: "Total videos, minutes, and bytes per creator",
+	Long: `Group the library by the creator field and total the number of videos,
+minutes, and bytes stored per creator, largest first. Videos without a
+creator are grouped as (none). Use --output csv for a spreadsheet.`,
+	Args: cobra.NoArgs,
+	RunE: runReportCreators,
+}
+
+var reportFilter string
+
+func init() {
+	rootCmd.AddCommand(reportCmd)
+	reportCmd.AddCommand(reportCreatorsCmd)
+
+	reportCreatorsCmd.Flags().StringVar(&reportFilter, "filter", "", "only include matching videos, e.g. 'status=ready'")
+}
+
+func runReportCreators(cmd *cobra.Command, args []string) error {
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	videos, err := selectVideos(client, reportFilter, reportFilter == "")
+	if err != nil {
+		return err
+	}
+
+	formatter, err := output.NewFormatter(outputFormat)
+	if err != nil {
+		return err
+	}
+
+	headers := []string{"Creator", "Videos", "Minutes", "Bytes"}
+	if err := formatter.FormatList(os.Stdout, headers, report.ByCreator(videos)); err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"cfstream/internal/api"
+	"cfstream/internal/output"
+	"cfstream/internal/upload"
+)
+
+var uploadResumeCmd = &cobra.Command{
+	Use:   "resume [video-id]",
+	Short: "Continue an interrupted file upload",
+	Long: `Continue a large file upload that was interrupted, e.g. by a lost
+connection or a killed process. Files of 200 MB or more are uploaded with the
+TUS protocol, and their upload URLs are recorded in the cfstream state
+directory ($XDG_STATE_HOME/cfstream/uploads.json). The server is asked how
+much it already received, so only the rest of the file is sent.
+
+Without a video ID, the only interrupted upload is resumed; when there are
+several, they are listed. The file must not have changed since the upload
+started. Use --discard to forget an upload without resuming it.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: notifyOnFinish("Upload", runUploadResume),
+}
+
+var resumeDiscard bool
+
+func init() {
+	uploadCmd.AddCommand(uploadResumeCmd)
+
+	uploadResumeCmd.Flags().BoolVar(&resumeDiscard, "discard", false, "forget the interrupted upload instead of resuming it")
+}
+
+func runUploadResume(cmd *cobra.Command, args []string) error {
+	state, err := upload.LoadState()
+	if err != nil {
+		return err
+	}
+
+	var pending upload.Pending
+	if len(args) == 1 {
+		p, ok := state.Uploads[args[0]]
+		if !ok {
+			return fmt.Errorf("no interrupted upload with video ID %s", args[0])
+		}
+		pending = p
+	} else {
+		list := state.List()
+		switch len(list) {
+		case 0:
+			if !quiet {
+				fmt.Println("No interrupted uploads to resume")
+			}
+			return nil
+		case 1:
+			pending = list[0]
+		default:
+			formatter, err := output.NewFormatter(outputFormat)
+			if err != nil {
+				return err
+			}
+			if err := formatter.FormatList(os.Stdout, []string{"UID", "Name", "Path", "Size", "Started"}, list); err != nil {
+				return fmt.Errorf("failed to format output: %w", err)
+			}
+			return fmt.Errorf("%d interrupted uploads; pass the video ID of the one to resume", len(list))
+		}
+	}
+
+	if resumeDiscard {
+		if err := upload.Forget(pending.UID); err != nil {
+			return err
+		}
+		if !quiet {
+			fmt.Printf("Discarded upload %s of %s\n", pending.UID, pending.Path)
+		}
+		return nil
+	}
+
+	if err := pending.CheckFile(); err != nil {
+		return err
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	if dryRun {
+		_, err := client.ResumeUpload(ctx, pending.Resumable(), pending.Path, nil)
+		if isDryRun(err) {
+			return nil
+		}
+		return fmt.Errorf("upload failed: %w", err)
+	}
+
+	if !quiet {
+		fmt.Printf("Resuming upload of %s (%s)...\n", filepath.Base(pending.Path), upload.FormatBytes(pending.Size))
+	}
+
+	progressTracker := upload.NewProgressTracker(newProgress(upload.ProgressOptions(pending.Size, filepath.Base(pending.Path))))
+	progressCh := make(chan api.UploadProgress, 10)
+	go func() {
+		for progress := range progressCh {
+			progressTracker.Update(progress)
+		}
+	}()
+
+	video, err := client.ResumeUpload(ctx, pending.Resumable(), pending.Path, progressCh)
+	close(progressCh)
+	progressTracker.Finish()
+
+	if err != nil {
+		if errors.Is(err, api.ErrUploadExpired) {
+			forgetUpload(pending.UID)
+			return fmt.Errorf("upload %s expired; upload %s again: %w", pending.UID, pending.Path, err)
+		}
+		return fmt.Errorf("upload failed: %w", err)
+	}
+	forgetUpload(pending.UID)
+
+	return reportFileUpload(ctx, client, video)
+}
+
+// resumeRecorder records the TUS upload of a file in the resume state, so an
+// interrupted upload can be continued with 'upload resume'.
+type resumeRecorder struct {
+	path string
+	name string
+	uid  string // Video ID once the upload is recorded
+}
+
+// record is an api.UploadOptions.OnResumable callback. Failing to record the
+// upload only prevents resuming it, so it is a warning.
+func (r *resumeRecorder) record(u *api.ResumableUpload) {
+	p, err := upload.NewPending(u, r.path, r.name)
+	if err == nil {
+		err = upload.Record(p)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to record upload for resuming: %v\n", err)
+		return
+	}
+	r.uid = u.UID
+}
+
+// finish forgets the recorded upload once it completed.
+func (r *resumeRecorder) finish() {
+	if r.uid != "" {
+		forgetUpload(r.uid)
+	}
+}
+
+// forgetUpload removes a finished upload from the resume state. Failing to
+// do so only leaves a stale entry, so it is a warning.
+func forgetUpload(uid string) {
+	if err := upload.Forget(uid); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to update upload state: %v\n", err)
+	}
+}
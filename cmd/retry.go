@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"cfstream/internal/api"
+	"cfstream/internal/output"
+)
+
+var videoRetryCmd = &cobra.Command{
+	Use:   "retry <video-id>",
+	Short: "Copy a failed video from its source URL again",
+	Long: `Re-ingest a video whose processing failed. The video is copied again from
+the source URL recorded in its sourceURL metadata key, keeping its name and
+metadata, and the errored copy is deleted once the new copy is submitted.
+
+The source URL is recorded by 'cfstream upload url' and 'cfstream migrate';
+videos uploaded from files, or from storage buckets with presigned URLs,
+cannot be retried. Only videos with status error are retried unless --force
+is set.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runVideoRetry,
+}
+
+var retryForce bool
+
+func init() {
+	videoCmd.AddCommand(videoRetryCmd)
+
+	videoRetryCmd.Flags().BoolVar(&retryForce, "force", false, "retry videos that have not failed, e.g. stuck in queued")
+}
+
+func runVideoRetry(cmd *cobra.Command, args []string) error {
+	videoID, err := resolveVideoID(args[0])
+	if err != nil {
+		return err
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	video, err := client.GetVideo(ctx, videoID)
+	if err != nil {
+		return fmt.Errorf("failed to get video: %w", err)
+	}
+
+	if video.Status != "error" && !retryForce {
+		return fmt.Errorf("video %s has status %s, not error (use --force to retry anyway)", videoID, video.Status)
+	}
+	sourceURL := video.SourceURL()
+	if sourceURL == "" {
+		return fmt.Errorf("video %s has no %s in its metadata; upload it again instead", videoID, api.MetaSourceURL)
+	}
+
+	if err := guardProtected(client, []string{videoID}); err != nil {
+		return err
+	}
+	ok, err := confirm(fmt.Sprintf("Copy %s from %s again and delete the errored copy?", video.Name, sourceURL))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		fmt.Println("Retry cancelled")
+		return nil
+	}
+
+	// The new copy is submitted first, so a failure leaves the errored video
+	// and its recorded source URL in place
+	retried, err := client.UploadFromURL(ctx, sourceURL, &api.UploadOptions{
+		Metadata:          video.Meta,
+		RequireSignedURLs: true,
+	})
+	if err != nil && !isDryRun(err) {
+		return fmt.Errorf("failed to copy video: %w", err)
+	}
+
+	if err := client.DeleteVideo(ctx, videoID); err != nil {
+		if isDryRun(err) {
+			return nil
+		}
+		return fmt.Errorf("copied video as %s but failed to delete %s: %w", retried.UID, videoID, err)
+	}
+
+	if !quiet {
+		fmt.Printf("Deleted errored video %s\n", videoID)
+		fmt.Printf("Video ID: %s\n", retried.UID)
+		fmt.Printf("Status: %s\n", retried.Status)
+	}
+
+	if outputFormat != outputFormatTable {
+		formatter, err := output.NewFormatter(outputFormat)
+		if err != nil {
+			return err
+		}
+		return formatter.FormatSingle(os.Stdout, retried)
+	}
+	return nil
+}
@@ -2,6 +2,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
@@ -11,20 +12,33 @@ import (
 	_ "github.com/schollz/progressbar/v3"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+
+	"cfstream/internal/progress"
 )
 
 const (
-	version           = "0.1.0"
-	outputFormatJSON  = "json"
-	outputFormatTable = "table"
-	outputFormatYAML  = "yaml"
+	version            = "0.1.0"
+	outputFormatJSON   = "json"
+	outputFormatNDJSON = "ndjson"
+	outputFormatTable  = "table"
+	outputFormatYAML   = "yaml"
+	outputFormatCSV    = "csv"
 )
 
 var (
 	// Global flags.
-	outputFormat string
-	quiet        bool
-	verbose      bool
+	outputFormat       string
+	quiet              bool
+	verbose            bool
+	notifyDesktop      bool
+	notifyWebhookURL   string
+	assumeYes          bool
+	dryRun             bool
+	waitOnRateLimit    bool
+	printCurl          bool
+	profileName        string
+	allProfiles        bool
+	profileConcurrency int
 )
 
 // rootCmd represents the base command when called without any subcommands.
@@ -36,24 +50,58 @@ var rootCmd = &cobra.Command{
 Upload videos, manage metadata, generate links, and retrieve embed codes
 for your Cloudflare Stream account.`,
 	Version: version,
+	// Errors are reported by executeRoot, which knows about --all-profiles
+	SilenceErrors: true,
+	SilenceUsage:  true,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := progress.Validate(progressMode); err != nil {
+			return err
+		}
+		if allProfiles {
+			return runAllProfiles(cmd, args)
+		}
+		return nil
+	},
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
+	if err := executeRoot(); err != nil {
 		os.Exit(1)
 	}
 }
 
+// executeRoot runs the command line set on rootCmd and reports any error.
+// A command that ran against every profile with --all-profiles succeeded.
+func executeRoot() error {
+	cmd, err := rootCmd.ExecuteC()
+	if err == nil || errors.Is(err, errRanAcrossProfiles) {
+		return nil
+	}
+	cmd.PrintErrln(cmd.ErrPrefix(), err.Error())
+	cmd.PrintErrf("Run '%v --help' for usage.\n", cmd.CommandPath())
+	return err
+}
+
 func init() {
 	// Add subcommands
 	rootCmd.AddCommand(uploadCmd)
 
 	// Global flags available to all commands
-	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", outputFormatTable, "output format (table, json, yaml)")
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", outputFormatTable, "output format (table, json, ndjson, yaml, csv, go-template=TEMPLATE)")
 	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "suppress non-essential output")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "verbose output")
+	rootCmd.PersistentFlags().BoolVarP(&assumeYes, "yes", "y", false, "assume yes for all confirmation prompts")
+	rootCmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "print the API calls mutating commands would make without executing them")
+	rootCmd.PersistentFlags().BoolVar(&waitOnRateLimit, "wait-on-rate-limit", false, "wait and retry when rate limited instead of failing (for unattended jobs)")
+	rootCmd.PersistentFlags().BoolVar(&printCurl, "print-curl", false, "print an equivalent curl command for each API request on stderr (token read from $CFSTREAM_API_TOKEN)")
+	rootCmd.PersistentFlags().StringVar(&profileName, "profile", "", "use the credentials of a profile from the config file")
+	rootCmd.PersistentFlags().BoolVar(&allProfiles, "all-profiles", false, "run the command against every configured profile and merge the output")
+	rootCmd.PersistentFlags().IntVar(&profileConcurrency, "profile-concurrency", 1, "number of profiles --all-profiles runs concurrently")
+	rootCmd.PersistentFlags().StringVar(&progressMode, "progress", progress.ModeBar, "progress output on stderr (bar, plain, json, none)")
+	rootCmd.PersistentFlags().BoolVar(&notifyDesktop, "notify", false, "show a desktop notification when long operations finish")
+	rootCmd.PersistentFlags().StringVar(&notifyWebhookURL, "notify-webhook", "", "Slack/Discord webhook URL to notify when long operations finish")
 
 	// Bind flags to viper for config file support
 	_ = viper.BindPFlag("output", rootCmd.PersistentFlags().Lookup("output")) //nolint:errcheck // Flag binding errors are not expected
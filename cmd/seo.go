@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"cfstream/internal/output"
+	"cfstream/internal/seo"
+)
+
+var seoCmd = &cobra.Command{
+	Use:   "seo",
+	Short: "Generate SEO metadata",
+	Long:  `Generate structured data that helps search engines index your videos.`,
+}
+
+var seoJSONLDCmd = &cobra.Command{
+	Use:   "jsonld <video-id>",
+	Short: "Print a schema.org VideoObject JSON-LD block",
+	Long: `Print a schema.org VideoObject JSON-LD <script> block for a video, ready to
+paste into a page head. The description comes from meta.description when set.
+
+With --output json or yaml, the bare VideoObject is printed instead.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSEOJSONLD,
+}
+
+func init() {
+	rootCmd.AddCommand(seoCmd)
+	seoCmd.AddCommand(seoJSONLDCmd)
+}
+
+func runSEOJSONLD(cmd *cobra.Command, args []string) error {
+	videoID, err := resolveVideoID(args[0])
+	if err != nil {
+		return err
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	video, err := client.GetVideo(ctx, videoID)
+	if err != nil {
+		return fmt.Errorf("failed to get video: %w", err)
+	}
+
+	customerCode, err := extractCustomerCodeFromURL(video.Preview)
+	if err != nil {
+		return fmt.Errorf("failed to extract customer code: %w", err)
+	}
+
+	name := video.Name
+	if name == "" {
+		name = video.UID
+	}
+	description, _ := video.Meta["description"].(string) //nolint:errcheck // Missing or non-string descriptions fall back to the name
+
+	obj := seo.NewVideoObject(name, description, video.Duration, video.Created)
+	obj.ThumbnailURL = video.Thumbnail
+	obj.EmbedURL = fmt.Sprintf("https://customer-%s.cloudflarestream.com/%s/iframe", customerCode, video.UID)
+	if !video.RequireSignedURLs {
+		obj.ContentURL = video.Preview
+	}
+
+	if outputFormat != outputFormatTable {
+		formatter, err := output.NewFormatter(outputFormat)
+		if err != nil {
+			return err
+		}
+		return formatter.FormatSingle(os.Stdout, obj)
+	}
+
+	tag, err := obj.ScriptTag()
+	if err != nil {
+		return err
+	}
+	fmt.Println(tag)
+	return nil
+}
@@ -0,0 +1,246 @@
+package cmd
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"cfstream/internal/server"
+	"cfstream/internal/signing"
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Run a local REST proxy for Cloudflare Stream",
+	Long: `Run a minimal authenticated HTTP API backed by your Cloudflare credentials.
+
+Frontend prototypes can list videos, create direct upload URLs, and mint
+signed tokens without ever seeing the Cloudflare API token. Requests must
+carry "Authorization: Bearer <token>". If --auth-token and
+CFSTREAM_SERVE_TOKEN are unset, a random token is generated and printed.
+
+Endpoints:
+  GET  /healthz                       health check (no auth)
+  GET  /videos?search=&creator=       list videos
+  POST /uploads                       create a direct upload URL
+                                      body: {"maxDurationSeconds": 600, "expires": "30m"}
+  POST /videos/{id}/token?duration=   mint a signed token
+
+Tokens are signed locally with the signing key named by --key-id from the keys
+directory, or else the profile's configured signing key, and minted through
+the API when no signing key is configured.`,
+	Args: cobra.NoArgs,
+	RunE: runServe,
+}
+
+var serveTokensCmd = &cobra.Command{
+	Use:   "tokens",
+	Short: "Run a local signed-token server for player development",
+	Long: `Run an unauthenticated local server that returns a fresh signed token for
+any video, for developing players against private videos.
+
+  GET /token/{videoID}  ->  {"token": "...", "expires": "..."}
+
+Tokens are signed locally, which avoids an API request per token, with the
+signing key named by --key-id (read from --key-file or the keys directory) or
+else the profile's configured signing key. Without a signing key they are
+minted through the API.
+Bind only to localhost: anyone who can reach the server can watch your videos.`,
+	Args: cobra.NoArgs,
+	RunE: runServeTokens,
+}
+
+var (
+	servePort        int
+	serveHost        string
+	serveAuthToken   string
+	serveAllowOrigin string
+	serveDuration    string
+	serveKeyID       string
+
+	serveTokensPort        int
+	serveTokensHost        string
+	serveTokensDuration    string
+	serveTokensAllowOrigin string
+	serveTokensKeyID       string
+	serveTokensKeyFile     string
+)
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+
+	serveCmd.Flags().IntVar(&servePort, "port", 9000, "port to listen on")
+	serveCmd.Flags().StringVar(&serveHost, "host", "127.0.0.1", "address to bind")
+	serveCmd.Flags().StringVar(&serveAuthToken, "auth-token", "", "bearer token clients must send (default: $CFSTREAM_SERVE_TOKEN or random)")
+	serveCmd.Flags().StringVar(&serveAllowOrigin, "cors-origin", "", "value for Access-Control-Allow-Origin (e.g. http://localhost:3000)")
+	serveCmd.Flags().StringVar(&serveDuration, "duration", "", "default signed token lifetime (default from config)")
+	serveCmd.Flags().StringVar(&serveKeyID, "key-id", "", "stored signing key to sign tokens with (default: the configured signing key)")
+
+	serveCmd.AddCommand(serveTokensCmd)
+	serveTokensCmd.Flags().IntVar(&serveTokensPort, "port", 9001, "port to listen on")
+	serveTokensCmd.Flags().StringVar(&serveTokensHost, "host", "127.0.0.1", "address to bind")
+	serveTokensCmd.Flags().StringVar(&serveTokensDuration, "duration", "", "token lifetime (default from config)")
+	serveTokensCmd.Flags().StringVar(&serveTokensAllowOrigin, "cors-origin", "*", "value for Access-Control-Allow-Origin")
+	serveTokensCmd.Flags().StringVar(&serveTokensKeyID, "key-id", "", "stored signing key to sign tokens with (default: the configured signing key)")
+	serveTokensCmd.Flags().StringVar(&serveTokensKeyFile, "key-file", "", "PEM file of the --key-id signing key (default: the keys directory)")
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	duration, err := parseTokenDuration(serveDuration)
+	if err != nil {
+		return err
+	}
+
+	authToken := serveAuthToken
+	if authToken == "" {
+		authToken = os.Getenv("CFSTREAM_SERVE_TOKEN")
+	}
+	generated := authToken == ""
+	if generated {
+		authToken, err = randomToken()
+		if err != nil {
+			return err
+		}
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	mint, _, err := localMinter(serveKeyID, "")
+	if err != nil {
+		return err
+	}
+
+	handler, err := server.New(client, server.Options{
+		AuthToken:      authToken,
+		AllowOrigin:    serveAllowOrigin,
+		SignedDuration: duration,
+		Mint:           mint,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create server: %w", err)
+	}
+
+	addr := net.JoinHostPort(serveHost, strconv.Itoa(servePort))
+	if !quiet {
+		fmt.Fprintf(os.Stderr, "Listening on http://%s\n", addr)
+		if generated {
+			fmt.Fprintf(os.Stderr, "Auth token: %s\n", authToken)
+		}
+	}
+
+	return listenAndServe(addr, handler)
+}
+
+func runServeTokens(cmd *cobra.Command, args []string) error {
+	duration, err := parseTokenDuration(serveTokensDuration)
+	if err != nil {
+		return err
+	}
+
+	mint, mode, err := localMinter(serveTokensKeyID, serveTokensKeyFile)
+	if err != nil {
+		return err
+	}
+	if mint == nil {
+		client, err := createClient()
+		if err != nil {
+			return err
+		}
+		mint = func(ctx context.Context, videoID string, exp time.Time) (string, error) {
+			return client.GetSignedToken(ctx, videoID, exp.Unix())
+		}
+		mode = "API"
+	}
+
+	addr := net.JoinHostPort(serveTokensHost, strconv.Itoa(serveTokensPort))
+	if !quiet {
+		fmt.Fprintf(os.Stderr, "Serving tokens on http://%s/token/{videoID} (%s, valid for %s)\n", addr, mode, duration)
+	}
+
+	return listenAndServe(addr, server.NewTokenServer(mint, duration, serveTokensAllowOrigin))
+}
+
+// localMinter returns a Minter that signs tokens with the signing key keyID,
+// read from keyFile or the keys directory, or else with the configured signing
+// key. It returns a nil Minter when no signing key is configured. mode
+// describes the key for display.
+func localMinter(keyID, keyFile string) (mint server.Minter, mode string, err error) {
+	var signer *signing.Signer
+	if keyFile != "" {
+		if keyID == "" {
+			return nil, "", fmt.Errorf("--key-file requires --key-id")
+		}
+		signer, err = signing.LoadSigner(keyID, keyFile)
+	} else {
+		cfg, cfgErr := loadConfig()
+		if cfgErr != nil {
+			return nil, "", cfgErr
+		}
+		if keyID == "" && cfg.SigningKeyID == "" {
+			return nil, "", nil
+		}
+		signer, err = localSigner(cfg, keyID)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	mint = func(_ context.Context, videoID string, exp time.Time) (string, error) {
+		return signer.Sign(videoID, exp)
+	}
+	return mint, "local signing key " + signer.KeyID(), nil
+}
+
+// listenAndServe runs handler on addr until SIGINT or SIGTERM, then shuts down gracefully.
+func listenAndServe(addr string, handler http.Handler) error {
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		return fmt.Errorf("server failed: %w", err)
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to shut down server: %w", err)
+	}
+
+	return nil
+}
+
+// randomToken returns a random hex-encoded 32-byte token.
+func randomToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
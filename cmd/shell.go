@@ -0,0 +1,379 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/adrg/xdg"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"golang.org/x/term"
+
+	"cfstream/internal/alias"
+	"cfstream/internal/api"
+	"cfstream/internal/index"
+)
+
+const shellPrompt = "cfstream> "
+
+var shellCmd = &cobra.Command{
+	Use:   "shell",
+	Short: "Start an interactive shell",
+	Long: `Start an interactive shell for running cfstream commands.
+
+The shell keeps a single API client for the whole session, remembers command
+history, and completes commands, flags, aliases, and video IDs (from the local
+index) with the Tab key. Type 'help' for usage, 'history' to list previous
+commands, and 'exit' or Ctrl-D to leave.`,
+	Args: cobra.NoArgs,
+	RunE: runShell,
+}
+
+// inShell is set while the interactive shell is running.
+var inShell bool
+
+func init() {
+	rootCmd.AddCommand(shellCmd)
+}
+
+// shellSession holds the state of an interactive shell.
+type shellSession struct {
+	history []string
+	idx     *index.Index
+}
+
+func runShell(cmd *cobra.Command, args []string) error {
+	if inShell {
+		return fmt.Errorf("already running inside cfstream shell")
+	}
+
+	// Commands in the session reuse one client per profile and client flags
+	sessionClients = make(map[sessionKey]api.Client)
+	inShell = true
+	defer func() {
+		sessionClients = nil
+		inShell = false
+	}()
+	if _, err := createClient(); err != nil {
+		return err
+	}
+
+	session := &shellSession{
+		history: loadShellHistory(),
+	}
+	session.reloadIndex()
+
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return session.runScript(os.Stdin)
+	}
+
+	return session.runInteractive()
+}
+
+// runInteractive reads commands from the terminal with line editing and completion.
+func (s *shellSession) runInteractive() error {
+	fd := int(os.Stdin.Fd())
+	terminal := term.NewTerminal(struct {
+		io.Reader
+		io.Writer
+	}{os.Stdin, os.Stdout}, shellPrompt)
+	terminal.AutoCompleteCallback = s.complete
+
+	if !quiet {
+		fmt.Printf("cfstream %s interactive shell. Type 'help' for commands, 'exit' to quit.\n", version)
+	}
+
+	for {
+		// Raw mode is only needed while editing the line; commands print normally
+		oldState, err := term.MakeRaw(fd)
+		if err != nil {
+			return fmt.Errorf("failed to initialize terminal: %w", err)
+		}
+		if width, height, err := term.GetSize(fd); err == nil {
+			_ = terminal.SetSize(width, height) //nolint:errcheck // Size errors are not critical
+		}
+		line, err := terminal.ReadLine()
+		_ = term.Restore(fd, oldState) //nolint:errcheck // Best effort restore
+
+		if errors.Is(err, io.EOF) {
+			fmt.Println()
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read input: %w", err)
+		}
+
+		if done := s.execute(line); done {
+			return nil
+		}
+	}
+}
+
+// runScript executes commands read line by line from a non-terminal input.
+func (s *shellSession) runScript(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if done := s.execute(scanner.Text()); done {
+			return nil
+		}
+	}
+	return scanner.Err()
+}
+
+// execute runs a single shell line. It returns true when the shell should exit.
+func (s *shellSession) execute(line string) bool {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return false
+	}
+
+	s.recordHistory(line)
+
+	args, err := splitShellArgs(line)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return false
+	}
+	if len(args) > 0 && args[0] == "cfstream" {
+		args = args[1:]
+	}
+	if len(args) == 0 {
+		return false
+	}
+
+	switch args[0] {
+	case "exit", "quit":
+		return true
+	case "history":
+		for i, entry := range s.history {
+			fmt.Printf("%5d  %s\n", i+1, entry)
+		}
+		return false
+	}
+
+	resetFlags(rootCmd)
+	rootCmd.SetArgs(args)
+	_ = executeRoot() //nolint:errcheck // The error is already reported to the user
+
+	// Pick up any videos the command added to the index
+	s.reloadIndex()
+	return false
+}
+
+// complete implements tab completion for commands, flags, aliases, and video IDs.
+func (s *shellSession) complete(line string, pos int, key rune) (string, int, bool) {
+	if key != '\t' {
+		return "", 0, false
+	}
+
+	head := line[:pos]
+	fields := strings.Fields(head)
+	word := ""
+	if len(fields) > 0 && !strings.HasSuffix(head, " ") {
+		word = fields[len(fields)-1]
+		fields = fields[:len(fields)-1]
+	}
+
+	candidates := s.candidates(fields, word)
+	if len(candidates) == 0 {
+		return "", 0, false
+	}
+
+	completion := longestCommonPrefix(candidates)
+	if len(candidates) == 1 {
+		completion += " "
+	}
+	if completion == word {
+		return "", 0, false
+	}
+
+	newHead := head[:len(head)-len(word)] + completion
+	return newHead + line[pos:], len(newHead), true
+}
+
+// candidates returns completion candidates for word given the preceding fields.
+func (s *shellSession) candidates(fields []string, word string) []string {
+	target, _, err := rootCmd.Find(fields)
+	if err != nil {
+		target = rootCmd
+	}
+
+	var matches []string
+	if strings.HasPrefix(word, "-") {
+		target.Flags().VisitAll(func(f *pflag.Flag) {
+			name := "--" + f.Name
+			if !f.Hidden && strings.HasPrefix(name, word) {
+				matches = append(matches, name)
+			}
+		})
+		return matches
+	}
+
+	if target.HasAvailableSubCommands() {
+		for _, sub := range target.Commands() {
+			if sub.IsAvailableCommand() && strings.HasPrefix(sub.Name(), word) {
+				matches = append(matches, sub.Name())
+			}
+		}
+		if target == rootCmd {
+			for _, builtin := range []string{"exit", "history", "quit"} {
+				if strings.HasPrefix(builtin, word) {
+					matches = append(matches, builtin)
+				}
+			}
+		}
+		return matches
+	}
+
+	if aliases, err := alias.Load(); err == nil {
+		for _, name := range aliases.Names() {
+			if strings.HasPrefix(name, word) {
+				matches = append(matches, name)
+			}
+		}
+	}
+	if s.idx != nil {
+		matches = append(matches, s.idx.Complete(word)...)
+	}
+	return matches
+}
+
+// reloadIndex refreshes the in-memory copy of the local video index.
+func (s *shellSession) reloadIndex() {
+	idx, err := index.Load()
+	if err != nil {
+		if verbose {
+			fmt.Fprintf(os.Stderr, "Warning: failed to load video index: %v\n", err)
+		}
+		return
+	}
+	s.idx = idx
+}
+
+// recordHistory appends a line to the session and on-disk history.
+func (s *shellSession) recordHistory(line string) {
+	s.history = append(s.history, line)
+
+	historyPath := shellHistoryPath()
+	if err := os.MkdirAll(filepath.Dir(historyPath), 0o755); err != nil {
+		return
+	}
+	f, err := os.OpenFile(historyPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	_, _ = fmt.Fprintln(f, line) //nolint:errcheck // History is best effort
+}
+
+// loadShellHistory reads previously saved shell history.
+func loadShellHistory() []string {
+	data, err := os.ReadFile(shellHistoryPath())
+	if err != nil {
+		return nil
+	}
+	var history []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if line != "" {
+			history = append(history, line)
+		}
+	}
+	return history
+}
+
+// shellHistoryPath returns the path to the shell history file.
+func shellHistoryPath() string {
+	return filepath.Join(xdg.StateHome, "cfstream", "shell_history")
+}
+
+// resetFlags restores every flag in the command tree to its default value,
+// so values from one shell command don't leak into the next.
+func resetFlags(c *cobra.Command) {
+	reset := func(f *pflag.Flag) {
+		if !f.Changed {
+			return
+		}
+		if sv, ok := f.Value.(pflag.SliceValue); ok {
+			_ = sv.Replace(nil) //nolint:errcheck // Replacing with an empty slice cannot fail
+		} else {
+			_ = f.Value.Set(f.DefValue) //nolint:errcheck // Defaults are always valid values
+		}
+		f.Changed = false
+	}
+	c.Flags().VisitAll(reset)
+	c.PersistentFlags().VisitAll(reset)
+	for _, sub := range c.Commands() {
+		resetFlags(sub)
+	}
+}
+
+// splitShellArgs splits a command line into arguments, honoring quotes and backslash escapes.
+func splitShellArgs(line string) ([]string, error) {
+	var (
+		args    []string
+		current strings.Builder
+		inArg   bool
+		quote   rune
+		escaped bool
+	)
+
+	for _, r := range line {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped = true
+			inArg = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quote in command")
+	}
+	if escaped {
+		return nil, fmt.Errorf("trailing backslash in command")
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+
+	return args, nil
+}
+
+// longestCommonPrefix returns the longest prefix shared by all strings.
+func longestCommonPrefix(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	prefix := values[0]
+	for _, v := range values[1:] {
+		for !strings.HasPrefix(v, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	return prefix
+}
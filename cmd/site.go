@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"cfstream/internal/api"
+	"cfstream/internal/filter"
+	"cfstream/internal/site"
+)
+
+var siteCmd = &cobra.Command{
+	Use:   "site",
+	Short: "Generate static sites",
+	Long:  `Generate static HTML sites from your video library.`,
+}
+
+var siteGenerateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Generate a static video gallery",
+	Long: `Generate a static HTML gallery with thumbnails and embedded players.
+
+Use --filter to select videos, e.g. --filter 'meta.category=training'.
+Private videos are skipped unless --sign-private is given, in which case a
+long-lived signed token is minted for each of them and baked into the pages.`,
+	Args: cobra.NoArgs,
+	RunE: runSiteGenerate,
+}
+
+var (
+	siteOut           string
+	siteFilter        string
+	siteTitle         string
+	siteSignPrivate   bool
+	siteTokenDuration string
+)
+
+func init() {
+	rootCmd.AddCommand(siteCmd)
+	siteCmd.AddCommand(siteGenerateCmd)
+
+	siteGenerateCmd.Flags().StringVar(&siteOut, "out", "site", "output directory")
+	siteGenerateCmd.Flags().StringVar(&siteFilter, "filter", "", "select videos, e.g. 'meta.category=training,status=ready'")
+	siteGenerateCmd.Flags().StringVar(&siteTitle, "title", "Videos", "site title")
+	siteGenerateCmd.Flags().BoolVar(&siteSignPrivate, "sign-private", false, "mint signed tokens so private videos can be included")
+	siteGenerateCmd.Flags().StringVar(&siteTokenDuration, "token-duration", "720h", "lifetime of signed tokens for private videos")
+}
+
+func runSiteGenerate(cmd *cobra.Command, args []string) error {
+	f, err := filter.Parse(siteFilter)
+	if err != nil {
+		return err
+	}
+
+	tokenDuration, err := time.ParseDuration(siteTokenDuration)
+	if err != nil {
+		return fmt.Errorf("invalid token duration: %w", err)
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	videos, err := listAllVideos(ctx, client, &api.ListOptions{})
+	if err != nil {
+		return err
+	}
+	videos = f.Apply(videos)
+
+	items := make([]site.Item, 0, len(videos))
+	skipped := 0
+	for _, video := range videos {
+		if !video.ReadyToStream {
+			skipped++
+			continue
+		}
+
+		customerCode, err := extractCustomerCodeFromURL(video.Preview)
+		if err != nil {
+			return fmt.Errorf("failed to extract customer code for %s: %w", video.UID, err)
+		}
+		base := fmt.Sprintf("https://customer-%s.cloudflarestream.com/%s", customerCode, video.UID)
+
+		item := site.Item{
+			UID:       video.UID,
+			Name:      video.Name,
+			Duration:  video.Duration,
+			Created:   video.Created,
+			Thumbnail: video.Thumbnail,
+			PlayerURL: base + "/iframe",
+		}
+
+		if video.RequireSignedURLs {
+			if !siteSignPrivate {
+				skipped++
+				continue
+			}
+			exp := time.Now().Add(tokenDuration).Unix()
+			token, err := client.GetSignedToken(ctx, video.UID, exp)
+			if err != nil {
+				return fmt.Errorf("failed to generate signed token for %s: %w", video.UID, err)
+			}
+			item.PlayerURL += "?token=" + token
+			item.Thumbnail = base + "/thumbnails/thumbnail.jpg?token=" + token
+		}
+
+		if item.Name == "" {
+			item.Name = video.UID
+		}
+		items = append(items, item)
+	}
+
+	if err := site.Generate(siteOut, siteTitle, items); err != nil {
+		return fmt.Errorf("failed to generate site: %w", err)
+	}
+
+	if !quiet {
+		fmt.Printf("Generated %d video page(s) in %s\n", len(items), siteOut)
+		if skipped > 0 {
+			fmt.Fprintf(os.Stderr, "Skipped %d video(s) that are not ready or are private (use --sign-private to include private videos)\n", skipped)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"cfstream/internal/output"
+)
+
+// Keys accepted by --sort on the commands that list videos and captions.
+// The first key is used when only --desc is given.
+var (
+	videoSortKeys   = []string{"created", "name", "duration", "status"}
+	captionSortKeys = []string{"language", "label", "status"}
+)
+
+var (
+	sortKey  string
+	sortDesc bool
+)
+
+// addSortFlags registers --sort, accepting keys, and --desc on cmd.
+func addSortFlags(cmd *cobra.Command, keys []string) {
+	cmd.Flags().StringVar(&sortKey, "sort", "", "sort by "+strings.Join(keys, ", "))
+	cmd.Flags().BoolVar(&sortDesc, "desc", false, "sort in descending order")
+}
+
+// sortList sorts items by the --sort and --desc flags, leaving them in API
+// order when neither is set.
+func sortList(items interface{}, keys []string) error {
+	if sortKey == "" && !sortDesc {
+		return nil
+	}
+
+	key := strings.ToLower(firstNonEmpty(sortKey, keys[0]))
+	if !slices.Contains(keys, key) {
+		return fmt.Errorf("invalid --sort value: %s (%s)", sortKey, strings.Join(keys, ", "))
+	}
+	return output.Sort(items, key, sortDesc)
+}
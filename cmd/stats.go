@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"cfstream/internal/api"
+	"cfstream/internal/output"
+)
+
+var videoStatsCmd = &cobra.Command{
+	Use:   "stats <video-id>",
+	Short: "Show size, encoding, and playback details of a video",
+	Long: `Show the full state of a video: its size and average bitrate, input
+dimensions, encoding status and error reason, playback manifest URLs, the
+watermark applied while encoding, the live input it was recorded from, and
+upload and scheduled deletion times.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runVideoStats,
+}
+
+// videoStats is the state of a video shown by video stats. Optional times
+// are nil when the API does not report them.
+type videoStats struct {
+	UID                   string     `json:"uid"`
+	Name                  string     `json:"name"`
+	Status                string     `json:"status"`
+	StatusDetails         string     `json:"statusDetails,omitempty"`
+	ErrorReasonCode       string     `json:"errorReasonCode,omitempty"`
+	ReadyToStream         bool       `json:"readyToStream"`
+	ReadyToStreamAt       *time.Time `json:"readyToStreamAt,omitempty"`
+	Duration              float64    `json:"duration"`
+	Size                  int64      `json:"size"`              // Size in bytes
+	Bitrate               int64      `json:"bitrate,omitempty"` // Average bits per second, from size and duration
+	Width                 int64      `json:"width"`
+	Height                int64      `json:"height"`
+	MaxDurationSeconds    int64      `json:"maxDurationSeconds"`
+	ThumbnailTimestampPct float64    `json:"thumbnailTimestampPct"`
+	Created               time.Time  `json:"created"`
+	Uploaded              *time.Time `json:"uploaded,omitempty"`
+	UploadExpiry          *time.Time `json:"uploadExpiry,omitempty"`
+	ScheduledDeletion     *time.Time `json:"scheduledDeletion,omitempty"`
+	HLS                   string     `json:"hls"`
+	DASH                  string     `json:"dash"`
+	Preview               string     `json:"preview"`
+	Thumbnail             string     `json:"thumbnail"`
+	WatermarkUID          string     `json:"watermarkUID,omitempty"`
+	WatermarkName         string     `json:"watermarkName,omitempty"`
+	LiveInput             string     `json:"liveInput,omitempty"`
+}
+
+func init() {
+	videoCmd.AddCommand(videoStatsCmd)
+}
+
+func runVideoStats(cmd *cobra.Command, args []string) error {
+	videoID, err := resolveVideoID(args[0])
+	if err != nil {
+		return err
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	video, err := client.GetVideo(ctx, videoID)
+	if err != nil {
+		return fmt.Errorf("failed to get video: %w", err)
+	}
+
+	formatter, err := output.NewFormatter(outputFormat)
+	if err != nil {
+		return err
+	}
+	if err := formatter.FormatSingle(os.Stdout, newVideoStats(video)); err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+	return nil
+}
+
+// newVideoStats derives the stats of v, including its average bitrate.
+func newVideoStats(v *api.Video) *videoStats {
+	stats := &videoStats{
+		UID:                   v.UID,
+		Name:                  v.Name,
+		Status:                v.Status,
+		StatusDetails:         v.StatusDetails,
+		ErrorReasonCode:       v.ErrorReasonCode,
+		ReadyToStream:         v.ReadyToStream,
+		ReadyToStreamAt:       optionalTime(v.ReadyToStreamAt),
+		Duration:              v.Duration,
+		Size:                  v.Size,
+		Width:                 v.Width,
+		Height:                v.Height,
+		MaxDurationSeconds:    v.MaxDurationSeconds,
+		ThumbnailTimestampPct: v.ThumbnailTimestampPct,
+		Created:               v.Created,
+		Uploaded:              optionalTime(v.Uploaded),
+		UploadExpiry:          optionalTime(v.UploadExpiry),
+		ScheduledDeletion:     optionalTime(v.ScheduledDeletion),
+		HLS:                   v.HLS,
+		DASH:                  v.DASH,
+		Preview:               v.Preview,
+		Thumbnail:             v.Thumbnail,
+		WatermarkUID:          v.Watermark,
+		WatermarkName:         v.WatermarkName,
+		LiveInput:             v.LiveInput,
+	}
+	if v.Duration > 0 && v.Size > 0 {
+		stats.Bitrate = int64(float64(v.Size) * 8 / v.Duration)
+	}
+	return stats
+}
+
+// optionalTime returns nil for the zero time.
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
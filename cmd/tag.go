@@ -0,0 +1,211 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"cfstream/internal/api"
+	"cfstream/internal/output"
+	"cfstream/internal/tags"
+)
+
+var tagCmd = &cobra.Command{
+	Use:   "tag",
+	Short: "Organize videos with tags",
+	Long: `Add, remove, and list video tags. Tags are stored comma-separated in the
+tags metadata key, so they need no separate database and work in filters,
+e.g. 'video list --tag webinar' or '--filter tag=webinar' on bulk commands.
+Tags are compared ignoring case.`,
+}
+
+var tagAddCmd = &cobra.Command{
+	Use:   "add <video-id> <tag>...",
+	Short: "Add tags to a video",
+	Args:  cobra.MinimumNArgs(2),
+	RunE:  runTagAdd,
+}
+
+var tagRemoveCmd = &cobra.Command{
+	Use:   "remove <video-id> <tag>...",
+	Short: "Remove tags from a video",
+	Args:  cobra.MinimumNArgs(2),
+	RunE:  runTagRemove,
+}
+
+var tagListCmd = &cobra.Command{
+	Use:   "list [video-id]",
+	Short: "List the tags of a video, or of the whole library",
+	Long: `List the tags of a video. Without a video ID, list every tag in the
+library with the number of videos carrying it.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runTagList,
+}
+
+// tagCount is one row of the library-wide tag list.
+type tagCount struct {
+	Tag    string `json:"tag"`
+	Videos int    `json:"videos"`
+}
+
+func init() {
+	rootCmd.AddCommand(tagCmd)
+	tagCmd.AddCommand(tagAddCmd)
+	tagCmd.AddCommand(tagRemoveCmd)
+	tagCmd.AddCommand(tagListCmd)
+}
+
+func runTagAdd(cmd *cobra.Command, args []string) error {
+	for _, tag := range args[1:] {
+		if err := tags.Validate(tag); err != nil {
+			return err
+		}
+	}
+	return updateTags(args[0], func(current []string) ([]string, bool) {
+		return tags.Add(current, args[1:]...)
+	})
+}
+
+func runTagRemove(cmd *cobra.Command, args []string) error {
+	return updateTags(args[0], func(current []string) ([]string, bool) {
+		return tags.Remove(current, args[1:]...)
+	})
+}
+
+// updateTags applies change to the tags of a video and saves them when
+// they changed. The rest of the metadata is kept.
+func updateTags(id string, change func([]string) ([]string, bool)) error {
+	videoID, err := resolveVideoID(id)
+	if err != nil {
+		return err
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	video, err := client.GetVideo(ctx, videoID)
+	if err != nil {
+		return fmt.Errorf("failed to get video: %w", err)
+	}
+
+	updated, changed := change(tags.Get(video.Meta))
+	if !changed {
+		if !quiet {
+			fmt.Printf("Tags of %s unchanged: %s\n", videoID, formatTags(updated))
+		}
+		return nil
+	}
+
+	if _, err := client.UpdateVideo(ctx, videoID, &api.UpdateOptions{Meta: tags.Set(video.Meta, updated)}); err != nil {
+		if isDryRun(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to update tags: %w", err)
+	}
+
+	if !quiet {
+		fmt.Printf("Tags of %s: %s\n", videoID, formatTags(updated))
+	}
+	return nil
+}
+
+func runTagList(cmd *cobra.Command, args []string) error {
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	formatter, err := output.NewFormatter(outputFormat)
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 1 {
+		videoID, err := resolveVideoID(args[0])
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		video, err := client.GetVideo(ctx, videoID)
+		if err != nil {
+			return fmt.Errorf("failed to get video: %w", err)
+		}
+
+		videoTags := tags.Get(video.Meta)
+		if outputFormat == outputFormatTable {
+			for _, tag := range videoTags {
+				fmt.Println(tag)
+			}
+			return nil
+		}
+		if videoTags == nil {
+			videoTags = []string{}
+		}
+		return formatter.FormatSingle(os.Stdout, map[string]interface{}{"uid": videoID, "tags": videoTags})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	counts := make(map[string]*tagCount)
+	pager := client.ListVideosPager(&api.ListOptions{})
+	for pager.More() {
+		page, err := pager.Next(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list videos: %w", err)
+		}
+		for _, v := range page {
+			for _, tag := range tags.Get(v.Meta) {
+				key := strings.ToLower(tag)
+				if counts[key] == nil {
+					counts[key] = &tagCount{Tag: tag}
+				}
+				counts[key].Videos++
+			}
+		}
+	}
+
+	if len(counts) == 0 {
+		if !quiet {
+			fmt.Println("No tagged videos found")
+		}
+		return nil
+	}
+
+	rows := make([]tagCount, 0, len(counts))
+	for _, c := range counts {
+		rows = append(rows, *c)
+	}
+	sort.Slice(rows, func(a, b int) bool {
+		if rows[a].Videos != rows[b].Videos {
+			return rows[a].Videos > rows[b].Videos
+		}
+		return strings.ToLower(rows[a].Tag) < strings.ToLower(rows[b].Tag)
+	})
+
+	if err := formatter.FormatList(os.Stdout, []string{"Tag", "Videos"}, rows); err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+	return nil
+}
+
+// formatTags formats tags for messages.
+func formatTags(t []string) string {
+	if len(t) == 0 {
+		return "(none)"
+	}
+	return strings.Join(t, ", ")
+}
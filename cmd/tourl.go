@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"cfstream/internal/api"
+	"cfstream/internal/output"
+	"cfstream/internal/upload"
+)
+
+var uploadToURLCmd = &cobra.Command{
+	Use:   "to-url <upload-url> <file>",
+	Short: "Upload a file to a direct upload URL created elsewhere",
+	Long: `Upload a file to a one-time upload URL created outside cfstream, e.g. a
+creator upload URL issued by your backend, to check it works end to end:
+
+  cfstream upload to-url https://upload.videodelivery.net/VIDEO_ID video.mp4
+
+URLs with /tus/ in the path, or any URL with --tus, are uploaded with the TUS
+protocol in chunks; others get a multipart/form-data POST. The URL authorizes
+the upload, so no account credentials are needed or sent. The name, metadata,
+and watermark are those given when the URL was created.`,
+	Args: cobra.ExactArgs(2),
+	RunE: notifyOnFinish("Upload", runUploadToURL),
+}
+
+// toURLResult describes a file uploaded by upload to-url.
+type toURLResult struct {
+	UID  string `json:"uid"`
+	File string `json:"file"`
+	Size int64  `json:"size"`
+}
+
+var toURLTUS bool
+
+func init() {
+	uploadCmd.AddCommand(uploadToURLCmd)
+
+	uploadToURLCmd.Flags().BoolVar(&toURLTUS, "tus", false, "upload with the TUS protocol even if the URL has no /tus/ path")
+}
+
+func runUploadToURL(cmd *cobra.Command, args []string) error {
+	uploadURL, filePath := args[0], args[1]
+
+	fileInfo, err := os.Stat(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to get file info: %w", err)
+	}
+
+	if dryRun {
+		_, err := api.UploadToURL(context.Background(), uploadURL, filePath, toURLTUS, nil, clientOptions()...)
+		if isDryRun(err) {
+			return nil
+		}
+		return fmt.Errorf("upload failed: %w", err)
+	}
+
+	if !quiet {
+		fmt.Printf("Uploading %s (%s)...\n", filepath.Base(filePath), upload.FormatBytes(fileInfo.Size()))
+	}
+
+	progressTracker := upload.NewProgressTracker(newProgress(upload.ProgressOptions(fileInfo.Size(), filepath.Base(filePath))))
+	progressCh := make(chan api.UploadProgress, 10)
+	go func() {
+		for progress := range progressCh {
+			progressTracker.Update(progress)
+		}
+	}()
+
+	uid, err := api.UploadToURL(context.Background(), uploadURL, filePath, toURLTUS, progressCh, clientOptions()...)
+	close(progressCh)
+	progressTracker.Finish()
+	if err != nil {
+		return fmt.Errorf("upload failed: %w", err)
+	}
+
+	formatter, err := output.NewFormatter(outputFormat)
+	if err != nil {
+		return err
+	}
+	result := toURLResult{UID: uid, File: filePath, Size: fileInfo.Size()}
+	if err := formatter.FormatSingle(os.Stdout, result); err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+	return nil
+}
@@ -4,23 +4,38 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
+	"sync"
 	"time"
 
 	"github.com/spf13/cobra"
 
 	"cfstream/internal/api"
-	"cfstream/internal/config"
+	"cfstream/internal/batch"
 	"cfstream/internal/output"
+	"cfstream/internal/reltime"
 	"cfstream/internal/upload"
 )
 
 var (
-	uploadName     string
-	uploadMetadata string
-	uploadExpires  string
-	maxDuration    int
+	uploadName         string
+	uploadMetadata     string
+	uploadExpires      string
+	maxDuration        int
+	uploadHTML         string
+	uploadWatermark    string
+	uploadThumbnailPct float64
+
+	uploadConcurrency int
+
+	urlWait        bool
+	urlWaitTimeout time.Duration
+
+	directCreator     string
+	directOrigins     []string
+	directDeleteAfter string
 )
 
 // uploadCmd represents the upload command.
@@ -29,193 +44,322 @@ var uploadCmd = &cobra.Command{
 	Short: "Upload videos to Cloudflare Stream",
 	Long: `Upload videos to Cloudflare Stream using various methods:
 
-- upload file <path> - Upload a local video file
+- upload file <path>... - Upload local video files
+- upload dir <dir>, upload watch <dir> - Upload the files of a directory
+- upload batch <manifest.csv> - Upload the entries of a CSV manifest
+- upload resume     - Continue an interrupted file upload
 - upload url <url>  - Upload from a URL
+- upload r2 <bucket/key>, upload s3 <bucket/key> - Copy from object storage
 - upload direct     - Generate a direct upload URL`,
 }
 
-// uploadFileCmd uploads a local video file.
+// uploadFileCmd uploads local video files.
 var uploadFileCmd = &cobra.Command{
-	Use:   "file <path>",
-	Short: "Upload a local video file",
-	Long: `Upload a local video file to Cloudflare Stream using multipart/form-data.
+	Use:   "file <path>...",
+	Short: "Upload local video files",
+	Long: `Upload local video files to Cloudflare Stream using multipart/form-data.
 
 This command uploads a video file with support for progress tracking.
-The upload uses standard multipart/form-data encoding.`,
-	Args: cobra.ExactArgs(1),
-	RunE: func(cmd *cobra.Command, args []string) error {
-		filePath := args[0]
+The upload uses standard multipart/form-data encoding. Files of 200 MB or more
+are uploaded in chunks with the TUS protocol; if such an upload is
+interrupted, continue it with 'cfstream upload resume'. Both methods apply
+--watermark, --max-duration, --allowed-origins, and --delete-after.
+
+Several files are uploaded concurrently, e.g.
+
+  cfstream upload file a.mp4 b.mp4 c.mp4 --concurrency 3
+
+Each video is named after its file. The uploaded videos are listed with a
+summary of failed files at the end, and the command exits non-zero when any
+file failed.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: notifyOnFinish("Upload", runUploadFile),
+}
+
+// fileUpload is a file to upload with the name and metadata of its video.
+type fileUpload struct {
+	Path string
+	Name string
+	Meta map[string]interface{}
+}
+
+// fileUploadResult is one row of the summary of a multi-file upload.
+type fileUploadResult struct {
+	File string
+	UID  string
+	Name string
+}
 
-		// Validate file exists
+func runUploadFile(cmd *cobra.Command, args []string) error {
+	if err := checkUploadFlags(); err != nil {
+		return err
+	}
+
+	// Validate files exist
+	for _, filePath := range args {
 		if _, err := os.Stat(filePath); os.IsNotExist(err) {
 			return fmt.Errorf("file not found: %s", filePath)
 		}
-
-		// Load configuration
-		cfg, err := config.Load()
+	}
+	if len(args) > 1 && uploadName != "" {
+		return fmt.Errorf("--name cannot be used with several files; videos are named after their files")
+	}
+	if maxDuration < 0 {
+		return fmt.Errorf("--max-duration must not be negative")
+	}
+	var deletion *time.Time
+	if directDeleteAfter != "" {
+		t, err := parseDeleteAfter(directDeleteAfter, time.Now())
 		if err != nil {
-			return fmt.Errorf("failed to load configuration: %w", err)
+			return err
 		}
+		deletion = &t
+	}
 
-		// Create API client
-		client, err := api.NewClient(cfg.AccountID, cfg.APIToken)
-		if err != nil {
-			return fmt.Errorf("failed to create API client: %w", err)
+	// Create API client
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	// Parse metadata if provided
+	var metadata map[string]interface{}
+	if uploadMetadata != "" {
+		if err := json.Unmarshal([]byte(uploadMetadata), &metadata); err != nil {
+			return fmt.Errorf("invalid metadata JSON: %w", err)
 		}
+	}
 
-		// Parse metadata if provided
-		var metadata map[string]interface{}
-		if uploadMetadata != "" {
-			if err := json.Unmarshal([]byte(uploadMetadata), &metadata); err != nil {
-				return fmt.Errorf("invalid metadata JSON: %w", err)
+	minutes, err := validateUploads(args)
+	if err != nil {
+		return err
+	}
+	if err := checkQuota(context.Background(), client, minutes); err != nil {
+		return err
+	}
+
+	if len(args) > 1 {
+		files := make([]fileUpload, 0, len(args))
+		seen := make(map[string]bool, len(args))
+		for _, filePath := range args {
+			// A file named twice is uploaded once
+			if !seen[filePath] {
+				seen[filePath] = true
+				files = append(files, fileUpload{Path: filePath, Name: filepath.Base(filePath), Meta: metadata})
 			}
 		}
+		return uploadFiles(client, files, uploadConcurrency, deletion, nil)
+	}
+	return uploadOneFile(client, args[0], metadata, deletion)
+}
 
-		// Prepare upload options
-		opts := &api.UploadOptions{
-			Name:              uploadName,
-			Metadata:          metadata,
-			RequireSignedURLs: true,
-		}
+// uploadOneFile uploads a file with a byte progress bar and waits for it to
+// be processed. deletion, when set, schedules the video for deletion.
+func uploadOneFile(client api.Client, filePath string, metadata map[string]interface{}, deletion *time.Time) error {
+	// Prepare upload options
+	opts := &api.UploadOptions{
+		Name:                  uploadName,
+		Metadata:              metadata,
+		RequireSignedURLs:     true,
+		Watermark:             uploadWatermark,
+		ThumbnailTimestampPct: uploadThumbnailPct,
+		MaxDurationSeconds:    maxDuration,
+		AllowedOrigins:        directOrigins,
+		ScheduledDeletion:     deletion,
+	}
 
-		// If name not provided, use filename
-		if opts.Name == "" {
-			opts.Name = filepath.Base(filePath)
-		}
+	// If name not provided, use filename
+	if opts.Name == "" {
+		opts.Name = filepath.Base(filePath)
+	}
 
-		// Get file size for progress tracking
-		fileInfo, err := os.Stat(filePath)
-		if err != nil {
-			return fmt.Errorf("failed to get file info: %w", err)
-		}
+	// Get file size for progress tracking
+	fileInfo, err := os.Stat(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to get file info: %w", err)
+	}
 
-		if !quiet {
-			fmt.Printf("Uploading %s (%s)...\n", filepath.Base(filePath), upload.FormatBytes(fileInfo.Size()))
+	if dryRun {
+		_, err := client.UploadFile(context.Background(), filePath, opts, nil)
+		if isDryRun(err) {
+			return nil
 		}
+		return fmt.Errorf("upload failed: %w", err)
+	}
 
-		// Create progress tracker
-		progressTracker := upload.NewProgressTracker(fileInfo.Size(), filepath.Base(filePath), quiet)
-
-		// Create progress channel
-		progressCh := make(chan api.UploadProgress, 10)
-		go func() {
-			for progress := range progressCh {
-				progressTracker.Update(progress)
-			}
-		}()
+	if !quiet {
+		fmt.Printf("Uploading %s (%s)...\n", filepath.Base(filePath), upload.FormatBytes(fileInfo.Size()))
+	}
 
-		// Upload file
-		ctx := context.Background()
-		video, err := client.UploadFile(ctx, filePath, opts, progressCh)
-		close(progressCh)
-		progressTracker.Finish()
+	// Create progress tracker
+	progressTracker := upload.NewProgressTracker(newProgress(upload.ProgressOptions(fileInfo.Size(), filepath.Base(filePath))))
 
-		if err != nil {
-			return fmt.Errorf("upload failed: %w", err)
+	// Create progress channel
+	progressCh := make(chan api.UploadProgress, 10)
+	go func() {
+		for progress := range progressCh {
+			progressTracker.Update(progress)
 		}
-
-		if !quiet {
-			fmt.Println("Upload complete")
-			fmt.Printf("Video ID: %s\n", video.UID)
-			fmt.Printf("Status: %s\n", video.Status)
-			if video.Preview != "" {
-				fmt.Printf("Preview: %s\n", video.Preview)
-			}
+	}()
+
+	// Large files are uploaded with TUS and recorded, so an interrupted
+	// upload can be continued with 'upload resume'
+	recorder := &resumeRecorder{path: filePath, name: opts.Name}
+	opts.OnResumable = recorder.record
+
+	// Upload file
+	ctx := context.Background()
+	video, err := client.UploadFile(ctx, filePath, opts, progressCh)
+	close(progressCh)
+	progressTracker.Finish()
+
+	if err != nil {
+		if recorder.uid != "" {
+			fmt.Fprintf(os.Stderr, "Resume with: cfstream upload resume %s\n", recorder.uid)
 		}
+		return fmt.Errorf("upload failed: %w", err)
+	}
+	recorder.finish()
+
+	return reportFileUpload(ctx, client, video)
+}
 
-		// Poll for processing status if not quiet
-		if !quiet && !video.ReadyToStream {
-			fmt.Println("\nProcessing video...")
-			if err := pollVideoStatus(ctx, client, video.UID); err != nil {
-				fmt.Printf("Warning: failed to check video status: %v\n", err)
+// uploadFiles uploads several files concurrently and prints the uploaded
+// videos and a summary of failed files. deletion, when set, schedules the
+// videos for deletion. onDone, when set, is called with the final result of
+// each file that was not only a dry run.
+func uploadFiles(client api.Client, files []fileUpload, concurrency int, deletion *time.Time, onDone func(path string, err error)) error {
+	// Dry-run plans are printed sequentially so they don't interleave
+	if dryRun {
+		concurrency = 1
+	}
+	if concurrency < 1 {
+		return fmt.Errorf("--concurrency must be at least 1")
+	}
+
+	byPath := make(map[string]fileUpload, len(files))
+	paths := make([]string, len(files))
+	for i, f := range files {
+		byPath[f.Path] = f
+		paths[i] = f.Path
+	}
+
+	var mu sync.Mutex
+	var uploaded []fileUploadResult
+
+	bar := batchProgress(len(paths), "Uploading")
+	results := batch.Run(context.Background(), paths, batch.Options{
+		Concurrency: concurrency,
+		Retries:     batchRetries,
+		Backoff:     time.Second,
+		OnThrottle:  reportThrottle(bar),
+		OnDone: func(r batch.Result) {
+			bar.Item(r.ID, r.Err)
+			if onDone != nil && !dryRun {
+				onDone(r.ID, r.Err)
 			}
+		},
+	}, func(ctx context.Context, filePath string) error {
+		file := byPath[filePath]
+		opts := &api.UploadOptions{
+			Name:                  file.Name,
+			Metadata:              file.Meta,
+			RequireSignedURLs:     true,
+			Watermark:             uploadWatermark,
+			ThumbnailTimestampPct: uploadThumbnailPct,
+			MaxDurationSeconds:    maxDuration,
+			AllowedOrigins:        directOrigins,
+			ScheduledDeletion:     deletion,
 		}
+		recorder := &resumeRecorder{path: filePath, name: opts.Name}
+		opts.OnResumable = recorder.record
 
-		// Output video details in requested format
-		if outputFormat != outputFormatTable {
-			formatter, err := output.NewFormatter(outputFormat)
-			if err != nil {
-				return err
+		video, err := client.UploadFile(ctx, filePath, opts, nil)
+		if isDryRun(err) {
+			return nil
+		}
+		if err != nil {
+			if recorder.uid != "" {
+				return fmt.Errorf("%w (resume with: cfstream upload resume %s)", err, recorder.uid)
 			}
-			return formatter.FormatSingle(os.Stdout, video)
+			return err
 		}
+		recorder.finish()
 
+		mu.Lock()
+		uploaded = append(uploaded, fileUploadResult{File: filePath, UID: video.UID, Name: opts.Name})
+		mu.Unlock()
 		return nil
-	},
-}
+	})
+	bar.Finish()
 
-// uploadURLCmd uploads a video from a URL.
-var uploadURLCmd = &cobra.Command{
-	Use:   "url <url>",
-	Short: "Upload a video from a URL",
-	Long: `Upload a video from a URL to Cloudflare Stream.
-
-Cloudflare will download the video from the provided URL and process it.
-Processing happens asynchronously, so the command returns immediately with
-a video ID.`,
-	Args: cobra.ExactArgs(1),
-	RunE: func(cmd *cobra.Command, args []string) error {
-		videoURL := args[0]
+	if dryRun {
+		planUploadSummary(files)
+		return nil
+	}
 
-		// Load configuration
-		cfg, err := config.Load()
+	if len(uploaded) > 0 {
+		formatter, err := output.NewFormatter(outputFormat)
 		if err != nil {
-			return fmt.Errorf("failed to load configuration: %w", err)
+			return err
 		}
-
-		// Create API client
-		client, err := api.NewClient(cfg.AccountID, cfg.APIToken)
-		if err != nil {
-			return fmt.Errorf("failed to create API client: %w", err)
+		if err := formatter.FormatList(os.Stdout, []string{"File", "UID", "Name"}, uploaded); err != nil {
+			return fmt.Errorf("failed to format output: %w", err)
 		}
+	}
 
-		// Parse metadata if provided
-		var metadata map[string]interface{}
-		if uploadMetadata != "" {
-			if err := json.Unmarshal([]byte(uploadMetadata), &metadata); err != nil {
-				return fmt.Errorf("invalid metadata JSON: %w", err)
-			}
-		}
+	return reportBatch(results, "uploaded")
+}
 
-		// Prepare upload options
-		opts := &api.UploadOptions{
-			Name:              uploadName,
-			Metadata:          metadata,
-			RequireSignedURLs: true,
+// reportFileUpload prints the result of a file upload and waits for
+// processing unless --quiet is set.
+func reportFileUpload(ctx context.Context, client api.Client, video *api.Video) error {
+	if !quiet {
+		fmt.Println("Upload complete")
+		fmt.Printf("Video ID: %s\n", video.UID)
+		fmt.Printf("Status: %s\n", video.Status)
+		if video.Preview != "" {
+			fmt.Printf("Preview: %s\n", video.Preview)
 		}
+	}
 
-		if !quiet {
-			fmt.Printf("Uploading from URL: %s\n", videoURL)
+	// Poll for processing status if not quiet
+	if !quiet && !video.ReadyToStream {
+		fmt.Println("\nProcessing video...")
+		if err := pollVideoStatus(ctx, client, video.UID); err != nil {
+			fmt.Printf("Warning: failed to check video status: %v\n", err)
 		}
+	}
 
-		// Upload from URL
-		ctx := context.Background()
-		video, err := client.UploadFromURL(ctx, videoURL, opts)
+	// Output video details in requested format
+	if outputFormat != outputFormatTable {
+		formatter, err := output.NewFormatter(outputFormat)
 		if err != nil {
-			return fmt.Errorf("upload failed: %w", err)
+			return err
 		}
+		return formatter.FormatSingle(os.Stdout, video)
+	}
 
-		if !quiet {
-			fmt.Println("Upload initiated")
-			fmt.Printf("Video ID: %s\n", video.UID)
-			fmt.Printf("Status: %s\n", video.Status)
-			if video.Preview != "" {
-				fmt.Printf("Preview: %s\n", video.Preview)
-			}
-			fmt.Println("\nNote: Video processing happens asynchronously. Use 'cfstream video get' to check status.")
-		}
+	return nil
+}
 
-		// Output video details in requested format
-		if outputFormat != outputFormatTable {
-			formatter, err := output.NewFormatter(outputFormat)
-			if err != nil {
-				return err
-			}
-			return formatter.FormatSingle(os.Stdout, video)
-		}
+// uploadURLCmd uploads a video from a URL.
+var uploadURLCmd = &cobra.Command{
+	Use:   "url <url>",
+	Short: "Upload a video from a URL",
+	Long: `Upload a video from a URL to Cloudflare Stream.
 
-		return nil
-	},
+Cloudflare will download the video from the provided URL and process it.
+Processing happens asynchronously, so the command returns immediately with
+a video ID. With --wait it instead follows the copy (downloading, queued,
+encoding) until the video is ready to stream, fails, or --timeout passes.
+
+The URL is recorded in the sourceURL metadata key, so a copy that fails can
+be submitted again with 'cfstream video retry'.`,
+	Args: cobra.ExactArgs(1),
+	RunE: notifyOnFinish("Upload", func(cmd *cobra.Command, args []string) error {
+		return uploadFromURL(args[0], "URL: "+args[0], "", true)
+	}),
 }
 
 // uploadDirectCmd generates a direct upload URL.
@@ -226,18 +370,21 @@ var uploadDirectCmd = &cobra.Command{
 
 This is useful when you want to allow users to upload videos directly to
 Cloudflare Stream without going through your server. The URL is time-limited
-and can be configured with upload constraints.`,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		// Load configuration
-		cfg, err := config.Load()
-		if err != nil {
-			return fmt.Errorf("failed to load configuration: %w", err)
-		}
+and can be configured with upload constraints.
+
+Use --html to also write a self-contained drag-and-drop upload page pointed
+at the URL, which can be shared with people who do not use the CLI.
 
+The uploaded video can be given metadata, a creator, the origins allowed to
+embed it, its thumbnail position, and a scheduled deletion, e.g.
+
+  cfstream upload direct --creator alice --metadata '{"name":"Intro"}' \
+    --allowed-origins example.com --thumbnail-pct 0.25 --delete-after 90d`,
+	RunE: func(cmd *cobra.Command, args []string) error {
 		// Create API client
-		client, err := api.NewClient(cfg.AccountID, cfg.APIToken)
+		client, err := createClient()
 		if err != nil {
-			return fmt.Errorf("failed to create API client: %w", err)
+			return err
 		}
 
 		// Parse expiry if provided
@@ -251,17 +398,51 @@ and can be configured with upload constraints.`,
 			expiry = &expiryTime
 		}
 
+		var metadata map[string]interface{}
+		if uploadMetadata != "" {
+			if err := json.Unmarshal([]byte(uploadMetadata), &metadata); err != nil {
+				return fmt.Errorf("invalid metadata JSON: %w", err)
+			}
+		}
+
+		if err := checkUploadFlags(); err != nil {
+			return err
+		}
+
+		var deletion *time.Time
+		if directDeleteAfter != "" {
+			t, err := parseDeleteAfter(directDeleteAfter, time.Now())
+			if err != nil {
+				return err
+			}
+			deletion = &t
+		}
+
 		// Prepare options
 		opts := &api.DirectUploadOptions{
-			MaxDurationSeconds: maxDuration,
-			Expiry:             expiry,
-			RequireSignedURLs:  true,
+			MaxDurationSeconds:    maxDuration,
+			Expiry:                expiry,
+			RequireSignedURLs:     true,
+			Watermark:             uploadWatermark,
+			Meta:                  metadata,
+			Creator:               directCreator,
+			AllowedOrigins:        directOrigins,
+			ThumbnailTimestampPct: uploadThumbnailPct,
+			ScheduledDeletion:     deletion,
 		}
 
-		// Create direct upload URL
+		// A direct upload can use up to its maximum duration
 		ctx := context.Background()
+		if err := checkQuota(ctx, client, float64(maxDuration)/60); err != nil {
+			return err
+		}
+
+		// Create direct upload URL
 		result, err := client.CreateDirectUploadURL(ctx, opts)
 		if err != nil {
+			if isDryRun(err) {
+				return nil
+			}
 			return fmt.Errorf("failed to create direct upload URL: %w", err)
 		}
 
@@ -274,6 +455,17 @@ and can be configured with upload constraints.`,
 			}
 		}
 
+		// Write a drag-and-drop upload page pointed at the URL
+		if uploadHTML != "" {
+			widget := upload.Widget{UploadURL: result.UploadURL, UID: result.UID, Expiry: result.Expiry}
+			if err := upload.WriteWidget(uploadHTML, widget); err != nil {
+				return err
+			}
+			if !quiet {
+				fmt.Printf("Upload page written to %s\n", uploadHTML)
+			}
+		}
+
 		// Output result in requested format
 		if outputFormat != outputFormatTable {
 			formatter, err := output.NewFormatter(outputFormat)
@@ -287,6 +479,140 @@ and can be configured with upload constraints.`,
 	},
 }
 
+// uploadFromURL asks Stream to copy the video at videoURL. source describes
+// the video in progress messages, so presigned URLs aren't printed, and
+// defaultName is used when --name is not set. With recordSource, videoURL is
+// kept in the metadata so 'video retry' can copy it again; presigned URLs
+// expire and are not recorded.
+func uploadFromURL(videoURL, source, defaultName string, recordSource bool) error {
+	if err := checkUploadFlags(); err != nil {
+		return err
+	}
+
+	// Create API client
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	// Parse metadata if provided
+	var metadata map[string]interface{}
+	if uploadMetadata != "" {
+		if err := json.Unmarshal([]byte(uploadMetadata), &metadata); err != nil {
+			return fmt.Errorf("invalid metadata JSON: %w", err)
+		}
+	}
+	if recordSource {
+		metadata = withMeta(metadata, api.MetaSourceURL, videoURL)
+	}
+
+	// Prepare upload options
+	opts := &api.UploadOptions{
+		Name:                  uploadName,
+		Metadata:              metadata,
+		RequireSignedURLs:     true,
+		Watermark:             uploadWatermark,
+		ThumbnailTimestampPct: uploadThumbnailPct,
+	}
+	if opts.Name == "" {
+		opts.Name = defaultName
+	}
+
+	ctx := context.Background()
+	if err := checkQuota(ctx, client, 0); err != nil {
+		return err
+	}
+
+	if !quiet && !dryRun {
+		fmt.Printf("Uploading from %s\n", source)
+	}
+
+	// Upload from URL
+	video, err := client.UploadFromURL(ctx, videoURL, opts)
+	if err != nil {
+		if isDryRun(err) {
+			return nil
+		}
+		return fmt.Errorf("upload failed: %w", err)
+	}
+
+	if !quiet {
+		fmt.Println("Upload initiated")
+		fmt.Printf("Video ID: %s\n", video.UID)
+		fmt.Printf("Status: %s\n", video.Status)
+		if video.Preview != "" {
+			fmt.Printf("Preview: %s\n", video.Preview)
+		}
+		if !urlWait {
+			fmt.Println("\nNote: Video processing happens asynchronously. Use 'cfstream video get' to check status.")
+		}
+	}
+
+	if urlWait {
+		waitCtx, cancel := context.WithTimeout(ctx, urlWaitTimeout)
+		defer cancel()
+
+		video, err = waitForVideo(waitCtx, client, video.UID, 5*time.Second)
+		if err != nil {
+			return err
+		}
+		if !quiet {
+			fmt.Println("Video ready for streaming")
+		}
+	}
+
+	// Output video details in requested format
+	if outputFormat != outputFormatTable {
+		formatter, err := output.NewFormatter(outputFormat)
+		if err != nil {
+			return err
+		}
+		return formatter.FormatSingle(os.Stdout, video)
+	}
+
+	return nil
+}
+
+// planUploadSummary prints the number and total size of the files a dry run
+// would upload.
+func planUploadSummary(files []fileUpload) {
+	var total int64
+	for _, f := range files {
+		if info, err := os.Stat(f.Path); err == nil {
+			total += info.Size()
+		}
+	}
+	fmt.Printf("Dry run: %d files (%s) would be uploaded\n", len(files), upload.FormatBytes(total))
+}
+
+// checkUploadFlags validates the flags shared by all uploads.
+func checkUploadFlags() error {
+	if uploadThumbnailPct < 0 || uploadThumbnailPct > 1 {
+		return fmt.Errorf("--thumbnail-pct must be between 0 and 1")
+	}
+	return nil
+}
+
+// withMeta returns a copy of meta with key set to value.
+func withMeta(meta map[string]interface{}, key string, value interface{}) map[string]interface{} {
+	updated := maps.Clone(meta)
+	if updated == nil {
+		updated = make(map[string]interface{}, 1)
+	}
+	updated[key] = value
+	return updated
+}
+
+// parseDeleteAfter parses when a video should be deleted, given as a day
+// count such as 30d, a Go duration such as 720h, or a date (2006-01-02).
+func parseDeleteAfter(value string, now time.Time) (time.Time, error) {
+	t, err := reltime.Parse(value, now, reltime.Future)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --delete-after value: %w", err)
+	}
+	return t, nil
+}
+
 // pollVideoStatus polls the video status until it's ready to stream.
 func pollVideoStatus(ctx context.Context, client api.Client, videoID string) error {
 	const maxAttempts = 60
@@ -328,14 +654,31 @@ func init() {
 	uploadCmd.AddCommand(uploadURLCmd)
 	uploadCmd.AddCommand(uploadDirectCmd)
 
+	// Flags shared by all uploads
+	uploadCmd.PersistentFlags().StringVar(&uploadWatermark, "watermark", "", "watermark profile UID to apply while encoding")
+	addQuotaFlag(uploadCmd.PersistentFlags())
+	uploadCmd.PersistentFlags().Float64Var(&uploadThumbnailPct, "thumbnail-pct", 0, "thumbnail position as a fraction of the duration (0-1)")
+
 	// Flags for file and url uploads
 	uploadFileCmd.Flags().StringVar(&uploadName, "name", "", "video name (defaults to filename)")
 	uploadFileCmd.Flags().StringVar(&uploadMetadata, "metadata", "", "video metadata as JSON")
+	uploadFileCmd.Flags().IntVar(&uploadConcurrency, "concurrency", 3, "number of files uploaded at once")
+	uploadFileCmd.Flags().BoolVar(&uploadValidate, "validate", false, "check duration, codec, and size against Stream limits before uploading")
+	uploadFileCmd.Flags().IntVar(&maxDuration, "max-duration", 0, "maximum video duration in seconds (default 6 hours)")
+	uploadFileCmd.Flags().StringSliceVar(&directOrigins, "allowed-origins", nil, "comma-separated origins allowed to embed the video")
+	uploadFileCmd.Flags().StringVar(&directDeleteAfter, "delete-after", "", "schedule deletion, e.g. 30d or 2006-01-02")
 
 	uploadURLCmd.Flags().StringVar(&uploadName, "name", "", "video name")
 	uploadURLCmd.Flags().StringVar(&uploadMetadata, "metadata", "", "video metadata as JSON")
+	uploadURLCmd.Flags().BoolVar(&urlWait, "wait", false, "wait until the video is ready to stream, printing status changes")
+	uploadURLCmd.Flags().DurationVar(&urlWaitTimeout, "timeout", 30*time.Minute, "maximum time to wait with --wait")
 
 	// Flags for direct upload
 	uploadDirectCmd.Flags().StringVar(&uploadExpires, "expires", "1h", "expiration duration (e.g., 1h, 30m)")
 	uploadDirectCmd.Flags().IntVar(&maxDuration, "max-duration", 0, "maximum video duration in seconds")
+	uploadDirectCmd.Flags().StringVar(&uploadHTML, "html", "", "also write a drag-and-drop upload page to this file")
+	uploadDirectCmd.Flags().StringVar(&uploadMetadata, "metadata", "", "video metadata as JSON")
+	uploadDirectCmd.Flags().StringVar(&directCreator, "creator", "", "creator ID to tag the video with")
+	uploadDirectCmd.Flags().StringSliceVar(&directOrigins, "allowed-origins", nil, "comma-separated origins allowed to embed the video")
+	uploadDirectCmd.Flags().StringVar(&directDeleteAfter, "delete-after", "", "schedule deletion, e.g. 30d or 2006-01-02")
 }
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"cfstream/internal/api"
+	"cfstream/internal/output"
+	"cfstream/internal/report"
+)
+
+var usageCmd = &cobra.Command{
+	Use:   "usage",
+	Short: "Show storage used against the plan",
+	Long: `Show the storage minutes used, the plan's limit and what remains of it,
+and the number of videos, e.g. before a large upload batch.
+
+Use --per-creator to also total videos and minutes per creator from the
+library, with each creator's share of the plan limit.`,
+	Args: cobra.NoArgs,
+	RunE: runUsage,
+}
+
+// usageSummary is the output of the usage command.
+type usageSummary struct {
+	StoredMinutes    float64 `json:"storedMinutes"`
+	LimitMinutes     float64 `json:"limitMinutes"`
+	RemainingMinutes float64 `json:"remainingMinutes"`
+	Used             string  `json:"used"`
+	Videos           int     `json:"videos"`
+}
+
+// usageReport is the output of usage --per-creator in formats that nest.
+type usageReport struct {
+	usageSummary `yaml:",inline"`
+	Creators     []report.CreatorTotal `json:"creators" yaml:"creators"`
+}
+
+// usageCreatorRow is one row of the usage --per-creator table.
+type usageCreatorRow struct {
+	Creator string
+	Videos  int
+	Minutes float64
+	Share   string
+}
+
+var usagePerCreator bool
+
+func init() {
+	rootCmd.AddCommand(usageCmd)
+
+	usageCmd.Flags().BoolVar(&usagePerCreator, "per-creator", false, "also break usage down by creator")
+}
+
+func runUsage(cmd *cobra.Command, args []string) error {
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	usage, err := client.GetStorageUsage(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get storage usage: %w", err)
+	}
+
+	summary := usageSummary{
+		StoredMinutes:    usage.TotalStorageMinutes,
+		LimitMinutes:     usage.TotalStorageMinutesLimit,
+		RemainingMinutes: usage.RemainingMinutes(),
+		Used:             quotaShare(usage.TotalStorageMinutes, usage),
+		Videos:           usage.VideoCount,
+	}
+
+	formatter, err := output.NewFormatter(outputFormat)
+	if err != nil {
+		return err
+	}
+
+	if !usagePerCreator {
+		if err := formatter.FormatSingle(os.Stdout, summary); err != nil {
+			return fmt.Errorf("failed to format output: %w", err)
+		}
+		return nil
+	}
+
+	videos, err := listAllVideos(ctx, client, &api.ListOptions{})
+	if err != nil {
+		return err
+	}
+	creators := report.ByCreator(videos)
+
+	if outputFormat != outputFormatTable {
+		if err := formatter.FormatSingle(os.Stdout, usageReport{usageSummary: summary, Creators: creators}); err != nil {
+			return fmt.Errorf("failed to format output: %w", err)
+		}
+		return nil
+	}
+
+	// Tables can't nest, so creators follow the summary as their own table
+	if err := formatter.FormatSingle(os.Stdout, summary); err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+	fmt.Println()
+
+	rows := make([]usageCreatorRow, len(creators))
+	for i, c := range creators {
+		rows[i] = usageCreatorRow{Creator: c.Creator, Videos: c.Videos, Minutes: c.Minutes, Share: quotaShare(c.Minutes, usage)}
+	}
+	if err := formatter.FormatList(os.Stdout, []string{"Creator", "Videos", "Minutes", "Share"}, rows); err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+	return nil
+}
+
+// quotaShare formats minutes as a percentage of the plan limit, or "-"
+// when the plan has no known limit.
+func quotaShare(minutes float64, usage *api.StorageUsage) string {
+	if usage.TotalStorageMinutesLimit <= 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f%%", minutes/usage.TotalStorageMinutesLimit*100)
+}
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"cfstream/internal/media"
+)
+
+// uploadValidate enables checking files against Stream's limits before
+// they are uploaded.
+var uploadValidate bool
+
+// validateUploads checks the files at paths against Stream's limits when
+// --validate is set and returns their total duration in minutes, for the
+// quota check. Files in formats that can't be inspected without ffprobe are
+// reported and not checked.
+func validateUploads(paths []string) (float64, error) {
+	if !uploadValidate {
+		return 0, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(len(paths))*30*time.Second)
+	defer cancel()
+
+	var minutes float64
+	var problems []error
+	for _, path := range paths {
+		info, err := media.Probe(ctx, path)
+		if errors.Is(err, media.ErrUnknownFormat) {
+			fmt.Fprintf(os.Stderr, "Warning: not validated: %v\n", err)
+			continue
+		}
+		if err != nil {
+			return 0, err
+		}
+		if verbose {
+			fmt.Fprintf(os.Stderr, "%s: %s, %s, %s (%s)\n", path, info.Container, info.VideoCodec, info.Duration.Round(time.Second), info.Prober)
+		}
+		if err := info.Validate(); err != nil {
+			problems = append(problems, err)
+		}
+		minutes += info.Duration.Minutes()
+	}
+
+	if len(problems) > 0 {
+		return 0, fmt.Errorf("validation failed:\n%w", errors.Join(problems...))
+	}
+	return minutes, nil
+}
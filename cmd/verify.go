@@ -0,0 +1,228 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"cfstream/internal/hls"
+	"cfstream/internal/output"
+)
+
+var videoVerifyCmd = &cobra.Command{
+	Use:   "verify <video-id>",
+	Short: "Verify a video plays end to end",
+	Long: `Verify that a video is actually playable: check it is ready, fetch the HLS
+master manifest (with a short-lived token for private videos), then fetch every
+rendition playlist and its first segment. Reports codecs, resolution, and
+bandwidth per rendition and exits non-zero if any step fails.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runVideoVerify,
+}
+
+// verifyReport is the result of video verify.
+type verifyReport struct {
+	UID        string            `json:"uid"`
+	Ready      bool              `json:"ready"`
+	Manifest   int               `json:"manifestStatus"`
+	Renditions []renditionReport `json:"renditions"`
+}
+
+// renditionReport is the verification result of one rendition.
+type renditionReport struct {
+	Rendition string `json:"rendition"`
+	Codecs    string `json:"codecs"`
+	Bandwidth int    `json:"bandwidth"`
+	Playlist  int    `json:"playlistStatus"`
+	Segment   int    `json:"segmentStatus"`
+	OK        bool   `json:"ok"`
+	Error     string `json:"error,omitempty"`
+}
+
+// maxPlaylistSize bounds how much of a playlist is read.
+const maxPlaylistSize = 4 << 20
+
+func init() {
+	videoCmd.AddCommand(videoVerifyCmd)
+}
+
+func runVideoVerify(cmd *cobra.Command, args []string) error {
+	videoID, err := resolveVideoID(args[0])
+	if err != nil {
+		return err
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	video, err := client.GetVideo(ctx, videoID)
+	if err != nil {
+		return fmt.Errorf("failed to get video: %w", err)
+	}
+
+	report := verifyReport{UID: video.UID, Ready: video.ReadyToStream}
+	if !report.Ready {
+		return fmt.Errorf("video %s is not ready to stream (status: %s)", videoID, video.Status)
+	}
+
+	entries, token, err := videoLinks(ctx, client, video, 5*time.Minute)
+	if err != nil {
+		return err
+	}
+	manifestURL := entries[0].URL
+
+	httpClient := &http.Client{Timeout: 15 * time.Second}
+	status, body, err := fetchPlaylist(ctx, httpClient, manifestURL)
+	report.Manifest = status
+	if err != nil {
+		return fmt.Errorf("failed to fetch HLS manifest: %w", err)
+	}
+
+	master, err := hls.ParseMaster(body, manifestURL)
+	if err != nil {
+		return fmt.Errorf("invalid HLS manifest: %w", err)
+	}
+
+	failed := 0
+	for _, v := range master.Variants {
+		r := renditionReport{Rendition: v.Resolution, Codecs: v.Codecs, Bandwidth: v.Bandwidth}
+		verifyRendition(ctx, httpClient, withPlaylistToken(v.URI, token), token, &r)
+		if !r.OK {
+			failed++
+		}
+		report.Renditions = append(report.Renditions, r)
+	}
+	for _, m := range master.Media {
+		if m.URI == "" {
+			continue
+		}
+		r := renditionReport{Rendition: strings.ToLower(m.Type) + " " + m.Name}
+		if m.Language != "" {
+			r.Rendition += " (" + m.Language + ")"
+		}
+		verifyRendition(ctx, httpClient, withPlaylistToken(m.URI, token), token, &r)
+		if !r.OK {
+			failed++
+		}
+		report.Renditions = append(report.Renditions, r)
+	}
+
+	formatter, err := output.NewFormatter(outputFormat)
+	if err != nil {
+		return err
+	}
+
+	if outputFormat != outputFormatTable {
+		if err := formatter.FormatSingle(os.Stdout, report); err != nil {
+			return fmt.Errorf("failed to format output: %w", err)
+		}
+	} else {
+		if !quiet {
+			fmt.Printf("Ready: yes\nManifest: %d (%d renditions)\n", report.Manifest, len(report.Renditions))
+		}
+		headers := []string{"Rendition", "Codecs", "Bandwidth", "Playlist", "Segment", "OK", "Error"}
+		if err := formatter.FormatList(os.Stdout, headers, report.Renditions); err != nil {
+			return fmt.Errorf("failed to format output: %w", err)
+		}
+	}
+
+	if len(report.Renditions) == 0 {
+		return fmt.Errorf("the HLS manifest of video %s lists no renditions", videoID)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d renditions failed verification", failed, len(report.Renditions))
+	}
+	return nil
+}
+
+// verifyRendition fetches a rendition playlist and its first segment and records the outcome in r.
+func verifyRendition(ctx context.Context, httpClient *http.Client, playlistURL, token string, r *renditionReport) {
+	status, body, err := fetchPlaylist(ctx, httpClient, playlistURL)
+	r.Playlist = status
+	if err != nil {
+		r.Error = err.Error()
+		return
+	}
+
+	segments, err := hls.ParseSegments(body, playlistURL)
+	if err != nil {
+		r.Error = err.Error()
+		return
+	}
+	if len(segments) == 0 {
+		r.Error = "playlist has no segments"
+		return
+	}
+
+	r.Segment, err = fetchStatus(ctx, httpClient, withPlaylistToken(segments[0], token))
+	if err != nil {
+		r.Error = err.Error()
+		return
+	}
+	if r.Segment != http.StatusOK {
+		r.Error = "segment: " + http.StatusText(r.Segment)
+		return
+	}
+	r.OK = true
+}
+
+// fetchPlaylist GETs a playlist and returns its status and body. Non-200
+// responses are returned as errors.
+func fetchPlaylist(ctx context.Context, httpClient *http.Client, url string) (int, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, "", err
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return resp.StatusCode, "", fmt.Errorf("playlist: %s", http.StatusText(resp.StatusCode))
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxPlaylistSize))
+	if err != nil {
+		return resp.StatusCode, "", fmt.Errorf("failed to read playlist: %w", err)
+	}
+	return resp.StatusCode, string(body), nil
+}
+
+// fetchStatus GETs url without reading the body and returns the response status.
+func fetchStatus(ctx context.Context, httpClient *http.Client, url string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+
+	return resp.StatusCode, nil
+}
+
+// withPlaylistToken carries a signed token over to a URI found in a playlist,
+// unless the URI already includes it.
+func withPlaylistToken(uri, token string) string {
+	if token == "" || strings.Contains(uri, token) {
+		return uri
+	}
+	return withToken(uri, token)
+}
@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"bufio"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -13,7 +12,11 @@ import (
 
 	"cfstream/internal/api"
 	"cfstream/internal/config"
+	"cfstream/internal/filter"
+	"cfstream/internal/index"
 	"cfstream/internal/output"
+	"cfstream/internal/progress"
+	"cfstream/internal/tags"
 )
 
 var videoCmd = &cobra.Command{
@@ -25,8 +28,20 @@ var videoCmd = &cobra.Command{
 var videoListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List videos",
-	Long:  `List videos from Cloudflare Stream with optional filtering.`,
-	RunE:  runVideoList,
+	Long: `List videos from Cloudflare Stream with optional filtering.
+
+Videos are listed newest first, --limit at a time. When more videos follow,
+the cursor to continue with is printed to stderr; pass it to --after for the
+next page. Use --all to list the whole library, fetching as many pages as
+needed.
+
+Use --since and --until to list the videos created in a time range, given
+as a day count (7d), a duration (12h), or a date (2024-01-01).
+
+Use --sort created, name, duration, or status, and --desc, to reorder the
+listed videos. Only the videos of the listing are sorted, so sort the whole
+library with --all.`,
+	RunE: runVideoList,
 }
 
 var videoGetCmd = &cobra.Command{
@@ -38,11 +53,43 @@ var videoGetCmd = &cobra.Command{
 }
 
 var videoDeleteCmd = &cobra.Command{
-	Use:   "delete <video-id>",
-	Short: "Delete a video",
-	Long:  `Delete a video from Cloudflare Stream.`,
-	Args:  cobra.ExactArgs(1),
-	RunE:  runVideoDelete,
+	Use:   "delete <video-id>...",
+	Short: "Delete videos",
+	Long: `Delete one or more videos from Cloudflare Stream.
+
+Several IDs, or IDs read one per line from stdin with --stdin, are deleted
+concurrently with a progress bar and a summary of failures at the end. When
+the API rate limits a request, the whole batch pauses for the requested time
+and runs with less concurrency until requests succeed again. Reading from
+stdin requires --yes.
+
+With --checkpoint, deleted IDs are recorded in a file and skipped when the
+command is rerun, so an interrupted batch resumes where it stopped.
+
+Instead of IDs, the videos to delete can be selected with --search, --status,
+and --older-than, e.g.
+
+  cfstream video delete --search demo --status error --older-than 90d --dry-run
+
+The matched videos are listed before the confirmation; --dry-run stops after
+listing them and the planned API calls.
+
+Videos in the protected_videos list of the config file are only deleted after
+their name is retyped, or with --override-protection. Unattended runs refuse
+to delete them without the flag; --yes does not override protection.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if deleteStdin {
+			return nil
+		}
+		if deleteByFilter() {
+			if len(args) > 0 {
+				return fmt.Errorf("video IDs cannot be combined with --search, --status, or --older-than")
+			}
+			return nil
+		}
+		return cobra.MinimumNArgs(1)(cmd, args)
+	},
+	RunE: runVideoDelete,
 }
 
 var videoUpdateCmd = &cobra.Command{
@@ -53,20 +100,44 @@ var videoUpdateCmd = &cobra.Command{
 	RunE:  runVideoUpdate,
 }
 
+var videoWaitCmd = &cobra.Command{
+	Use:   "wait <video-id>",
+	Short: "Wait for a video to finish processing",
+	Long:  `Poll a video until it is ready to stream or processing fails.`,
+	Args:  cobra.ExactArgs(1),
+	RunE:  notifyOnFinish("Video processing", runVideoWait),
+}
+
+// videoListHeaders are the columns of every command that lists videos.
+var videoListHeaders = []string{"UID", "Name", "Status", "Duration", "Created"}
+
 var (
 	// List flags.
 	listSearch string
 	listLimit  int
 	listAfter  string
 	listStatus string
-
-	// Delete flags.
-	deleteYes bool
+	listAll    bool
+	listSince  string
+	listUntil  string
+	listTag    string
 
 	// Update flags.
 	updateName              string
 	updateMetadata          string
 	updateRequireSignedURLs string
+
+	// Delete flags.
+	deleteStdin       bool
+	deleteConcurrency int
+	deleteCheckpoint  string
+	deleteSearch      string
+	deleteStatus      string
+	deleteOlderThan   string
+
+	// Wait flags.
+	waitTimeout  time.Duration
+	waitInterval time.Duration
 )
 
 func init() {
@@ -75,20 +146,36 @@ func init() {
 	videoCmd.AddCommand(videoGetCmd)
 	videoCmd.AddCommand(videoDeleteCmd)
 	videoCmd.AddCommand(videoUpdateCmd)
+	videoCmd.AddCommand(videoWaitCmd)
 
 	// List command flags
 	videoListCmd.Flags().StringVar(&listSearch, "search", "", "search by video name")
 	videoListCmd.Flags().IntVar(&listLimit, "limit", 50, "number of videos to return")
-	videoListCmd.Flags().StringVar(&listAfter, "after", "", "cursor for pagination")
-	videoListCmd.Flags().StringVar(&listStatus, "status", "", "filter by status (ready, processing, error)")
-
-	// Delete command flags
-	videoDeleteCmd.Flags().BoolVarP(&deleteYes, "yes", "y", false, "skip confirmation")
+	videoListCmd.Flags().StringVar(&listAfter, "after", "", "continue after this cursor from a previous listing")
+	videoListCmd.Flags().BoolVar(&listAll, "all", false, "list every video, ignoring --limit")
+	videoListCmd.Flags().StringVar(&listSince, "since", "", "only videos created since, e.g. 7d, 12h, or 2024-01-01")
+	videoListCmd.Flags().StringVar(&listUntil, "until", "", "only videos created before, e.g. 30d or 2024-01-01")
+	videoListCmd.Flags().StringVar(&listTag, "tag", "", "only videos with this tag (see 'cfstream tag')")
+	addSortFlags(videoListCmd, videoSortKeys)
+	videoListCmd.Flags().StringVar(&listStatus, "status", "", "filter by status (ready, processing, error, or a state such as queued)")
 
 	// Update command flags
 	videoUpdateCmd.Flags().StringVar(&updateName, "name", "", "new name for the video")
 	videoUpdateCmd.Flags().StringVar(&updateMetadata, "metadata", "", "JSON string of metadata key-value pairs")
 	videoUpdateCmd.Flags().StringVar(&updateRequireSignedURLs, "require-signed", "", "require signed URLs (true/false)")
+
+	// Delete command flags
+	videoDeleteCmd.Flags().BoolVar(&deleteStdin, "stdin", false, "read video IDs from stdin, one per line")
+	videoDeleteCmd.Flags().IntVar(&deleteConcurrency, "concurrency", 4, "number of concurrent deletions")
+	videoDeleteCmd.Flags().StringVar(&deleteCheckpoint, "checkpoint", "", "file recording deleted IDs; completed IDs are skipped on rerun")
+	videoDeleteCmd.Flags().StringVar(&deleteSearch, "search", "", "delete the videos whose name matches")
+	videoDeleteCmd.Flags().StringVar(&deleteStatus, "status", "", "delete the videos with this status, e.g. error")
+	videoDeleteCmd.Flags().StringVar(&deleteOlderThan, "older-than", "", "delete the videos created before, e.g. 90d or 2024-01-01")
+	videoDeleteCmd.Flags().BoolVar(&overrideProtection, "override-protection", false, "delete videos listed in protected_videos without retyping their names")
+
+	// Wait command flags
+	videoWaitCmd.Flags().DurationVar(&waitTimeout, "timeout", 30*time.Minute, "maximum time to wait")
+	videoWaitCmd.Flags().DurationVar(&waitInterval, "interval", 5*time.Second, "polling interval")
 }
 
 func runVideoList(cmd *cobra.Command, args []string) error {
@@ -97,17 +184,79 @@ func runVideoList(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
 	defer cancel()
 
 	opts := &api.ListOptions{
 		Search: listSearch,
 		Status: listStatus,
+		Limit:  listLimit,
+		After:  listAfter,
+	}
+	if listAll {
+		opts.Limit = 0
+	} else if listLimit <= 0 {
+		return fmt.Errorf("--limit must be positive; use --all to list every video")
 	}
 
-	videos, err := client.ListVideos(ctx, opts)
-	if err != nil {
-		return fmt.Errorf("failed to list videos: %w", err)
+	now := time.Now()
+	if listSince != "" {
+		since, err := parseTimeAgo("--since", listSince, now)
+		if err != nil {
+			return err
+		}
+		opts.Start = &since
+	}
+	if listUntil != "" {
+		until, err := parseTimeAgo("--until", listUntil, now)
+		if err != nil {
+			return err
+		}
+		opts.End = &until
+	}
+	if opts.Start != nil && opts.End != nil && !opts.Start.Before(*opts.End) {
+		return fmt.Errorf("--since must be before --until")
+	}
+
+	// Tags are matched locally, so a tagged listing pages until it has
+	// --limit matches
+	var tagFilter *filter.Filter
+	if listTag != "" {
+		if err := tags.Validate(listTag); err != nil {
+			return err
+		}
+		if tagFilter, err = filter.Parse("tag=" + listTag); err != nil {
+			return err
+		}
+	}
+	pagerOpts := *opts
+	if tagFilter != nil {
+		pagerOpts.Limit = 0
+	}
+
+	var videos []api.Video
+	pager := client.ListVideosPager(&pagerOpts)
+	for pager.More() && (opts.Limit == 0 || len(videos) < opts.Limit) {
+		page, err := pager.Next(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list videos: %w", err)
+		}
+		if tagFilter != nil {
+			page = tagFilter.Apply(page)
+		}
+		videos = append(videos, page...)
+	}
+	if opts.Limit > 0 && len(videos) > opts.Limit {
+		videos = videos[:opts.Limit]
+	}
+	cursor := ""
+	if len(videos) > 0 {
+		cursor = api.VideoCursor(&videos[len(videos)-1])
+	}
+
+	// Remember listed videos for completion; the index is only a cache
+	if err := index.Update(videos); err != nil && verbose {
+		fmt.Fprintf(os.Stderr, "Warning: failed to update video index: %v\n", err)
 	}
 
 	if len(videos) == 0 {
@@ -117,6 +266,10 @@ func runVideoList(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	if err := sortList(videos, videoSortKeys); err != nil {
+		return err
+	}
+
 	// Create formatter
 	formatter, err := output.NewFormatter(outputFormat)
 	if err != nil {
@@ -124,16 +277,23 @@ func runVideoList(cmd *cobra.Command, args []string) error {
 	}
 
 	// Format and display videos
-	headers := []string{"UID", "Name", "Status", "Duration", "Created"}
-	if err := formatter.FormatList(os.Stdout, headers, videos); err != nil {
+	if err := formatter.FormatList(os.Stdout, videoListHeaders, videos); err != nil {
 		return fmt.Errorf("failed to format output: %w", err)
 	}
 
+	// A listing that stopped at --limit may have more videos after it
+	if !listAll && len(videos) == listLimit && !quiet {
+		fmt.Fprintf(os.Stderr, "More videos may follow; continue with --after %s\n", cursor)
+	}
+
 	return nil
 }
 
 func runVideoGet(cmd *cobra.Command, args []string) error {
-	videoID := args[0]
+	videoID, err := resolveVideoID(args[0])
+	if err != nil {
+		return err
+	}
 
 	client, err := createClient()
 	if err != nil {
@@ -163,32 +323,69 @@ func runVideoGet(cmd *cobra.Command, args []string) error {
 }
 
 func runVideoDelete(cmd *cobra.Command, args []string) error {
-	videoID := args[0]
+	if deleteByFilter() {
+		if deleteStdin {
+			return fmt.Errorf("--stdin cannot be combined with --search, --status, or --older-than")
+		}
+		return deleteMatching()
+	}
 
-	// Confirm deletion unless --yes flag is provided
-	if !deleteYes {
-		fmt.Printf("Are you sure you want to delete video %s? (y/N): ", videoID)
-		reader := bufio.NewReader(os.Stdin)
-		response, err := reader.ReadString('\n')
+	ids := args
+	if deleteStdin {
+		stdinIDs, err := readIDs(stdinReader)
 		if err != nil {
-			return fmt.Errorf("failed to read confirmation: %w", err)
+			return err
 		}
-		response = strings.TrimSpace(strings.ToLower(response))
-		if response != "y" && response != "yes" {
-			fmt.Println("Deletion cancelled")
-			return nil
+		ids = append(ids, stdinIDs...)
+	}
+
+	videoIDs := make([]string, 0, len(ids))
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		videoID, err := resolveVideoID(id)
+		if err != nil {
+			return err
+		}
+		if !seen[videoID] {
+			seen[videoID] = true
+			videoIDs = append(videoIDs, videoID)
 		}
 	}
 
+	if len(videoIDs) == 0 {
+		return fmt.Errorf("no video IDs given")
+	}
+	if len(videoIDs) > 1 || deleteStdin || deleteCheckpoint != "" {
+		return deleteVideos(videoIDs, deleteConcurrency, deleteCheckpoint)
+	}
+	videoID := videoIDs[0]
+
 	client, err := createClient()
 	if err != nil {
 		return err
 	}
 
+	if err := guardProtected(client, videoIDs); err != nil {
+		return err
+	}
+
+	// Confirm deletion unless --yes flag is provided
+	ok, err := confirm(fmt.Sprintf("Are you sure you want to delete video %s?", videoID))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		fmt.Println("Deletion cancelled")
+		return nil
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
 	if err := client.DeleteVideo(ctx, videoID); err != nil {
+		if isDryRun(err) {
+			return nil
+		}
 		return fmt.Errorf("failed to delete video: %w", err)
 	}
 
@@ -199,8 +396,64 @@ func runVideoDelete(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// deleteByFilter reports whether video delete selects videos by filter flags
+// instead of IDs.
+func deleteByFilter() bool {
+	return deleteSearch != "" || deleteStatus != "" || deleteOlderThan != ""
+}
+
+// deleteMatching lists the videos matching the delete filter flags and
+// deletes them after confirmation.
+func deleteMatching() error {
+	opts := &api.ListOptions{Search: deleteSearch, Status: deleteStatus}
+	if deleteOlderThan != "" {
+		before, err := parseTimeAgo("--older-than", deleteOlderThan, time.Now())
+		if err != nil {
+			return err
+		}
+		opts.End = &before
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	videos, err := listAllVideos(ctx, client, opts)
+	if err != nil {
+		return err
+	}
+
+	if len(videos) == 0 {
+		if !quiet {
+			fmt.Println("No videos match; nothing to delete")
+		}
+		return nil
+	}
+
+	formatter, err := output.NewFormatter(outputFormat)
+	if err != nil {
+		return err
+	}
+	if err := formatter.FormatList(os.Stdout, videoListHeaders, videos); err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+
+	videoIDs := make([]string, len(videos))
+	for i, v := range videos {
+		videoIDs[i] = v.UID
+	}
+	return deleteVideos(videoIDs, deleteConcurrency, deleteCheckpoint)
+}
+
 func runVideoUpdate(cmd *cobra.Command, args []string) error {
-	videoID := args[0]
+	videoID, err := resolveVideoID(args[0])
+	if err != nil {
+		return err
+	}
 
 	// Validate that at least one update option is provided
 	if updateName == "" && updateMetadata == "" && updateRequireSignedURLs == "" {
@@ -256,6 +509,9 @@ func runVideoUpdate(cmd *cobra.Command, args []string) error {
 
 	video, err := client.UpdateVideo(ctx, videoID, opts)
 	if err != nil {
+		if isDryRun(err) {
+			return nil
+		}
 		return fmt.Errorf("failed to update video: %w", err)
 	}
 
@@ -277,12 +533,130 @@ func runVideoUpdate(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-// createClient creates an API client from configuration.
-func createClient() (api.Client, error) {
+func runVideoWait(cmd *cobra.Command, args []string) error {
+	videoID, err := resolveVideoID(args[0])
+	if err != nil {
+		return err
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), waitTimeout)
+	defer cancel()
+
+	video, err := waitForVideo(ctx, client, videoID, waitInterval)
+	if err != nil {
+		return err
+	}
+
+	formatter, err := output.NewFormatter(outputFormat)
+	if err != nil {
+		return err
+	}
+
+	if err := formatter.FormatSingle(os.Stdout, video); err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+
+	return nil
+}
+
+// waitForVideo polls a video until it is ready, fails, or ctx is done.
+// Status changes are printed on stderr; with --progress json the encoding
+// percentage is reported as progress events instead.
+func waitForVideo(ctx context.Context, client api.Client, videoID string, interval time.Duration) (*api.Video, error) {
+	events := progressMode == progress.ModeJSON
+	bar := progress.None()
+	if events {
+		bar = newProgress(progress.Options{Total: 100, Description: "Processing " + videoID, Phase: "processing"})
+	}
+	defer bar.Finish()
+
+	lastStatus := ""
+	for {
+		video, err := client.GetVideo(ctx, videoID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, fmt.Errorf("timed out waiting for video %s", videoID)
+			}
+			return nil, fmt.Errorf("failed to get video: %w", err)
+		}
+
+		if video.ReadyToStream {
+			bar.Set(100)
+			return video, nil
+		}
+		if video.Status == "error" {
+			return video, fmt.Errorf("video processing failed: %s", video.StatusDetails)
+		}
+
+		if pct, ok := encodingPercent(video); ok {
+			bar.Set(int64(pct))
+		}
+
+		status := video.Status
+		if video.StatusDetails != "" {
+			status += " (" + video.StatusDetails + ")"
+		}
+		if !quiet && !events && status != lastStatus {
+			fmt.Fprintf(os.Stderr, "Status: %s\n", status)
+		}
+		lastStatus = status
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("timed out waiting for video %s (last status: %s)", videoID, status)
+		case <-time.After(interval):
+		}
+	}
+}
+
+// sessionKey identifies the global flags a client was created with.
+type sessionKey struct {
+	profile         string
+	printCurl       bool
+	waitOnRateLimit bool
+}
+
+// sessionClients holds the clients reused by createClient while the
+// interactive shell is running, keyed by the flags they were created with.
+// It is nil outside the shell.
+var sessionClients map[sessionKey]api.Client
+
+// currentSessionKey returns the session key for the current global flags.
+func currentSessionKey() sessionKey {
+	return sessionKey{profile: profileName, printCurl: printCurl, waitOnRateLimit: waitOnRateLimit}
+}
+
+// loadConfig loads the configuration with the account settings of the
+// --profile profile, if given.
+func loadConfig() (*config.Config, error) {
 	cfg, err := config.Load()
 	if err != nil {
 		return nil, fmt.Errorf("failed to load configuration: %w", err)
 	}
+	if profileName != "" {
+		if err := cfg.UseProfile(profileName); err != nil {
+			return nil, err
+		}
+	}
+	return cfg, nil
+}
+
+// createClient creates an API client from configuration.
+func createClient() (api.Client, error) {
+	key := currentSessionKey()
+	if client, ok := sessionClients[key]; ok && !dryRun {
+		return client, nil
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil, err
+	}
 
 	if cfg.AccountID == "" {
 		return nil, fmt.Errorf("account ID not configured (run 'cfstream config init')")
@@ -291,10 +665,30 @@ func createClient() (api.Client, error) {
 		return nil, fmt.Errorf("API token not configured (run 'cfstream config init')")
 	}
 
-	client, err := api.NewClient(cfg.AccountID, cfg.APIToken)
+	client, err := api.NewClient(cfg.AccountID, cfg.APIToken, clientOptions()...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create API client: %w", err)
 	}
 
+	if sessionClients != nil && !dryRun {
+		sessionClients[key] = client
+	}
 	return client, nil
 }
+
+// clientOptions returns the API client options set by the global flags.
+func clientOptions() []api.Option {
+	var opts []api.Option
+	if dryRun {
+		opts = append(opts, api.WithDryRun(os.Stdout))
+	}
+	if printCurl {
+		opts = append(opts, api.WithPrintCurl(os.Stderr))
+	}
+	if waitOnRateLimit {
+		opts = append(opts, api.WithRateLimitWait(func(wait time.Duration) {
+			fmt.Fprintf(os.Stderr, "Rate limited: waiting %s before retrying\n", wait.Round(time.Second))
+		}))
+	}
+	return opts
+}
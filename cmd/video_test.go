@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/adrg/xdg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"cfstream/internal/api"
+)
+
+func TestCreateClient_SessionSwitchesProfile(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("CFSTREAM_ACCOUNT_ID", "")
+	t.Setenv("CFSTREAM_API_TOKEN", "")
+	xdg.Reload()
+	t.Cleanup(xdg.Reload)
+
+	config := `account_id: default-account
+api_token: default-token
+profiles:
+  personal:
+    account_id: personal-account
+    api_token: personal-token
+`
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "cfstream"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "cfstream", "config.yaml"), []byte(config), 0o600))
+
+	sessionClients = make(map[sessionKey]api.Client)
+	t.Cleanup(func() {
+		sessionClients = nil
+		profileName = ""
+		printCurl = false
+	})
+
+	first, err := createClient()
+	require.NoError(t, err)
+	again, err := createClient()
+	require.NoError(t, err)
+	assert.Same(t, first, again, "the session reuses the client")
+
+	profileName = "personal"
+	personal, err := createClient()
+	require.NoError(t, err)
+	assert.NotSame(t, first, personal, "--profile gets its own client")
+
+	printCurl = true
+	curl, err := createClient()
+	require.NoError(t, err)
+	assert.NotSame(t, personal, curl, "--print-curl gets its own client")
+
+	profileName, printCurl = "", false
+	back, err := createClient()
+	require.NoError(t, err)
+	assert.Same(t, first, back)
+}
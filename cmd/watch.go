@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"cfstream/internal/api"
+)
+
+var videoWatchCmd = &cobra.Command{
+	Use:   "watch <video-id>",
+	Short: "Stream status changes of a video until it is ready or fails",
+	Long: `Poll a video and print a line whenever its status or encoding progress
+changes, until it is ready to stream or processing fails, e.g.
+
+  cfstream video watch VIDEO_ID
+  cfstream video watch VIDEO_ID --output ndjson | jq .pctComplete
+
+With --output ndjson each change is a JSON object on its own line. The
+command exits non-zero when processing fails. Watching has no time limit
+unless --timeout is set.`,
+	Args: cobra.ExactArgs(1),
+	RunE: notifyOnFinish("Video processing", runVideoWatch),
+}
+
+// watchEvent is a status change reported by video watch.
+type watchEvent struct {
+	Time          time.Time `json:"time"`
+	UID           string    `json:"uid"`
+	Status        string    `json:"status"`
+	PctComplete   *float64  `json:"pctComplete,omitempty"`
+	Details       string    `json:"details,omitempty"`
+	ReadyToStream bool      `json:"readyToStream"`
+}
+
+var (
+	watchInterval time.Duration
+	watchTimeout  time.Duration
+)
+
+func init() {
+	videoCmd.AddCommand(videoWatchCmd)
+
+	videoWatchCmd.Flags().DurationVar(&watchInterval, "interval", 2*time.Second, "polling interval")
+	videoWatchCmd.Flags().DurationVar(&watchTimeout, "timeout", 0, "stop watching after this long (default: no limit)")
+}
+
+func runVideoWatch(cmd *cobra.Command, args []string) error {
+	if outputFormat != outputFormatTable && outputFormat != outputFormatNDJSON {
+		return fmt.Errorf("video watch supports --output table or ndjson, not %s", outputFormat)
+	}
+	if watchInterval <= 0 {
+		return fmt.Errorf("--interval must be positive")
+	}
+
+	videoID, err := resolveVideoID(args[0])
+	if err != nil {
+		return err
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	if watchTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, watchTimeout)
+		defer cancel()
+	}
+
+	if outputFormat == outputFormatTable {
+		fmt.Printf("%-8s  %-13s  %6s  %s\n", "TIME", "STATUS", "PCT", "DETAILS")
+	}
+
+	var last *watchEvent
+	for {
+		video, err := client.GetVideo(ctx, videoID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return fmt.Errorf("stopped watching video %s after %s", videoID, watchTimeout)
+			}
+			return fmt.Errorf("failed to get video: %w", err)
+		}
+
+		event := newWatchEvent(video, time.Now())
+		if last == nil || event.changedFrom(last) {
+			if err := printWatchEvent(event); err != nil {
+				return err
+			}
+			last = event
+		}
+
+		if video.ReadyToStream {
+			return nil
+		}
+		if video.Status == "error" {
+			return fmt.Errorf("video processing failed: %s", video.StatusDetails)
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("stopped watching video %s after %s", videoID, watchTimeout)
+		case <-time.After(watchInterval):
+		}
+	}
+}
+
+// newWatchEvent describes the current state of video.
+func newWatchEvent(video *api.Video, now time.Time) *watchEvent {
+	event := &watchEvent{
+		Time:          now,
+		UID:           video.UID,
+		Status:        video.Status,
+		Details:       video.StatusDetails,
+		ReadyToStream: video.ReadyToStream,
+	}
+	if pct, ok := encodingPercent(video); ok {
+		event.PctComplete = &pct
+		event.Details = ""
+	}
+	return event
+}
+
+// changedFrom reports whether e differs from the previous event.
+func (e *watchEvent) changedFrom(prev *watchEvent) bool {
+	if e.Status != prev.Status || e.Details != prev.Details || e.ReadyToStream != prev.ReadyToStream {
+		return true
+	}
+	if (e.PctComplete == nil) != (prev.PctComplete == nil) {
+		return true
+	}
+	return e.PctComplete != nil && *e.PctComplete != *prev.PctComplete
+}
+
+// printWatchEvent prints event as a table row or an NDJSON line.
+func printWatchEvent(event *watchEvent) error {
+	if outputFormat == outputFormatNDJSON {
+		line, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("failed to encode event: %w", err)
+		}
+		fmt.Println(string(line))
+		return nil
+	}
+
+	pct := "-"
+	if event.PctComplete != nil {
+		pct = fmt.Sprintf("%.1f%%", *event.PctComplete)
+	}
+	status := event.Status
+	if event.ReadyToStream {
+		status = "ready"
+	}
+	fmt.Printf("%-8s  %-13s  %6s  %s\n", event.Time.Format("15:04:05"), status, pct, event.Details)
+	return nil
+}
+
+// encodingPercent returns the encoding progress of a video from its status
+// details, e.g. "42.5% complete".
+func encodingPercent(video *api.Video) (float64, bool) {
+	pct, ok := strings.CutSuffix(video.StatusDetails, "% complete")
+	if !ok {
+		return 0, false
+	}
+	n, err := strconv.ParseFloat(pct, 64)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
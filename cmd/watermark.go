@@ -0,0 +1,207 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"cfstream/internal/api"
+)
+
+var watermarkCmd = &cobra.Command{
+	Use:   "watermark",
+	Short: "Work with watermark profiles",
+	Long:  `Work with watermark profiles that are burned into videos at upload time.`,
+}
+
+var watermarkPreviewCmd = &cobra.Command{
+	Use:   "preview <profile-uid>",
+	Short: "Render a thumbnail of a sample video with a watermark",
+	Long: `Render a watermark profile onto a sample video and save a thumbnail, so its
+position, scale, and opacity can be checked before the profile is used for
+all uploads.
+
+Watermarks are applied while a video is encoded, so the sample given with
+--video is copied with the profile applied, the thumbnail of the copy is
+saved to --out, and the copy is deleted afterwards unless --keep is set. The
+sample video needs MP4 downloads enabled.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runWatermarkPreview,
+}
+
+var (
+	watermarkVideo   string
+	watermarkOut     string
+	watermarkTime    string
+	watermarkKeep    bool
+	watermarkTimeout time.Duration
+)
+
+// maxThumbnailSize bounds how much of a thumbnail is downloaded.
+const maxThumbnailSize = 20 << 20
+
+func init() {
+	rootCmd.AddCommand(watermarkCmd)
+	watermarkCmd.AddCommand(watermarkPreviewCmd)
+
+	watermarkPreviewCmd.Flags().StringVar(&watermarkVideo, "video", "", "sample video to apply the watermark to (required)")
+	watermarkPreviewCmd.Flags().StringVar(&watermarkOut, "out", "preview.jpg", "file to save the thumbnail to")
+	watermarkPreviewCmd.Flags().StringVar(&watermarkTime, "time", "", "timestamp of the thumbnail (e.g., 10s, 1m30s)")
+	watermarkPreviewCmd.Flags().BoolVar(&watermarkKeep, "keep", false, "keep the watermarked copy instead of deleting it")
+	watermarkPreviewCmd.Flags().DurationVar(&watermarkTimeout, "timeout", 15*time.Minute, "maximum time to wait for the copy to be encoded")
+	_ = watermarkPreviewCmd.MarkFlagRequired("video") //nolint:errcheck // Flag is registered above
+}
+
+func runWatermarkPreview(cmd *cobra.Command, args []string) error {
+	profileUID := args[0]
+
+	videoID, err := resolveVideoID(watermarkVideo)
+	if err != nil {
+		return err
+	}
+
+	var thumbnailTime time.Duration
+	if watermarkTime != "" {
+		thumbnailTime, err = time.ParseDuration(watermarkTime)
+		if err != nil {
+			return fmt.Errorf("invalid time format: %w", err)
+		}
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), watermarkTimeout)
+	defer cancel()
+
+	watermark, err := client.GetWatermark(ctx, profileUID)
+	if err != nil {
+		return fmt.Errorf("failed to get watermark profile: %w", err)
+	}
+
+	sourceURL, err := sampleDownloadURL(ctx, client, videoID)
+	if err != nil {
+		return err
+	}
+
+	copied, err := client.UploadFromURL(ctx, sourceURL, &api.UploadOptions{
+		Name:              fmt.Sprintf("watermark preview %s", watermark.UID),
+		RequireSignedURLs: true,
+		Watermark:         watermark.UID,
+	})
+	if err != nil {
+		if isDryRun(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to copy sample video: %w", err)
+	}
+
+	if !watermarkKeep {
+		defer func() {
+			// The preview copy is deleted even if the command timed out
+			cleanupCtx, cleanupCancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cleanupCancel()
+			if err := client.DeleteVideo(cleanupCtx, copied.UID); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to delete preview copy %s: %v\n", copied.UID, err)
+			}
+		}()
+	}
+
+	if !quiet {
+		fmt.Fprintf(os.Stderr, "Encoding sample with watermark %s (%s, opacity %.2f)...\n", watermark.UID, watermark.Position, watermark.Opacity)
+	}
+
+	video, err := waitForVideo(ctx, client, copied.UID, 5*time.Second)
+	if err != nil {
+		return err
+	}
+
+	thumbnailURL := video.Thumbnail
+	if watermarkTime != "" {
+		sep := "?"
+		if strings.Contains(thumbnailURL, "?") {
+			sep = "&"
+		}
+		thumbnailURL += fmt.Sprintf("%stime=%.0fs", sep, thumbnailTime.Seconds())
+	}
+	if video.RequireSignedURLs {
+		token, err := signedTokenFor(ctx, client, video.UID, &api.TokenOptions{})
+		if err != nil {
+			return err
+		}
+		thumbnailURL = withToken(thumbnailURL, token)
+	}
+
+	if err := downloadFile(ctx, thumbnailURL, watermarkOut); err != nil {
+		return fmt.Errorf("failed to download thumbnail: %w", err)
+	}
+
+	if !quiet {
+		fmt.Printf("Preview written to %s\n", watermarkOut)
+		if watermarkKeep {
+			fmt.Printf("Watermarked copy kept as %s\n", video.UID)
+		}
+	}
+	return nil
+}
+
+// sampleDownloadURL returns a URL the API can copy the sample video from,
+// signed with a downloadable token for private videos.
+func sampleDownloadURL(ctx context.Context, client api.Client, videoID string) (string, error) {
+	video, err := client.GetVideo(ctx, videoID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get video: %w", err)
+	}
+
+	downloads, err := client.GetDownloads(ctx, videoID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get downloads: %w", err)
+	}
+	if downloads.Default == nil || downloads.Default.Status != "ready" {
+		return "", fmt.Errorf("sample video %s has no ready MP4 download; enable downloads for it first", videoID)
+	}
+
+	if !video.RequireSignedURLs {
+		return downloads.Default.URL, nil
+	}
+	token, err := signedTokenFor(ctx, client, videoID, &api.TokenOptions{Downloadable: true})
+	if err != nil {
+		return "", err
+	}
+	return withToken(downloads.Default.URL, token), nil
+}
+
+// downloadFile saves the body of a GET request to path.
+func downloadFile(ctx context.Context, url, path string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status: %s", resp.Status)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxThumbnailSize))
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, body, 0o644); err != nil { //nolint:gosec // Previews are not sensitive
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,406 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"cfstream/internal/api"
+	"cfstream/internal/output"
+	"cfstream/internal/webhook"
+)
+
+var webhookCmd = &cobra.Command{
+	Use:   "webhook",
+	Short: "Work with Stream webhooks",
+	Long:  `Manage and debug the webhook notifications Stream sends when videos are ready or fail.`,
+}
+
+var webhookSetCmd = &cobra.Command{
+	Use:   "set <url>",
+	Short: "Subscribe a URL to video notifications",
+	Long: `Have Stream POST a notification to a URL whenever a video becomes ready or
+fails processing, replacing any existing subscription. The output includes the
+signing secret used to verify the Webhook-Signature header of notifications.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runWebhookSet,
+}
+
+var webhookGetCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Show the webhook subscription",
+	Args:  cobra.NoArgs,
+	RunE:  runWebhookGet,
+}
+
+var webhookDeleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Remove the webhook subscription",
+	Args:  cobra.NoArgs,
+	RunE:  runWebhookDelete,
+}
+
+var webhookVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Verify a received webhook signature offline",
+	Long: `Check the Webhook-Signature header of a received webhook against its payload,
+without calling the API, e.g.
+
+  cfstream webhook verify --secret "$SECRET" \
+    --signature 'time=1230811200,sig1=60493ec9...' --payload payload.json
+
+The payload must be the raw request body exactly as received; use --payload -
+to read it from stdin. The secret is shown by 'cfstream webhook get' and can
+also be set with CFSTREAM_WEBHOOK_SECRET. Exits non-zero when the signature
+does not match.`,
+	Args: cobra.NoArgs,
+	RunE: runWebhookVerify,
+}
+
+var webhookListenCmd = &cobra.Command{
+	Use:   "listen",
+	Short: "Receive and verify webhooks locally",
+	Long: `Run an HTTP server that receives Stream notifications, checks their
+Webhook-Signature header, and prints each verified notification as one line of
+JSON. Requests with a bad signature are rejected with 401.
+
+The secret comes from --secret, CFSTREAM_WEBHOOK_SECRET, or the account's
+webhook subscription. Use --forward to pass verified notifications on to the
+handler under development, e.g. behind a tunnel:
+
+  cfstream webhook set https://my-tunnel.example.com
+  cfstream webhook listen --port 8080 --forward http://localhost:3000/hooks/stream`,
+	Args: cobra.NoArgs,
+	RunE: runWebhookListen,
+}
+
+var webhookTestCmd = &cobra.Command{
+	Use:   "test",
+	Short: "Send a signed test notification to a handler",
+	Long: `Send a realistic, signed Stream notification to a webhook handler, so it can
+be exercised without uploading a video, e.g.
+
+  cfstream webhook test --event ready --target http://localhost:3000/hook
+  cfstream webhook test --event error --target http://localhost:3000/hook --video VIDEO_ID
+
+--event is ready or error. With --video the payload is built from a real
+video; otherwise a sample video is used. The signing secret comes from
+--secret, CFSTREAM_WEBHOOK_SECRET, or the account's webhook subscription.
+Exits non-zero when the handler does not answer with a 2xx status.`,
+	Args: cobra.NoArgs,
+	RunE: runWebhookTest,
+}
+
+// webhookVerifyResult is the output of webhook verify.
+type webhookVerifyResult struct {
+	Valid    bool   `json:"valid"`
+	SignedAt string `json:"signedAt"`
+	Age      string `json:"age"`
+	Received string `json:"receivedSignature"`
+	Computed string `json:"computedSignature"`
+}
+
+var (
+	webhookSecret    string
+	webhookSignature string
+	webhookPayload   string
+	webhookPort      int
+	webhookHost      string
+	webhookForward   string
+	webhookEvent     string
+	webhookTarget    string
+	webhookVideo     string
+)
+
+func init() {
+	rootCmd.AddCommand(webhookCmd)
+	webhookCmd.AddCommand(webhookSetCmd)
+	webhookCmd.AddCommand(webhookGetCmd)
+	webhookCmd.AddCommand(webhookDeleteCmd)
+	webhookCmd.AddCommand(webhookVerifyCmd)
+	webhookCmd.AddCommand(webhookListenCmd)
+	webhookCmd.AddCommand(webhookTestCmd)
+
+	webhookVerifyCmd.Flags().StringVar(&webhookSecret, "secret", "", "webhook signing secret (default $CFSTREAM_WEBHOOK_SECRET)")
+	webhookVerifyCmd.Flags().StringVar(&webhookSignature, "signature", "", "value of the Webhook-Signature header (required)")
+	webhookVerifyCmd.Flags().StringVar(&webhookPayload, "payload", "", "file holding the raw request body, or - for stdin (required)")
+	_ = webhookVerifyCmd.MarkFlagRequired("signature") //nolint:errcheck // Flag is registered above
+	_ = webhookVerifyCmd.MarkFlagRequired("payload")   //nolint:errcheck // Flag is registered above
+
+	webhookListenCmd.Flags().StringVar(&webhookSecret, "secret", "", "webhook signing secret (default $CFSTREAM_WEBHOOK_SECRET or the subscription's)")
+	webhookListenCmd.Flags().IntVar(&webhookPort, "port", 8080, "port to listen on")
+	webhookListenCmd.Flags().StringVar(&webhookHost, "host", "127.0.0.1", "address to bind")
+	webhookTestCmd.Flags().StringVar(&webhookEvent, "event", webhook.EventReady, "notification to send (ready, error)")
+	webhookTestCmd.Flags().StringVar(&webhookTarget, "target", "", "URL of the webhook handler (required)")
+	webhookTestCmd.Flags().StringVar(&webhookVideo, "video", "", "build the payload from this video instead of a sample")
+	webhookTestCmd.Flags().StringVar(&webhookSecret, "secret", "", "webhook signing secret (default $CFSTREAM_WEBHOOK_SECRET or the subscription's)")
+	_ = webhookTestCmd.MarkFlagRequired("target") //nolint:errcheck // Flag is registered above
+
+	webhookListenCmd.Flags().StringVar(&webhookForward, "forward", "", "URL to forward verified notifications to")
+}
+
+func runWebhookListen(cmd *cobra.Command, args []string) error {
+	if webhookForward != "" {
+		if u, err := url.Parse(webhookForward); err != nil || (u.Scheme != "https" && u.Scheme != "http") {
+			return fmt.Errorf("invalid --forward URL %q", webhookForward)
+		}
+	}
+
+	secret, err := subscriptionSecret()
+	if err != nil {
+		return err
+	}
+
+	// Notifications arrive concurrently; lines must not interleave
+	var mu sync.Mutex
+	listener := webhook.NewListener(secret, webhook.ListenerOptions{
+		Forward: webhookForward,
+		OnEvent: func(payload []byte) {
+			// Compacting keeps each notification on one line
+			var line bytes.Buffer
+			if err := json.Compact(&line, payload); err != nil {
+				line.Reset()
+				line.Write(payload)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			fmt.Println(line.String())
+		},
+		OnReject: func(err error) {
+			fmt.Fprintf(os.Stderr, "Rejected notification: %v\n", err)
+		},
+	})
+
+	addr := net.JoinHostPort(webhookHost, strconv.Itoa(webhookPort))
+	if !quiet {
+		fmt.Fprintf(os.Stderr, "Listening for webhooks on http://%s\n", addr)
+	}
+	return listenAndServe(addr, listener)
+}
+
+func runWebhookTest(cmd *cobra.Command, args []string) error {
+	if u, err := url.Parse(webhookTarget); err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return fmt.Errorf("invalid --target URL %q", webhookTarget)
+	}
+
+	var video *api.Video
+	if webhookVideo != "" {
+		videoID, err := resolveVideoID(webhookVideo)
+		if err != nil {
+			return err
+		}
+		client, err := createClient()
+		if err != nil {
+			return err
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		video, err = client.GetVideo(ctx, videoID)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("failed to get video: %w", err)
+		}
+	}
+
+	payload, err := webhook.Fixture(webhookEvent, video)
+	if err != nil {
+		return err
+	}
+
+	secret, err := subscriptionSecret()
+	if err != nil {
+		return err
+	}
+
+	if verbose {
+		fmt.Fprintf(os.Stderr, "Payload: %s\n", payload)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	status, body, err := webhook.Send(ctx, &http.Client{}, webhookTarget, secret, payload)
+	if err != nil {
+		return err
+	}
+
+	if !quiet {
+		fmt.Printf("%s notification sent to %s: %d %s\n", webhookEvent, webhookTarget, status, http.StatusText(status))
+		if len(body) > 0 && verbose {
+			fmt.Printf("Response: %s\n", body)
+		}
+	}
+	if status < 200 || status >= 300 {
+		return fmt.Errorf("handler answered %d %s", status, http.StatusText(status))
+	}
+	return nil
+}
+
+func runWebhookSet(cmd *cobra.Command, args []string) error {
+	u, err := url.Parse(args[0])
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return fmt.Errorf("invalid notification URL %q: use an http(s) URL", args[0])
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	hook, err := client.SetWebhook(ctx, args[0])
+	if err != nil {
+		if isDryRun(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to set webhook: %w", err)
+	}
+
+	if err := printWebhook(hook); err != nil {
+		return err
+	}
+	if !quiet {
+		fmt.Fprintln(os.Stderr, "Keep the secret to verify notifications, e.g. with 'cfstream webhook verify'")
+	}
+	return nil
+}
+
+func runWebhookGet(cmd *cobra.Command, args []string) error {
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	hook, err := client.GetWebhook(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get webhook: %w", err)
+	}
+	return printWebhook(hook)
+}
+
+func runWebhookDelete(cmd *cobra.Command, args []string) error {
+	ok, err := confirm("Are you sure you want to remove the webhook subscription?")
+	if err != nil {
+		return err
+	}
+	if !ok {
+		fmt.Println("Deletion cancelled")
+		return nil
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := client.DeleteWebhook(ctx); err != nil {
+		if isDryRun(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+
+	if !quiet {
+		fmt.Println("Webhook subscription removed")
+	}
+	return nil
+}
+
+// subscriptionSecret returns the webhook secret from --secret,
+// CFSTREAM_WEBHOOK_SECRET, or the account's webhook subscription.
+func subscriptionSecret() (string, error) {
+	if secret := firstNonEmpty(webhookSecret, os.Getenv("CFSTREAM_WEBHOOK_SECRET")); secret != "" {
+		return secret, nil
+	}
+
+	client, err := createClient()
+	if err != nil {
+		return "", err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	hook, err := client.GetWebhook(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get webhook secret (pass --secret instead): %w", err)
+	}
+	if hook.Secret == "" {
+		return "", fmt.Errorf("no webhook secret; pass --secret or subscribe with 'cfstream webhook set'")
+	}
+	return hook.Secret, nil
+}
+
+// printWebhook prints a webhook subscription in the selected output format.
+func printWebhook(hook *api.Webhook) error {
+	formatter, err := output.NewFormatter(outputFormat)
+	if err != nil {
+		return err
+	}
+	if err := formatter.FormatSingle(os.Stdout, hook); err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+	return nil
+}
+
+func runWebhookVerify(cmd *cobra.Command, args []string) error {
+	secret := firstNonEmpty(webhookSecret, os.Getenv("CFSTREAM_WEBHOOK_SECRET"))
+	if secret == "" {
+		return fmt.Errorf("--secret or CFSTREAM_WEBHOOK_SECRET is required")
+	}
+
+	var payload []byte
+	var err error
+	if webhookPayload == "-" {
+		payload, err = io.ReadAll(os.Stdin)
+	} else {
+		payload, err = os.ReadFile(webhookPayload)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read payload: %w", err)
+	}
+
+	result, err := webhook.Verify(secret, webhookSignature, payload)
+	if err != nil {
+		return err
+	}
+
+	formatter, err := output.NewFormatter(outputFormat)
+	if err != nil {
+		return err
+	}
+	if err := formatter.FormatSingle(os.Stdout, webhookVerifyResult{
+		Valid:    result.Valid,
+		SignedAt: result.Time.UTC().Format(time.RFC3339),
+		Age:      time.Since(result.Time).Round(time.Second).String(),
+		Received: result.Received,
+		Computed: result.Computed,
+	}); err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+
+	if result.Valid {
+		return nil
+	}
+	if result.TrimmedMatch {
+		fmt.Fprintln(os.Stderr, "Hint: the signature matches once trailing newlines are removed from the payload; the file was likely saved with an extra newline")
+	}
+	return fmt.Errorf("webhook signature does not match")
+}
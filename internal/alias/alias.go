@@ -0,0 +1,99 @@
+// Package alias manages human-friendly local names for video IDs.
+package alias
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/adrg/xdg"
+	"gopkg.in/yaml.v3"
+)
+
+// Aliases maps alias names to video UIDs.
+type Aliases map[string]string
+
+// Load reads aliases from disk.
+// Returns an empty set if no alias file exists.
+func Load() (Aliases, error) {
+	aliases := make(Aliases)
+
+	data, err := os.ReadFile(Path())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return aliases, nil
+		}
+		return nil, fmt.Errorf("failed to read aliases: %w", err)
+	}
+
+	if err := yaml.Unmarshal(data, &aliases); err != nil {
+		return nil, fmt.Errorf("failed to parse aliases: %w", err)
+	}
+	if aliases == nil {
+		aliases = make(Aliases)
+	}
+
+	return aliases, nil
+}
+
+// Save writes aliases to disk.
+func Save(aliases Aliases) error {
+	if aliases == nil {
+		return fmt.Errorf("aliases cannot be nil")
+	}
+
+	aliasPath := Path()
+	if err := os.MkdirAll(filepath.Dir(aliasPath), 0o755); err != nil {
+		return fmt.Errorf("failed to create alias directory: %w", err)
+	}
+
+	data, err := yaml.Marshal(map[string]string(aliases))
+	if err != nil {
+		return fmt.Errorf("failed to encode aliases: %w", err)
+	}
+
+	if err := os.WriteFile(aliasPath, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write aliases: %w", err)
+	}
+
+	return nil
+}
+
+// Path returns the full path to the alias file.
+func Path() string {
+	return filepath.Join(xdg.ConfigHome, "cfstream", "aliases.yaml")
+}
+
+// ValidateName checks that an alias name is usable on the command line.
+func ValidateName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("alias name cannot be empty")
+	}
+	if strings.ContainsAny(name, " \t\n") {
+		return fmt.Errorf("alias name cannot contain whitespace: %q", name)
+	}
+	if strings.HasPrefix(name, "-") {
+		return fmt.Errorf("alias name cannot start with '-': %q", name)
+	}
+	return nil
+}
+
+// Resolve returns the UID for name if it is an alias, or name unchanged otherwise.
+func (a Aliases) Resolve(name string) string {
+	if uid, ok := a[name]; ok {
+		return uid
+	}
+	return name
+}
+
+// Names returns the sorted alias names.
+func (a Aliases) Names() []string {
+	names := make([]string, 0, len(a))
+	for name := range a {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,87 @@
+package alias
+
+import (
+	"os"
+	"testing"
+
+	"github.com/adrg/xdg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_Empty(t *testing.T) {
+	useTempConfig(t)
+
+	aliases, err := Load()
+	require.NoError(t, err)
+	assert.Empty(t, aliases)
+}
+
+func TestSaveAndLoad(t *testing.T) {
+	useTempConfig(t)
+
+	err := Save(Aliases{"intro-video": "5d5bc37ffcf54c9b82e996823bffbb81"})
+	require.NoError(t, err)
+
+	aliases, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, "5d5bc37ffcf54c9b82e996823bffbb81", aliases["intro-video"])
+}
+
+func TestSave_Nil(t *testing.T) {
+	err := Save(nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "aliases cannot be nil")
+}
+
+func TestResolve(t *testing.T) {
+	aliases := Aliases{"intro": "uid-123"}
+
+	assert.Equal(t, "uid-123", aliases.Resolve("intro"))
+	assert.Equal(t, "uid-456", aliases.Resolve("uid-456"))
+}
+
+func TestValidateName(t *testing.T) {
+	tests := []struct {
+		name    string
+		alias   string
+		wantErr bool
+	}{
+		{name: "simple", alias: "intro-video", wantErr: false},
+		{name: "empty", alias: "", wantErr: true},
+		{name: "whitespace", alias: "intro video", wantErr: true},
+		{name: "flag-like", alias: "--intro", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateName(tt.alias)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNames(t *testing.T) {
+	aliases := Aliases{"b": "2", "a": "1"}
+	assert.Equal(t, []string{"a", "b"}, aliases.Names())
+}
+
+// useTempConfig points XDG_CONFIG_HOME at a temporary directory for the test.
+func useTempConfig(t *testing.T) {
+	t.Helper()
+	oldXDGConfig := os.Getenv("XDG_CONFIG_HOME")
+	t.Cleanup(func() {
+		if oldXDGConfig != "" {
+			os.Setenv("XDG_CONFIG_HOME", oldXDGConfig)
+		} else {
+			os.Unsetenv("XDG_CONFIG_HOME")
+		}
+		xdg.Reload()
+	})
+	os.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	xdg.Reload()
+}
@@ -0,0 +1,415 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// topVideosQuery ranks videos by minutes viewed and by playback count.
+const topVideosQuery = `query TopVideos($accountTag: string!, $start: Date!, $end: Date!, $limit: uint64!) {
+  viewer {
+    accounts(filter: {accountTag: $accountTag}) {
+      minutes: streamMinutesViewedAdaptiveGroups(
+        filter: {date_geq: $start, date_leq: $end}
+        orderBy: [sum_minutesViewed_DESC]
+        limit: $limit
+      ) {
+        sum { minutesViewed }
+        dimensions { uid }
+      }
+      views: videoPlaybackEventsAdaptiveGroups(
+        filter: {date_geq: $start, date_leq: $end}
+        orderBy: [count_DESC]
+        limit: $limit
+      ) {
+        count
+        dimensions { uid }
+      }
+    }
+  }
+}`
+
+// viewSeriesQuery returns views and minutes viewed of one video grouped by a
+// time dimension, which is substituted for %[1]s.
+const viewSeriesQuery = `query ViewSeries($accountTag: string!, $uid: string!, $start: Time!, $end: Time!) {
+  viewer {
+    accounts(filter: {accountTag: $accountTag}) {
+      minutes: streamMinutesViewedAdaptiveGroups(
+        filter: {uid: $uid, datetime_geq: $start, datetime_lt: $end}
+        orderBy: [%[1]s_ASC]
+        limit: 10000
+      ) {
+        sum { minutesViewed }
+        dimensions { ts: %[1]s }
+      }
+      views: videoPlaybackEventsAdaptiveGroups(
+        filter: {uid: $uid, datetime_geq: $start, datetime_lt: $end}
+        orderBy: [%[1]s_ASC]
+        limit: 10000
+      ) {
+        count
+        dimensions { ts: %[1]s }
+      }
+    }
+  }
+}`
+
+// countryViewsQuery returns views and minutes viewed of one video per viewer country.
+const countryViewsQuery = `query CountryViews($accountTag: string!, $uid: string!, $start: Time!, $end: Time!) {
+  viewer {
+    accounts(filter: {accountTag: $accountTag}) {
+      minutes: streamMinutesViewedAdaptiveGroups(
+        filter: {uid: $uid, datetime_geq: $start, datetime_lt: $end}
+        limit: 10000
+      ) {
+        sum { minutesViewed }
+        dimensions { country: clientCountryName }
+      }
+      views: videoPlaybackEventsAdaptiveGroups(
+        filter: {uid: $uid, datetime_geq: $start, datetime_lt: $end}
+        limit: 10000
+      ) {
+        count
+        dimensions { country: clientCountryName }
+      }
+    }
+  }
+}`
+
+// analyticsDate is the date format used by GraphQL Analytics filters.
+const analyticsDate = "2006-01-02"
+
+// Analytics series intervals.
+const (
+	IntervalHour = "hour"
+	IntervalDay  = "day"
+	IntervalWeek = "week"
+)
+
+// TopVideos ranks videos by views or minutes viewed over a date range.
+func (c *ClientImpl) TopVideos(ctx context.Context, opts *AnalyticsOptions) ([]VideoStats, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("%w: analytics options cannot be nil", ErrInvalidInput)
+	}
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+
+	var data struct {
+		Viewer struct {
+			Accounts []struct {
+				Minutes []struct {
+					Sum struct {
+						MinutesViewed float64 `json:"minutesViewed"`
+					} `json:"sum"`
+					Dimensions struct {
+						UID string `json:"uid"`
+					} `json:"dimensions"`
+				} `json:"minutes"`
+				Views []struct {
+					Count      int64 `json:"count"`
+					Dimensions struct {
+						UID string `json:"uid"`
+					} `json:"dimensions"`
+				} `json:"views"`
+			} `json:"accounts"`
+		} `json:"viewer"`
+	}
+	variables := map[string]interface{}{
+		"accountTag": c.accountID,
+		"start":      opts.Since.UTC().Format(analyticsDate),
+		"end":        opts.until().UTC().Format(analyticsDate),
+		"limit":      limit,
+	}
+	if err := c.graphql(ctx, topVideosQuery, variables, &data); err != nil {
+		return nil, err
+	}
+
+	// Merge both rankings so every video has views and minutes
+	byUID := make(map[string]*VideoStats)
+	stats := func(uid string) *VideoStats {
+		if s, ok := byUID[uid]; ok {
+			return s
+		}
+		s := &VideoStats{UID: uid}
+		byUID[uid] = s
+		return s
+	}
+	for _, account := range data.Viewer.Accounts {
+		for _, g := range account.Minutes {
+			stats(g.Dimensions.UID).MinutesViewed = g.Sum.MinutesViewed
+		}
+		for _, g := range account.Views {
+			stats(g.Dimensions.UID).Views = g.Count
+		}
+	}
+
+	result := make([]VideoStats, 0, len(byUID))
+	for _, s := range byUID {
+		result = append(result, *s)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if opts.OrderBy == OrderByMinutes && a.MinutesViewed != b.MinutesViewed {
+			return a.MinutesViewed > b.MinutesViewed
+		}
+		if a.Views != b.Views {
+			return a.Views > b.Views
+		}
+		if a.MinutesViewed != b.MinutesViewed {
+			return a.MinutesViewed > b.MinutesViewed
+		}
+		return a.UID < b.UID
+	})
+	if len(result) > limit {
+		result = result[:limit]
+	}
+
+	return result, nil
+}
+
+// ViewSeries returns views and minutes viewed of a video per interval.
+// Intervals without views are included with zero values.
+func (c *ClientImpl) ViewSeries(ctx context.Context, videoID string, opts *AnalyticsOptions) ([]SeriesPoint, error) {
+	if videoID == "" {
+		return nil, fmt.Errorf("%w: video ID cannot be empty", ErrInvalidInput)
+	}
+	if opts == nil {
+		return nil, fmt.Errorf("%w: analytics options cannot be nil", ErrInvalidInput)
+	}
+
+	// Weeks are aggregated from days
+	dimension := "date"
+	switch opts.Interval {
+	case IntervalHour:
+		dimension = "datetimeHour"
+	case "", IntervalDay, IntervalWeek:
+	default:
+		return nil, fmt.Errorf("%w: unsupported interval %q", ErrInvalidInput, opts.Interval)
+	}
+
+	var data struct {
+		Viewer struct {
+			Accounts []struct {
+				Minutes []struct {
+					Sum struct {
+						MinutesViewed float64 `json:"minutesViewed"`
+					} `json:"sum"`
+					Dimensions struct {
+						TS string `json:"ts"`
+					} `json:"dimensions"`
+				} `json:"minutes"`
+				Views []struct {
+					Count      int64 `json:"count"`
+					Dimensions struct {
+						TS string `json:"ts"`
+					} `json:"dimensions"`
+				} `json:"views"`
+			} `json:"accounts"`
+		} `json:"viewer"`
+	}
+	variables := map[string]interface{}{
+		"accountTag": c.accountID,
+		"uid":        videoID,
+		"start":      opts.Since.UTC().Format(time.RFC3339),
+		"end":        opts.until().UTC().Format(time.RFC3339),
+	}
+	if err := c.graphql(ctx, fmt.Sprintf(viewSeriesQuery, dimension), variables, &data); err != nil {
+		return nil, err
+	}
+
+	// Lay out empty buckets first so quiet intervals show up as zeros
+	var series []SeriesPoint
+	index := make(map[time.Time]int)
+	for t := bucket(opts.Since, opts.Interval); t.Before(opts.until()); t = nextBucket(t, opts.Interval) {
+		index[t] = len(series)
+		series = append(series, SeriesPoint{Time: t})
+	}
+	point := func(ts string) *SeriesPoint {
+		t, err := time.Parse(time.RFC3339, ts)
+		if err != nil {
+			if t, err = time.Parse(analyticsDate, ts); err != nil {
+				return nil
+			}
+		}
+		i, ok := index[bucket(t, opts.Interval)]
+		if !ok {
+			return nil
+		}
+		return &series[i]
+	}
+
+	for _, account := range data.Viewer.Accounts {
+		for _, g := range account.Minutes {
+			if p := point(g.Dimensions.TS); p != nil {
+				p.MinutesViewed += g.Sum.MinutesViewed
+			}
+		}
+		for _, g := range account.Views {
+			if p := point(g.Dimensions.TS); p != nil {
+				p.Views += g.Count
+			}
+		}
+	}
+
+	return series, nil
+}
+
+// CountryViews returns views and minutes viewed of a video per viewer
+// country, ordered by views. Countries are ISO 3166-1 alpha-2 codes.
+func (c *ClientImpl) CountryViews(ctx context.Context, videoID string, opts *AnalyticsOptions) ([]CountryStats, error) {
+	if videoID == "" {
+		return nil, fmt.Errorf("%w: video ID cannot be empty", ErrInvalidInput)
+	}
+	if opts == nil {
+		return nil, fmt.Errorf("%w: analytics options cannot be nil", ErrInvalidInput)
+	}
+
+	var data struct {
+		Viewer struct {
+			Accounts []struct {
+				Minutes []struct {
+					Sum struct {
+						MinutesViewed float64 `json:"minutesViewed"`
+					} `json:"sum"`
+					Dimensions struct {
+						Country string `json:"country"`
+					} `json:"dimensions"`
+				} `json:"minutes"`
+				Views []struct {
+					Count      int64 `json:"count"`
+					Dimensions struct {
+						Country string `json:"country"`
+					} `json:"dimensions"`
+				} `json:"views"`
+			} `json:"accounts"`
+		} `json:"viewer"`
+	}
+	variables := map[string]interface{}{
+		"accountTag": c.accountID,
+		"uid":        videoID,
+		"start":      opts.Since.UTC().Format(time.RFC3339),
+		"end":        opts.until().UTC().Format(time.RFC3339),
+	}
+	if err := c.graphql(ctx, countryViewsQuery, variables, &data); err != nil {
+		return nil, err
+	}
+
+	byCountry := make(map[string]*CountryStats)
+	stats := func(country string) *CountryStats {
+		if s, ok := byCountry[country]; ok {
+			return s
+		}
+		s := &CountryStats{Country: country}
+		byCountry[country] = s
+		return s
+	}
+	for _, account := range data.Viewer.Accounts {
+		for _, g := range account.Minutes {
+			stats(g.Dimensions.Country).MinutesViewed += g.Sum.MinutesViewed
+		}
+		for _, g := range account.Views {
+			stats(g.Dimensions.Country).Views += g.Count
+		}
+	}
+
+	result := make([]CountryStats, 0, len(byCountry))
+	for _, s := range byCountry {
+		result = append(result, *s)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.Views != b.Views {
+			return a.Views > b.Views
+		}
+		if a.MinutesViewed != b.MinutesViewed {
+			return a.MinutesViewed > b.MinutesViewed
+		}
+		return a.Country < b.Country
+	})
+
+	return result, nil
+}
+
+// bucket truncates t to the start of its interval in UTC. Weeks start on Monday.
+func bucket(t time.Time, interval string) time.Time {
+	t = t.UTC()
+	switch interval {
+	case IntervalHour:
+		return t.Truncate(time.Hour)
+	case IntervalWeek:
+		day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	default:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	}
+}
+
+// nextBucket returns the start of the interval after t.
+func nextBucket(t time.Time, interval string) time.Time {
+	switch interval {
+	case IntervalHour:
+		return t.Add(time.Hour)
+	case IntervalWeek:
+		return t.AddDate(0, 0, 7)
+	default:
+		return t.AddDate(0, 0, 1)
+	}
+}
+
+// until returns the end of the analytics range, defaulting to now.
+func (o *AnalyticsOptions) until() time.Time {
+	if o.Until.IsZero() {
+		return time.Now()
+	}
+	return o.Until
+}
+
+// graphql sends a query to the GraphQL Analytics API and decodes its data into result.
+func (c *ClientImpl) graphql(ctx context.Context, query string, variables map[string]interface{}, result interface{}) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"query":     query,
+		"variables": variables,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/graphql", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	respBody, err := c.execute(req)
+	if err != nil {
+		return err
+	}
+
+	var resp struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	if len(resp.Errors) > 0 {
+		return fmt.Errorf("GraphQL error: %s", resp.Errors[0].Message)
+	}
+
+	if result != nil && len(resp.Data) > 0 {
+		if err := json.Unmarshal(resp.Data, result); err != nil {
+			return fmt.Errorf("failed to parse response: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTopVideos(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/graphql", r.URL.Path)
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+
+		var body struct {
+			Query     string                 `json:"query"`
+			Variables map[string]interface{} `json:"variables"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Contains(t, body.Query, "streamMinutesViewedAdaptiveGroups")
+		assert.Equal(t, "acct", body.Variables["accountTag"])
+		assert.Equal(t, "2024-01-01", body.Variables["start"])
+		assert.Equal(t, "2024-01-31", body.Variables["end"])
+
+		w.Write([]byte(`{"data":{"viewer":{"accounts":[{
+			"minutes":[{"sum":{"minutesViewed":500},"dimensions":{"uid":"a"}},{"sum":{"minutesViewed":90},"dimensions":{"uid":"b"}}],
+			"views":[{"count":40,"dimensions":{"uid":"b"}},{"count":10,"dimensions":{"uid":"a"}},{"count":5,"dimensions":{"uid":"c"}}]
+		}]}}}`)) //nolint:errcheck // Test server
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv)
+	opts := &AnalyticsOptions{
+		Since: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Until: time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+		Limit: 2,
+	}
+
+	stats, err := client.TopVideos(context.Background(), opts)
+	require.NoError(t, err)
+	assert.Equal(t, []VideoStats{
+		{UID: "b", Views: 40, MinutesViewed: 90},
+		{UID: "a", Views: 10, MinutesViewed: 500},
+	}, stats)
+
+	opts.OrderBy = OrderByMinutes
+	stats, err = client.TopVideos(context.Background(), opts)
+	require.NoError(t, err)
+	require.Len(t, stats, 2)
+	assert.Equal(t, "a", stats[0].UID)
+}
+
+func TestViewSeries(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query     string                 `json:"query"`
+			Variables map[string]interface{} `json:"variables"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Contains(t, body.Query, "ts: date")
+		assert.Equal(t, "abc", body.Variables["uid"])
+
+		w.Write([]byte(`{"data":{"viewer":{"accounts":[{
+			"minutes":[{"sum":{"minutesViewed":12.5},"dimensions":{"ts":"2024-01-02"}}],
+			"views":[{"count":3,"dimensions":{"ts":"2024-01-02"}},{"count":1,"dimensions":{"ts":"2024-01-03"}}]
+		}]}}}`)) //nolint:errcheck // Test server
+	}))
+	defer srv.Close()
+
+	series, err := newTestClient(t, srv).ViewSeries(context.Background(), "abc", &AnalyticsOptions{
+		Since:    time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Until:    time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC),
+		Interval: IntervalDay,
+	})
+	require.NoError(t, err)
+	require.Len(t, series, 3)
+	assert.Equal(t, SeriesPoint{Time: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}, series[0])
+	assert.Equal(t, int64(3), series[1].Views)
+	assert.Equal(t, 12.5, series[1].MinutesViewed)
+	assert.Equal(t, int64(1), series[2].Views)
+}
+
+func TestCountryViews(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query     string                 `json:"query"`
+			Variables map[string]interface{} `json:"variables"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Contains(t, body.Query, "clientCountryName")
+		assert.Equal(t, "abc", body.Variables["uid"])
+		assert.Equal(t, "2024-01-01T00:00:00Z", body.Variables["start"])
+
+		w.Write([]byte(`{"data":{"viewer":{"accounts":[{
+			"minutes":[{"sum":{"minutesViewed":30},"dimensions":{"country":"US"}},{"sum":{"minutesViewed":8},"dimensions":{"country":"DE"}}],
+			"views":[{"count":4,"dimensions":{"country":"DE"}},{"count":4,"dimensions":{"country":"US"}},{"count":1,"dimensions":{"country":"FR"}}]
+		}]}}}`)) //nolint:errcheck // Test server
+	}))
+	defer srv.Close()
+
+	stats, err := newTestClient(t, srv).CountryViews(context.Background(), "abc", &AnalyticsOptions{
+		Since: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Until: time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []CountryStats{
+		{Country: "US", Views: 4, MinutesViewed: 30},
+		{Country: "DE", Views: 4, MinutesViewed: 8},
+		{Country: "FR", Views: 1},
+	}, stats)
+}
+
+func TestBucket(t *testing.T) {
+	ts := time.Date(2024, 1, 4, 15, 30, 0, 0, time.UTC) // Thursday
+	assert.Equal(t, time.Date(2024, 1, 4, 15, 0, 0, 0, time.UTC), bucket(ts, IntervalHour))
+	assert.Equal(t, time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC), bucket(ts, IntervalDay))
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), bucket(ts, IntervalWeek))
+}
+
+func TestGraphQLErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data":null,"errors":[{"message":"unknown field"}]}`)) //nolint:errcheck // Test server
+	}))
+	defer srv.Close()
+
+	_, err := newTestClient(t, srv).TopVideos(context.Background(), &AnalyticsOptions{Since: time.Now()})
+	assert.EqualError(t, err, "GraphQL error: unknown field")
+}
@@ -4,13 +4,14 @@ import (
 	"bytes"
 	"context"
 	"encoding/base64"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
 	"net/http"
+	"net/url"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -24,6 +25,10 @@ type Client interface {
 	// ListVideos retrieves a list of videos with optional filtering.
 	ListVideos(ctx context.Context, opts *ListOptions) ([]Video, error)
 
+	// ListVideosPager returns a pager over all videos matching opts, for
+	// libraries larger than one page.
+	ListVideosPager(opts *ListOptions) *VideoPager
+
 	// GetVideo retrieves details for a specific video by ID.
 	GetVideo(ctx context.Context, videoID string) (*Video, error)
 
@@ -36,28 +41,152 @@ type Client interface {
 	// GetSignedToken generates a signed token for a video.
 	GetSignedToken(ctx context.Context, videoID string, duration int64) (string, error)
 
+	// GetSignedTokenWithOptions generates a signed token with additional claims.
+	GetSignedTokenWithOptions(ctx context.Context, videoID string, opts *TokenOptions) (string, error)
+
 	// GetEmbedCode returns the HTML embed code for a video.
 	GetEmbedCode(ctx context.Context, videoID string, opts *EmbedOptions) (string, error)
 
 	// UploadFile uploads a video file using multipart/form-data.
 	UploadFile(ctx context.Context, filePath string, opts *UploadOptions, progressCh chan<- UploadProgress) (*Video, error)
 
+	// ResumeUpload continues an interrupted TUS upload of a file.
+	ResumeUpload(ctx context.Context, upload *ResumableUpload, filePath string, progressCh chan<- UploadProgress) (*Video, error)
+
 	// UploadFromURL uploads a video from a URL.
 	UploadFromURL(ctx context.Context, url string, opts *UploadOptions) (*Video, error)
 
+	// CreateClip creates a new video from part of an existing one.
+	CreateClip(ctx context.Context, videoID string, opts *ClipOptions) (*Video, error)
+
 	// CreateDirectUploadURL generates a direct upload URL for end users.
 	CreateDirectUploadURL(ctx context.Context, opts *DirectUploadOptions) (*DirectUploadResult, error)
+
+	// GetDownloads returns the MP4 download renditions of a video.
+	GetDownloads(ctx context.Context, videoID string) (*Downloads, error)
+
+	// EnableDownloads starts generating the MP4 download of a video.
+	EnableDownloads(ctx context.Context, videoID string) (*Downloads, error)
+
+	// UploadCaption uploads a WebVTT caption file for a language.
+	UploadCaption(ctx context.Context, videoID, language, filePath string) (*Caption, error)
+
+	// GetWatermark retrieves a watermark profile by UID.
+	GetWatermark(ctx context.Context, uid string) (*Watermark, error)
+
+	// ListCaptions lists the caption tracks of a video.
+	ListCaptions(ctx context.Context, videoID string) ([]Caption, error)
+
+	// DownloadCaption returns the WebVTT file of a caption track.
+	DownloadCaption(ctx context.Context, videoID, language string) ([]byte, error)
+
+	// DeleteCaption removes the caption track of a language.
+	DeleteCaption(ctx context.Context, videoID, language string) error
+
+	// GenerateCaption starts generating captions for a language from the
+	// video's audio. The returned track is usually still in progress.
+	GenerateCaption(ctx context.Context, videoID, language string) (*Caption, error)
+
+	// ListAudioTracks lists the additional audio tracks of a video.
+	ListAudioTracks(ctx context.Context, videoID string) ([]AudioTrack, error)
+
+	// SetDefaultAudioTrack makes an audio track the one the player uses by default.
+	SetDefaultAudioTrack(ctx context.Context, videoID, trackID string) (*AudioTrack, error)
+
+	// GetLiveInput retrieves details for a specific live input by ID.
+	GetLiveInput(ctx context.Context, inputID string) (*LiveInput, error)
+
+	// CreateLiveInput creates a new live input.
+	CreateLiveInput(ctx context.Context, opts *LiveInputOptions) (*LiveInput, error)
+
+	// UpdateLiveInput updates the settings of a live input.
+	UpdateLiveInput(ctx context.Context, inputID string, opts *LiveInputOptions) (*LiveInput, error)
+
+	// ListLiveInputVideos retrieves the videos recorded from a live input.
+	ListLiveInputVideos(ctx context.Context, inputID string) ([]Video, error)
+
+	// TopVideos ranks videos by views or minutes viewed over a date range.
+	TopVideos(ctx context.Context, opts *AnalyticsOptions) ([]VideoStats, error)
+
+	// GetStorageUsage returns the storage minutes used by the account.
+	GetStorageUsage(ctx context.Context) (*StorageUsage, error)
+
+	// SetWebhook subscribes notificationURL to video notifications,
+	// replacing any existing subscription.
+	SetWebhook(ctx context.Context, notificationURL string) (*Webhook, error)
+
+	// GetWebhook returns the current webhook subscription.
+	GetWebhook(ctx context.Context) (*Webhook, error)
+
+	// DeleteWebhook removes the webhook subscription.
+	DeleteWebhook(ctx context.Context) error
+
+	// CreateSigningKey creates a key for signing tokens locally. The private
+	// key is only returned by this call.
+	CreateSigningKey(ctx context.Context) (*SigningKey, error)
+
+	// ListSigningKeys lists the account's signing keys without their private keys.
+	ListSigningKeys(ctx context.Context) ([]SigningKey, error)
+
+	// DeleteSigningKey deletes a signing key, invalidating the tokens it signed.
+	DeleteSigningKey(ctx context.Context, keyID string) error
+
+	// ViewSeries returns views and minutes viewed of a video per interval.
+	ViewSeries(ctx context.Context, videoID string, opts *AnalyticsOptions) ([]SeriesPoint, error)
+
+	// CountryViews returns views and minutes viewed of a video per viewer country.
+	CountryViews(ctx context.Context, videoID string, opts *AnalyticsOptions) ([]CountryStats, error)
 }
 
+// apiBaseURL is the base URL of the Cloudflare v4 API.
+const apiBaseURL = "https://api.cloudflare.com/client/v4"
+
 // ClientImpl implements the Client interface using the Cloudflare SDK.
 type ClientImpl struct {
-	sdk       *cloudflare.Client
-	accountID string
-	apiToken  string
+	sdk        *cloudflare.Client
+	accountID  string
+	apiToken   string
+	baseURL    string
+	httpClient *http.Client
+	dryRun     io.Writer
+	curl       io.Writer
+
+	waitOnRateLimit bool
+	rateLimitWait   func(wait time.Duration)
+
+	// tusBackoff is the wait before the first retry of a failed TUS chunk
+	tusBackoff time.Duration
+}
+
+// Option configures optional client behavior.
+type Option func(*ClientImpl)
+
+// WithBaseURL overrides the Cloudflare API base URL.
+// It is mainly useful for pointing the client at a test server.
+func WithBaseURL(baseURL string) Option {
+	return func(c *ClientImpl) {
+		c.baseURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// WithHTTPClient sets the HTTP client used for all requests.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *ClientImpl) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithDryRun makes the client describe mutating requests on w instead of
+// sending them. Mutating methods then return ErrDryRun. Requests that don't
+// change account state, such as reads and signed token creation, are still sent.
+func WithDryRun(w io.Writer) Option {
+	return func(c *ClientImpl) {
+		c.dryRun = w
+	}
 }
 
 // NewClient creates a new Cloudflare Stream API client.
-func NewClient(accountID, apiToken string) (Client, error) {
+func NewClient(accountID, apiToken string, opts ...Option) (Client, error) {
 	if accountID == "" {
 		return nil, fmt.Errorf("account ID is required")
 	}
@@ -65,50 +194,159 @@ func NewClient(accountID, apiToken string) (Client, error) {
 		return nil, fmt.Errorf("API token is required")
 	}
 
-	sdk := cloudflare.NewClient(
+	c := newClientImpl(accountID, apiToken, opts)
+	c.sdk = cloudflare.NewClient(
 		option.WithAPIToken(apiToken),
+		option.WithBaseURL(c.baseURL+"/"),
+		option.WithHTTPClient(c.httpClient),
 	)
 
-	return &ClientImpl{
-		sdk:       sdk,
-		accountID: accountID,
-		apiToken:  apiToken,
-	}, nil
+	return c, nil
+}
+
+// newClientImpl applies opts to a client without the SDK. Requests carry no
+// Authorization header when apiToken is empty.
+func newClientImpl(accountID, apiToken string, opts []Option) *ClientImpl {
+	c := &ClientImpl{
+		accountID:  accountID,
+		apiToken:   apiToken,
+		baseURL:    apiBaseURL,
+		httpClient: &http.Client{},
+		tusBackoff: time.Second,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	// Curl commands are printed per attempt, so rate-limit retries show up too
+	if c.curl != nil {
+		transport := c.httpClient.Transport
+		if transport == nil {
+			transport = http.DefaultTransport
+		}
+		httpClient := *c.httpClient
+		httpClient.Transport = &curlTransport{next: transport, w: c.curl}
+		c.httpClient = &httpClient
+	}
+
+	if c.waitOnRateLimit {
+		transport := c.httpClient.Transport
+		if transport == nil {
+			transport = http.DefaultTransport
+		}
+		httpClient := *c.httpClient
+		httpClient.Transport = &rateLimitTransport{next: transport, notify: c.rateLimitWait}
+		c.httpClient = &httpClient
+	}
+
+	return c
 }
 
-// ListVideos retrieves a list of videos with optional filtering.
+// ListVideos retrieves a page of videos with optional filtering.
 func (c *ClientImpl) ListVideos(ctx context.Context, opts *ListOptions) ([]Video, error) {
+	videos, _, err := c.listPage(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	if opts != nil && opts.Limit > 0 && len(videos) > opts.Limit {
+		videos = videos[:opts.Limit]
+	}
+	return videos, nil
+}
+
+// ListVideosPager returns a pager over all videos matching opts.
+func (c *ClientImpl) ListVideosPager(opts *ListOptions) *VideoPager {
+	return newVideoPager(c.listPage, opts)
+}
+
+// listPage fetches one page of videos after opts.After and reports whether
+// the API returned a full page, so more videos may follow.
+func (c *ClientImpl) listPage(ctx context.Context, opts *ListOptions) ([]Video, bool, error) {
 	params := stream.StreamListParams{
 		AccountID: cloudflare.F(c.accountID),
 	}
+	if opts == nil {
+		opts = &ListOptions{}
+	}
 
-	// Apply options if provided
-	if opts != nil {
-		if opts.Search != "" {
-			params.Search = cloudflare.F(opts.Search)
-		}
-		if opts.Creator != "" {
-			params.Creator = cloudflare.F(opts.Creator)
-		}
-		if opts.Start != nil {
-			params.Start = cloudflare.F(*opts.Start)
-		}
-		if opts.End != nil {
-			params.End = cloudflare.F(*opts.End)
+	if opts.Search != "" {
+		params.Search = cloudflare.F(opts.Search)
+	}
+	if opts.Creator != "" {
+		params.Creator = cloudflare.F(opts.Creator)
+	}
+	if opts.Start != nil {
+		params.Start = cloudflare.F(*opts.Start)
+	}
+	if opts.End != nil {
+		params.End = cloudflare.F(*opts.End)
+	}
+	if opts.Asc {
+		params.Asc = cloudflare.F(true)
+	}
+
+	status := strings.ToLower(opts.Status)
+	switch status {
+	case "", StatusProcessing:
+	case string(stream.StreamListParamsStatusPendingupload), string(stream.StreamListParamsStatusDownloading),
+		string(stream.StreamListParamsStatusQueued), string(stream.StreamListParamsStatusInprogress),
+		string(stream.StreamListParamsStatusReady), string(stream.StreamListParamsStatusError):
+		params.Status = cloudflare.F(stream.StreamListParamsStatus(status))
+	default:
+		return nil, false, fmt.Errorf("%w: unknown status %q (ready, processing, error, pendingupload, downloading, queued, inprogress)", ErrInvalidInput, opts.Status)
+	}
+
+	// The API takes whole seconds, so the page starts at the cursor's second
+	// and the videos up to the cursor are dropped afterwards
+	var cursor time.Time
+	if opts.After != "" {
+		var err error
+		if cursor, err = parseCursor(opts.After); err != nil {
+			return nil, false, err
 		}
 		if opts.Asc {
-			params.Asc = cloudflare.F(true)
+			params.Start = cloudflare.F(cursor.Truncate(time.Second))
+		} else {
+			params.End = cloudflare.F(cursor.Truncate(time.Second).Add(time.Second))
 		}
 	}
 
 	page, err := c.sdk.Stream.List(ctx, params)
 	if err != nil {
-		return nil, WrapError(err)
+		return nil, false, WrapError(err)
 	}
 
-	// Extract videos from page
-	videos := page.Result
-	return VideosFromSDK(videos), nil
+	videos := VideosFromSDK(page.Result)
+	full := len(page.Result) >= listPageSize
+	if opts.After != "" {
+		videos = afterCursor(videos, cursor, opts.Asc)
+	}
+
+	// Processing spans several states the API can't filter on at once, and
+	// the other statuses are checked again in case the API ignored them
+	if status != "" {
+		kept := videos[:0]
+		for _, v := range videos {
+			if matchesStatus(v.Status, status) {
+				kept = append(kept, v)
+			}
+		}
+		videos = kept
+	}
+	return videos, full, nil
+}
+
+// StatusProcessing is the ListOptions.Status of videos that are still being
+// uploaded or encoded, whatever their exact state.
+const StatusProcessing = "processing"
+
+// matchesStatus reports whether a video in state matches a status filter.
+func matchesStatus(state, status string) bool {
+	state = strings.ToLower(state)
+	if status == StatusProcessing {
+		return state != string(stream.StreamListParamsStatusReady) && state != string(stream.StreamListParamsStatusError)
+	}
+	return state == status
 }
 
 // GetVideo retrieves details for a specific video by ID.
@@ -135,6 +373,11 @@ func (c *ClientImpl) DeleteVideo(ctx context.Context, videoID string) error {
 		return fmt.Errorf("%w: video ID cannot be empty", ErrInvalidInput)
 	}
 
+	if c.dryRun != nil {
+		c.plan(http.MethodDelete, c.accountURL("stream/%s", videoID), nil, nil)
+		return ErrDryRun
+	}
+
 	params := stream.StreamDeleteParams{
 		AccountID: cloudflare.F(c.accountID),
 	}
@@ -164,129 +407,52 @@ func (c *ClientImpl) UpdateVideo(ctx context.Context, videoID string, opts *Upda
 	if opts.RequireSignedURLs != nil {
 		body["requireSignedURLs"] = *opts.RequireSignedURLs
 	}
-
-	jsonBody, err := json.Marshal(body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request body: %w", err)
-	}
-
-	// Make direct HTTP request to update video
-	url := fmt.Sprintf("https://api.cloudflare.com/client/v4/accounts/%s/stream/%s", c.accountID, videoID)
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(jsonBody))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("Authorization", "Bearer "+c.apiToken)
-	req.Header.Set("Content-Type", "application/json")
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to execute request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(respBody))
-	}
-
-	// Parse response
-	var apiResp struct {
-		Result  stream.Video `json:"result"`
-		Success bool         `json:"success"`
-		Errors  []struct {
-			Code    int    `json:"code"`
-			Message string `json:"message"`
-		} `json:"errors"`
-	}
-
-	if err := json.Unmarshal(respBody, &apiResp); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+	if opts.AllowedOrigins != nil {
+		body["allowedOrigins"] = opts.AllowedOrigins
 	}
 
-	if !apiResp.Success {
-		if len(apiResp.Errors) > 0 {
-			return nil, fmt.Errorf("API error: %s", apiResp.Errors[0].Message)
-		}
-		return nil, fmt.Errorf("API request failed")
+	var video stream.Video
+	if err := c.mutate(ctx, http.MethodPost, c.accountURL("stream/%s", videoID), body, &video); err != nil {
+		return nil, err
 	}
 
-	return VideoFromSDK(&apiResp.Result), nil
+	return VideoFromSDK(&video), nil
 }
 
 // GetSignedToken generates a signed token for a video.
 func (c *ClientImpl) GetSignedToken(ctx context.Context, videoID string, duration int64) (string, error) {
+	return c.GetSignedTokenWithOptions(ctx, videoID, &TokenOptions{Expires: duration})
+}
+
+// GetSignedTokenWithOptions generates a signed token with additional claims.
+func (c *ClientImpl) GetSignedTokenWithOptions(ctx context.Context, videoID string, opts *TokenOptions) (string, error) {
 	if videoID == "" {
 		return "", fmt.Errorf("%w: video ID cannot be empty", ErrInvalidInput)
 	}
-
-	// Build request body with expiration time
-	body := make(map[string]interface{})
-	if duration > 0 {
-		body["exp"] = duration
-	}
-
-	jsonBody, err := json.Marshal(body)
-	if err != nil {
-		return "", fmt.Errorf("failed to marshal request body: %w", err)
-	}
-
-	// Make HTTP request to create token
-	url := fmt.Sprintf("https://api.cloudflare.com/client/v4/accounts/%s/stream/%s/token", c.accountID, videoID)
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(jsonBody))
-	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("Authorization", "Bearer "+c.apiToken)
-	req.Header.Set("Content-Type", "application/json")
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return "", fmt.Errorf("failed to execute request: %w", err)
+	if opts == nil {
+		opts = &TokenOptions{}
 	}
-	defer resp.Body.Close()
 
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", fmt.Errorf("failed to read response: %w", err)
+	// Build request body with expiration time and claims
+	body := make(map[string]interface{})
+	if opts.Expires > 0 {
+		body["exp"] = opts.Expires
 	}
-
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(respBody))
+	if opts.Downloadable {
+		body["downloadable"] = true
 	}
-
-	// Parse response
-	var apiResp struct {
-		Result struct {
-			Token string `json:"token"`
-		} `json:"result"`
-		Success bool `json:"success"`
-		Errors  []struct {
-			Code    int    `json:"code"`
-			Message string `json:"message"`
-		} `json:"errors"`
+	if len(opts.AccessRules) > 0 {
+		body["accessRules"] = opts.AccessRules
 	}
 
-	if err := json.Unmarshal(respBody, &apiResp); err != nil {
-		return "", fmt.Errorf("failed to parse response: %w", err)
+	var result struct {
+		Token string `json:"token"`
 	}
-
-	if !apiResp.Success {
-		if len(apiResp.Errors) > 0 {
-			return "", fmt.Errorf("API error: %s", apiResp.Errors[0].Message)
-		}
-		return "", fmt.Errorf("API request failed")
+	if err := c.doJSON(ctx, http.MethodPost, c.accountURL("stream/%s/token", videoID), body, &result); err != nil {
+		return "", err
 	}
 
-	return apiResp.Result.Token, nil
+	return result.Token, nil
 }
 
 // GetEmbedCode returns the HTML embed code for a video.
@@ -307,7 +473,48 @@ func (c *ClientImpl) GetEmbedCode(ctx context.Context, videoID string, opts *Emb
 		return "", fmt.Errorf("failed to extract customer code: %w", err)
 	}
 
-	// Build iframe URL with query parameters
+	return EmbedHTML(customerCode, videoID, opts), nil
+}
+
+// EmbedHTML builds the iframe embed code for a video or live input.
+func EmbedHTML(customerCode, videoID string, opts *EmbedOptions) string {
+	iframeURL := EmbedURL(customerCode, videoID, opts)
+
+	width, height := 1280, 720
+	if opts != nil && opts.Width > 0 {
+		width = opts.Width
+	}
+	if opts != nil && opts.Height > 0 {
+		height = opts.Height
+	}
+
+	// Build iframe HTML
+	style := "border: none;"
+	if opts != nil && opts.Responsive {
+		// Responsive style with the aspect ratio of the requested size (16:9 by default)
+		return fmt.Sprintf(`<div style="position: relative; padding-top: %s%%;">
+  <iframe
+    src="%s"
+    style="border: none; position: absolute; top: 0; left: 0; height: 100%%; width: 100%%;"
+    allow="accelerometer; gyroscope; autoplay; encrypted-media; picture-in-picture;"
+    allowfullscreen="true">
+  </iframe>
+</div>`, strconv.FormatFloat(float64(height)*100/float64(width), 'f', -1, 64), iframeURL)
+	}
+
+	return fmt.Sprintf(`<iframe
+  src="%s"
+  style="%s"
+  height="%d"
+  width="%d"
+  allow="accelerometer; gyroscope; autoplay; encrypted-media; picture-in-picture;"
+  allowfullscreen="true">
+</iframe>`, iframeURL, style, height, width)
+}
+
+// EmbedURL builds the Stream player iframe URL for a video with the player
+// options from opts encoded as query parameters.
+func EmbedURL(customerCode, videoID string, opts *EmbedOptions) string {
 	iframeURL := fmt.Sprintf("https://customer-%s.cloudflarestream.com/%s/iframe", customerCode, videoID)
 
 	queryParams := make([]string, 0)
@@ -328,34 +535,27 @@ func (c *ClientImpl) GetEmbedCode(ctx context.Context, videoID string, opts *Emb
 		if !opts.Controls {
 			queryParams = append(queryParams, "controls=false")
 		}
+
+		optional := []struct{ key, value string }{
+			{"poster", opts.Poster},
+			{"preload", opts.Preload},
+			{"primaryColor", opts.PrimaryColor},
+			{"letterboxColor", opts.LetterboxColor},
+			{"startTime", opts.StartTime},
+			{"defaultTextTrack", opts.DefaultTextTrack},
+		}
+		for _, p := range optional {
+			if p.value != "" {
+				queryParams = append(queryParams, p.key+"="+url.QueryEscape(p.value))
+			}
+		}
 	}
 
 	if len(queryParams) > 0 {
 		iframeURL += "?" + strings.Join(queryParams, "&")
 	}
 
-	// Build iframe HTML
-	style := "border: none;"
-	if opts != nil && opts.Responsive {
-		// Responsive style with 16:9 aspect ratio
-		return fmt.Sprintf(`<div style="position: relative; padding-top: 56.25%%;">
-  <iframe
-    src="%s"
-    style="border: none; position: absolute; top: 0; left: 0; height: 100%%; width: 100%%;"
-    allow="accelerometer; gyroscope; autoplay; encrypted-media; picture-in-picture;"
-    allowfullscreen="true">
-  </iframe>
-</div>`, iframeURL), nil
-	}
-
-	return fmt.Sprintf(`<iframe
-  src="%s"
-  style="%s"
-  height="720"
-  width="1280"
-  allow="accelerometer; gyroscope; autoplay; encrypted-media; picture-in-picture;"
-  allowfullscreen="true">
-</iframe>`, iframeURL, style), nil
+	return iframeURL
 }
 
 // extractCustomerCode extracts the customer code from a preview URL.
@@ -391,83 +591,370 @@ func (c *ClientImpl) CreateDirectUploadURL(ctx context.Context, opts *DirectUplo
 		opts = &DirectUploadOptions{}
 	}
 
-	// Build request body
-	body := make(map[string]interface{})
-	if opts.MaxDurationSeconds > 0 {
-		body["maxDurationSeconds"] = opts.MaxDurationSeconds
+	var apiResult struct {
+		UploadURL string `json:"uploadURL"`
+		UID       string `json:"uid"`
+	}
+	if err := c.mutate(ctx, http.MethodPost, c.accountURL("stream/direct_upload"), directUploadBody(opts), &apiResult); err != nil {
+		return nil, err
+	}
+
+	result := &DirectUploadResult{
+		UploadURL: apiResult.UploadURL,
+		UID:       apiResult.UID,
 	}
+
 	if opts.Expiry != nil {
-		body["expiry"] = opts.Expiry.Format(time.RFC3339)
+		result.Expiry = *opts.Expiry
 	}
-	if opts.RequireSignedURLs {
-		body["requireSignedURLs"] = true
+
+	return result, nil
+}
+
+// GetDownloads returns the MP4 download renditions of a video.
+// Default is nil when downloads have not been enabled.
+func (c *ClientImpl) GetDownloads(ctx context.Context, videoID string) (*Downloads, error) {
+	if videoID == "" {
+		return nil, fmt.Errorf("%w: video ID cannot be empty", ErrInvalidInput)
 	}
 
-	jsonBody, err := json.Marshal(body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request body: %w", err)
+	var downloads Downloads
+	if err := c.doJSON(ctx, http.MethodGet, c.accountURL("stream/%s/downloads", videoID), nil, &downloads); err != nil {
+		return nil, err
+	}
+
+	return &downloads, nil
+}
+
+// EnableDownloads starts generating the MP4 download of a video. The download
+// is not usable until its status becomes ready; enabling it again is harmless.
+func (c *ClientImpl) EnableDownloads(ctx context.Context, videoID string) (*Downloads, error) {
+	if videoID == "" {
+		return nil, fmt.Errorf("%w: video ID cannot be empty", ErrInvalidInput)
+	}
+
+	var downloads Downloads
+	if err := c.mutate(ctx, http.MethodPost, c.accountURL("stream/%s/downloads", videoID), nil, &downloads); err != nil {
+		return nil, err
+	}
+
+	return &downloads, nil
+}
+
+// UploadCaption uploads a WebVTT caption file for a language, replacing any existing track.
+func (c *ClientImpl) UploadCaption(ctx context.Context, videoID, language, filePath string) (*Caption, error) {
+	if videoID == "" {
+		return nil, fmt.Errorf("%w: video ID cannot be empty", ErrInvalidInput)
+	}
+	if language == "" {
+		return nil, fmt.Errorf("%w: language cannot be empty", ErrInvalidInput)
+	}
+
+	var caption Caption
+	if err := c.doMultipart(ctx, http.MethodPut, c.accountURL("stream/%s/captions/%s", videoID, url.PathEscape(language)), filePath, &caption); err != nil {
+		return nil, err
+	}
+
+	return &caption, nil
+}
+
+// GetWatermark retrieves a watermark profile by UID.
+func (c *ClientImpl) GetWatermark(ctx context.Context, uid string) (*Watermark, error) {
+	if uid == "" {
+		return nil, fmt.Errorf("%w: watermark UID cannot be empty", ErrInvalidInput)
+	}
+
+	var watermark Watermark
+	if err := c.doJSON(ctx, http.MethodGet, c.accountURL("stream/watermarks/%s", uid), nil, &watermark); err != nil {
+		return nil, err
+	}
+
+	return &watermark, nil
+}
+
+// ListCaptions lists the caption tracks of a video.
+func (c *ClientImpl) ListCaptions(ctx context.Context, videoID string) ([]Caption, error) {
+	if videoID == "" {
+		return nil, fmt.Errorf("%w: video ID cannot be empty", ErrInvalidInput)
+	}
+
+	var captions []Caption
+	if err := c.doJSON(ctx, http.MethodGet, c.accountURL("stream/%s/captions", videoID), nil, &captions); err != nil {
+		return nil, err
+	}
+
+	return captions, nil
+}
+
+// DownloadCaption returns the WebVTT file of a caption track.
+func (c *ClientImpl) DownloadCaption(ctx context.Context, videoID, language string) ([]byte, error) {
+	if videoID == "" {
+		return nil, fmt.Errorf("%w: video ID cannot be empty", ErrInvalidInput)
+	}
+	if language == "" {
+		return nil, fmt.Errorf("%w: language cannot be empty", ErrInvalidInput)
 	}
 
-	// Make HTTP request
-	url := fmt.Sprintf("https://api.cloudflare.com/client/v4/accounts/%s/stream/direct_upload", c.accountID)
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(jsonBody))
+	// The vtt endpoint returns the file itself rather than a JSON envelope
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.accountURL("stream/%s/captions/%s/vtt", videoID, url.PathEscape(language)), nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.apiToken)
-	req.Header.Set("Content-Type", "application/json")
+	return c.execute(req)
+}
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to execute request: %w", err)
+// DeleteCaption removes the caption track of a language.
+func (c *ClientImpl) DeleteCaption(ctx context.Context, videoID, language string) error {
+	if videoID == "" {
+		return fmt.Errorf("%w: video ID cannot be empty", ErrInvalidInput)
+	}
+	if language == "" {
+		return fmt.Errorf("%w: language cannot be empty", ErrInvalidInput)
 	}
-	defer resp.Body.Close()
 
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+	return c.mutate(ctx, http.MethodDelete, c.accountURL("stream/%s/captions/%s", videoID, url.PathEscape(language)), nil, nil)
+}
+
+// GenerateCaption starts generating captions for a language from the video's
+// audio. Poll ListCaptions until the track's status is ready.
+func (c *ClientImpl) GenerateCaption(ctx context.Context, videoID, language string) (*Caption, error) {
+	if videoID == "" {
+		return nil, fmt.Errorf("%w: video ID cannot be empty", ErrInvalidInput)
+	}
+	if language == "" {
+		return nil, fmt.Errorf("%w: language cannot be empty", ErrInvalidInput)
+	}
+
+	var caption Caption
+	if err := c.mutate(ctx, http.MethodPost, c.accountURL("stream/%s/captions/%s/generate", videoID, url.PathEscape(language)), nil, &caption); err != nil {
+		return nil, err
+	}
+
+	return &caption, nil
+}
+
+// ListAudioTracks lists the additional audio tracks of a video.
+func (c *ClientImpl) ListAudioTracks(ctx context.Context, videoID string) ([]AudioTrack, error) {
+	if videoID == "" {
+		return nil, fmt.Errorf("%w: video ID cannot be empty", ErrInvalidInput)
+	}
+
+	var tracks []AudioTrack
+	if err := c.doJSON(ctx, http.MethodGet, c.accountURL("stream/%s/audio", videoID), nil, &tracks); err != nil {
+		return nil, err
+	}
+
+	return tracks, nil
+}
+
+// SetDefaultAudioTrack makes an audio track the one the Stream player uses
+// by default. Stream clears the flag on the other tracks.
+func (c *ClientImpl) SetDefaultAudioTrack(ctx context.Context, videoID, trackID string) (*AudioTrack, error) {
+	if videoID == "" || trackID == "" {
+		return nil, fmt.Errorf("%w: video ID and audio track ID cannot be empty", ErrInvalidInput)
+	}
+
+	var track AudioTrack
+	body := map[string]bool{"default": true}
+	if err := c.mutate(ctx, http.MethodPatch, c.accountURL("stream/%s/audio/%s", videoID, trackID), body, &track); err != nil {
+		return nil, err
+	}
+
+	return &track, nil
+}
+
+// GetStorageUsage returns the storage minutes used by the account.
+func (c *ClientImpl) GetStorageUsage(ctx context.Context) (*StorageUsage, error) {
+	var usage StorageUsage
+	if err := c.doJSON(ctx, http.MethodGet, c.accountURL("stream/storage-usage"), nil, &usage); err != nil {
+		return nil, err
+	}
+
+	return &usage, nil
+}
+
+// SetWebhook subscribes notificationURL to video notifications, replacing
+// any existing subscription. The result includes the signing secret.
+func (c *ClientImpl) SetWebhook(ctx context.Context, notificationURL string) (*Webhook, error) {
+	if notificationURL == "" {
+		return nil, fmt.Errorf("%w: notification URL cannot be empty", ErrInvalidInput)
+	}
+
+	var webhook Webhook
+	body := map[string]string{"notificationUrl": notificationURL}
+	if err := c.mutate(ctx, http.MethodPut, c.accountURL("stream/webhook"), body, &webhook); err != nil {
+		return nil, err
+	}
+
+	return &webhook, nil
+}
+
+// GetWebhook returns the current webhook subscription.
+func (c *ClientImpl) GetWebhook(ctx context.Context) (*Webhook, error) {
+	var webhook Webhook
+	if err := c.doJSON(ctx, http.MethodGet, c.accountURL("stream/webhook"), nil, &webhook); err != nil {
+		return nil, err
+	}
+
+	return &webhook, nil
+}
+
+// DeleteWebhook removes the webhook subscription.
+func (c *ClientImpl) DeleteWebhook(ctx context.Context) error {
+	return c.mutate(ctx, http.MethodDelete, c.accountURL("stream/webhook"), nil, nil)
+}
+
+// CreateSigningKey creates a key for signing tokens locally. The result
+// holds the private key, which can't be retrieved again.
+func (c *ClientImpl) CreateSigningKey(ctx context.Context) (*SigningKey, error) {
+	var key SigningKey
+	if err := c.mutate(ctx, http.MethodPost, c.accountURL("stream/keys"), nil, &key); err != nil {
+		return nil, err
+	}
+
+	return &key, nil
+}
+
+// ListSigningKeys lists the account's signing keys without their private keys.
+func (c *ClientImpl) ListSigningKeys(ctx context.Context) ([]SigningKey, error) {
+	var keys []SigningKey
+	if err := c.doJSON(ctx, http.MethodGet, c.accountURL("stream/keys"), nil, &keys); err != nil {
+		return nil, err
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(respBody))
+	return keys, nil
+}
+
+// DeleteSigningKey deletes a signing key. Tokens signed with it stop working.
+func (c *ClientImpl) DeleteSigningKey(ctx context.Context, keyID string) error {
+	if keyID == "" {
+		return fmt.Errorf("%w: signing key ID cannot be empty", ErrInvalidInput)
+	}
+
+	return c.mutate(ctx, http.MethodDelete, c.accountURL("stream/keys/%s", keyID), nil, nil)
+}
+
+// GetLiveInput retrieves details for a specific live input by ID.
+func (c *ClientImpl) GetLiveInput(ctx context.Context, inputID string) (*LiveInput, error) {
+	if inputID == "" {
+		return nil, fmt.Errorf("%w: live input ID cannot be empty", ErrInvalidInput)
+	}
+
+	var input LiveInput
+	if err := c.doJSON(ctx, http.MethodGet, c.accountURL("stream/live_inputs/%s", inputID), nil, &input); err != nil {
+		return nil, err
 	}
 
-	// Parse response
-	var apiResp struct {
-		Result struct {
-			UploadURL string `json:"uploadURL"`
-			UID       string `json:"uid"`
-		} `json:"result"`
-		Success bool `json:"success"`
-		Errors  []struct {
-			Code    int    `json:"code"`
-			Message string `json:"message"`
-		} `json:"errors"`
+	return &input, nil
+}
+
+// CreateLiveInput creates a new live input.
+func (c *ClientImpl) CreateLiveInput(ctx context.Context, opts *LiveInputOptions) (*LiveInput, error) {
+	if opts == nil {
+		opts = &LiveInputOptions{}
+	}
+
+	var input LiveInput
+	if err := c.mutate(ctx, http.MethodPost, c.accountURL("stream/live_inputs"), liveInputBody(opts), &input); err != nil {
+		return nil, err
 	}
 
-	if err := json.Unmarshal(respBody, &apiResp); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+	return &input, nil
+}
+
+// UpdateLiveInput updates the settings of a live input.
+func (c *ClientImpl) UpdateLiveInput(ctx context.Context, inputID string, opts *LiveInputOptions) (*LiveInput, error) {
+	if inputID == "" {
+		return nil, fmt.Errorf("%w: live input ID cannot be empty", ErrInvalidInput)
+	}
+	if opts == nil {
+		return nil, fmt.Errorf("%w: update options cannot be nil", ErrInvalidInput)
+	}
+
+	var input LiveInput
+	if err := c.mutate(ctx, http.MethodPut, c.accountURL("stream/live_inputs/%s", inputID), liveInputBody(opts), &input); err != nil {
+		return nil, err
+	}
+
+	return &input, nil
+}
+
+// liveInputBody builds the request body for creating or updating a live input.
+func liveInputBody(opts *LiveInputOptions) map[string]interface{} {
+	body := make(map[string]interface{})
+	if opts.Name != "" {
+		body["meta"] = map[string]interface{}{"name": opts.Name}
+	}
+
+	recording := make(map[string]interface{})
+	if opts.RecordingMode != "" {
+		recording["mode"] = opts.RecordingMode
+	}
+	if opts.RequireSignedURLs != nil {
+		recording["requireSignedURLs"] = *opts.RequireSignedURLs
+	}
+	if len(recording) > 0 {
+		body["recording"] = recording
 	}
 
-	if !apiResp.Success {
-		if len(apiResp.Errors) > 0 {
-			return nil, fmt.Errorf("API error: %s", apiResp.Errors[0].Message)
+	if opts.DeleteRecordingAfterDays != nil {
+		// Cloudflare disables auto-deletion when the field is null
+		if days := *opts.DeleteRecordingAfterDays; days > 0 {
+			body["deleteRecordingAfterDays"] = days
+		} else {
+			body["deleteRecordingAfterDays"] = nil
 		}
-		return nil, fmt.Errorf("API request failed")
 	}
 
-	result := &DirectUploadResult{
-		UploadURL: apiResp.Result.UploadURL,
-		UID:       apiResp.Result.UID,
+	return body
+}
+
+// ListLiveInputVideos retrieves the videos recorded from a live input.
+func (c *ClientImpl) ListLiveInputVideos(ctx context.Context, inputID string) ([]Video, error) {
+	if inputID == "" {
+		return nil, fmt.Errorf("%w: live input ID cannot be empty", ErrInvalidInput)
 	}
 
-	if opts.Expiry != nil {
-		result.Expiry = *opts.Expiry
+	var videos []stream.Video
+	if err := c.doJSON(ctx, http.MethodGet, c.accountURL("stream/live_inputs/%s/videos", inputID), nil, &videos); err != nil {
+		return nil, err
 	}
 
-	return result, nil
+	return VideosFromSDK(videos), nil
+}
+
+// directUploadBody builds the request body for a direct upload URL.
+func directUploadBody(opts *DirectUploadOptions) map[string]interface{} {
+	body := make(map[string]interface{})
+	if opts.MaxDurationSeconds > 0 {
+		body["maxDurationSeconds"] = opts.MaxDurationSeconds
+	}
+	if opts.Expiry != nil {
+		body["expiry"] = opts.Expiry.Format(time.RFC3339)
+	}
+	if opts.RequireSignedURLs {
+		body["requireSignedURLs"] = true
+	}
+	if opts.Watermark != "" {
+		body["watermark"] = map[string]string{"uid": opts.Watermark}
+	}
+	if len(opts.Meta) > 0 {
+		body["meta"] = opts.Meta
+	}
+	if opts.Creator != "" {
+		body["creator"] = opts.Creator
+	}
+	if len(opts.AllowedOrigins) > 0 {
+		body["allowedOrigins"] = opts.AllowedOrigins
+	}
+	if opts.ThumbnailTimestampPct > 0 {
+		body["thumbnailTimestampPct"] = opts.ThumbnailTimestampPct
+	}
+	if opts.ScheduledDeletion != nil {
+		body["scheduledDeletion"] = opts.ScheduledDeletion.Format(time.RFC3339)
+	}
+	return body
 }
 
 // UploadFromURL uploads a video from a URL.
@@ -478,79 +965,72 @@ func (c *ClientImpl) UploadFromURL(ctx context.Context, url string, opts *Upload
 	if opts == nil {
 		opts = &UploadOptions{}
 	}
+	if err := checkThumbnailPct(opts.ThumbnailTimestampPct); err != nil {
+		return nil, err
+	}
 
 	// Build request body
 	body := make(map[string]interface{})
 	body["url"] = url
-	body["requireSignedURLs"] = true
+	body["requireSignedURLs"] = opts.RequireSignedURLs
+	if opts.Creator != "" {
+		body["creator"] = opts.Creator
+	}
 
 	// Add metadata if provided
-	meta := make(map[string]interface{})
-	if opts.Name != "" {
-		meta["name"] = opts.Name
-	}
-	if opts.Metadata != nil {
-		for k, v := range opts.Metadata {
-			meta[k] = v
-		}
-	}
-	if len(meta) > 0 {
+	if meta := uploadMeta(opts); len(meta) > 0 {
 		body["meta"] = meta
 	}
-
-	jsonBody, err := json.Marshal(body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request body: %w", err)
+	if opts.Watermark != "" {
+		body["watermark"] = map[string]string{"uid": opts.Watermark}
+	}
+	if opts.ThumbnailTimestampPct > 0 {
+		body["thumbnailTimestampPct"] = opts.ThumbnailTimestampPct
 	}
 
-	// Make HTTP request
-	apiURL := fmt.Sprintf("https://api.cloudflare.com/client/v4/accounts/%s/stream/copy", c.accountID)
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, apiURL, bytes.NewReader(jsonBody))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+	var video stream.Video
+	if err := c.mutate(ctx, http.MethodPost, c.accountURL("stream/copy"), body, &video); err != nil {
+		return nil, err
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.apiToken)
-	req.Header.Set("Content-Type", "application/json")
+	return VideoFromSDK(&video), nil
+}
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to execute request: %w", err)
+// CreateClip creates a new video from the part of a video between
+// opts.Start and opts.End. The clip is processed like an upload and is not
+// ready to stream until its status becomes ready.
+func (c *ClientImpl) CreateClip(ctx context.Context, videoID string, opts *ClipOptions) (*Video, error) {
+	if videoID == "" {
+		return nil, fmt.Errorf("%w: video ID cannot be empty", ErrInvalidInput)
 	}
-	defer resp.Body.Close()
-
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+	if opts == nil || opts.Start < 0 || opts.End <= opts.Start {
+		return nil, fmt.Errorf("%w: clip end must be after its start", ErrInvalidInput)
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(respBody))
+	body := map[string]interface{}{
+		"clippedFromVideoUID": videoID,
+		"startTimeSeconds":    opts.Start,
+		"endTimeSeconds":      opts.End,
+		"requireSignedURLs":   opts.RequireSignedURLs,
 	}
 
-	// Parse response
-	var apiResp struct {
-		Result  stream.Video `json:"result"`
-		Success bool         `json:"success"`
-		Errors  []struct {
-			Code    int    `json:"code"`
-			Message string `json:"message"`
-		} `json:"errors"`
+	meta := make(map[string]interface{})
+	for k, v := range opts.Metadata {
+		meta[k] = v
 	}
-
-	if err := json.Unmarshal(respBody, &apiResp); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+	if opts.Name != "" {
+		meta["name"] = opts.Name
+	}
+	if len(meta) > 0 {
+		body["meta"] = meta
 	}
 
-	if !apiResp.Success {
-		if len(apiResp.Errors) > 0 {
-			return nil, fmt.Errorf("API error: %s", apiResp.Errors[0].Message)
-		}
-		return nil, fmt.Errorf("API request failed")
+	var video stream.Video
+	if err := c.mutate(ctx, http.MethodPost, c.accountURL("stream/clip"), body, &video); err != nil {
+		return nil, err
 	}
 
-	return VideoFromSDK(&apiResp.Result), nil
+	return VideoFromSDK(&video), nil
 }
 
 // UploadFile uploads a video file using multipart/form-data or TUS protocol.
@@ -561,6 +1041,9 @@ func (c *ClientImpl) UploadFile(ctx context.Context, filePath string, opts *Uplo
 	if opts == nil {
 		opts = &UploadOptions{}
 	}
+	if err := checkThumbnailPct(opts.ThumbnailTimestampPct); err != nil {
+		return nil, err
+	}
 
 	// Open the file
 	file, err := os.Open(filePath)
@@ -576,17 +1059,27 @@ func (c *ClientImpl) UploadFile(ctx context.Context, filePath string, opts *Uplo
 	}
 	fileSize := fileInfo.Size()
 
-	// Choose upload method based on file size
-	const tusThreshold = 200 * 1024 * 1024 // 200 MB
+	if c.dryRun != nil {
+		c.planUpload(file.Name(), fileSize, opts)
+		return nil, ErrDryRun
+	}
 
+	// Choose upload method based on file size
 	if fileSize >= tusThreshold {
 		// Use TUS for large files
-		tusURL := fmt.Sprintf("https://api.cloudflare.com/client/v4/accounts/%s/stream", c.accountID)
+		tusURL := c.accountURL("stream")
 		videoID, err := c.tusUploadDirect(ctx, tusURL, file, fileSize, opts, progressCh)
 		if err != nil {
 			return nil, fmt.Errorf("TUS upload failed: %w", err)
 		}
 
+		// TUS metadata only carries the name, so other keys are set after
+		if len(opts.Metadata) > 0 {
+			if _, err := c.UpdateVideo(ctx, videoID, &UpdateOptions{Meta: uploadMeta(opts)}); err != nil {
+				return nil, fmt.Errorf("failed to set video metadata: %w", err)
+			}
+		}
+
 		// Get the video details
 		video, err := c.GetVideo(ctx, videoID)
 		if err != nil {
@@ -597,11 +1090,7 @@ func (c *ClientImpl) UploadFile(ctx context.Context, filePath string, opts *Uplo
 	}
 
 	// For smaller files, use direct upload URL with multipart
-	directOpts := &DirectUploadOptions{
-		MaxDurationSeconds: 21600, // 6 hours max video duration
-		RequireSignedURLs:  true,
-	}
-	directResult, err := c.CreateDirectUploadURL(ctx, directOpts)
+	directResult, err := c.CreateDirectUploadURL(ctx, fileUploadOptions(opts))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create direct upload URL: %w", err)
 	}
@@ -622,7 +1111,7 @@ func (c *ClientImpl) UploadFile(ctx context.Context, filePath string, opts *Uplo
 
 // multipartUpload performs a multipart/form-data upload.
 func (c *ClientImpl) multipartUpload(ctx context.Context, uploadURL string, file *os.File, fileSize int64, opts *UploadOptions, progressCh chan<- UploadProgress) error {
-	_ = opts // opts currently unused - metadata is set via UpdateVideo after upload
+	_ = opts // opts currently unused - metadata is set when creating the upload URL
 
 	// Create a pipe for streaming the multipart data
 	pr, pw := io.Pipe()
@@ -680,8 +1169,7 @@ func (c *ClientImpl) multipartUpload(ctx context.Context, uploadURL string, file
 	req.Header.Set("Content-Type", writer.FormDataContentType())
 
 	// Send the request
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return fmt.Errorf("upload request failed: %w", err)
 	}
@@ -697,13 +1185,7 @@ func (c *ClientImpl) multipartUpload(ctx context.Context, uploadURL string, file
 
 // tusUploadDirect uploads directly to the Stream TUS endpoint (for large files).
 func (c *ClientImpl) tusUploadDirect(ctx context.Context, tusURL string, file *os.File, fileSize int64, opts *UploadOptions, progressCh chan<- UploadProgress) (string, error) {
-	// Build Upload-Metadata header
-	var metadataParts []string
-	if opts.Name != "" {
-		encoded := fmt.Sprintf("name %s", base64.StdEncoding.EncodeToString([]byte(opts.Name)))
-		metadataParts = append(metadataParts, encoded)
-	}
-	uploadMetadata := strings.Join(metadataParts, ",")
+	uploadMetadata := tusMetadata(opts)
 
 	// Create initial TUS request
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tusURL, nil)
@@ -717,9 +1199,11 @@ func (c *ClientImpl) tusUploadDirect(ctx context.Context, tusURL string, file *o
 	if uploadMetadata != "" {
 		req.Header.Set("Upload-Metadata", uploadMetadata)
 	}
+	if opts.Creator != "" {
+		req.Header.Set("Upload-Creator", opts.Creator)
+	}
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return "", fmt.Errorf("failed to initiate TUS upload: %w", err)
 	}
@@ -744,57 +1228,323 @@ func (c *ClientImpl) tusUploadDirect(ctx context.Context, tusURL string, file *o
 	}
 	videoID := locationParts[len(locationParts)-1]
 
-	// Upload file in chunks (50 MB)
-	const chunkSize = 50 * 1024 * 1024
-	buffer := make([]byte, chunkSize)
-	var offset int64
+	if opts.OnResumable != nil {
+		opts.OnResumable(&ResumableUpload{UID: videoID, Location: location})
+	}
 
-	for {
-		n, err := file.Read(buffer)
-		if n == 0 && errors.Is(err, io.EOF) {
-			break
-		}
-		if err != nil && !errors.Is(err, io.EOF) {
-			return "", fmt.Errorf("failed to read file: %w", err)
-		}
+	if err := c.tusSend(ctx, location, file, 0, fileSize, progressCh); err != nil {
+		return "", err
+	}
 
-		// Upload chunk
-		chunkReq, err := http.NewRequestWithContext(ctx, http.MethodPatch, location, bytes.NewReader(buffer[:n]))
-		if err != nil {
-			return "", fmt.Errorf("failed to create chunk request: %w", err)
+	return videoID, nil
+}
+
+// ResumeUpload continues an interrupted TUS upload of filePath. The server
+// is asked how many bytes it already has, so only the rest of the file is
+// sent.
+func (c *ClientImpl) ResumeUpload(ctx context.Context, upload *ResumableUpload, filePath string, progressCh chan<- UploadProgress) (*Video, error) {
+	if upload == nil || upload.UID == "" || upload.Location == "" {
+		return nil, fmt.Errorf("%w: upload ID and location are required", ErrInvalidInput)
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	fileInfo, err := file.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get file info: %w", err)
+	}
+	fileSize := fileInfo.Size()
+
+	if c.dryRun != nil {
+		c.plan(http.MethodHead, upload.Location, map[string]string{"Tus-Resumable": "1.0.0"}, nil)
+		c.plan(http.MethodPatch, upload.Location, map[string]string{
+			"Tus-Resumable": "1.0.0",
+			"Content-Type":  "application/offset+octet-stream",
+		}, fmt.Sprintf("the rest of %s (%d bytes) from the server offset", file.Name(), fileSize))
+		return nil, ErrDryRun
+	}
+
+	offset, length, err := c.tusOffset(ctx, upload.Location)
+	if err != nil {
+		return nil, err
+	}
+	if length >= 0 && length != fileSize {
+		return nil, fmt.Errorf("%w: %s is %d bytes but the upload expects %d", ErrInvalidInput, filePath, fileSize, length)
+	}
+
+	if offset < fileSize {
+		if err := c.tusSend(ctx, upload.Location, file, offset, fileSize, progressCh); err != nil {
+			return nil, fmt.Errorf("TUS upload failed: %w", err)
 		}
+	}
 
-		chunkReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.apiToken))
-		chunkReq.Header.Set("Tus-Resumable", "1.0.0")
-		chunkReq.Header.Set("Upload-Offset", fmt.Sprintf("%d", offset))
-		chunkReq.Header.Set("Content-Type", "application/offset+octet-stream")
-		chunkReq.Header.Set("Content-Length", fmt.Sprintf("%d", n))
+	video, err := c.GetVideo(ctx, upload.UID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get video details: %w", err)
+	}
 
-		chunkResp, err := client.Do(chunkReq)
-		if err != nil {
-			return "", fmt.Errorf("chunk upload failed: %w", err)
+	return video, nil
+}
+
+// tusOffset asks the TUS server how many bytes of an upload it has received.
+// The length is -1 when the server does not report it.
+func (c *ClientImpl) tusOffset(ctx context.Context, location string) (offset, length int64, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, location, nil)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to create TUS request: %w", err)
+	}
+
+	c.authorize(req)
+	req.Header.Set("Tus-Resumable", "1.0.0")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get TUS upload offset: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent:
+	case http.StatusNotFound, http.StatusGone:
+		return 0, 0, ErrUploadExpired
+	default:
+		return 0, 0, fmt.Errorf("TUS offset request failed with status %d", resp.StatusCode)
+	}
+
+	offset, err = strconv.ParseInt(resp.Header.Get("Upload-Offset"), 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid Upload-Offset header: %w", err)
+	}
+	length = -1
+	if value := resp.Header.Get("Upload-Length"); value != "" {
+		if length, err = strconv.ParseInt(value, 10, 64); err != nil {
+			return 0, 0, fmt.Errorf("invalid Upload-Length header: %w", err)
 		}
-		defer chunkResp.Body.Close()
+	}
+
+	return offset, length, nil
+}
 
-		if chunkResp.StatusCode != http.StatusNoContent {
-			body, _ := io.ReadAll(chunkResp.Body) //nolint:errcheck // Error message, best effort read
-			return "", fmt.Errorf("chunk upload failed with status %d: %s", chunkResp.StatusCode, string(body))
+// tusSend uploads file from offset to the end in chunks to a TUS upload.
+// Chunks failing with a network error, a server error, a rate limit, or an
+// offset conflict are retried with exponential backoff (or the Retry-After
+// delay), continuing from the offset the server reports.
+func (c *ClientImpl) tusSend(ctx context.Context, location string, file *os.File, offset, fileSize int64, progressCh chan<- UploadProgress) error {
+	buffer := make([]byte, tusChunkSize)
+	backoff := c.tusBackoff
+	retries := 0
+	for offset < fileSize {
+		n, err := file.ReadAt(buffer, offset)
+		if n == 0 || (err != nil && !errors.Is(err, io.EOF)) {
+			return fmt.Errorf("failed to read file: %w", err)
 		}
 
-		offset += int64(n)
+		wait, err := c.tusPatch(ctx, location, buffer[:n], offset)
+		if err == nil {
+			offset += int64(n)
+			backoff, retries = c.tusBackoff, 0
 
-		// Send progress update
-		if progressCh != nil {
-			select {
-			case progressCh <- UploadProgress{BytesSent: offset, BytesTotal: fileSize}:
-			default:
+			// Send progress update
+			if progressCh != nil {
+				select {
+				case progressCh <- UploadProgress{BytesSent: offset, BytesTotal: fileSize}:
+				default:
+				}
 			}
+			continue
+		}
+		if wait < 0 || ctx.Err() != nil {
+			return err
+		}
+		if retries == tusChunkRetries {
+			return fmt.Errorf("%w (gave up after %d retries)", err, retries)
+		}
+		retries++
+
+		if wait == 0 {
+			wait = backoff
+			backoff = min(backoff*2, maxRateLimitBackoff)
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("%w: %w", err, ctx.Err())
+		case <-timer.C:
 		}
 
-		if errors.Is(err, io.EOF) {
-			break
+		// The server may have stored part of the chunk. If it can't be asked,
+		// the chunk is sent again and a wrong offset fails with a conflict.
+		serverOffset, _, err := c.tusOffset(ctx, location)
+		switch {
+		case errors.Is(err, ErrUploadExpired):
+			return err
+		case err == nil:
+			offset = serverOffset
 		}
 	}
 
-	return videoID, nil
+	return nil
+}
+
+// tusPatch sends one chunk of a TUS upload at offset. When it fails, wait is
+// -1 if the failure is permanent, the Retry-After delay of a rate-limited
+// response, or zero for other transient failures.
+func (c *ClientImpl) tusPatch(ctx context.Context, location string, chunk []byte, offset int64) (wait time.Duration, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPatch, location, bytes.NewReader(chunk))
+	if err != nil {
+		return -1, fmt.Errorf("failed to create chunk request: %w", err)
+	}
+
+	c.authorize(req)
+	req.Header.Set("Tus-Resumable", "1.0.0")
+	req.Header.Set("Upload-Offset", fmt.Sprintf("%d", offset))
+	req.Header.Set("Content-Type", "application/offset+octet-stream")
+	req.Header.Set("Content-Length", fmt.Sprintf("%d", len(chunk)))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("chunk upload failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNoContent {
+		return 0, nil
+	}
+
+	body, _ := io.ReadAll(resp.Body) //nolint:errcheck // Error message, best effort read
+	err = fmt.Errorf("chunk upload failed with status %d: %s", resp.StatusCode, string(body))
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()), err
+	case resp.StatusCode == http.StatusConflict, resp.StatusCode >= http.StatusInternalServerError:
+		return 0, err
+	default:
+		return -1, err
+	}
+}
+
+// authorize sets the API token on a TUS request. Clients for one-time
+// upload URLs have no token; the URL itself authorizes the upload.
+func (c *ClientImpl) authorize(req *http.Request) {
+	if c.apiToken != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.apiToken))
+	}
+}
+
+// fileUploadOptions returns the direct upload options used for small file
+// uploads. All options are set here because the one-time upload URL only
+// accepts the file.
+func fileUploadOptions(opts *UploadOptions) *DirectUploadOptions {
+	maxDuration := opts.MaxDurationSeconds
+	if maxDuration <= 0 {
+		maxDuration = 21600 // 6 hours max video duration
+	}
+	return &DirectUploadOptions{
+		MaxDurationSeconds:    maxDuration,
+		Expiry:                opts.Expiry,
+		RequireSignedURLs:     opts.RequireSignedURLs,
+		Watermark:             opts.Watermark,
+		Meta:                  uploadMeta(opts),
+		Creator:               opts.Creator,
+		AllowedOrigins:        opts.AllowedOrigins,
+		ThumbnailTimestampPct: opts.ThumbnailTimestampPct,
+		ScheduledDeletion:     opts.ScheduledDeletion,
+	}
+}
+
+// uploadMeta returns the video metadata for opts: the metadata with the
+// name added.
+func uploadMeta(opts *UploadOptions) map[string]interface{} {
+	meta := make(map[string]interface{}, len(opts.Metadata)+1)
+	for k, v := range opts.Metadata {
+		meta[k] = v
+	}
+	if opts.Name != "" {
+		meta["name"] = opts.Name
+	}
+	return meta
+}
+
+// checkThumbnailPct returns an error unless pct is a fraction of the duration.
+func checkThumbnailPct(pct float64) error {
+	if pct < 0 || pct > 1 {
+		return fmt.Errorf("%w: thumbnail position must be between 0 and 1", ErrInvalidInput)
+	}
+	return nil
+}
+
+// tusMetadata builds the TUS Upload-Metadata header value for opts, with the
+// same options a small file gets through fileUploadOptions. Values are base64
+// encoded; requiresignedurls is a flag without a value.
+func tusMetadata(opts *UploadOptions) string {
+	var metadataParts []string
+	add := func(key, value string) {
+		metadataParts = append(metadataParts, key+" "+base64.StdEncoding.EncodeToString([]byte(value)))
+	}
+
+	if opts.Name != "" {
+		add("name", opts.Name)
+	}
+	if opts.Watermark != "" {
+		add("watermark", opts.Watermark)
+	}
+	if opts.RequireSignedURLs {
+		metadataParts = append(metadataParts, "requiresignedurls")
+	}
+	if opts.MaxDurationSeconds > 0 {
+		add("maxdurationseconds", strconv.Itoa(opts.MaxDurationSeconds))
+	}
+	if opts.Expiry != nil {
+		add("expiry", opts.Expiry.UTC().Format(time.RFC3339))
+	}
+	if len(opts.AllowedOrigins) > 0 {
+		add("allowedorigins", strings.Join(opts.AllowedOrigins, ","))
+	}
+	if opts.ThumbnailTimestampPct > 0 {
+		add("thumbnailtimestamppct", strconv.FormatFloat(opts.ThumbnailTimestampPct, 'f', -1, 64))
+	}
+	if opts.ScheduledDeletion != nil {
+		add("scheduleddeletion", opts.ScheduledDeletion.UTC().Format(time.RFC3339))
+	}
+	return strings.Join(metadataParts, ",")
+}
+
+// planUpload describes the requests UploadFile would make in dry-run mode.
+func (c *ClientImpl) planUpload(fileName string, fileSize int64, opts *UploadOptions) {
+	if fileSize >= tusThreshold {
+		chunks := (fileSize + tusChunkSize - 1) / tusChunkSize
+		fmt.Fprintf(c.dryRun, "[dry-run] upload %s (%d bytes) with TUS in %d chunk(s)\n", fileName, fileSize, chunks)
+
+		headers := map[string]string{
+			"Tus-Resumable": "1.0.0",
+			"Upload-Length": fmt.Sprintf("%d", fileSize),
+		}
+		if metadata := tusMetadata(opts); metadata != "" {
+			headers["Upload-Metadata"] = metadata
+		}
+		if opts.Creator != "" {
+			headers["Upload-Creator"] = opts.Creator
+		}
+		c.plan(http.MethodPost, c.accountURL("stream"), headers, nil)
+
+		c.plan(http.MethodPatch, "<tus upload location>", map[string]string{
+			"Tus-Resumable": "1.0.0",
+			"Content-Type":  "application/offset+octet-stream",
+		}, fmt.Sprintf("%d chunk(s) of up to %d bytes from %s", chunks, tusChunkSize, fileName))
+		if len(opts.Metadata) > 0 {
+			c.plan(http.MethodPost, c.accountURL("stream/<video id>"), nil, map[string]interface{}{"meta": uploadMeta(opts)})
+		}
+		return
+	}
+
+	fmt.Fprintf(c.dryRun, "[dry-run] upload %s (%d bytes) with a multipart direct upload\n", fileName, fileSize)
+	c.plan(http.MethodPost, c.accountURL("stream/direct_upload"), nil, directUploadBody(fileUploadOptions(opts)))
+	c.plan(http.MethodPost, "<one-time upload URL>", map[string]string{
+		"Content-Type": "multipart/form-data",
+	}, fmt.Sprintf("file=%s (%d bytes)", fileName, fileSize))
 }
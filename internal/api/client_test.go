@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"testing"
 	"time"
@@ -26,6 +27,13 @@ func (m *MockClient) ListVideos(ctx context.Context, opts *ListOptions) ([]Video
 	return args.Get(0).([]Video), args.Error(1)
 }
 
+func (m *MockClient) ListVideosPager(opts *ListOptions) *VideoPager {
+	return newVideoPager(func(ctx context.Context, opts *ListOptions) ([]Video, bool, error) {
+		videos, err := m.ListVideos(ctx, opts)
+		return videos, len(videos) >= listPageSize, err
+	}, opts)
+}
+
 func (m *MockClient) GetVideo(ctx context.Context, videoID string) (*Video, error) {
 	args := m.Called(ctx, videoID)
 	if args.Get(0) == nil {
@@ -39,6 +47,202 @@ func (m *MockClient) DeleteVideo(ctx context.Context, videoID string) error {
 	return args.Error(0)
 }
 
+func (m *MockClient) GetSignedTokenWithOptions(ctx context.Context, videoID string, opts *TokenOptions) (string, error) {
+	args := m.Called(ctx, videoID, opts)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockClient) UploadCaption(ctx context.Context, videoID, language, filePath string) (*Caption, error) {
+	args := m.Called(ctx, videoID, language, filePath)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Caption), args.Error(1)
+}
+
+func (m *MockClient) GetDownloads(ctx context.Context, videoID string) (*Downloads, error) {
+	args := m.Called(ctx, videoID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Downloads), args.Error(1)
+}
+
+func (m *MockClient) EnableDownloads(ctx context.Context, videoID string) (*Downloads, error) {
+	args := m.Called(ctx, videoID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Downloads), args.Error(1)
+}
+
+func (m *MockClient) CreateClip(ctx context.Context, videoID string, opts *ClipOptions) (*Video, error) {
+	args := m.Called(ctx, videoID, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Video), args.Error(1)
+}
+
+func (m *MockClient) CreateSigningKey(ctx context.Context) (*SigningKey, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*SigningKey), args.Error(1)
+}
+
+func (m *MockClient) ListSigningKeys(ctx context.Context) ([]SigningKey, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]SigningKey), args.Error(1)
+}
+
+func (m *MockClient) DeleteSigningKey(ctx context.Context, keyID string) error {
+	args := m.Called(ctx, keyID)
+	return args.Error(0)
+}
+
+func (m *MockClient) ListAudioTracks(ctx context.Context, videoID string) ([]AudioTrack, error) {
+	args := m.Called(ctx, videoID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]AudioTrack), args.Error(1)
+}
+
+func (m *MockClient) SetDefaultAudioTrack(ctx context.Context, videoID, trackID string) (*AudioTrack, error) {
+	args := m.Called(ctx, videoID, trackID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*AudioTrack), args.Error(1)
+}
+
+func (m *MockClient) GetLiveInput(ctx context.Context, inputID string) (*LiveInput, error) {
+	args := m.Called(ctx, inputID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*LiveInput), args.Error(1)
+}
+
+func (m *MockClient) ListLiveInputVideos(ctx context.Context, inputID string) ([]Video, error) {
+	args := m.Called(ctx, inputID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]Video), args.Error(1)
+}
+
+func (m *MockClient) CreateLiveInput(ctx context.Context, opts *LiveInputOptions) (*LiveInput, error) {
+	args := m.Called(ctx, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*LiveInput), args.Error(1)
+}
+
+func (m *MockClient) UpdateLiveInput(ctx context.Context, inputID string, opts *LiveInputOptions) (*LiveInput, error) {
+	args := m.Called(ctx, inputID, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*LiveInput), args.Error(1)
+}
+
+func (m *MockClient) TopVideos(ctx context.Context, opts *AnalyticsOptions) ([]VideoStats, error) {
+	args := m.Called(ctx, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]VideoStats), args.Error(1)
+}
+
+func (m *MockClient) ViewSeries(ctx context.Context, videoID string, opts *AnalyticsOptions) ([]SeriesPoint, error) {
+	args := m.Called(ctx, videoID, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]SeriesPoint), args.Error(1)
+}
+
+func (m *MockClient) CountryViews(ctx context.Context, videoID string, opts *AnalyticsOptions) ([]CountryStats, error) {
+	args := m.Called(ctx, videoID, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]CountryStats), args.Error(1)
+}
+
+func (m *MockClient) GetWatermark(ctx context.Context, uid string) (*Watermark, error) {
+	args := m.Called(ctx, uid)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Watermark), args.Error(1)
+}
+
+func (m *MockClient) ListCaptions(ctx context.Context, videoID string) ([]Caption, error) {
+	args := m.Called(ctx, videoID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]Caption), args.Error(1)
+}
+
+func (m *MockClient) DownloadCaption(ctx context.Context, videoID, language string) ([]byte, error) {
+	args := m.Called(ctx, videoID, language)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *MockClient) DeleteCaption(ctx context.Context, videoID, language string) error {
+	args := m.Called(ctx, videoID, language)
+	return args.Error(0)
+}
+
+func (m *MockClient) GenerateCaption(ctx context.Context, videoID, language string) (*Caption, error) {
+	args := m.Called(ctx, videoID, language)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Caption), args.Error(1)
+}
+
+func (m *MockClient) SetWebhook(ctx context.Context, notificationURL string) (*Webhook, error) {
+	args := m.Called(ctx, notificationURL)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Webhook), args.Error(1)
+}
+
+func (m *MockClient) GetWebhook(ctx context.Context) (*Webhook, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Webhook), args.Error(1)
+}
+
+func (m *MockClient) DeleteWebhook(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func (m *MockClient) GetStorageUsage(ctx context.Context) (*StorageUsage, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*StorageUsage), args.Error(1)
+}
+
 // Test NewClient validation
 func TestNewClient(t *testing.T) {
 	tests := []struct {
@@ -127,6 +331,35 @@ func TestVideoFromSDK(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "input, size, and playback details",
+			input: &stream.Video{
+				UID:                "test-uid-input",
+				Created:            now,
+				Uploaded:           now,
+				Input:              stream.VideoInput{Width: 1920, Height: 1080},
+				Size:               4194304,
+				MaxDurationSeconds: 3600,
+				LiveInput:          "live-1",
+				Playback: stream.VideoPlayback{
+					Hls:  "https://example.com/manifest/video.m3u8",
+					Dash: "https://example.com/manifest/video.mpd",
+				},
+			},
+			expected: &Video{
+				UID:                "test-uid-input",
+				Name:               "test-uid-input",
+				Created:            now,
+				Uploaded:           now,
+				Width:              1920,
+				Height:             1080,
+				Size:               4194304,
+				MaxDurationSeconds: 3600,
+				LiveInput:          "live-1",
+				HLS:                "https://example.com/manifest/video.m3u8",
+				DASH:               "https://example.com/manifest/video.mpd",
+			},
+		},
 		{
 			name: "video without name uses UID",
 			input: &stream.Video{
@@ -202,6 +435,12 @@ func TestVideoFromSDK(t *testing.T) {
 }
 
 // Test VideosFromSDK conversion
+func TestVideoSourceURL(t *testing.T) {
+	assert.Equal(t, "", (&Video{}).SourceURL())
+	assert.Equal(t, "", (&Video{Meta: map[string]interface{}{MetaSourceURL: 42}}).SourceURL())
+	assert.Equal(t, "https://example.com/a.mp4", (&Video{Meta: map[string]interface{}{MetaSourceURL: "https://example.com/a.mp4"}}).SourceURL())
+}
+
 func TestVideosFromSDK(t *testing.T) {
 	now := time.Now()
 
@@ -310,6 +549,24 @@ func TestWrapError(t *testing.T) {
 	}
 }
 
+func TestRetryAfter(t *testing.T) {
+	err := WrapError(&cloudflare.Error{
+		StatusCode: http.StatusTooManyRequests,
+		Response:   &http.Response{Header: http.Header{"Retry-After": []string{"7"}}},
+	})
+	assert.ErrorIs(t, err, ErrRateLimit)
+	assert.Equal(t, 7*time.Second, RetryAfter(err))
+
+	wrapped := fmt.Errorf("failed to delete video: %w", statusError(http.StatusTooManyRequests, http.Header{}, []byte("slow down")))
+	assert.ErrorIs(t, wrapped, ErrRateLimit)
+	assert.Equal(t, time.Duration(0), RetryAfter(wrapped))
+	assert.Equal(t, time.Duration(0), RetryAfter(ErrNotFound))
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, 30*time.Second, parseRetryAfter(now.Add(30*time.Second).Format(http.TimeFormat), now))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("soon", now))
+}
+
 // Test MockClient usage
 func TestMockClient(t *testing.T) {
 	ctx := context.Background()
@@ -364,3 +621,45 @@ func TestMockClient(t *testing.T) {
 		mockClient.AssertExpectations(t)
 	})
 }
+
+// Test EmbedURL query parameters
+func TestEmbedURL(t *testing.T) {
+	tests := []struct {
+		name string
+		opts *EmbedOptions
+		want string
+	}{
+		{
+			name: "no options",
+			opts: nil,
+			want: "https://customer-abc.cloudflarestream.com/vid/iframe",
+		},
+		{
+			name: "defaults with controls",
+			opts: &EmbedOptions{Controls: true},
+			want: "https://customer-abc.cloudflarestream.com/vid/iframe",
+		},
+		{
+			name: "player options are escaped",
+			opts: &EmbedOptions{
+				Controls:         true,
+				SignedToken:      "tok",
+				Autoplay:         true,
+				Poster:           "https://example.com/p.jpg?x=1",
+				Preload:          "metadata",
+				PrimaryColor:     "#f48120",
+				StartTime:        "1m30s",
+				DefaultTextTrack: "en",
+			},
+			want: "https://customer-abc.cloudflarestream.com/vid/iframe?token=tok&autoplay=true" +
+				"&poster=https%3A%2F%2Fexample.com%2Fp.jpg%3Fx%3D1&preload=metadata&primaryColor=%23f48120" +
+				"&startTime=1m30s&defaultTextTrack=en",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, EmbedURL("abc", "vid", tt.opts))
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// TokenEnvVar is the environment variable curl commands read the API token
+// from, so printed commands never contain the token itself.
+const TokenEnvVar = "CFSTREAM_API_TOKEN"
+
+// maxCurlBody is the largest request body included in a curl command; larger
+// bodies, such as upload chunks, are replaced by a placeholder.
+const maxCurlBody = 64 << 10
+
+// WithPrintCurl makes the client write an equivalent curl command to w for
+// every API request it sends, and for every request described in dry-run
+// mode. The API token is replaced by $CFSTREAM_API_TOKEN.
+func WithPrintCurl(w io.Writer) Option {
+	return func(c *ClientImpl) {
+		c.curl = w
+	}
+}
+
+// curlTransport prints each request as a curl command before sending it.
+type curlTransport struct {
+	next http.RoundTripper
+	w    io.Writer
+}
+
+func (t *curlTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := peekBody(req)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Fprintln(t.w, curlCommand(req.Method, req.URL.String(), req.Header, body, req.ContentLength))
+	return t.next.RoundTrip(req)
+}
+
+// peekBody returns a small request body without consuming it. Large or
+// unknown-length bodies are not read and nil is returned.
+func peekBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody || req.ContentLength < 0 || req.ContentLength > maxCurlBody {
+		return nil, nil
+	}
+	if req.GetBody != nil {
+		rc, err := req.GetBody()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+		defer rc.Close()
+		return io.ReadAll(rc)
+	}
+
+	data, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+	req.Body.Close()
+	req.Body = io.NopCloser(bytes.NewReader(data))
+	return data, nil
+}
+
+// curlCommand formats a request as a shell-quoted curl command. Bearer
+// tokens are replaced by a reference to TokenEnvVar and SDK bookkeeping
+// headers are left out. size is the body length, used for the placeholder
+// when body is nil but the request has one.
+func curlCommand(method, url string, header http.Header, body []byte, size int64) string {
+	var b strings.Builder
+	b.WriteString("curl")
+	if method != http.MethodGet {
+		b.WriteString(" -X " + method)
+	}
+	b.WriteString(" " + shellQuote(url))
+
+	keys := make([]string, 0, len(header))
+	for k := range header {
+		if skipCurlHeader(k) {
+			continue
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		for _, v := range header[k] {
+			if http.CanonicalHeaderKey(k) == "Authorization" && strings.HasPrefix(v, "Bearer ") {
+				// Double quotes let the shell expand the variable
+				b.WriteString(fmt.Sprintf(` -H "Authorization: Bearer $%s"`, TokenEnvVar))
+				continue
+			}
+			b.WriteString(" -H " + shellQuote(k+": "+v))
+		}
+	}
+
+	switch {
+	case body != nil:
+		b.WriteString(" --data-raw " + shellQuote(string(body)))
+	case size > 0:
+		b.WriteString(fmt.Sprintf(" --data-binary @FILE  # %d-byte body omitted", size))
+	}
+	return b.String()
+}
+
+// skipCurlHeader reports whether a header is transport or SDK bookkeeping
+// that curl doesn't need.
+func skipCurlHeader(key string) bool {
+	key = http.CanonicalHeaderKey(key)
+	switch key {
+	case "User-Agent", "Accept-Encoding", "Content-Length", "Idempotency-Key":
+		return true
+	}
+	return strings.HasPrefix(key, "X-Stainless-")
+}
+
+// planCurl prints the curl command of a request described in dry-run mode.
+// Bodies that are descriptions rather than JSON, such as upload plans, and
+// placeholder URLs are not printed.
+func (c *ClientImpl) planCurl(method, url string, headers map[string]string, body interface{}) {
+	if c.curl == nil || strings.HasPrefix(url, "<") {
+		return
+	}
+	if _, ok := body.(string); ok {
+		return
+	}
+
+	header := http.Header{}
+	if c.apiToken != "" {
+		header.Set("Authorization", "Bearer "+c.apiToken)
+	}
+	for k, v := range headers {
+		header.Set(k, v)
+	}
+
+	var data []byte
+	if body != nil {
+		var err error
+		data, err = json.Marshal(body)
+		if err != nil {
+			return
+		}
+		header.Set("Content-Type", "application/json")
+	}
+	fmt.Fprintln(c.curl, curlCommand(method, url, header, data, int64(len(data))))
+}
+
+// shellQuote quotes s for a POSIX shell.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
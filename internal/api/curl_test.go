@@ -0,0 +1,69 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCurlCommand(t *testing.T) {
+	header := http.Header{
+		"Authorization":    {"Bearer secret-token"},
+		"Content-Type":     {"application/json"},
+		"User-Agent":       {"Go-http-client/1.1"},
+		"X-Stainless-Lang": {"go"},
+		"Tus-Resumable":    {"1.0.0"},
+	}
+
+	cmd := curlCommand(http.MethodPost, "https://api.example.com/stream?x=1&y=2", header, []byte(`{"name":"It's"}`), 15)
+	assert.Equal(t, `curl -X POST 'https://api.example.com/stream?x=1&y=2'`+
+		` -H "Authorization: Bearer $CFSTREAM_API_TOKEN"`+
+		` -H 'Content-Type: application/json' -H 'Tus-Resumable: 1.0.0'`+
+		` --data-raw '{"name":"It'\''s"}'`, cmd)
+	assert.NotContains(t, cmd, "secret-token")
+
+	assert.Equal(t, "curl 'https://api.example.com/stream'", curlCommand(http.MethodGet, "https://api.example.com/stream", nil, nil, 0))
+	assert.Contains(t, curlCommand(http.MethodPatch, "https://u", nil, nil, 1<<20), "1048576-byte body omitted")
+}
+
+func TestPrintCurl(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The body must still reach the server after being printed
+		var body map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, float64(3600), body["exp"])
+		w.Write([]byte(`{"success":true,"result":{"token":"signed"}}`)) //nolint:errcheck // Test server
+	}))
+	defer srv.Close()
+
+	var out bytes.Buffer
+	client := newTestClient(t, srv, WithPrintCurl(&out))
+
+	var result struct{ Token string }
+	require.NoError(t, client.doJSON(context.Background(), http.MethodPost, client.accountURL("stream/abc/token"), map[string]int{"exp": 3600}, &result))
+
+	line := strings.TrimSpace(out.String())
+	assert.Equal(t, `curl -X POST '`+srv.URL+`/accounts/acct/stream/abc/token'`+
+		` -H "Authorization: Bearer $CFSTREAM_API_TOKEN" -H 'Content-Type: application/json'`+
+		` --data-raw '{"exp":3600}'`, line)
+}
+
+func TestPrintCurl_DryRun(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request in dry-run mode: %s %s", r.Method, r.URL.Path)
+	}))
+	defer srv.Close()
+
+	var plan, curl bytes.Buffer
+	client := newTestClient(t, srv, WithDryRun(&plan), WithPrintCurl(&curl))
+
+	assert.ErrorIs(t, client.DeleteVideo(context.Background(), "abc"), ErrDryRun)
+	assert.Equal(t, `curl -X DELETE '`+srv.URL+`/accounts/acct/stream/abc' -H "Authorization: Bearer $CFSTREAM_API_TOKEN"`+"\n", curl.String())
+}
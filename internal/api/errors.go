@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/cloudflare/cloudflare-go/v3"
 )
@@ -23,6 +25,13 @@ var (
 
 	// ErrInvalidInput is returned when input validation fails.
 	ErrInvalidInput = errors.New("invalid input")
+
+	// ErrDryRun is returned by mutating methods when the client is in dry-run mode.
+	ErrDryRun = errors.New("dry run: request not sent")
+
+	// ErrUploadExpired is returned when resuming a TUS upload the server no
+	// longer has (404 or 410).
+	ErrUploadExpired = errors.New("upload expired: the server no longer accepts it")
 )
 
 // WrapError converts Cloudflare SDK errors into user-friendly errors.
@@ -74,10 +83,11 @@ func wrapAPIError(apiErr *cloudflare.Error) error {
 		}
 		return fmt.Errorf("%w", ErrForbidden)
 	case http.StatusTooManyRequests:
-		if errMsg != "" {
-			return fmt.Errorf("%w: %s", ErrRateLimit, errMsg)
+		rateErr := &RateLimitError{Message: errMsg}
+		if apiErr.Response != nil {
+			rateErr.RetryAfter = parseRetryAfter(apiErr.Response.Header.Get("Retry-After"), time.Now())
 		}
-		return fmt.Errorf("%w", ErrRateLimit)
+		return rateErr
 	case http.StatusBadRequest:
 		if errMsg != "" {
 			return fmt.Errorf("%w: %s", ErrInvalidInput, errMsg)
@@ -90,3 +100,65 @@ func wrapAPIError(apiErr *cloudflare.Error) error {
 		return fmt.Errorf("API error (status %d)", statusCode)
 	}
 }
+
+// statusError converts a non-2xx HTTP response into an error, wrapping the
+// matching sentinel error for well-known status codes.
+func statusError(statusCode int, header http.Header, body []byte) error {
+	switch statusCode {
+	case http.StatusNotFound:
+		return fmt.Errorf("%w: %s", ErrNotFound, string(body))
+	case http.StatusUnauthorized:
+		return fmt.Errorf("%w: %s", ErrUnauthorized, string(body))
+	case http.StatusForbidden:
+		return fmt.Errorf("%w: %s", ErrForbidden, string(body))
+	case http.StatusTooManyRequests:
+		return &RateLimitError{
+			RetryAfter: parseRetryAfter(header.Get("Retry-After"), time.Now()),
+			Message:    string(body),
+		}
+	default:
+		return fmt.Errorf("API request failed with status %d: %s", statusCode, string(body))
+	}
+}
+
+// RateLimitError is returned for 429 responses. It matches ErrRateLimit with
+// errors.Is and carries the delay the API asked for, if any.
+type RateLimitError struct {
+	RetryAfter time.Duration // Zero if the response had no Retry-After header
+	Message    string
+}
+
+func (e *RateLimitError) Error() string {
+	if e.Message == "" {
+		return ErrRateLimit.Error()
+	}
+	return ErrRateLimit.Error() + ": " + e.Message
+}
+
+func (e *RateLimitError) Unwrap() error {
+	return ErrRateLimit
+}
+
+// RetryAfter returns the delay requested by a rate-limited response, or zero
+// if err is not a rate limit error or the API gave no delay.
+func RetryAfter(err error) time.Duration {
+	var rateErr *RateLimitError
+	if errors.As(err, &rateErr) {
+		return rateErr.RetryAfter
+	}
+	return 0
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an HTTP date.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil && t.After(now) {
+		return t.Sub(now)
+	}
+	return 0
+}
@@ -21,7 +21,39 @@ type Video struct {
 	Preview           string
 	Thumbnail         string
 	Creator           string
-	Meta              map[string]interface{}
+
+	Width              int64 // Original input width in pixels, -1 if unknown
+	Height             int64 // Original input height in pixels, -1 if unknown
+	Size               int64 // Size in bytes
+	Uploaded           time.Time
+	MaxDurationSeconds int64    // Upload duration limit, -1 if unknown
+	LiveInput          string   // ID of the live input the video was recorded from
+	HLS                string   // HLS manifest URL
+	DASH               string   // DASH manifest URL
+	AllowedOrigins     []string // Origins allowed to embed the video; empty allows all
+
+	ErrorReasonCode       string // Why encoding failed, e.g. ERR_NON_VIDEO
+	ReadyToStreamAt       time.Time
+	ThumbnailTimestampPct float64
+	UploadExpiry          time.Time // When a pending direct upload URL expires
+	ScheduledDeletion     time.Time // When the video is deleted automatically, zero if never
+	Watermark             string    // UID of the watermark profile applied while encoding
+	WatermarkName         string
+
+	Meta map[string]interface{}
+}
+
+// MetaSourceURL is the metadata key recording the URL a video was copied
+// from, so a failed copy can be submitted again.
+const MetaSourceURL = "sourceURL"
+
+// SourceURL returns the URL the video was copied from, or "" when it was
+// not recorded.
+func (v *Video) SourceURL() string {
+	if url, ok := v.Meta[MetaSourceURL].(string); ok {
+		return url
+	}
+	return ""
 }
 
 // ListOptions contains parameters for listing videos.
@@ -32,22 +64,61 @@ type ListOptions struct {
 	End     *time.Time
 	Status  string
 	Asc     bool
+	Limit   int    // Maximum number of videos to return; 0 returns a full page
+	After   string // Cursor from VideoPager.Cursor or VideoCursor to continue after
 }
 
 // UpdateOptions contains parameters for updating a video.
 type UpdateOptions struct {
 	Meta              map[string]interface{}
 	RequireSignedURLs *bool // Pointer to allow nil (optional)
+	// AllowedOrigins replaces the embedding origins; nil leaves them unchanged
+	// and an empty non-nil slice allows all origins
+	AllowedOrigins []string
 }
 
 // EmbedOptions contains parameters for customizing embed code.
 type EmbedOptions struct {
-	Responsive  bool
-	Autoplay    bool
-	Muted       bool
-	Loop        bool
-	Controls    bool
-	SignedToken string
+	Responsive       bool
+	Autoplay         bool
+	Muted            bool
+	Loop             bool
+	Controls         bool
+	SignedToken      string
+	Width            int    // Player width in pixels (default 1280)
+	Height           int    // Player height in pixels (default 720)
+	Poster           string // Poster image URL shown before playback
+	Preload          string // none, metadata, or auto
+	PrimaryColor     string // Player accent color, e.g. #f48120
+	LetterboxColor   string // Letterbox color, e.g. transparent
+	StartTime        string // Initial playback position, e.g. 1m30s or 90
+	DefaultTextTrack string // Language code of the caption track to show
+}
+
+// TokenOptions contains parameters for generating a signed token.
+type TokenOptions struct {
+	Expires      int64        // Unix timestamp; zero uses the API default
+	Downloadable bool         // Allow MP4 downloads with this token
+	AccessRules  []AccessRule // Evaluated in order; the first matching rule decides
+}
+
+// Access rule types and actions.
+const (
+	AccessRuleAny     = "any"
+	AccessRuleIP      = "ip.src"
+	AccessRuleCountry = "ip.geoip.country"
+
+	AccessAllow = "allow"
+	AccessBlock = "block"
+)
+
+// AccessRule allows or blocks playback with a signed token by viewer IP
+// address or country. A rule of type any matches every viewer.
+type AccessRule struct {
+	Type    string   `json:"type"`
+	Action  string   `json:"action"`
+	IP      []string `json:"ip,omitempty"`      // CIDR ranges for ip.src
+	Country []string `json:"country,omitempty"` // ISO 3166-1 alpha-2 codes for ip.geoip.country
 }
 
 // UploadOptions contains parameters for uploading a video.
@@ -55,13 +126,51 @@ type UploadOptions struct {
 	Name              string
 	Metadata          map[string]interface{}
 	RequireSignedURLs bool
+	Watermark         string // Watermark profile UID applied while encoding
+	Creator           string // Creator ID to tag the video with
+
+	// ThumbnailTimestampPct is the thumbnail position as a fraction of the
+	// duration (0-1); zero keeps Stream's default
+	ThumbnailTimestampPct float64
+
+	// File uploads only
+	MaxDurationSeconds int        // Longest accepted video; zero means 6 hours
+	Expiry             *time.Time // When the upload URL stops accepting the file
+	AllowedOrigins     []string   // Origins allowed to embed the video; empty allows all
+	ScheduledDeletion  *time.Time // When the uploaded video is deleted automatically
+
+	// OnResumable is called when a TUS upload is created, so it can be
+	// continued with ResumeUpload after an interruption
+	OnResumable func(*ResumableUpload)
+}
+
+// ResumableUpload identifies a TUS upload that can be continued after an
+// interruption.
+type ResumableUpload struct {
+	UID      string // Video ID
+	Location string // TUS upload URL
+}
+
+// ClipOptions contains parameters for clipping a video.
+type ClipOptions struct {
+	Start             int64 // Start of the clip in seconds into the source video
+	End               int64 // End of the clip in seconds into the source video
+	Name              string
+	Metadata          map[string]interface{}
+	RequireSignedURLs bool
 }
 
 // DirectUploadOptions contains parameters for creating a direct upload URL.
 type DirectUploadOptions struct {
-	MaxDurationSeconds int
-	Expiry             *time.Time
-	RequireSignedURLs  bool
+	MaxDurationSeconds    int
+	Expiry                *time.Time
+	RequireSignedURLs     bool
+	Watermark             string // Watermark profile UID applied while encoding
+	Meta                  map[string]interface{}
+	Creator               string
+	AllowedOrigins        []string   // Origins allowed to embed the video; empty allows all
+	ThumbnailTimestampPct float64    // Thumbnail position as a fraction of the duration (0-1)
+	ScheduledDeletion     *time.Time // When the uploaded video is deleted automatically
 }
 
 // DirectUploadResult contains the response from creating a direct upload URL.
@@ -71,6 +180,175 @@ type DirectUploadResult struct {
 	Expiry    time.Time
 }
 
+// Downloads describes the MP4 download renditions of a video.
+type Downloads struct {
+	Default *Download `json:"default"`
+}
+
+// Download is a single MP4 download rendition.
+type Download struct {
+	Status          string  `json:"status"`
+	URL             string  `json:"url"`
+	PercentComplete float64 `json:"percentComplete"`
+}
+
+// Caption is a caption or subtitle track of a video.
+type Caption struct {
+	Language  string `json:"language"`
+	Label     string `json:"label"`
+	Generated bool   `json:"generated"`
+	Status    string `json:"status"`
+}
+
+// AudioTrack is an additional audio track of a video, such as a dubbed language.
+type AudioTrack struct {
+	UID     string `json:"uid"`
+	Label   string `json:"label"`
+	Default bool   `json:"default"` // Played by default in the Stream player
+	Status  string `json:"status"`
+}
+
+// Watermark is a watermark profile that can be applied to uploads.
+type Watermark struct {
+	UID      string    `json:"uid"`
+	Name     string    `json:"name"`
+	Opacity  float64   `json:"opacity"`
+	Padding  float64   `json:"padding"`
+	Scale    float64   `json:"scale"`
+	Position string    `json:"position"`
+	Created  time.Time `json:"created"`
+}
+
+// LiveInput is a live input that accepts a broadcast and plays it back.
+type LiveInput struct {
+	UID       string                 `json:"uid"`
+	Meta      map[string]interface{} `json:"meta"`
+	Created   time.Time              `json:"created"`
+	Modified  time.Time              `json:"modified"`
+	Recording LiveRecording          `json:"recording"`
+
+	// DeleteRecordingAfterDays is how long recordings are kept; zero keeps them forever
+	DeleteRecordingAfterDays int `json:"deleteRecordingAfterDays,omitempty"`
+
+	// Ingest endpoints for publishing a broadcast
+	RTMPS  LiveEndpoint `json:"rtmps"`
+	SRT    SRTEndpoint  `json:"srt"`
+	WebRTC LiveEndpoint `json:"webRTC"` // WHIP
+
+	// Playback endpoints for pulling the broadcast
+	RTMPSPlayback  LiveEndpoint `json:"rtmpsPlayback"`
+	SRTPlayback    SRTEndpoint  `json:"srtPlayback"`
+	WebRTCPlayback LiveEndpoint `json:"webRTCPlayback"` // WHEP
+}
+
+// LiveRecording holds the recording and playback settings of a live input.
+type LiveRecording struct {
+	Mode              string   `json:"mode"`
+	RequireSignedURLs bool     `json:"requireSignedURLs"`
+	AllowedOrigins    []string `json:"allowedOrigins"`
+	TimeoutSeconds    int      `json:"timeoutSeconds"`
+}
+
+// LiveEndpoint is an ingest or playback endpoint of a live input.
+type LiveEndpoint struct {
+	URL       string `json:"url"`
+	StreamKey string `json:"streamKey,omitempty"`
+}
+
+// LiveInputOptions contains parameters for creating or updating a live input.
+// Nil and empty fields are left unchanged.
+type LiveInputOptions struct {
+	Name              string
+	RecordingMode     string // off or automatic
+	RequireSignedURLs *bool
+	// DeleteRecordingAfterDays sets the recording retention in days; zero disables auto-deletion
+	DeleteRecordingAfterDays *int
+}
+
+// SRTEndpoint is an SRT ingest or playback endpoint of a live input.
+type SRTEndpoint struct {
+	URL        string `json:"url"`
+	StreamID   string `json:"streamId,omitempty"`
+	Passphrase string `json:"passphrase,omitempty"`
+}
+
+// Name returns the name from the live input metadata, falling back to the UID.
+func (l *LiveInput) Name() string {
+	if name, ok := l.Meta["name"].(string); ok && name != "" {
+		return name
+	}
+	return l.UID
+}
+
+// Analytics ranking orders.
+const (
+	OrderByViews   = "views"
+	OrderByMinutes = "minutes"
+)
+
+// AnalyticsOptions contains parameters for querying viewership analytics.
+type AnalyticsOptions struct {
+	Since    time.Time
+	Until    time.Time // Zero means now
+	Limit    int
+	OrderBy  string // OrderByViews (default) or OrderByMinutes
+	Interval string // IntervalHour, IntervalDay (default), or IntervalWeek
+}
+
+// SeriesPoint holds viewership totals for one interval of a time series.
+type SeriesPoint struct {
+	Time          time.Time `json:"time"`
+	Views         int64     `json:"views"`
+	MinutesViewed float64   `json:"minutesViewed"`
+}
+
+// VideoStats holds viewership totals for a video.
+type VideoStats struct {
+	UID           string  `json:"uid"`
+	Name          string  `json:"name"`
+	Views         int64   `json:"views"`
+	MinutesViewed float64 `json:"minutesViewed"`
+}
+
+// CountryStats holds viewership totals of a video from one viewer country.
+type CountryStats struct {
+	Country       string  `json:"country"`
+	Views         int64   `json:"views"`
+	MinutesViewed float64 `json:"minutesViewed"`
+}
+
+// Webhook is the account's webhook subscription for video notifications.
+type Webhook struct {
+	NotificationURL string    `json:"notificationUrl"`
+	Modified        time.Time `json:"modified"`
+	Secret          string    `json:"secret"` // Key for verifying Webhook-Signature headers
+}
+
+// SigningKey is a key for signing Stream tokens locally. PEM and JWK are
+// base64 encoded and only returned when the key is created.
+type SigningKey struct {
+	ID      string    `json:"id"`
+	PEM     string    `json:"pem"`
+	JWK     string    `json:"jwk"`
+	Created time.Time `json:"created"`
+}
+
+// StorageUsage reports the storage minutes used by an account against its plan.
+type StorageUsage struct {
+	TotalStorageMinutes      float64 `json:"totalStorageMinutes"`
+	TotalStorageMinutesLimit float64 `json:"totalStorageMinutesLimit"`
+	VideoCount               int     `json:"videoCount"`
+}
+
+// RemainingMinutes returns the storage minutes left on the plan.
+// It returns -1 when the plan has no known limit.
+func (u *StorageUsage) RemainingMinutes() float64 {
+	if u.TotalStorageMinutesLimit <= 0 {
+		return -1
+	}
+	return max(u.TotalStorageMinutesLimit-u.TotalStorageMinutes, 0)
+}
+
 // UploadProgress represents the current state of an upload.
 type UploadProgress struct {
 	BytesSent  int64
@@ -93,6 +371,24 @@ func VideoFromSDK(v *stream.Video) *Video {
 		Preview:           v.Preview,
 		Thumbnail:         v.Thumbnail,
 		Creator:           v.Creator,
+
+		Width:              v.Input.Width,
+		Height:             v.Input.Height,
+		Size:               int64(v.Size),
+		Uploaded:           v.Uploaded,
+		MaxDurationSeconds: v.MaxDurationSeconds,
+		LiveInput:          v.LiveInput,
+		HLS:                v.Playback.Hls,
+		DASH:               v.Playback.Dash,
+		AllowedOrigins:     v.AllowedOrigins,
+
+		ErrorReasonCode:       v.Status.ErrorReasonCode,
+		ReadyToStreamAt:       v.ReadyToStreamAt,
+		ThumbnailTimestampPct: v.ThumbnailTimestampPct,
+		UploadExpiry:          v.UploadExpiry,
+		ScheduledDeletion:     v.ScheduledDeletion,
+		Watermark:             v.Watermark.UID,
+		WatermarkName:         v.Watermark.Name,
 	}
 
 	// Extract status information
@@ -0,0 +1,89 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+)
+
+// UploadToURL uploads a file to a one-time upload URL created elsewhere, such
+// as a creator upload URL issued by a backend, and returns the UID of the
+// video, which Stream puts last in the URL path. The URL authorizes the
+// upload, so no API credentials are needed or sent; opts may set dry-run,
+// curl, and rate-limit behavior.
+//
+// TUS URLs, those with /tus/ in the path or any URL when tus is set, are
+// sent in chunks from the offset the server reports. Other URLs get a
+// multipart/form-data POST.
+func UploadToURL(ctx context.Context, uploadURL, filePath string, tus bool, progressCh chan<- UploadProgress, opts ...Option) (string, error) {
+	u, err := url.Parse(uploadURL)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return "", fmt.Errorf("%w: invalid upload URL: %s", ErrInvalidInput, uploadURL)
+	}
+	tus = tus || strings.Contains(u.Path, "/tus/")
+
+	uid := path.Base(u.Path)
+	if uid == "/" || uid == "." {
+		uid = ""
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	fileInfo, err := file.Stat()
+	if err != nil {
+		return "", fmt.Errorf("failed to get file info: %w", err)
+	}
+	fileSize := fileInfo.Size()
+
+	c := newClientImpl("", "", opts)
+
+	if c.dryRun != nil {
+		if tus {
+			chunks := (fileSize + tusChunkSize - 1) / tusChunkSize
+			fmt.Fprintf(c.dryRun, "[dry-run] upload %s (%d bytes) with TUS in up to %d chunk(s)\n", file.Name(), fileSize, chunks)
+			c.plan(http.MethodHead, uploadURL, map[string]string{"Tus-Resumable": "1.0.0"}, nil)
+			c.plan(http.MethodPatch, uploadURL, map[string]string{
+				"Tus-Resumable": "1.0.0",
+				"Content-Type":  "application/offset+octet-stream",
+			}, fmt.Sprintf("%s (%d bytes) from the server offset", file.Name(), fileSize))
+			return "", ErrDryRun
+		}
+		fmt.Fprintf(c.dryRun, "[dry-run] upload %s (%d bytes) with a multipart upload\n", file.Name(), fileSize)
+		c.plan(http.MethodPost, uploadURL, map[string]string{
+			"Content-Type": "multipart/form-data",
+		}, fmt.Sprintf("file=%s (%d bytes)", file.Name(), fileSize))
+		return "", ErrDryRun
+	}
+
+	if !tus {
+		if err := c.multipartUpload(ctx, uploadURL, file, fileSize, nil, progressCh); err != nil {
+			return "", fmt.Errorf("upload failed: %w", err)
+		}
+		return uid, nil
+	}
+
+	// One-time TUS URLs are created with the upload length, so the upload
+	// continues from the offset like a resumed one
+	offset, length, err := c.tusOffset(ctx, uploadURL)
+	if err != nil {
+		return "", err
+	}
+	if length >= 0 && length != fileSize {
+		return "", fmt.Errorf("%w: %s is %d bytes but the upload URL expects %d", ErrInvalidInput, filePath, fileSize, length)
+	}
+	if offset < fileSize {
+		if err := c.tusSend(ctx, uploadURL, file, offset, fileSize, progressCh); err != nil {
+			return "", fmt.Errorf("TUS upload failed: %w", err)
+		}
+	}
+
+	return uid, nil
+}
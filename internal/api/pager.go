@@ -0,0 +1,107 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// listPageSize is the most videos the Stream API returns for one list request.
+const listPageSize = 1000
+
+// VideoPager lists the video library one page at a time, e.g.
+//
+//	pager := client.ListVideosPager(&api.ListOptions{})
+//	for pager.More() {
+//		videos, err := pager.Next(ctx)
+//		...
+//	}
+//
+// Stream has no list cursors, so pages continue from the creation time of
+// the last video returned.
+type VideoPager struct {
+	fetch  func(ctx context.Context, opts *ListOptions) ([]Video, bool, error)
+	opts   ListOptions
+	cursor string
+	count  int
+	done   bool
+}
+
+// newVideoPager returns a pager over fetch, which returns one page of the
+// videos after opts.After and whether the page was full.
+func newVideoPager(fetch func(ctx context.Context, opts *ListOptions) ([]Video, bool, error), opts *ListOptions) *VideoPager {
+	p := &VideoPager{fetch: fetch}
+	if opts != nil {
+		p.opts = *opts
+	}
+	p.cursor = p.opts.After
+	return p
+}
+
+// More reports whether Next may return more videos.
+func (p *VideoPager) More() bool {
+	return !p.done
+}
+
+// Next returns the next page of videos. Once More reports false, Next
+// returns an empty page.
+func (p *VideoPager) Next(ctx context.Context) ([]Video, error) {
+	if p.done {
+		return []Video{}, nil
+	}
+
+	opts := p.opts
+	opts.After = p.cursor
+	opts.Limit = 0
+
+	videos, full, err := p.fetch(ctx, &opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.opts.Limit > 0 && p.count+len(videos) >= p.opts.Limit {
+		videos = videos[:p.opts.Limit-p.count]
+		p.done = true
+	}
+	if !full || len(videos) == 0 {
+		p.done = true
+	}
+
+	p.count += len(videos)
+	if len(videos) > 0 {
+		p.cursor = VideoCursor(&videos[len(videos)-1])
+	}
+	return videos, nil
+}
+
+// Cursor returns the cursor after the last video returned so far, for
+// ListOptions.After. It is empty before the first page.
+func (p *VideoPager) Cursor() string {
+	return p.cursor
+}
+
+// VideoCursor returns the cursor that continues a listing after video.
+func VideoCursor(video *Video) string {
+	return video.Created.UTC().Format(time.RFC3339Nano)
+}
+
+// parseCursor parses a cursor returned by VideoCursor.
+func parseCursor(cursor string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339Nano, cursor)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: invalid list cursor %q", ErrInvalidInput, cursor)
+	}
+	return t, nil
+}
+
+// afterCursor drops the videos that a listing in the given order returned
+// before or at the cursor time.
+func afterCursor(videos []Video, cursor time.Time, asc bool) []Video {
+	kept := videos[:0]
+	for _, v := range videos {
+		if (asc && v.Created.After(cursor)) || (!asc && v.Created.Before(cursor)) {
+			kept = append(kept, v)
+		}
+	}
+	return kept
+}
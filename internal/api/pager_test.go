@@ -0,0 +1,133 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pagedLibrary serves a library of n videos, one created per 100ms and
+// listed newest first, in pages of listPageSize like the list API.
+func pagedLibrary(t *testing.T, n int) (*httptest.Server, *int) {
+	t.Helper()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	requests := 0
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		end := time.Date(9999, 1, 1, 0, 0, 0, 0, time.UTC)
+		if value := r.URL.Query().Get("end"); value != "" {
+			var err error
+			end, err = time.Parse(time.RFC3339, value)
+			assert.NoError(t, err)
+		}
+
+		var result []map[string]interface{}
+		for i := n - 1; i >= 0 && len(result) < listPageSize; i-- {
+			created := start.Add(time.Duration(i) * 100 * time.Millisecond)
+			if created.Before(end) {
+				result = append(result, map[string]interface{}{
+					"uid":     fmt.Sprintf("v%d", i),
+					"created": created.Format(time.RFC3339Nano),
+				})
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"success": true, "result": result}) //nolint:errcheck // Test server
+	}))
+	return srv, &requests
+}
+
+func TestVideoPager(t *testing.T) {
+	srv, requests := pagedLibrary(t, 2500)
+	defer srv.Close()
+
+	pager := newTestClient(t, srv).ListVideosPager(&ListOptions{})
+	seen := make(map[string]bool)
+	for pager.More() {
+		videos, err := pager.Next(context.Background())
+		require.NoError(t, err)
+		for _, v := range videos {
+			assert.False(t, seen[v.UID], "duplicate %s", v.UID)
+			seen[v.UID] = true
+		}
+	}
+
+	assert.Len(t, seen, 2500)
+	assert.Equal(t, 3, *requests)
+}
+
+func TestVideoPager_Limit(t *testing.T) {
+	srv, _ := pagedLibrary(t, 2500)
+	defer srv.Close()
+
+	client := newTestClient(t, srv)
+	pager := client.ListVideosPager(&ListOptions{Limit: 1200})
+
+	var videos []Video
+	for pager.More() {
+		page, err := pager.Next(context.Background())
+		require.NoError(t, err)
+		videos = append(videos, page...)
+	}
+	require.Len(t, videos, 1200)
+	assert.Equal(t, "v1300", videos[1199].UID)
+
+	// The cursor continues where the limited listing stopped
+	next, err := client.ListVideos(context.Background(), &ListOptions{After: pager.Cursor(), Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, next, 2)
+	assert.Equal(t, "v1299", next[0].UID)
+	assert.Equal(t, "v1298", next[1].UID)
+}
+
+func TestListVideos_InvalidCursor(t *testing.T) {
+	srv, _ := pagedLibrary(t, 1)
+	defer srv.Close()
+
+	_, err := newTestClient(t, srv).ListVideos(context.Background(), &ListOptions{After: "page-2"})
+	assert.ErrorIs(t, err, ErrInvalidInput)
+}
+
+func TestListVideos_Status(t *testing.T) {
+	var query string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query().Get("status")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"success":true,"result":[` + //nolint:errcheck // Test server
+			`{"uid":"a","status":{"state":"ready"}},` +
+			`{"uid":"b","status":{"state":"error"}},` +
+			`{"uid":"c","status":{"state":"inprogress"}},` +
+			`{"uid":"d","status":{"state":"queued"}}]}`))
+	}))
+	defer srv.Close()
+	client := newTestClient(t, srv)
+
+	uids := func(videos []Video) []string {
+		var ids []string
+		for _, v := range videos {
+			ids = append(ids, v.UID)
+		}
+		return ids
+	}
+
+	videos, err := client.ListVideos(context.Background(), &ListOptions{Status: "error"})
+	require.NoError(t, err)
+	assert.Equal(t, "error", query)
+	assert.Equal(t, []string{"b"}, uids(videos))
+
+	videos, err = client.ListVideos(context.Background(), &ListOptions{Status: "Processing"})
+	require.NoError(t, err)
+	assert.Empty(t, query)
+	assert.Equal(t, []string{"c", "d"}, uids(videos))
+
+	_, err = client.ListVideos(context.Background(), &ListOptions{Status: "done"})
+	assert.ErrorIs(t, err, ErrInvalidInput)
+}
@@ -0,0 +1,71 @@
+package api
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// maxRateLimitBackoff caps the wait between retries when the API sends no Retry-After.
+const maxRateLimitBackoff = time.Minute
+
+// WithRateLimitWait makes the client wait and retry rate-limited (429)
+// requests instead of failing them, until the request's context ends.
+// notify is called with the wait before each retry and may be nil.
+func WithRateLimitWait(notify func(wait time.Duration)) Option {
+	return func(c *ClientImpl) {
+		c.rateLimitWait = notify
+		c.waitOnRateLimit = true
+	}
+}
+
+// rateLimitTransport retries requests that receive a 429 response after the
+// Retry-After delay, or an exponential backoff when none is given.
+type rateLimitTransport struct {
+	next   http.RoundTripper
+	notify func(wait time.Duration)
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	backoff := time.Second
+	for {
+		resp, err := t.next.RoundTrip(req)
+		if err != nil || resp.StatusCode != http.StatusTooManyRequests {
+			return resp, err
+		}
+		// Requests whose body can't be replayed are returned as is
+		if req.Body != nil && req.GetBody == nil {
+			return resp, nil
+		}
+
+		wait := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+		if wait <= 0 {
+			wait = backoff
+			backoff = min(backoff*2, maxRateLimitBackoff)
+		}
+		_, _ = io.Copy(io.Discard, resp.Body) //nolint:errcheck // Draining lets the connection be reused
+		resp.Body.Close()
+
+		if t.notify != nil {
+			t.notify(wait)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, fmt.Errorf("%w: gave up waiting: %w", ErrRateLimit, req.Context().Err())
+		case <-timer.C:
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("failed to replay request body: %w", err)
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	// tusThreshold is the file size at which uploads switch to the TUS protocol.
+	tusThreshold = 200 * 1024 * 1024 // 200 MB
+
+	// tusChunkSize is the size of each TUS PATCH request.
+	tusChunkSize = 50 * 1024 * 1024 // 50 MB
+
+	// tusChunkRetries is the number of retries of a failed TUS chunk before
+	// the upload fails.
+	tusChunkRetries = 5
+)
+
+// apiResponse is the standard Cloudflare v4 API response envelope.
+type apiResponse struct {
+	Result  json.RawMessage `json:"result"`
+	Success bool            `json:"success"`
+	Errors  []struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// accountURL returns the absolute URL of an account-scoped API path.
+func (c *ClientImpl) accountURL(format string, args ...interface{}) string {
+	return fmt.Sprintf("%s/accounts/%s/%s", c.baseURL, c.accountID, fmt.Sprintf(format, args...))
+}
+
+// mutate sends a request that changes account state. In dry-run mode the
+// request is described instead and ErrDryRun is returned.
+func (c *ClientImpl) mutate(ctx context.Context, method, url string, body, result interface{}) error {
+	if c.dryRun != nil {
+		c.plan(method, url, nil, body)
+		return ErrDryRun
+	}
+	return c.doJSON(ctx, method, url, body, result)
+}
+
+// doJSON sends a JSON request to the Cloudflare API and decodes the result
+// field of the response envelope into result, if result is non-nil.
+func (c *ClientImpl) doJSON(ctx context.Context, method, url string, body, result interface{}) error {
+	var reqBody io.Reader
+	if body != nil {
+		jsonBody, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		reqBody = bytes.NewReader(jsonBody)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	return c.send(req, result)
+}
+
+// doMultipart uploads a file as the "file" field of a multipart form and
+// decodes the response like doJSON. In dry-run mode the request is described instead.
+func (c *ClientImpl) doMultipart(ctx context.Context, method, url, filePath string, result interface{}) error {
+	if c.dryRun != nil {
+		c.plan(method, url, map[string]string{"Content-Type": "multipart/form-data"}, fmt.Sprintf("file=%s", filePath))
+		return ErrDryRun
+	}
+
+	file, err := os.Open(filePath) //nolint:gosec // Path is provided by the user
+	if err != nil {
+		return fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+	part, err := writer.CreateFormFile("file", filepath.Base(filePath))
+	if err != nil {
+		return fmt.Errorf("failed to create form file: %w", err)
+	}
+	if _, err := io.Copy(part, file); err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("failed to close multipart writer: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, &buf)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	return c.send(req, result)
+}
+
+// send authenticates and executes req, then decodes the result field of the
+// Cloudflare response envelope into result, if result is non-nil.
+func (c *ClientImpl) send(req *http.Request, result interface{}) error {
+	respBody, err := c.execute(req)
+	if err != nil {
+		return err
+	}
+
+	var apiResp apiResponse
+	if err := json.Unmarshal(respBody, &apiResp); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if !apiResp.Success {
+		if len(apiResp.Errors) > 0 {
+			return fmt.Errorf("API error: %s", apiResp.Errors[0].Message)
+		}
+		return fmt.Errorf("API request failed")
+	}
+
+	if result != nil && len(apiResp.Result) > 0 {
+		if err := json.Unmarshal(apiResp.Result, result); err != nil {
+			return fmt.Errorf("failed to parse response: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// execute authenticates and executes req and returns the body of a 2xx response.
+func (c *ClientImpl) execute(req *http.Request) ([]byte, error) {
+	req.Header.Set("Authorization", "Bearer "+c.apiToken)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, statusError(resp.StatusCode, resp.Header, respBody)
+	}
+
+	return respBody, nil
+}
+
+// plan writes a description of a request that would be sent to the dry-run writer.
+// A string body is written verbatim; any other non-nil body is written as indented JSON.
+// With WithPrintCurl, the equivalent curl command follows the description.
+func (c *ClientImpl) plan(method, url string, headers map[string]string, body interface{}) {
+	fmt.Fprintf(c.dryRun, "[dry-run] %s %s\n", method, url)
+	defer c.planCurl(method, url, headers, body)
+
+	keys := make([]string, 0, len(headers))
+	for k := range headers {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(c.dryRun, "  %s: %s\n", k, headers[k])
+	}
+
+	switch b := body.(type) {
+	case nil:
+	case string:
+		fmt.Fprintf(c.dryRun, "  %s\n", b)
+	default:
+		data, err := json.MarshalIndent(b, "  ", "  ")
+		if err != nil {
+			fmt.Fprintf(c.dryRun, "  <unencodable body: %v>\n", err)
+			return
+		}
+		fmt.Fprintf(c.dryRun, "  %s\n", strings.TrimSpace(string(data)))
+	}
+}
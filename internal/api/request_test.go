@@ -0,0 +1,843 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestClient returns a client pointed at srv.
+func newTestClient(t *testing.T, srv *httptest.Server, opts ...Option) *ClientImpl {
+	t.Helper()
+	opts = append([]Option{WithBaseURL(srv.URL), WithHTTPClient(srv.Client())}, opts...)
+	client, err := NewClient("acct", "token", opts...)
+	require.NoError(t, err)
+	return client.(*ClientImpl)
+}
+
+func TestDoJSON(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		assert.Equal(t, "/accounts/acct/stream/abc/token", r.URL.Path)
+
+		var body map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, float64(3600), body["exp"])
+
+		w.Write([]byte(`{"success":true,"result":{"token":"signed"}}`)) //nolint:errcheck // Test server
+	}))
+	defer srv.Close()
+
+	token, err := newTestClient(t, srv).GetSignedToken(context.Background(), "abc", 3600)
+	require.NoError(t, err)
+	assert.Equal(t, "signed", token)
+}
+
+func TestGetDownloads(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/accounts/acct/stream/abc/downloads", r.URL.Path)
+		w.Write([]byte(`{"success":true,"result":{"default":{"status":"ready","url":"https://example.com/abc.mp4","percentComplete":100}}}`)) //nolint:errcheck // Test server
+	}))
+	defer srv.Close()
+
+	// Reads are still sent in dry-run mode
+	downloads, err := newTestClient(t, srv, WithDryRun(io.Discard)).GetDownloads(context.Background(), "abc")
+	require.NoError(t, err)
+	require.NotNil(t, downloads.Default)
+	assert.Equal(t, "ready", downloads.Default.Status)
+	assert.Equal(t, "https://example.com/abc.mp4", downloads.Default.URL)
+}
+
+func TestEnableDownloads(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/accounts/acct/stream/abc/downloads", r.URL.Path)
+		w.Write([]byte(`{"success":true,"result":{"default":{"status":"inprogress","url":"https://example.com/abc.mp4","percentComplete":0}}}`)) //nolint:errcheck // Test server
+	}))
+	defer srv.Close()
+
+	downloads, err := newTestClient(t, srv).EnableDownloads(context.Background(), "abc")
+	require.NoError(t, err)
+	require.NotNil(t, downloads.Default)
+	assert.Equal(t, "inprogress", downloads.Default.Status)
+}
+
+func TestCreateClip(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/accounts/acct/stream/clip", r.URL.Path)
+
+		var body map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "abc", body["clippedFromVideoUID"])
+		assert.Equal(t, float64(30), body["startTimeSeconds"])
+		assert.Equal(t, float64(90), body["endTimeSeconds"])
+		assert.Equal(t, map[string]interface{}{"name": "Highlight", "campaign": "spring"}, body["meta"])
+
+		w.Write([]byte(`{"success":true,"result":{"uid":"clip1","clippedFromVideoUID":"abc","meta":{"name":"Highlight","campaign":"spring"},"status":{"state":"queued"}}}`)) //nolint:errcheck // Test server
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv)
+	video, err := client.CreateClip(context.Background(), "abc", &ClipOptions{
+		Start:    30,
+		End:      90,
+		Name:     "Highlight",
+		Metadata: map[string]interface{}{"campaign": "spring"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "clip1", video.UID)
+	assert.Equal(t, "Highlight", video.Name)
+	assert.Equal(t, "queued", video.Status)
+
+	_, err = client.CreateClip(context.Background(), "abc", &ClipOptions{Start: 90, End: 30})
+	assert.ErrorIs(t, err, ErrInvalidInput)
+}
+
+func TestGetStorageUsage(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/accounts/acct/stream/storage-usage", r.URL.Path)
+		w.Write([]byte(`{"success":true,"result":{"totalStorageMinutes":950,"totalStorageMinutesLimit":1000,"videoCount":12}}`)) //nolint:errcheck // Test server
+	}))
+	defer srv.Close()
+
+	usage, err := newTestClient(t, srv).GetStorageUsage(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 12, usage.VideoCount)
+	assert.Equal(t, float64(50), usage.RemainingMinutes())
+
+	assert.Equal(t, float64(-1), (&StorageUsage{TotalStorageMinutes: 10}).RemainingMinutes())
+	assert.Equal(t, float64(0), (&StorageUsage{TotalStorageMinutes: 20, TotalStorageMinutesLimit: 10}).RemainingMinutes())
+}
+
+func TestGetLiveInput(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/accounts/acct/stream/live_inputs/live1", r.URL.Path)
+		w.Write([]byte(`{"success":true,"result":{"uid":"live1","meta":{"name":"Town hall"},"recording":{"mode":"automatic","requireSignedURLs":true},"rtmps":{"url":"rtmps://live.cloudflare.com:443/live/","streamKey":"secret"},"srt":{"url":"srt://live.cloudflare.com:778","streamId":"sid","passphrase":"pass"},"webRTC":{"url":"https://customer-x.cloudflarestream.com/abc/webRTC/publish"},"webRTCPlayback":{"url":"https://customer-x.cloudflarestream.com/abc/webRTC/play"}}}`)) //nolint:errcheck // Test server
+	}))
+	defer srv.Close()
+
+	input, err := newTestClient(t, srv).GetLiveInput(context.Background(), "live1")
+	require.NoError(t, err)
+	assert.Equal(t, "Town hall", input.Name())
+	assert.True(t, input.Recording.RequireSignedURLs)
+	assert.Equal(t, "secret", input.RTMPS.StreamKey)
+	assert.Equal(t, "sid", input.SRT.StreamID)
+	assert.Equal(t, "pass", input.SRT.Passphrase)
+	assert.Equal(t, "https://customer-x.cloudflarestream.com/abc/webRTC/publish", input.WebRTC.URL)
+	assert.Equal(t, "https://customer-x.cloudflarestream.com/abc/webRTC/play", input.WebRTCPlayback.URL)
+}
+
+func TestGetVideo_Details(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/accounts/acct/stream/abc", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"success":true,"result":{"uid":"abc","meta":{"name":"Intro"},"status":{"state":"ready"},"readyToStream":true,"readyToStreamAt":"2024-05-01T10:00:00Z","duration":10,"size":2500000,"input":{"width":1920,"height":1080},"playback":{"hls":"https://example.com/abc/manifest/video.m3u8","dash":"https://example.com/abc/manifest/video.mpd"},"watermark":{"uid":"wm1","name":"Logo"},"liveInput":"live1"}}`)) //nolint:errcheck // Test server
+	}))
+	defer srv.Close()
+
+	video, err := newTestClient(t, srv).GetVideo(context.Background(), "abc")
+	require.NoError(t, err)
+	assert.Equal(t, "Intro", video.Name)
+	assert.Equal(t, "ready", video.Status)
+	assert.Equal(t, time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC), video.ReadyToStreamAt.UTC())
+	assert.True(t, video.ScheduledDeletion.IsZero())
+	assert.Equal(t, int64(2500000), video.Size)
+	assert.Equal(t, int64(1920), video.Width)
+	assert.Equal(t, "https://example.com/abc/manifest/video.mpd", video.DASH)
+	assert.Equal(t, "wm1", video.Watermark)
+	assert.Equal(t, "Logo", video.WatermarkName)
+	assert.Equal(t, "live1", video.LiveInput)
+}
+
+func TestUpdateLiveInput(t *testing.T) {
+	tests := []struct {
+		name string
+		days int
+		want interface{}
+	}{
+		{name: "sets retention", days: 30, want: float64(30)},
+		{name: "zero disables auto-deletion", days: 0, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPut, r.Method)
+				assert.Equal(t, "/accounts/acct/stream/live_inputs/live1", r.URL.Path)
+
+				var body map[string]interface{}
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				assert.Contains(t, body, "deleteRecordingAfterDays")
+				assert.Equal(t, tt.want, body["deleteRecordingAfterDays"])
+				assert.NotContains(t, body, "recording")
+
+				w.Write([]byte(`{"success":true,"result":{"uid":"live1","deleteRecordingAfterDays":30}}`)) //nolint:errcheck // Test server
+			}))
+			defer srv.Close()
+
+			days := tt.days
+			input, err := newTestClient(t, srv).UpdateLiveInput(context.Background(), "live1", &LiveInputOptions{DeleteRecordingAfterDays: &days})
+			require.NoError(t, err)
+			assert.Equal(t, 30, input.DeleteRecordingAfterDays)
+		})
+	}
+}
+
+func TestListLiveInputVideos(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/accounts/acct/stream/live_inputs/live1/videos", r.URL.Path)
+		w.Write([]byte(`{"success":true,"result":[{"uid":"rec1","meta":{"name":"Monday"},"status":{"state":"ready"},"created":"2024-01-01T00:00:00Z"}]}`)) //nolint:errcheck // Test server
+	}))
+	defer srv.Close()
+
+	videos, err := newTestClient(t, srv).ListLiveInputVideos(context.Background(), "live1")
+	require.NoError(t, err)
+	require.Len(t, videos, 1)
+	assert.Equal(t, "rec1", videos[0].UID)
+	assert.Equal(t, "Monday", videos[0].Name)
+	assert.Equal(t, "ready", videos[0].Status)
+}
+
+func TestGetSignedTokenWithOptions(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, true, body["downloadable"])
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"type": "ip.geoip.country", "action": "allow", "country": []interface{}{"US", "CA"}},
+			map[string]interface{}{"type": "any", "action": "block"},
+		}, body["accessRules"])
+		w.Write([]byte(`{"success":true,"result":{"token":"dl"}}`)) //nolint:errcheck // Test server
+	}))
+	defer srv.Close()
+
+	token, err := newTestClient(t, srv).GetSignedTokenWithOptions(context.Background(), "abc", &TokenOptions{
+		Downloadable: true,
+		AccessRules: []AccessRule{
+			{Type: AccessRuleCountry, Action: AccessAllow, Country: []string{"US", "CA"}},
+			{Type: AccessRuleAny, Action: AccessBlock},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "dl", token)
+}
+
+func TestUploadCaption(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/accounts/acct/stream/abc/captions/en", r.URL.Path)
+
+		file, header, err := r.FormFile("file")
+		require.NoError(t, err)
+		defer file.Close()
+		data, _ := io.ReadAll(file) //nolint:errcheck // Test server
+		assert.Equal(t, "subs.vtt", header.Filename)
+		assert.Equal(t, "WEBVTT\n", string(data))
+
+		w.Write([]byte(`{"success":true,"result":{"language":"en","label":"English","status":"ready"}}`)) //nolint:errcheck // Test server
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "subs.vtt")
+	require.NoError(t, os.WriteFile(path, []byte("WEBVTT\n"), 0o600))
+
+	caption, err := newTestClient(t, srv).UploadCaption(context.Background(), "abc", "en", path)
+	require.NoError(t, err)
+	assert.Equal(t, "English", caption.Label)
+}
+
+func TestUpdateVideo_AllowedOrigins(t *testing.T) {
+	tests := []struct {
+		name    string
+		origins []string
+		want    interface{}
+	}{
+		{name: "sets origins", origins: []string{"example.com"}, want: []interface{}{"example.com"}},
+		{name: "empty clears origins", origins: []string{}, want: []interface{}{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var body map[string]interface{}
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				assert.Equal(t, tt.want, body["allowedOrigins"])
+				assert.NotContains(t, body, "meta")
+
+				w.Write([]byte(`{"success":true,"result":{"uid":"abc","allowedOrigins":["example.com"]}}`)) //nolint:errcheck // Test server
+			}))
+			defer srv.Close()
+
+			video, err := newTestClient(t, srv).UpdateVideo(context.Background(), "abc", &UpdateOptions{AllowedOrigins: tt.origins})
+			require.NoError(t, err)
+			assert.Equal(t, []string{"example.com"}, video.AllowedOrigins)
+		})
+	}
+}
+
+func TestUploadFromURL_Watermark(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/accounts/acct/stream/copy", r.URL.Path)
+
+		var body map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, map[string]interface{}{"uid": "wm1"}, body["watermark"])
+
+		w.Write([]byte(`{"success":true,"result":{"uid":"copy1"}}`)) //nolint:errcheck // Test server
+	}))
+	defer srv.Close()
+
+	video, err := newTestClient(t, srv).UploadFromURL(context.Background(), "https://example.com/a.mp4", &UploadOptions{Watermark: "wm1"})
+	require.NoError(t, err)
+	assert.Equal(t, "copy1", video.UID)
+}
+
+func TestUploadFromURL_Options(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, false, body["requireSignedURLs"])
+		assert.Equal(t, "alice", body["creator"])
+		assert.Equal(t, map[string]interface{}{"name": "Intro", "course": "go101"}, body["meta"])
+		assert.Equal(t, 0.5, body["thumbnailTimestampPct"])
+
+		w.Write([]byte(`{"success":true,"result":{"uid":"copy1"}}`)) //nolint:errcheck // Test server
+	}))
+	defer srv.Close()
+
+	_, err := newTestClient(t, srv).UploadFromURL(context.Background(), "https://example.com/a.mp4", &UploadOptions{
+		Name:                  "Intro",
+		Metadata:              map[string]interface{}{"course": "go101"},
+		Creator:               "alice",
+		ThumbnailTimestampPct: 0.5,
+	})
+	require.NoError(t, err)
+
+	_, err = newTestClient(t, srv).UploadFromURL(context.Background(), "https://example.com/a.mp4", &UploadOptions{ThumbnailTimestampPct: 1.5})
+	assert.ErrorIs(t, err, ErrInvalidInput)
+}
+
+func TestCreateDirectUploadURL_Watermark(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/accounts/acct/stream/direct_upload", r.URL.Path)
+
+		var body map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, map[string]interface{}{"uid": "wm1"}, body["watermark"])
+
+		w.Write([]byte(`{"success":true,"result":{"uid":"up1","uploadURL":"https://upload.example.com/up1"}}`)) //nolint:errcheck // Test server
+	}))
+	defer srv.Close()
+
+	result, err := newTestClient(t, srv).CreateDirectUploadURL(context.Background(), &DirectUploadOptions{Watermark: "wm1"})
+	require.NoError(t, err)
+	assert.Equal(t, "up1", result.UID)
+}
+
+func TestCreateDirectUploadURL_Options(t *testing.T) {
+	deletion := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, map[string]interface{}{"name": "intro"}, body["meta"])
+		assert.Equal(t, "alice", body["creator"])
+		assert.Equal(t, []interface{}{"example.com"}, body["allowedOrigins"])
+		assert.InDelta(t, 0.25, body["thumbnailTimestampPct"], 1e-9)
+		assert.Equal(t, "2030-01-02T03:04:05Z", body["scheduledDeletion"])
+		assert.NotContains(t, body, "watermark")
+
+		w.Write([]byte(`{"success":true,"result":{"uid":"up1","uploadURL":"https://upload.example.com/up1"}}`)) //nolint:errcheck // Test server
+	}))
+	defer srv.Close()
+
+	_, err := newTestClient(t, srv).CreateDirectUploadURL(context.Background(), &DirectUploadOptions{
+		Meta:                  map[string]interface{}{"name": "intro"},
+		Creator:               "alice",
+		AllowedOrigins:        []string{"example.com"},
+		ThumbnailTimestampPct: 0.25,
+		ScheduledDeletion:     &deletion,
+	})
+	require.NoError(t, err)
+}
+
+func TestTUSMetadata_Watermark(t *testing.T) {
+	metadata := tusMetadata(&UploadOptions{Name: "a", Watermark: "wm1"})
+	assert.Equal(t, "name YQ==,watermark d20x", metadata)
+}
+
+func TestTUSMetadata_Options(t *testing.T) {
+	expiry := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	deletion := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	metadata := tusMetadata(&UploadOptions{
+		RequireSignedURLs:  true,
+		MaxDurationSeconds: 600,
+		Expiry:             &expiry,
+		AllowedOrigins:     []string{"example.com", "*.example.org"},
+		ScheduledDeletion:  &deletion,
+
+		ThumbnailTimestampPct: 0.25,
+	})
+
+	parts := strings.Split(metadata, ",")
+	require.Len(t, parts, 6)
+	assert.Equal(t, "requiresignedurls", parts[0])
+
+	values := make(map[string]string)
+	for _, part := range parts[1:] {
+		key, encoded, ok := strings.Cut(part, " ")
+		require.True(t, ok, part)
+		value, err := base64.StdEncoding.DecodeString(encoded)
+		require.NoError(t, err)
+		values[key] = string(value)
+	}
+	assert.Equal(t, map[string]string{
+		"maxdurationseconds":    "600",
+		"expiry":                "2025-01-02T03:04:05Z",
+		"allowedorigins":        "example.com,*.example.org",
+		"scheduleddeletion":     "2025-06-01T00:00:00Z",
+		"thumbnailtimestamppct": "0.25",
+	}, values)
+
+	// Small files get the same options through the direct upload
+	direct := fileUploadOptions(&UploadOptions{AllowedOrigins: []string{"example.com"}, ScheduledDeletion: &deletion})
+	assert.Equal(t, 21600, direct.MaxDurationSeconds)
+	assert.Equal(t, []string{"example.com"}, direct.AllowedOrigins)
+	assert.Equal(t, &deletion, direct.ScheduledDeletion)
+}
+
+func TestResumeUpload(t *testing.T) {
+	var patched []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodHead && r.URL.Path == "/tus/abc":
+			assert.Equal(t, "1.0.0", r.Header.Get("Tus-Resumable"))
+			w.Header().Set("Upload-Offset", "4")
+			w.Header().Set("Upload-Length", "10")
+			w.WriteHeader(http.StatusOK)
+		case r.Method == http.MethodPatch && r.URL.Path == "/tus/abc":
+			assert.Equal(t, "4", r.Header.Get("Upload-Offset"))
+			patched, _ = io.ReadAll(r.Body) //nolint:errcheck // Checked below
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodHead && r.URL.Path == "/tus/gone":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			assert.Equal(t, "/accounts/acct/stream/abc", r.URL.Path)
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"success":true,"result":{"uid":"abc","status":{"state":"queued"}}}`)) //nolint:errcheck // Test server
+		}
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "video.mp4")
+	require.NoError(t, os.WriteFile(path, []byte("0123456789"), 0o600))
+
+	client := newTestClient(t, srv)
+	ctx := context.Background()
+
+	video, err := client.ResumeUpload(ctx, &ResumableUpload{UID: "abc", Location: srv.URL + "/tus/abc"}, path, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "abc", video.UID)
+	assert.Equal(t, "456789", string(patched))
+
+	_, err = client.ResumeUpload(ctx, &ResumableUpload{UID: "gone", Location: srv.URL + "/tus/gone"}, path, nil)
+	assert.ErrorIs(t, err, ErrUploadExpired)
+
+	require.NoError(t, os.WriteFile(path, []byte("short"), 0o600))
+	_, err = client.ResumeUpload(ctx, &ResumableUpload{UID: "abc", Location: srv.URL + "/tus/abc"}, path, nil)
+	assert.ErrorIs(t, err, ErrInvalidInput)
+}
+
+func TestResumeUpload_RetriesChunks(t *testing.T) {
+	var received []byte
+	offset, failures := 4, 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodHead:
+			w.Header().Set("Upload-Offset", strconv.Itoa(offset))
+			w.WriteHeader(http.StatusOK)
+		case r.Method == http.MethodPatch:
+			assert.Equal(t, strconv.Itoa(offset), r.Header.Get("Upload-Offset"))
+			chunk, _ := io.ReadAll(r.Body) //nolint:errcheck // Checked below
+			switch failures++; failures {
+			case 1:
+				// Part of the chunk arrives before the server fails
+				received = append(received, chunk[:2]...)
+				offset += 2
+				w.WriteHeader(http.StatusBadGateway)
+			case 2:
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusTooManyRequests)
+			default:
+				received = append(received, chunk...)
+				offset += len(chunk)
+				w.WriteHeader(http.StatusNoContent)
+			}
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"success":true,"result":{"uid":"abc","status":{"state":"queued"}}}`)) //nolint:errcheck // Test server
+		}
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "video.mp4")
+	require.NoError(t, os.WriteFile(path, []byte("0123456789"), 0o600))
+
+	client := newTestClient(t, srv)
+	client.tusBackoff = time.Millisecond
+
+	_, err := client.ResumeUpload(context.Background(), &ResumableUpload{UID: "abc", Location: srv.URL + "/tus/abc"}, path, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "456789", string(received))
+	assert.Equal(t, 3, failures)
+}
+
+func TestResumeUpload_ChunkFailures(t *testing.T) {
+	patches := 0
+	status := http.StatusServiceUnavailable
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			w.Header().Set("Upload-Offset", "0")
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		patches++
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "video.mp4")
+	require.NoError(t, os.WriteFile(path, []byte("0123456789"), 0o600))
+
+	client := newTestClient(t, srv)
+	client.tusBackoff = time.Millisecond
+	upload := &ResumableUpload{UID: "abc", Location: srv.URL + "/tus/abc"}
+
+	// Transient failures are retried until the retries run out
+	_, err := client.ResumeUpload(context.Background(), upload, path, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "gave up after 5 retries")
+	assert.Equal(t, tusChunkRetries+1, patches)
+
+	// Other client errors fail at once
+	patches, status = 0, http.StatusForbidden
+	_, err = client.ResumeUpload(context.Background(), upload, path, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status 403")
+	assert.Equal(t, 1, patches)
+}
+
+func TestUploadToURL(t *testing.T) {
+	var form, patched []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The one-time URL authorizes the upload
+		assert.Empty(t, r.Header.Get("Authorization"))
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/abc":
+			file, _, err := r.FormFile("file")
+			require.NoError(t, err)
+			form, _ = io.ReadAll(file) //nolint:errcheck // Checked below
+		case r.Method == http.MethodHead && r.URL.Path == "/tus/def":
+			w.Header().Set("Upload-Offset", "0")
+			w.Header().Set("Upload-Length", "10")
+			w.WriteHeader(http.StatusOK)
+		case r.Method == http.MethodPatch && r.URL.Path == "/tus/def":
+			patched, _ = io.ReadAll(r.Body) //nolint:errcheck // Checked below
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "video.mp4")
+	require.NoError(t, os.WriteFile(path, []byte("0123456789"), 0o600))
+	ctx := context.Background()
+
+	uid, err := UploadToURL(ctx, srv.URL+"/abc", path, false, nil, WithHTTPClient(srv.Client()))
+	require.NoError(t, err)
+	assert.Equal(t, "abc", uid)
+	assert.Equal(t, "0123456789", string(form))
+
+	uid, err = UploadToURL(ctx, srv.URL+"/tus/def?tusv2=true", path, false, nil, WithHTTPClient(srv.Client()))
+	require.NoError(t, err)
+	assert.Equal(t, "def", uid)
+	assert.Equal(t, "0123456789", string(patched))
+
+	_, err = UploadToURL(ctx, "not a url", path, false, nil)
+	assert.ErrorIs(t, err, ErrInvalidInput)
+
+	var out bytes.Buffer
+	_, err = UploadToURL(ctx, srv.URL+"/abc", path, true, nil, WithDryRun(&out))
+	assert.ErrorIs(t, err, ErrDryRun)
+	assert.Contains(t, out.String(), "with TUS")
+	assert.Contains(t, out.String(), "[dry-run] HEAD "+srv.URL+"/abc")
+}
+
+func TestDownloadCaptions(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/accounts/acct/stream/abc/captions":
+			w.Write([]byte(`{"success":true,"result":[{"language":"en","label":"English"},{"language":"pt-BR","label":"Português"}]}`)) //nolint:errcheck // Test server
+		case "/accounts/acct/stream/abc/captions/pt-BR/vtt":
+			w.Write([]byte("WEBVTT\n")) //nolint:errcheck // Test server
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv)
+
+	captions, err := client.ListCaptions(context.Background(), "abc")
+	require.NoError(t, err)
+	require.Len(t, captions, 2)
+	assert.Equal(t, "pt-BR", captions[1].Language)
+
+	vtt, err := client.DownloadCaption(context.Background(), "abc", "pt-BR")
+	require.NoError(t, err)
+	assert.Equal(t, "WEBVTT\n", string(vtt))
+}
+
+func TestDeleteCaption(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		assert.Equal(t, "/accounts/acct/stream/abc/captions/pt-BR", r.URL.Path)
+		w.Write([]byte(`{"success":true,"result":""}`)) //nolint:errcheck // Test server
+	}))
+	defer srv.Close()
+
+	require.NoError(t, newTestClient(t, srv).DeleteCaption(context.Background(), "abc", "pt-BR"))
+	assert.ErrorIs(t, newTestClient(t, srv).DeleteCaption(context.Background(), "abc", ""), ErrInvalidInput)
+}
+
+func TestAudioTracks(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			assert.Equal(t, "/accounts/acct/stream/abc/audio", r.URL.Path)
+			w.Write([]byte(`{"success":true,"result":[{"uid":"t1","label":"English","default":true,"status":"ready"},{"uid":"t2","label":"Deutsch","default":false,"status":"ready"}]}`)) //nolint:errcheck // Test server
+		case http.MethodPatch:
+			assert.Equal(t, "/accounts/acct/stream/abc/audio/t2", r.URL.Path)
+			var body map[string]interface{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, map[string]interface{}{"default": true}, body)
+			w.Write([]byte(`{"success":true,"result":{"uid":"t2","label":"Deutsch","default":true,"status":"ready"}}`)) //nolint:errcheck // Test server
+		}
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv)
+	tracks, err := client.ListAudioTracks(context.Background(), "abc")
+	require.NoError(t, err)
+	require.Len(t, tracks, 2)
+	assert.True(t, tracks[0].Default)
+	assert.Equal(t, "Deutsch", tracks[1].Label)
+
+	track, err := client.SetDefaultAudioTrack(context.Background(), "abc", "t2")
+	require.NoError(t, err)
+	assert.True(t, track.Default)
+
+	_, err = client.SetDefaultAudioTrack(context.Background(), "abc", "")
+	assert.ErrorIs(t, err, ErrInvalidInput)
+}
+
+func TestGenerateCaption(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/accounts/acct/stream/abc/captions/en/generate", r.URL.Path)
+		w.Write([]byte(`{"success":true,"result":{"language":"en","label":"English (auto-generated)","generated":true,"status":"inprogress"}}`)) //nolint:errcheck // Test server
+	}))
+	defer srv.Close()
+
+	caption, err := newTestClient(t, srv).GenerateCaption(context.Background(), "abc", "en")
+	require.NoError(t, err)
+	assert.True(t, caption.Generated)
+	assert.Equal(t, "inprogress", caption.Status)
+}
+
+func TestWebhook(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/accounts/acct/stream/webhook", r.URL.Path)
+		switch r.Method {
+		case http.MethodPut:
+			var body map[string]string
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, "https://example.com/hook", body["notificationUrl"])
+			fallthrough
+		case http.MethodGet:
+			w.Write([]byte(`{"success":true,"result":{"notificationUrl":"https://example.com/hook","modified":"2024-06-01T12:00:00Z","secret":"s3cret"}}`)) //nolint:errcheck // Test server
+		case http.MethodDelete:
+			w.Write([]byte(`{"success":true,"result":""}`)) //nolint:errcheck // Test server
+		}
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv)
+	ctx := context.Background()
+
+	webhook, err := client.SetWebhook(ctx, "https://example.com/hook")
+	require.NoError(t, err)
+	assert.Equal(t, "s3cret", webhook.Secret)
+
+	webhook, err = client.GetWebhook(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/hook", webhook.NotificationURL)
+
+	require.NoError(t, client.DeleteWebhook(ctx))
+}
+
+func TestSigningKeys(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/accounts/acct/stream/keys":
+			w.Write([]byte(`{"success":true,"result":{"id":"key1","pem":"LS0tLS1CRUdJTg==","jwk":"eyJrdHkiOiJSU0EifQ==","created":"2024-06-01T12:00:00Z"}}`)) //nolint:errcheck // Test server
+		case r.Method == http.MethodGet && r.URL.Path == "/accounts/acct/stream/keys":
+			w.Write([]byte(`{"success":true,"result":[{"id":"key1","created":"2024-06-01T12:00:00Z"},{"id":"key2","created":"2024-07-01T12:00:00Z"}]}`)) //nolint:errcheck // Test server
+		case r.Method == http.MethodDelete && r.URL.Path == "/accounts/acct/stream/keys/key1":
+			w.Write([]byte(`{"success":true,"result":"ok"}`)) //nolint:errcheck // Test server
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv)
+	ctx := context.Background()
+
+	key, err := client.CreateSigningKey(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "key1", key.ID)
+	assert.Equal(t, "LS0tLS1CRUdJTg==", key.PEM)
+	assert.Equal(t, "eyJrdHkiOiJSU0EifQ==", key.JWK)
+
+	keys, err := client.ListSigningKeys(ctx)
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+	assert.Equal(t, "key2", keys[1].ID)
+	assert.Equal(t, 2024, keys[1].Created.Year())
+
+	require.NoError(t, client.DeleteSigningKey(ctx, "key1"))
+	assert.ErrorIs(t, client.DeleteSigningKey(ctx, ""), ErrInvalidInput)
+}
+
+func TestDoJSON_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr error
+		wantMsg string
+	}{
+		{name: "not found", status: http.StatusNotFound, body: `{}`, wantErr: ErrNotFound},
+		{name: "unauthorized", status: http.StatusUnauthorized, body: `{}`, wantErr: ErrUnauthorized},
+		{name: "server error", status: http.StatusInternalServerError, body: `oops`, wantMsg: "API request failed with status 500: oops"},
+		{name: "unsuccessful envelope", status: http.StatusOK, body: `{"success":false,"errors":[{"code":10000,"message":"bad"}]}`, wantMsg: "API error: bad"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body)) //nolint:errcheck // Test server
+			}))
+			defer srv.Close()
+
+			_, err := newTestClient(t, srv).GetSignedToken(context.Background(), "abc", 0)
+			require.Error(t, err)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "got %v", err)
+			}
+			if tt.wantMsg != "" {
+				assert.EqualError(t, err, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestWithRateLimitWait(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		var body map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "Town hall", body["meta"].(map[string]interface{})["name"], "body is replayed on retry")
+
+		if calls == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"success":true,"result":{"uid":"live1"}}`)) //nolint:errcheck // Test server
+	}))
+	defer srv.Close()
+
+	var waits []time.Duration
+	client := newTestClient(t, srv, WithRateLimitWait(func(wait time.Duration) {
+		waits = append(waits, wait)
+	}))
+
+	name := "Town hall"
+	input, err := client.UpdateLiveInput(context.Background(), "live1", &LiveInputOptions{Name: name})
+	require.NoError(t, err)
+	assert.Equal(t, "live1", input.UID)
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, []time.Duration{time.Second}, waits)
+
+	// Without the option a 429 fails immediately
+	calls = 0
+	_, err = newTestClient(t, srv).UpdateLiveInput(context.Background(), "live1", &LiveInputOptions{Name: name})
+	assert.ErrorIs(t, err, ErrRateLimit)
+	assert.Equal(t, time.Second, RetryAfter(err))
+}
+
+func TestDryRun(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request in dry-run mode: %s %s", r.Method, r.URL.Path)
+	}))
+	defer srv.Close()
+
+	var out bytes.Buffer
+	client := newTestClient(t, srv, WithDryRun(&out))
+	ctx := context.Background()
+
+	_, err := client.UpdateVideo(ctx, "abc", &UpdateOptions{Meta: map[string]interface{}{"name": "New"}})
+	assert.ErrorIs(t, err, ErrDryRun)
+	assert.Contains(t, out.String(), "[dry-run] POST "+srv.URL+"/accounts/acct/stream/abc\n")
+	assert.Contains(t, out.String(), `"name": "New"`)
+
+	out.Reset()
+	assert.ErrorIs(t, client.DeleteVideo(ctx, "abc"), ErrDryRun)
+	assert.Equal(t, "[dry-run] DELETE "+srv.URL+"/accounts/acct/stream/abc\n", out.String())
+
+	out.Reset()
+	path := filepath.Join(t.TempDir(), "clip.mp4")
+	require.NoError(t, os.WriteFile(path, []byte("data"), 0o600))
+	_, err = client.UploadFile(ctx, path, &UploadOptions{}, nil)
+	assert.ErrorIs(t, err, ErrDryRun)
+	assert.Contains(t, out.String(), "[dry-run] POST "+srv.URL+"/accounts/acct/stream/direct_upload")
+	assert.Contains(t, out.String(), "clip.mp4 (4 bytes)")
+	assert.Contains(t, out.String(), "with a multipart direct upload")
+
+	out.Reset()
+	_, err = client.UploadFile(ctx, path, &UploadOptions{Name: "Clip", Metadata: map[string]interface{}{"sha256": "abc"}}, nil)
+	assert.ErrorIs(t, err, ErrDryRun)
+	assert.Contains(t, out.String(), `"name": "Clip"`)
+	assert.Contains(t, out.String(), `"sha256": "abc"`)
+}
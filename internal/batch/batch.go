@@ -0,0 +1,119 @@
+// Package batch runs per-item API operations concurrently, retrying items
+// that hit the rate limit.
+package batch
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/sourcegraph/conc/pool"
+
+	"cfstream/internal/api"
+)
+
+// Result is the outcome of one item of a batch.
+type Result struct {
+	ID       string
+	Err      error
+	Attempts int
+	Skipped  bool // Already completed according to the checkpoint
+}
+
+// Options configures a batch run.
+type Options struct {
+	Concurrency int           // Maximum items in flight; defaults to 1
+	Retries     int           // Retries per item after a rate-limited attempt
+	Backoff     time.Duration // Wait before the first retry, doubled for each further retry
+	OnDone      func(Result)  // Called as each item finishes; may be called concurrently
+	Checkpoint  *Checkpoint   // Skips completed items and records new ones; optional
+
+	// OnThrottle is called when a rate-limited request pauses the batch, with
+	// the pause and the reduced concurrency.
+	OnThrottle func(wait time.Duration, concurrency int)
+}
+
+// Run calls fn for every id using a bounded worker pool and returns the
+// results in the order of ids. Items failing with api.ErrRateLimit are retried;
+// other errors are recorded immediately. A rate limit pauses the whole batch
+// for the Retry-After delay (or the exponential backoff when the API gave
+// none) and reduces concurrency until requests succeed again. With a
+// checkpoint, items it lists are skipped and successful items are recorded.
+func Run(ctx context.Context, ids []string, opts Options, fn func(ctx context.Context, id string) error) []Result {
+	concurrency := max(opts.Concurrency, 1)
+	limit := newLimiter(concurrency)
+
+	p := pool.NewWithResults[Result]().WithMaxGoroutines(concurrency)
+	for _, id := range ids {
+		p.Go(func() Result {
+			result := Result{ID: id, Skipped: true}
+			if opts.Checkpoint == nil || !opts.Checkpoint.Done(id) {
+				result = runOne(ctx, id, opts, limit, fn)
+				if result.Err == nil && opts.Checkpoint != nil {
+					result.Err = opts.Checkpoint.Record(id)
+				}
+			}
+			if opts.OnDone != nil {
+				opts.OnDone(result)
+			}
+			return result
+		})
+	}
+	return p.Wait()
+}
+
+// runOne runs fn for a single id, retrying on rate limits.
+func runOne(ctx context.Context, id string, opts Options, limit *limiter, fn func(ctx context.Context, id string) error) Result {
+	result := Result{ID: id}
+	backoff := opts.Backoff
+
+	for {
+		if err := limit.acquire(ctx); err != nil {
+			result.Err = err
+			return result
+		}
+		result.Attempts++
+		result.Err = fn(ctx, id)
+		limit.release(result.Err == nil)
+
+		if !errors.Is(result.Err, api.ErrRateLimit) {
+			return result
+		}
+
+		wait := api.RetryAfter(result.Err)
+		if wait <= 0 {
+			wait = backoff
+		}
+		limit.throttle(wait)
+		if opts.OnThrottle != nil {
+			opts.OnThrottle(wait, limit.current())
+		}
+
+		if result.Attempts > opts.Retries {
+			return result
+		}
+		backoff *= 2
+	}
+}
+
+// Skipped returns the number of results skipped because of the checkpoint.
+func Skipped(results []Result) int {
+	n := 0
+	for _, r := range results {
+		if r.Skipped {
+			n++
+		}
+	}
+	return n
+}
+
+// Failed returns the results that ended in an error.
+func Failed(results []Result) []Result {
+	var failed []Result
+	for _, r := range results {
+		if r.Err != nil {
+			failed = append(failed, r)
+		}
+	}
+	return failed
+}
@@ -0,0 +1,151 @@
+package batch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"cfstream/internal/api"
+)
+
+func TestRun(t *testing.T) {
+	ids := []string{"a", "b", "c", "d", "e"}
+
+	var inFlight, peak int32
+	var mu sync.Mutex
+	var done []string
+
+	results := Run(context.Background(), ids, Options{
+		Concurrency: 2,
+		OnDone: func(r Result) {
+			mu.Lock()
+			done = append(done, r.ID)
+			mu.Unlock()
+		},
+	}, func(ctx context.Context, id string) error {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		if id == "c" {
+			return errors.New("boom")
+		}
+		return nil
+	})
+
+	require.Len(t, results, len(ids))
+	for i, r := range results {
+		assert.Equal(t, ids[i], r.ID, "results keep input order")
+	}
+	assert.LessOrEqual(t, peak, int32(2))
+	assert.Len(t, done, len(ids))
+
+	failed := Failed(results)
+	require.Len(t, failed, 1)
+	assert.Equal(t, "c", failed[0].ID)
+	assert.EqualError(t, failed[0].Err, "boom")
+}
+
+func TestRun_RetriesRateLimit(t *testing.T) {
+	var calls int32
+	results := Run(context.Background(), []string{"a"}, Options{Retries: 3, Backoff: time.Millisecond}, func(ctx context.Context, id string) error {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			return fmt.Errorf("%w: slow down", api.ErrRateLimit)
+		}
+		return nil
+	})
+
+	require.Len(t, results, 1)
+	assert.NoError(t, results[0].Err)
+	assert.Equal(t, 3, results[0].Attempts)
+}
+
+func TestRun_GivesUpAfterRetries(t *testing.T) {
+	results := Run(context.Background(), []string{"a"}, Options{Retries: 1, Backoff: time.Millisecond}, func(ctx context.Context, id string) error {
+		return api.ErrRateLimit
+	})
+
+	assert.ErrorIs(t, results[0].Err, api.ErrRateLimit)
+	assert.Equal(t, 2, results[0].Attempts)
+}
+
+func TestRun_DoesNotRetryOtherErrors(t *testing.T) {
+	results := Run(context.Background(), []string{"a"}, Options{Retries: 3, Backoff: time.Millisecond}, func(ctx context.Context, id string) error {
+		return api.ErrNotFound
+	})
+
+	assert.ErrorIs(t, results[0].Err, api.ErrNotFound)
+	assert.Equal(t, 1, results[0].Attempts)
+}
+
+func TestRun_Checkpoint(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint")
+	require.NoError(t, os.WriteFile(path, []byte("a\n\n"), 0o600))
+
+	cp, err := OpenCheckpoint(path)
+	require.NoError(t, err)
+	assert.Equal(t, 1, cp.Len())
+
+	var calls []string
+	var mu sync.Mutex
+	results := Run(context.Background(), []string{"a", "b", "c"}, Options{Checkpoint: cp}, func(ctx context.Context, id string) error {
+		mu.Lock()
+		calls = append(calls, id)
+		mu.Unlock()
+		if id == "c" {
+			return errors.New("boom")
+		}
+		return nil
+	})
+	require.NoError(t, cp.Close())
+
+	assert.ElementsMatch(t, []string{"b", "c"}, calls)
+	assert.True(t, results[0].Skipped)
+	assert.Equal(t, 1, Skipped(results))
+	assert.Len(t, Failed(results), 1)
+
+	// A rerun only retries the failed item
+	cp, err = OpenCheckpoint(path)
+	require.NoError(t, err)
+	defer cp.Close()
+	assert.True(t, cp.Done("a"))
+	assert.True(t, cp.Done("b"))
+	assert.False(t, cp.Done("c"))
+}
+
+func TestRun_RespectsRetryAfter(t *testing.T) {
+	var calls int32
+	var throttled []int
+	start := time.Now()
+	results := Run(context.Background(), []string{"a", "b"}, Options{
+		Concurrency: 2,
+		Retries:     1,
+		Backoff:     time.Millisecond,
+		OnThrottle: func(wait time.Duration, concurrency int) {
+			throttled = append(throttled, concurrency)
+		},
+	}, func(ctx context.Context, id string) error {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			return &api.RateLimitError{RetryAfter: 30 * time.Millisecond}
+		}
+		return nil
+	})
+
+	assert.Empty(t, Failed(results))
+	assert.Equal(t, []int{1}, throttled)
+	assert.GreaterOrEqual(t, time.Since(start), 30*time.Millisecond)
+}
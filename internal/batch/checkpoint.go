@@ -0,0 +1,75 @@
+package batch
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Checkpoint records completed items in a file so that an interrupted batch
+// can be rerun without repeating them. The file holds one ID per line.
+type Checkpoint struct {
+	mu   sync.Mutex
+	done map[string]bool
+	file *os.File
+}
+
+// OpenCheckpoint loads the IDs already recorded in path and opens it for
+// appending, creating the file if it does not exist.
+func OpenCheckpoint(path string) (*Checkpoint, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o600) //nolint:gosec // Path is chosen by the user
+	if err != nil {
+		return nil, fmt.Errorf("failed to open checkpoint: %w", err)
+	}
+
+	c := &Checkpoint{done: make(map[string]bool), file: file}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if id := strings.TrimSpace(scanner.Text()); id != "" {
+			c.done[id] = true
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to read checkpoint: %w", err)
+	}
+
+	return c, nil
+}
+
+// Done reports whether id was completed by an earlier run.
+func (c *Checkpoint) Done(id string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.done[id]
+}
+
+// Len returns the number of completed items.
+func (c *Checkpoint) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.done)
+}
+
+// Record marks id as completed and appends it to the file immediately, so
+// progress survives a crash.
+func (c *Checkpoint) Record(id string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.done[id] {
+		return nil
+	}
+	if _, err := fmt.Fprintln(c.file, id); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	c.done[id] = true
+	return nil
+}
+
+// Close closes the checkpoint file.
+func (c *Checkpoint) Close() error {
+	return c.file.Close()
+}
@@ -0,0 +1,102 @@
+package batch
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// limiter is shared by the workers of one batch. A rate-limited request
+// pauses every worker and halves the number of requests allowed in flight;
+// the limit grows back by one after each run of that many successes.
+type limiter struct {
+	mu          sync.Mutex
+	limit       int
+	max         int
+	inFlight    int
+	successes   int
+	pausedUntil time.Time
+	wake        chan struct{} // Closed and replaced whenever a slot may have opened
+}
+
+func newLimiter(concurrency int) *limiter {
+	return &limiter{limit: concurrency, max: concurrency, wake: make(chan struct{})}
+}
+
+// acquire blocks until the batch is not paused and a slot is free.
+func (l *limiter) acquire(ctx context.Context) error {
+	for {
+		l.mu.Lock()
+		wait := time.Until(l.pausedUntil)
+		if wait <= 0 && l.inFlight < l.limit {
+			l.inFlight++
+			l.mu.Unlock()
+			return nil
+		}
+		wake := l.wake
+		l.mu.Unlock()
+
+		var timer *time.Timer
+		var expired <-chan time.Time
+		if wait > 0 {
+			timer = time.NewTimer(wait)
+			expired = timer.C
+		}
+
+		select {
+		case <-ctx.Done():
+			if timer != nil {
+				timer.Stop()
+			}
+			return ctx.Err()
+		case <-wake:
+		case <-expired:
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+	}
+}
+
+// release frees the slot taken by acquire. Successful requests slowly raise
+// the limit back towards the configured concurrency.
+func (l *limiter) release(ok bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.inFlight--
+	if ok && l.limit < l.max {
+		l.successes++
+		if l.successes >= l.limit {
+			l.limit++
+			l.successes = 0
+		}
+	}
+	l.broadcast()
+}
+
+// throttle pauses the whole batch for wait and halves the limit.
+func (l *limiter) throttle(wait time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.limit = max(l.limit/2, 1)
+	l.successes = 0
+	if until := time.Now().Add(wait); until.After(l.pausedUntil) {
+		l.pausedUntil = until
+	}
+	l.broadcast()
+}
+
+// current returns the number of requests currently allowed in flight.
+func (l *limiter) current() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.limit
+}
+
+// broadcast wakes all waiting workers. The caller must hold l.mu.
+func (l *limiter) broadcast() {
+	close(l.wake)
+	l.wake = make(chan struct{})
+}
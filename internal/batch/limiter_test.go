@@ -0,0 +1,43 @@
+package batch
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimiter_Throttle(t *testing.T) {
+	l := newLimiter(4)
+	ctx := context.Background()
+
+	l.throttle(20 * time.Millisecond)
+	assert.Equal(t, 2, l.current())
+
+	start := time.Now()
+	require.NoError(t, l.acquire(ctx))
+	assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond, "acquire waits out the pause")
+	require.NoError(t, l.acquire(ctx))
+
+	// The reduced limit is enforced
+	blocked, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, l.acquire(blocked), context.DeadlineExceeded)
+
+	// Successes raise the limit back up to the configured concurrency
+	l.release(true)
+	l.release(true)
+	assert.Equal(t, 3, l.current())
+	for range 10 {
+		require.NoError(t, l.acquire(ctx))
+		l.release(true)
+	}
+	assert.Equal(t, 4, l.current())
+
+	l.throttle(0)
+	l.throttle(0)
+	l.throttle(0)
+	assert.Equal(t, 1, l.current(), "limit never drops below one")
+}
@@ -0,0 +1,152 @@
+// Package caption locates caption files on disk for batch uploads.
+package caption
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// File is a caption file and the video track it belongs to.
+type File struct {
+	Path     string
+	VideoID  string
+	Language string
+}
+
+// Pattern matches file paths against a template such as "{uid}.{lang}.vtt".
+type Pattern struct {
+	re *regexp.Regexp
+}
+
+// ParsePattern compiles a filename template. The template must contain the
+// {uid} and {lang} placeholders; everything else is matched literally.
+// Templates may contain "/" to match paths relative to the scanned directory.
+func ParsePattern(template string) (*Pattern, error) {
+	if !strings.Contains(template, "{uid}") || !strings.Contains(template, "{lang}") {
+		return nil, fmt.Errorf("pattern %q must contain {uid} and {lang}", template)
+	}
+
+	var b strings.Builder
+	b.WriteString("^")
+	rest := template
+	for rest != "" {
+		i := strings.Index(rest, "{")
+		if i < 0 {
+			b.WriteString(regexp.QuoteMeta(rest))
+			break
+		}
+		b.WriteString(regexp.QuoteMeta(rest[:i]))
+		rest = rest[i:]
+
+		switch {
+		case strings.HasPrefix(rest, "{uid}"):
+			b.WriteString(`(?P<uid>[^/]+)`)
+			rest = rest[len("{uid}"):]
+		case strings.HasPrefix(rest, "{lang}"):
+			// BCP 47 style language tags such as en, pt-BR, or zh-Hant
+			b.WriteString(`(?P<lang>[A-Za-z]{2,3}(?:-[A-Za-z0-9]+)*)`)
+			rest = rest[len("{lang}"):]
+		default:
+			b.WriteString(regexp.QuoteMeta("{"))
+			rest = rest[1:]
+		}
+	}
+	b.WriteString("$")
+
+	re, err := regexp.Compile(b.String())
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern %q: %w", template, err)
+	}
+	return &Pattern{re: re}, nil
+}
+
+// Match extracts the video ID and language from a slash-separated path.
+func (p *Pattern) Match(path string) (videoID, language string, ok bool) {
+	m := p.re.FindStringSubmatch(path)
+	if m == nil {
+		return "", "", false
+	}
+	return m[p.re.SubexpIndex("uid")], m[p.re.SubexpIndex("lang")], true
+}
+
+// Scan walks dir and returns the files whose path relative to dir matches p.
+func Scan(dir string, p *Pattern) ([]File, error) {
+	var files []File
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		if videoID, language, ok := p.Match(filepath.ToSlash(rel)); ok {
+			files = append(files, File{Path: path, VideoID: videoID, Language: language})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan %s: %w", dir, err)
+	}
+	return files, nil
+}
+
+// LoadMapping reads a CSV file with a header row containing file, uid, and
+// lang columns. Relative file paths are resolved against dir.
+func LoadMapping(path, dir string) ([]File, error) {
+	f, err := os.Open(path) //nolint:gosec // Path is provided by the user
+	if err != nil {
+		return nil, fmt.Errorf("failed to open mapping: %w", err)
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.TrimLeadingSpace = true
+
+	header, err := r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mapping header: %w", err)
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"file", "uid", "lang"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("mapping is missing the %q column", name)
+		}
+	}
+
+	var files []File
+	for {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read mapping: %w", err)
+		}
+
+		file := record[columns["file"]]
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(dir, file)
+		}
+		files = append(files, File{
+			Path:     file,
+			VideoID:  strings.TrimSpace(record[columns["uid"]]),
+			Language: strings.TrimSpace(record[columns["lang"]]),
+		})
+	}
+	return files, nil
+}
@@ -0,0 +1,99 @@
+package caption
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPattern(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		path     string
+		wantUID  string
+		wantLang string
+		wantOK   bool
+	}{
+		{"{uid}.{lang}.vtt", "abc123.en.vtt", "abc123", "en", true},
+		{"{uid}.{lang}.vtt", "abc123.pt-BR.vtt", "abc123", "pt-BR", true},
+		{"{uid}.{lang}.vtt", "abc123.en.srt", "", "", false},
+		{"{lang}/{uid}.vtt", "fr/abc123.vtt", "abc123", "fr", true},
+		{"{uid}_{lang}.vtt", "my_video_de.vtt", "my_video", "de", true},
+		{"{uid}.{lang}.vtt", "sub/abc123.en.vtt", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.path, func(t *testing.T) {
+			p, err := ParsePattern(tt.pattern)
+			require.NoError(t, err)
+			uid, lang, ok := p.Match(tt.path)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantUID, uid)
+			assert.Equal(t, tt.wantLang, lang)
+		})
+	}
+
+	_, err := ParsePattern("{uid}.vtt")
+	assert.Error(t, err)
+}
+
+func TestScan(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a.en.vtt", "a.fr.vtt", "b.en.vtt", "notes.txt"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("WEBVTT\n"), 0o600))
+	}
+
+	p, err := ParsePattern("{uid}.{lang}.vtt")
+	require.NoError(t, err)
+	files, err := Scan(dir, p)
+	require.NoError(t, err)
+
+	require.Len(t, files, 3)
+	assert.Equal(t, File{Path: filepath.Join(dir, "a.en.vtt"), VideoID: "a", Language: "en"}, files[0])
+}
+
+func TestLoadMapping(t *testing.T) {
+	dir := t.TempDir()
+	mapping := filepath.Join(dir, "map.csv")
+	require.NoError(t, os.WriteFile(mapping, []byte("file,uid,lang\nintro-english.vtt,abc,en\n/abs/intro.vtt, def, fr\n"), 0o600))
+
+	files, err := LoadMapping(mapping, dir)
+	require.NoError(t, err)
+	assert.Equal(t, []File{
+		{Path: filepath.Join(dir, "intro-english.vtt"), VideoID: "abc", Language: "en"},
+		{Path: "/abs/intro.vtt", VideoID: "def", Language: "fr"},
+	}, files)
+
+	require.NoError(t, os.WriteFile(mapping, []byte("file,uid\nx.vtt,abc\n"), 0o600))
+	_, err = LoadMapping(mapping, dir)
+	assert.Error(t, err)
+}
+
+func TestValidateLanguage(t *testing.T) {
+	for _, tag := range []string{"en", "pt-BR", "zh-Hant", "zh-Hant-TW", "es-419", "sl-rozaj", "fil"} {
+		assert.NoError(t, ValidateLanguage(tag), tag)
+	}
+
+	tests := []struct {
+		tag     string
+		wantErr string
+	}{
+		{"en_US", `did you mean "en-US"?`},
+		{"zh_hant_tw", `did you mean "zh-Hant-TW"?`},
+		{"jp", `did you mean "ja"?`},
+		{"English", `did you mean "en"?`},
+		{"e", "use a BCP 47 tag"},
+		{"en-", "use a BCP 47 tag"},
+		{"", "cannot be empty"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			err := ValidateLanguage(tt.tag)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
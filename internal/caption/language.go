@@ -0,0 +1,95 @@
+package caption
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// languageTag matches the common subset of BCP 47: a 2-3 letter language,
+// an optional script, an optional region, and optional variants.
+var languageTag = regexp.MustCompile(`^[a-zA-Z]{2,3}(-[a-zA-Z]{4})?(-[a-zA-Z]{2}|-[0-9]{3})?(-[a-zA-Z0-9]{5,8}|-[0-9][a-zA-Z0-9]{3})*$`)
+
+// commonMistakes maps frequently used non-language codes and language names
+// to the tag that was most likely meant.
+var commonMistakes = map[string]string{
+	// Country codes used as language codes
+	"jp": "ja",
+	"cn": "zh",
+	"kr": "ko",
+	"gr": "el",
+	"dk": "da",
+	"cz": "cs",
+	"ua": "uk",
+	"iw": "he",
+	"in": "id",
+
+	// Language names
+	"english":    "en",
+	"spanish":    "es",
+	"french":     "fr",
+	"german":     "de",
+	"italian":    "it",
+	"portuguese": "pt",
+	"dutch":      "nl",
+	"japanese":   "ja",
+	"chinese":    "zh",
+	"korean":     "ko",
+	"russian":    "ru",
+	"arabic":     "ar",
+	"hindi":      "hi",
+	"polish":     "pl",
+	"swedish":    "sv",
+	"turkish":    "tr",
+}
+
+// ValidateLanguage checks that tag is a well-formed BCP 47 language tag such
+// as en, pt-BR, or zh-Hant. The error suggests a correction when the mistake
+// is a common one, e.g. en_US instead of en-US.
+func ValidateLanguage(tag string) error {
+	if tag == "" {
+		return fmt.Errorf("language tag cannot be empty")
+	}
+
+	suggestion := SuggestLanguage(tag)
+	if languageTag.MatchString(tag) && suggestion == "" {
+		return nil
+	}
+	if suggestion != "" && suggestion != tag {
+		return fmt.Errorf("invalid language tag %q: did you mean %q?", tag, suggestion)
+	}
+	return fmt.Errorf("invalid language tag %q: use a BCP 47 tag such as en, pt-BR, or zh-Hant", tag)
+}
+
+// SuggestLanguage returns the tag most likely meant by a malformed or
+// mistaken one, or "" if tag looks right or no suggestion can be made.
+func SuggestLanguage(tag string) string {
+	normalized := strings.ReplaceAll(strings.TrimSpace(tag), "_", "-")
+	parts := strings.Split(normalized, "-")
+
+	if fixed, ok := commonMistakes[strings.ToLower(parts[0])]; ok {
+		parts[0] = fixed
+		return canonicalCase(parts)
+	}
+
+	if normalized == tag || !languageTag.MatchString(normalized) {
+		return ""
+	}
+	return canonicalCase(parts)
+}
+
+// canonicalCase joins tag subtags using the conventional case: lowercase
+// language, title case script, and uppercase region.
+func canonicalCase(parts []string) string {
+	for i, p := range parts {
+		switch {
+		case i == 0:
+			parts[i] = strings.ToLower(p)
+		case len(p) == 4 && !strings.ContainsAny(p[:1], "0123456789"):
+			parts[i] = strings.ToUpper(p[:1]) + strings.ToLower(p[1:])
+		case len(p) == 2:
+			parts[i] = strings.ToUpper(p)
+		}
+	}
+	return strings.Join(parts, "-")
+}
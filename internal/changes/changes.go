@@ -0,0 +1,71 @@
+// Package changes derives change events by comparing successive listings of
+// the library, approximating a change feed without webhooks.
+package changes
+
+import (
+	"sort"
+	"time"
+
+	"cfstream/internal/api"
+	"cfstream/internal/index"
+)
+
+// Event types.
+const (
+	EventCreated = "created"
+	EventReady   = "ready"
+	EventErrored = "errored"
+	EventDeleted = "deleted"
+)
+
+// Event is one change, written as a line of NDJSON.
+type Event struct {
+	Event  string    `json:"event"`
+	Time   time.Time `json:"time"`
+	UID    string    `json:"uid"`
+	Name   string    `json:"name,omitempty"`
+	Status string    `json:"status,omitempty"`
+	Error  string    `json:"error,omitempty"` // errored only
+}
+
+// Diff compares the full library listing with the previously known videos
+// and returns the changes, stamped with now. A video first seen already
+// ready yields both a created and a ready event. Deleted events come last,
+// ordered by UID.
+func Diff(known map[string]index.Entry, videos []api.Video, now time.Time) []Event {
+	var events []Event
+	seen := make(map[string]bool, len(videos))
+
+	for i := range videos {
+		v := &videos[i]
+		seen[v.UID] = true
+
+		prev, existed := known[v.UID]
+		if !existed {
+			events = append(events, Event{Event: EventCreated, Time: now, UID: v.UID, Name: v.Name, Status: v.Status})
+		}
+		if existed && prev.Status == v.Status {
+			continue
+		}
+
+		switch v.Status {
+		case "ready":
+			events = append(events, Event{Event: EventReady, Time: now, UID: v.UID, Name: v.Name, Status: v.Status})
+		case "error":
+			events = append(events, Event{Event: EventErrored, Time: now, UID: v.UID, Name: v.Name, Status: v.Status, Error: v.StatusDetails})
+		}
+	}
+
+	var deleted []string
+	for uid := range known {
+		if !seen[uid] {
+			deleted = append(deleted, uid)
+		}
+	}
+	sort.Strings(deleted)
+	for _, uid := range deleted {
+		events = append(events, Event{Event: EventDeleted, Time: now, UID: uid, Name: known[uid].Name})
+	}
+
+	return events
+}
@@ -0,0 +1,79 @@
+package changes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/adrg/xdg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"cfstream/internal/api"
+	"cfstream/internal/index"
+)
+
+func TestDiff(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	known := map[string]index.Entry{
+		"processing": {UID: "processing", Name: "A", Status: "inprogress"},
+		"failing":    {UID: "failing", Name: "B", Status: "queued"},
+		"steady":     {UID: "steady", Name: "C", Status: "ready"},
+		"gone":       {UID: "gone", Name: "D", Status: "ready"},
+	}
+	videos := []api.Video{
+		{UID: "processing", Name: "A", Status: "ready"},
+		{UID: "failing", Name: "B", Status: "error", StatusDetails: "unsupported codec"},
+		{UID: "steady", Name: "C", Status: "ready"},
+		{UID: "fresh", Name: "E", Status: "ready"},
+		{UID: "queued", Name: "F", Status: "queued"},
+	}
+
+	assert.Equal(t, []Event{
+		{Event: EventReady, Time: now, UID: "processing", Name: "A", Status: "ready"},
+		{Event: EventErrored, Time: now, UID: "failing", Name: "B", Status: "error", Error: "unsupported codec"},
+		{Event: EventCreated, Time: now, UID: "fresh", Name: "E", Status: "ready"},
+		{Event: EventReady, Time: now, UID: "fresh", Name: "E", Status: "ready"},
+		{Event: EventCreated, Time: now, UID: "queued", Name: "F", Status: "queued"},
+		{Event: EventDeleted, Time: now, UID: "gone", Name: "D"},
+	}, Diff(known, videos, now))
+}
+
+func TestDiff_NoChanges(t *testing.T) {
+	known := map[string]index.Entry{"a": {UID: "a", Status: "ready"}}
+	assert.Empty(t, Diff(known, []api.Video{{UID: "a", Status: "ready"}}, time.Now()))
+}
+
+func TestState(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	xdg.Reload()
+	t.Cleanup(xdg.Reload)
+
+	state, err := LoadState("")
+	require.NoError(t, err)
+	assert.True(t, state.Updated.IsZero())
+
+	state.Record([]api.Video{{UID: "a", Name: "A", Status: "ready"}})
+	require.NoError(t, SaveState("", state))
+
+	loaded, err := LoadState("")
+	require.NoError(t, err)
+	assert.Equal(t, "A", loaded.Videos["a"].Name)
+
+	// Each profile keeps its own state
+	other, err := LoadState("personal")
+	require.NoError(t, err)
+	assert.True(t, other.Updated.IsZero())
+	assert.Empty(t, other.Videos)
+
+	// A new listing replaces the recorded videos
+	loaded.Record([]api.Video{{UID: "b", Status: "queued"}})
+	assert.Equal(t, []string{"b"}, keys(loaded.Videos))
+}
+
+func keys(videos map[string]index.Entry) []string {
+	uids := make([]string, 0, len(videos))
+	for uid := range videos {
+		uids = append(uids, uid)
+	}
+	return uids
+}
@@ -0,0 +1,82 @@
+package changes
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/adrg/xdg"
+
+	"cfstream/internal/api"
+	"cfstream/internal/index"
+)
+
+// State is the library as of the last poll. It is kept apart from the
+// completion index, which other commands update from partial listings, so
+// only full listings of one account are ever compared.
+type State struct {
+	Updated time.Time              `json:"updated"`
+	Videos  map[string]index.Entry `json:"videos"`
+}
+
+// LoadState reads the state of profile, which is empty for the default
+// account. Returns an empty state if no state file exists.
+func LoadState(profile string) (*State, error) {
+	state := &State{Videos: make(map[string]index.Entry)}
+
+	data, err := os.ReadFile(StatePath(profile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return state, nil
+		}
+		return nil, fmt.Errorf("failed to read events state: %w", err)
+	}
+
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("failed to parse events state: %w", err)
+	}
+	if state.Videos == nil {
+		state.Videos = make(map[string]index.Entry)
+	}
+
+	return state, nil
+}
+
+// SaveState writes the state of profile to disk.
+func SaveState(profile string, state *State) error {
+	statePath := StatePath(profile)
+	if err := os.MkdirAll(filepath.Dir(statePath), 0o755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode events state: %w", err)
+	}
+
+	if err := os.WriteFile(statePath, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write events state: %w", err)
+	}
+
+	return nil
+}
+
+// StatePath returns the path to the state file of profile.
+func StatePath(profile string) string {
+	name := "events.json"
+	if profile != "" {
+		name = "events-" + profile + ".json"
+	}
+	return filepath.Join(xdg.StateHome, "cfstream", name)
+}
+
+// Record replaces the state with a full listing of the library.
+func (s *State) Record(videos []api.Video) {
+	s.Videos = make(map[string]index.Entry, len(videos))
+	for _, v := range videos {
+		s.Videos[v.UID] = index.Entry{UID: v.UID, Name: v.Name, Status: v.Status, Modified: v.Modified}
+	}
+	s.Updated = time.Now()
+}
@@ -0,0 +1,48 @@
+// Package chart renders small unicode charts for terminal output.
+package chart
+
+import (
+	"math"
+	"strings"
+)
+
+// sparkTicks are the block characters used by Sparkline, from lowest to highest.
+var sparkTicks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline renders values as a single line of block characters scaled
+// between zero and the largest value.
+func Sparkline(values []float64) string {
+	peak := maxValue(values)
+
+	var b strings.Builder
+	for _, v := range values {
+		i := 0
+		if peak > 0 && v > 0 {
+			i = int(math.Round(v / peak * float64(len(sparkTicks)-1)))
+		}
+		b.WriteRune(sparkTicks[i])
+	}
+	return b.String()
+}
+
+// Bar renders value as a horizontal bar of up to width cells relative to peak.
+func Bar(value, peak float64, width int) string {
+	if peak <= 0 || value <= 0 || width <= 0 {
+		return ""
+	}
+	cells := int(math.Round(value / peak * float64(width)))
+	if cells == 0 {
+		// Keep non-zero values visible
+		cells = 1
+	}
+	return strings.Repeat("█", min(cells, width))
+}
+
+// maxValue returns the largest of values, or zero for an empty slice.
+func maxValue(values []float64) float64 {
+	peak := 0.0
+	for _, v := range values {
+		peak = math.Max(peak, v)
+	}
+	return peak
+}
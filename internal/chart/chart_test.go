@@ -0,0 +1,35 @@
+package chart
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSparkline(t *testing.T) {
+	assert.Equal(t, "▁▅█", Sparkline([]float64{0, 50, 100}))
+	assert.Equal(t, "▁▁▁", Sparkline([]float64{0, 0, 0}))
+	assert.Equal(t, "", Sparkline(nil))
+}
+
+func TestBar(t *testing.T) {
+	tests := []struct {
+		name  string
+		value float64
+		peak  float64
+		width int
+		want  string
+	}{
+		{name: "full width at peak", value: 10, peak: 10, width: 5, want: "█████"},
+		{name: "half", value: 5, peak: 10, width: 4, want: "██"},
+		{name: "small values stay visible", value: 1, peak: 1000, width: 10, want: "█"},
+		{name: "zero is empty", value: 0, peak: 10, width: 10, want: ""},
+		{name: "no data", value: 0, peak: 0, width: 10, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Bar(tt.value, tt.peak, tt.width))
+		})
+	}
+}
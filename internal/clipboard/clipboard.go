@@ -0,0 +1,90 @@
+// Package clipboard copies text to the system clipboard.
+package clipboard
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// tool is an external command that reads clipboard contents from stdin.
+type tool struct {
+	name string
+	args []string
+}
+
+// Copy places text on the system clipboard.
+// Over SSH, or when no clipboard tool is installed, it falls back to an
+// OSC 52 escape sequence that most modern terminal emulators understand.
+func Copy(text string) error {
+	if isSSH() {
+		return copyOSC52(text)
+	}
+
+	for _, t := range tools() {
+		if _, err := exec.LookPath(t.name); err != nil {
+			continue
+		}
+		cmd := exec.Command(t.name, t.args...) //nolint:gosec // Tool names come from a fixed list
+		cmd.Stdin = strings.NewReader(text)
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("failed to copy to clipboard with %s: %w", t.name, err)
+		}
+		return nil
+	}
+
+	return copyOSC52(text)
+}
+
+// tools returns clipboard commands to try for the current platform, in order.
+func tools() []tool {
+	switch runtime.GOOS {
+	case "darwin":
+		return []tool{{name: "pbcopy"}}
+	case "windows":
+		return []tool{{name: "clip.exe"}}
+	default:
+		var list []tool
+		if os.Getenv("WAYLAND_DISPLAY") != "" {
+			list = append(list, tool{name: "wl-copy"})
+		}
+		return append(list,
+			tool{name: "xclip", args: []string{"-selection", "clipboard"}},
+			tool{name: "xsel", args: []string{"--clipboard", "--input"}},
+			tool{name: "clip.exe"}, // WSL
+		)
+	}
+}
+
+// isSSH reports whether the process is running inside an SSH session.
+func isSSH() bool {
+	return os.Getenv("SSH_TTY") != "" || os.Getenv("SSH_CONNECTION") != ""
+}
+
+// copyOSC52 writes an OSC 52 sequence to the controlling terminal.
+func copyOSC52(text string) error {
+	var w io.Writer = os.Stderr
+	if tty, err := os.OpenFile("/dev/tty", os.O_WRONLY, 0); err == nil {
+		defer tty.Close()
+		w = tty
+	}
+
+	if _, err := io.WriteString(w, OSC52(text)); err != nil {
+		return fmt.Errorf("failed to write OSC 52 sequence: %w", err)
+	}
+	return nil
+}
+
+// OSC52 returns the terminal escape sequence that sets the clipboard to text.
+// Inside tmux the sequence is wrapped in a DCS passthrough.
+func OSC52(text string) string {
+	seq := "\x1b]52;c;" + base64.StdEncoding.EncodeToString([]byte(text)) + "\a"
+	if os.Getenv("TMUX") != "" {
+		seq = "\x1bPtmux;\x1b" + seq + "\x1b\\"
+	}
+	return seq
+}
@@ -0,0 +1,28 @@
+package clipboard
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOSC52(t *testing.T) {
+	t.Setenv("TMUX", "")
+
+	assert.Equal(t, "\x1b]52;c;aGVsbG8=\a", OSC52("hello"))
+}
+
+func TestOSC52_Tmux(t *testing.T) {
+	t.Setenv("TMUX", "/tmp/tmux-1000/default,1234,0")
+
+	assert.Equal(t, "\x1bPtmux;\x1b\x1b]52;c;aGVsbG8=\a\x1b\\", OSC52("hello"))
+}
+
+func TestIsSSH(t *testing.T) {
+	t.Setenv("SSH_TTY", "")
+	t.Setenv("SSH_CONNECTION", "")
+	assert.False(t, isSSH())
+
+	t.Setenv("SSH_CONNECTION", "10.0.0.1 5555 10.0.0.2 22")
+	assert.True(t, isSSH())
+}
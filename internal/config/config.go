@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/adrg/xdg"
 	"github.com/spf13/viper"
@@ -13,10 +15,54 @@ import (
 
 // Config holds the configuration for cfstream CLI.
 type Config struct {
-	AccountID             string `mapstructure:"account_id"`
-	APIToken              string `mapstructure:"api_token"`
-	DefaultOutput         string `mapstructure:"default_output"`
-	DefaultSignedDuration string `mapstructure:"default_signed_duration"`
+	AccountID             string              `mapstructure:"account_id"`
+	APIToken              string              `mapstructure:"api_token"`
+	DefaultOutput         string              `mapstructure:"default_output"`
+	DefaultSignedDuration string              `mapstructure:"default_signed_duration"`
+	SigningKeyID          string              `mapstructure:"signing_key_id"`
+	SigningKeyPEMPath     string              `mapstructure:"signing_key_pem_path"`
+	Notifications         NotificationsConfig `mapstructure:"notifications"`
+	Lifecycle             LifecycleConfig     `mapstructure:"lifecycle"`
+
+	// ProtectedVideos lists videos that deletions refuse to remove without
+	// explicit confirmation: UIDs, aliases, or filter expressions such as
+	// meta.keep=true
+	ProtectedVideos []string `mapstructure:"protected_videos"`
+
+	// Profiles holds the credentials of additional accounts by name
+	Profiles map[string]Profile `mapstructure:"profiles"`
+}
+
+// DefaultProfile names the top-level account_id and api_token when they are
+// used alongside named profiles.
+const DefaultProfile = "default"
+
+// Profile holds the credentials of one named account. Signing keys belong
+// to an account, so each profile has its own.
+type Profile struct {
+	AccountID         string `mapstructure:"account_id"`
+	APIToken          string `mapstructure:"api_token"`
+	SigningKeyID      string `mapstructure:"signing_key_id"`
+	SigningKeyPEMPath string `mapstructure:"signing_key_pem_path"`
+}
+
+// NotificationsConfig holds settings for completion notifications.
+type NotificationsConfig struct {
+	WebhookURL string `mapstructure:"webhook_url"`
+}
+
+// LifecycleConfig holds the rules evaluated by the lifecycle commands.
+type LifecycleConfig struct {
+	Rules []LifecycleRule `mapstructure:"rules"`
+}
+
+// LifecycleRule applies an action to the videos matching a filter expression,
+// optionally only once they are older than OlderThan (e.g., 30d, 12h).
+type LifecycleRule struct {
+	Name      string `mapstructure:"name"`
+	Filter    string `mapstructure:"filter"`
+	OlderThan string `mapstructure:"older_than"`
+	Action    string `mapstructure:"action"`
 }
 
 // Load reads configuration from file and environment variables.
@@ -47,9 +93,12 @@ func Load() (*Config, error) {
 	}
 
 	// Environment variables override config file
-	_ = v.BindEnv("account_id", "CFSTREAM_ACCOUNT_ID") //nolint:errcheck // Env binding errors are not expected
-	_ = v.BindEnv("api_token", "CFSTREAM_API_TOKEN")   //nolint:errcheck // Env binding errors are not expected
-	_ = v.BindEnv("default_output", "CFSTREAM_OUTPUT") //nolint:errcheck // Env binding errors are not expected
+	_ = v.BindEnv("account_id", "CFSTREAM_ACCOUNT_ID")                     //nolint:errcheck // Env binding errors are not expected
+	_ = v.BindEnv("api_token", "CFSTREAM_API_TOKEN")                       //nolint:errcheck // Env binding errors are not expected
+	_ = v.BindEnv("default_output", "CFSTREAM_OUTPUT")                     //nolint:errcheck // Env binding errors are not expected
+	_ = v.BindEnv("signing_key_id", "CFSTREAM_SIGNING_KEY_ID")             //nolint:errcheck // Env binding errors are not expected
+	_ = v.BindEnv("signing_key_pem_path", "CFSTREAM_SIGNING_KEY_PEM_PATH") //nolint:errcheck // Env binding errors are not expected
+	_ = v.BindEnv("notifications.webhook_url", "CFSTREAM_NOTIFY_WEBHOOK")  //nolint:errcheck // Env binding errors are not expected
 
 	// Create config struct
 	cfg := &Config{
@@ -57,6 +106,19 @@ func Load() (*Config, error) {
 		APIToken:              v.GetString("api_token"),
 		DefaultOutput:         v.GetString("default_output"),
 		DefaultSignedDuration: v.GetString("default_signed_duration"),
+		SigningKeyID:          v.GetString("signing_key_id"),
+		SigningKeyPEMPath:     v.GetString("signing_key_pem_path"),
+		Notifications: NotificationsConfig{
+			WebhookURL: v.GetString("notifications.webhook_url"),
+		},
+		ProtectedVideos: v.GetStringSlice("protected_videos"),
+	}
+
+	if err := v.UnmarshalKey("lifecycle", &cfg.Lifecycle); err != nil {
+		return nil, fmt.Errorf("failed to read lifecycle rules: %w", err)
+	}
+	if err := v.UnmarshalKey("profiles", &cfg.Profiles); err != nil {
+		return nil, fmt.Errorf("failed to read profiles: %w", err)
 	}
 
 	return cfg, nil
@@ -84,6 +146,34 @@ func Save(cfg *Config) error {
 	v.Set("api_token", cfg.APIToken)
 	v.Set("default_output", cfg.DefaultOutput)
 	v.Set("default_signed_duration", cfg.DefaultSignedDuration)
+	if cfg.SigningKeyID != "" {
+		v.Set("signing_key_id", cfg.SigningKeyID)
+		v.Set("signing_key_pem_path", cfg.SigningKeyPEMPath)
+	}
+	if cfg.Notifications.WebhookURL != "" {
+		v.Set("notifications.webhook_url", cfg.Notifications.WebhookURL)
+	}
+	if len(cfg.Lifecycle.Rules) > 0 {
+		rules := make([]map[string]string, len(cfg.Lifecycle.Rules))
+		for i, rule := range cfg.Lifecycle.Rules {
+			rules[i] = map[string]string{"name": rule.Name, "filter": rule.Filter, "action": rule.Action}
+			if rule.OlderThan != "" {
+				rules[i]["older_than"] = rule.OlderThan
+			}
+		}
+		v.Set("lifecycle.rules", rules)
+	}
+	if len(cfg.ProtectedVideos) > 0 {
+		v.Set("protected_videos", cfg.ProtectedVideos)
+	}
+	for name, profile := range cfg.Profiles {
+		settings := map[string]string{"account_id": profile.AccountID, "api_token": profile.APIToken}
+		if profile.SigningKeyID != "" {
+			settings["signing_key_id"] = profile.SigningKeyID
+			settings["signing_key_pem_path"] = profile.SigningKeyPEMPath
+		}
+		v.Set("profiles."+name, settings)
+	}
 
 	// Write config file
 	if err := v.WriteConfig(); err != nil {
@@ -101,6 +191,94 @@ func Save(cfg *Config) error {
 	return nil
 }
 
+// SetSigningKey records the signing key used for local token signing in the
+// config file, or removes it when keyID is empty. The key is set on the named
+// profile, or at the top level when profile is empty or the default profile.
+// Unlike Save, it changes only these settings, so values from the
+// environment are not written to the file.
+func SetSigningKey(profile, keyID, pemPath string) error {
+	configPath := Path()
+	if err := os.MkdirAll(filepath.Dir(configPath), 0o755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	v := viper.New()
+	v.SetConfigFile(configPath)
+	v.SetConfigType("yaml")
+	if err := v.ReadInConfig(); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	// Viper can't unset a key, so the settings are rebuilt without it
+	all := v.AllSettings()
+	settings := all
+	if profile = strings.ToLower(profile); profile != "" {
+		profiles, _ := all["profiles"].(map[string]interface{})
+		profileSettings, ok := profiles[profile].(map[string]interface{})
+		switch {
+		case ok:
+			settings = profileSettings
+		case profile != DefaultProfile:
+			return fmt.Errorf("profile %q not found in %s", profile, configPath)
+		}
+	}
+	delete(settings, "signing_key_id")
+	delete(settings, "signing_key_pem_path")
+	if keyID != "" {
+		settings["signing_key_id"] = keyID
+		settings["signing_key_pem_path"] = pemPath
+	}
+
+	w := viper.New()
+	w.SetConfigType("yaml")
+	if err := w.MergeConfigMap(all); err != nil {
+		return fmt.Errorf("failed to update config: %w", err)
+	}
+	if err := w.WriteConfigAs(configPath); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	return nil
+}
+
+// KeysDir returns the directory holding signing keys created by cfstream.
+func KeysDir() string {
+	return filepath.Join(xdg.ConfigHome, "cfstream", "keys")
+}
+
+// UseProfile replaces the account credentials and signing key with those of
+// the named profile; a profile without a signing key has its tokens signed
+// by the API. Names are case-insensitive. The default profile keeps the
+// top-level settings unless a profile is explicitly named default.
+func (c *Config) UseProfile(name string) error {
+	name = strings.ToLower(name)
+	profile, ok := c.Profiles[name]
+	if !ok {
+		if name == DefaultProfile {
+			return nil
+		}
+		return fmt.Errorf("profile %q not found in %s", name, Path())
+	}
+	c.AccountID = profile.AccountID
+	c.APIToken = profile.APIToken
+	c.SigningKeyID = profile.SigningKeyID
+	c.SigningKeyPEMPath = profile.SigningKeyPEMPath
+	return nil
+}
+
+// ProfileNames returns the names of all configured accounts in order,
+// including the default profile when top-level credentials are set.
+func (c *Config) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles)+1)
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if _, ok := c.Profiles[DefaultProfile]; !ok && c.AccountID != "" {
+		names = append([]string{DefaultProfile}, names...)
+	}
+	return names
+}
+
 // Path returns the full path to the config file.
 func Path() string {
 	return filepath.Join(xdg.ConfigHome, "cfstream", "config.yaml")
@@ -150,6 +150,269 @@ func TestSave_Success(t *testing.T) {
 	assert.Equal(t, cfg.DefaultSignedDuration, loadedCfg.DefaultSignedDuration)
 }
 
+func TestSave_Notifications(t *testing.T) {
+	clearEnv(t)
+
+	tempDir := t.TempDir()
+	oldXDGConfig := os.Getenv("XDG_CONFIG_HOME")
+	defer func() {
+		if oldXDGConfig != "" {
+			os.Setenv("XDG_CONFIG_HOME", oldXDGConfig)
+		} else {
+			os.Unsetenv("XDG_CONFIG_HOME")
+		}
+		xdg.Reload()
+	}()
+	os.Setenv("XDG_CONFIG_HOME", tempDir)
+	xdg.Reload()
+
+	cfg := &Config{
+		AccountID:             "account",
+		APIToken:              "token",
+		DefaultOutput:         "table",
+		DefaultSignedDuration: "1h",
+		Notifications: NotificationsConfig{
+			WebhookURL: "https://hooks.slack.com/services/T000/B000/XXXX",
+		},
+	}
+	require.NoError(t, Save(cfg))
+
+	loadedCfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, cfg.Notifications.WebhookURL, loadedCfg.Notifications.WebhookURL)
+
+	// Environment overrides the file
+	os.Setenv("CFSTREAM_NOTIFY_WEBHOOK", "https://example.com/hook")
+	loadedCfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/hook", loadedCfg.Notifications.WebhookURL)
+}
+
+func TestSave_SigningKey(t *testing.T) {
+	clearEnv(t)
+
+	tempDir := t.TempDir()
+	oldXDGConfig := os.Getenv("XDG_CONFIG_HOME")
+	defer func() {
+		if oldXDGConfig != "" {
+			os.Setenv("XDG_CONFIG_HOME", oldXDGConfig)
+		} else {
+			os.Unsetenv("XDG_CONFIG_HOME")
+		}
+		xdg.Reload()
+	}()
+	os.Setenv("XDG_CONFIG_HOME", tempDir)
+	xdg.Reload()
+
+	cfg := &Config{
+		AccountID:             "account",
+		APIToken:              "token",
+		DefaultOutput:         "table",
+		DefaultSignedDuration: "1h",
+		SigningKeyID:          "key123",
+		SigningKeyPEMPath:     "/keys/stream.pem",
+	}
+	require.NoError(t, Save(cfg))
+
+	loadedCfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, "key123", loadedCfg.SigningKeyID)
+	assert.Equal(t, "/keys/stream.pem", loadedCfg.SigningKeyPEMPath)
+
+	os.Setenv("CFSTREAM_SIGNING_KEY_ID", "key456")
+	defer os.Unsetenv("CFSTREAM_SIGNING_KEY_ID")
+	loadedCfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, "key456", loadedCfg.SigningKeyID)
+}
+
+func TestSetSigningKey(t *testing.T) {
+	clearEnv(t)
+
+	tempDir := t.TempDir()
+	oldXDGConfig := os.Getenv("XDG_CONFIG_HOME")
+	defer func() {
+		if oldXDGConfig != "" {
+			os.Setenv("XDG_CONFIG_HOME", oldXDGConfig)
+		} else {
+			os.Unsetenv("XDG_CONFIG_HOME")
+		}
+		xdg.Reload()
+	}()
+	os.Setenv("XDG_CONFIG_HOME", tempDir)
+	xdg.Reload()
+
+	// Works without a config file
+	require.NoError(t, SetSigningKey("", "key1", "/keys/key1.pem"))
+	loadedCfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, "key1", loadedCfg.SigningKeyID)
+
+	cfg := &Config{
+		AccountID:             "account",
+		APIToken:              "token",
+		DefaultOutput:         "json",
+		DefaultSignedDuration: "1h",
+		ProtectedVideos:       []string{"abc123"},
+	}
+	require.NoError(t, Save(cfg))
+
+	// Environment values are not written to the file
+	os.Setenv("CFSTREAM_API_TOKEN", "env-token")
+	defer os.Unsetenv("CFSTREAM_API_TOKEN")
+
+	require.NoError(t, SetSigningKey("", "key2", "/keys/key2.pem"))
+	data, err := os.ReadFile(Path())
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "env-token")
+
+	os.Unsetenv("CFSTREAM_API_TOKEN")
+	loadedCfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, "key2", loadedCfg.SigningKeyID)
+	assert.Equal(t, "/keys/key2.pem", loadedCfg.SigningKeyPEMPath)
+	assert.Equal(t, "token", loadedCfg.APIToken)
+	assert.Equal(t, "json", loadedCfg.DefaultOutput)
+	assert.Equal(t, []string{"abc123"}, loadedCfg.ProtectedVideos)
+
+	require.NoError(t, SetSigningKey("", "", ""))
+	loadedCfg, err = Load()
+	require.NoError(t, err)
+	assert.Empty(t, loadedCfg.SigningKeyID)
+	assert.Empty(t, loadedCfg.SigningKeyPEMPath)
+	assert.Equal(t, "token", loadedCfg.APIToken)
+
+	// Profiles have their own keys
+	cfg.Profiles = map[string]Profile{"acme": {AccountID: "acme-account", APIToken: "acme-token"}}
+	require.NoError(t, Save(cfg))
+	require.NoError(t, SetSigningKey("ACME", "key3", "/keys/key3.pem"))
+	loadedCfg, err = Load()
+	require.NoError(t, err)
+	assert.Empty(t, loadedCfg.SigningKeyID)
+	assert.Equal(t, "key3", loadedCfg.Profiles["acme"].SigningKeyID)
+	assert.Equal(t, "acme-token", loadedCfg.Profiles["acme"].APIToken)
+
+	assert.Error(t, SetSigningKey("initech", "key4", "/keys/key4.pem"))
+}
+
+func TestSave_Lifecycle(t *testing.T) {
+	clearEnv(t)
+
+	tempDir := t.TempDir()
+	oldXDGConfig := os.Getenv("XDG_CONFIG_HOME")
+	defer func() {
+		if oldXDGConfig != "" {
+			os.Setenv("XDG_CONFIG_HOME", oldXDGConfig)
+		} else {
+			os.Unsetenv("XDG_CONFIG_HOME")
+		}
+		xdg.Reload()
+	}()
+	os.Setenv("XDG_CONFIG_HOME", tempDir)
+	xdg.Reload()
+
+	cfg := &Config{
+		AccountID:             "account",
+		APIToken:              "token",
+		DefaultOutput:         "table",
+		DefaultSignedDuration: "1h",
+		Lifecycle: LifecycleConfig{Rules: []LifecycleRule{
+			{Name: "temp", Filter: "meta.temp=true", OlderThan: "30d", Action: "delete"},
+			{Name: "ceo", Filter: "creator=ceo", Action: "protect"},
+		}},
+	}
+	require.NoError(t, Save(cfg))
+
+	loadedCfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, cfg.Lifecycle, loadedCfg.Lifecycle)
+}
+
+func TestSave_ProtectedVideos(t *testing.T) {
+	clearEnv(t)
+
+	tempDir := t.TempDir()
+	oldXDGConfig := os.Getenv("XDG_CONFIG_HOME")
+	defer func() {
+		if oldXDGConfig != "" {
+			os.Setenv("XDG_CONFIG_HOME", oldXDGConfig)
+		} else {
+			os.Unsetenv("XDG_CONFIG_HOME")
+		}
+		xdg.Reload()
+	}()
+	os.Setenv("XDG_CONFIG_HOME", tempDir)
+	xdg.Reload()
+
+	cfg := &Config{
+		AccountID:             "account",
+		APIToken:              "token",
+		DefaultOutput:         "table",
+		DefaultSignedDuration: "1h",
+		ProtectedVideos:       []string{"abc123", "keynote", "meta.keep=true"},
+	}
+	require.NoError(t, Save(cfg))
+
+	loadedCfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, cfg.ProtectedVideos, loadedCfg.ProtectedVideos)
+}
+
+func TestSave_Profiles(t *testing.T) {
+	clearEnv(t)
+
+	tempDir := t.TempDir()
+	oldXDGConfig := os.Getenv("XDG_CONFIG_HOME")
+	defer func() {
+		if oldXDGConfig != "" {
+			os.Setenv("XDG_CONFIG_HOME", oldXDGConfig)
+		} else {
+			os.Unsetenv("XDG_CONFIG_HOME")
+		}
+		xdg.Reload()
+	}()
+	os.Setenv("XDG_CONFIG_HOME", tempDir)
+	xdg.Reload()
+
+	cfg := &Config{
+		AccountID:             "account",
+		APIToken:              "token",
+		DefaultOutput:         "table",
+		DefaultSignedDuration: "1h",
+		Profiles: map[string]Profile{
+			"acme":   {AccountID: "acme-account", APIToken: "acme-token", SigningKeyID: "acme-key", SigningKeyPEMPath: "/keys/acme.pem"},
+			"globex": {AccountID: "globex-account", APIToken: "globex-token"},
+		},
+	}
+	require.NoError(t, Save(cfg))
+
+	loadedCfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, cfg.Profiles, loadedCfg.Profiles)
+	assert.Equal(t, []string{DefaultProfile, "acme", "globex"}, loadedCfg.ProfileNames())
+}
+
+func TestUseProfile(t *testing.T) {
+	cfg := &Config{
+		AccountID:    "account",
+		APIToken:     "token",
+		SigningKeyID: "key",
+		Profiles:     map[string]Profile{"acme": {AccountID: "acme-account", APIToken: "acme-token"}},
+	}
+
+	require.NoError(t, cfg.UseProfile(DefaultProfile))
+	assert.Equal(t, "account", cfg.AccountID)
+	assert.Equal(t, "key", cfg.SigningKeyID)
+
+	// The top-level signing key belongs to another account
+	require.NoError(t, cfg.UseProfile("ACME"))
+	assert.Equal(t, "acme-account", cfg.AccountID)
+	assert.Equal(t, "acme-token", cfg.APIToken)
+	assert.Empty(t, cfg.SigningKeyID)
+
+	assert.Error(t, cfg.UseProfile("initech"))
+}
+
 func TestSave_NilConfig(t *testing.T) {
 	err := Save(nil)
 	require.Error(t, err)
@@ -254,6 +517,17 @@ func TestValidate_Errors(t *testing.T) {
 			},
 			expectError: "api_token is required",
 		},
+		{
+			name: "signing key without pem path",
+			config: &Config{
+				AccountID:             "account",
+				APIToken:              "token",
+				DefaultOutput:         "table",
+				DefaultSignedDuration: "1h",
+				SigningKeyID:          "key123",
+			},
+			expectError: "signing_key_id and signing_key_pem_path must be set together",
+		},
 		{
 			name: "invalid output format",
 			config: &Config{
@@ -274,6 +548,17 @@ func TestValidate_Errors(t *testing.T) {
 			},
 			expectError: "default_signed_duration must be a valid duration string",
 		},
+		{
+			name: "invalid webhook URL",
+			config: &Config{
+				AccountID:             "account",
+				APIToken:              "token",
+				DefaultOutput:         "table",
+				DefaultSignedDuration: "1h",
+				Notifications:         NotificationsConfig{WebhookURL: "hooks.slack.com/services/x"},
+			},
+			expectError: "notifications.webhook_url must be an http(s) URL",
+		},
 	}
 
 	for _, tt := range tests {
@@ -307,6 +592,9 @@ func clearEnv(t *testing.T) {
 		"CFSTREAM_ACCOUNT_ID",
 		"CFSTREAM_API_TOKEN",
 		"CFSTREAM_OUTPUT",
+		"CFSTREAM_NOTIFY_WEBHOOK",
+		"CFSTREAM_SIGNING_KEY_ID",
+		"CFSTREAM_SIGNING_KEY_PEM_PATH",
 	}
 	for _, key := range envVars {
 		os.Unsetenv(key)
@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 	"strings"
 	"time"
 )
@@ -49,5 +50,18 @@ func Validate(cfg *Config) error {
 		return fmt.Errorf("default_signed_duration must be a valid duration string (e.g., 1h, 30m, 1h30m): %w", err)
 	}
 
+	// The local token signer needs both the key ID and the key
+	if (cfg.SigningKeyID == "") != (cfg.SigningKeyPEMPath == "") {
+		return fmt.Errorf("signing_key_id and signing_key_pem_path must be set together")
+	}
+
+	// Validate notification webhook
+	if webhookURL := cfg.Notifications.WebhookURL; webhookURL != "" {
+		u, err := url.Parse(webhookURL)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return fmt.Errorf("notifications.webhook_url must be an http(s) URL (got: %s)", webhookURL)
+		}
+	}
+
 	return nil
 }
@@ -0,0 +1,193 @@
+// Package embed renders player snippets other than the Stream iframe:
+// the <stream> web component, a React component, and a plain HLS.js player.
+package embed
+
+import (
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+
+	"cfstream/internal/api"
+)
+
+// Targets lists the supported snippet targets, including the default iframe.
+var Targets = []string{"iframe", "stream-element", "react", "hls-js"}
+
+// Video identifies the video a snippet plays.
+type Video struct {
+	CustomerCode string
+	UID          string
+}
+
+// Render returns the snippet for target. The iframe target is produced by
+// api.Client.GetEmbedCode and is not handled here.
+func Render(target string, v Video, opts *api.EmbedOptions) (string, error) {
+	if opts == nil {
+		opts = &api.EmbedOptions{Controls: true}
+	}
+
+	switch target {
+	case "stream-element":
+		return streamElement(v, opts), nil
+	case "react":
+		return react(v, opts), nil
+	case "hls-js":
+		return hlsJS(v, opts), nil
+	default:
+		return "", fmt.Errorf("unsupported target: %s (supported: %s)", target, strings.Join(Targets, ", "))
+	}
+}
+
+// source returns the video reference used by the Stream SDKs: the signed
+// token for private videos and the UID otherwise.
+func source(v Video, opts *api.EmbedOptions) string {
+	if opts.SignedToken != "" {
+		return opts.SignedToken
+	}
+	return v.UID
+}
+
+// attribute is a player setting shared by the element and React snippets.
+type attribute struct {
+	html  string // <stream> attribute name
+	react string // React prop name
+	value string // empty for boolean attributes
+}
+
+func attributes(opts *api.EmbedOptions) []attribute {
+	var attrs []attribute
+	add := func(htmlName, reactName, value string) {
+		attrs = append(attrs, attribute{html: htmlName, react: reactName, value: value})
+	}
+
+	if opts.Controls {
+		add("controls", "controls", "")
+	}
+	if opts.Autoplay {
+		add("autoplay", "autoplay", "")
+	}
+	if opts.Muted {
+		add("muted", "muted", "")
+	}
+	if opts.Loop {
+		add("loop", "loop", "")
+	}
+	if opts.Poster != "" {
+		add("poster", "poster", opts.Poster)
+	}
+	if opts.Preload != "" {
+		add("preload", "preload", opts.Preload)
+	}
+	if opts.PrimaryColor != "" {
+		add("primary-color", "primaryColor", opts.PrimaryColor)
+	}
+	if opts.LetterboxColor != "" {
+		add("letterbox-color", "letterboxColor", opts.LetterboxColor)
+	}
+	if opts.StartTime != "" {
+		add("start-time", "startTime", opts.StartTime)
+	}
+	if opts.DefaultTextTrack != "" {
+		add("default-text-track", "defaultTextTrack", opts.DefaultTextTrack)
+	}
+	if opts.Width > 0 {
+		add("width", "width", strconv.Itoa(opts.Width))
+	}
+	if opts.Height > 0 {
+		add("height", "height", strconv.Itoa(opts.Height))
+	}
+	return attrs
+}
+
+func streamElement(v Video, opts *api.EmbedOptions) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, `<stream src="%s"`, html.EscapeString(source(v, opts)))
+	for _, a := range attributes(opts) {
+		if a.value == "" {
+			fmt.Fprintf(&b, " %s", a.html)
+		} else {
+			fmt.Fprintf(&b, ` %s="%s"`, a.html, html.EscapeString(a.value))
+		}
+	}
+	b.WriteString("></stream>\n")
+	fmt.Fprintf(&b, `<script data-cfasync="false" defer type="text/javascript" src="https://customer-%s.cloudflarestream.com/embed/sdk.latest.js"></script>`, v.CustomerCode)
+	return b.String()
+}
+
+func react(v Video, opts *api.EmbedOptions) string {
+	var b strings.Builder
+	b.WriteString("// npm install @cloudflare/stream-react\n")
+	b.WriteString("import { Stream } from \"@cloudflare/stream-react\";\n\n")
+	b.WriteString("export function Player() {\n  return (\n    <Stream\n")
+	fmt.Fprintf(&b, "      src=%s\n", strconv.Quote(source(v, opts)))
+	fmt.Fprintf(&b, "      customerCode=%s\n", strconv.Quote(v.CustomerCode))
+	for _, a := range attributes(opts) {
+		if a.value == "" {
+			fmt.Fprintf(&b, "      %s\n", a.react)
+		} else {
+			fmt.Fprintf(&b, "      %s=%s\n", a.react, strconv.Quote(a.value))
+		}
+	}
+	if opts.Responsive {
+		b.WriteString("      responsive\n")
+	}
+	b.WriteString("    />\n  );\n}")
+	return b.String()
+}
+
+func hlsJS(v Video, opts *api.EmbedOptions) string {
+	manifest := fmt.Sprintf("https://customer-%s.cloudflarestream.com/%s/manifest/video.m3u8", v.CustomerCode, v.UID)
+	if opts.SignedToken != "" {
+		manifest += "?token=" + opts.SignedToken
+	}
+
+	var attrs []string
+	if opts.Controls {
+		attrs = append(attrs, "controls")
+	}
+	if opts.Autoplay {
+		attrs = append(attrs, "autoplay")
+	}
+	if opts.Muted {
+		attrs = append(attrs, "muted")
+	}
+	if opts.Loop {
+		attrs = append(attrs, "loop")
+	}
+	attrs = append(attrs, "playsinline")
+	if opts.Poster != "" {
+		attrs = append(attrs, fmt.Sprintf(`poster="%s"`, html.EscapeString(opts.Poster)))
+	}
+	if opts.Preload != "" {
+		attrs = append(attrs, fmt.Sprintf(`preload="%s"`, html.EscapeString(opts.Preload)))
+	}
+	if opts.Responsive {
+		attrs = append(attrs, `style="width: 100%; height: auto;"`)
+	} else {
+		width, height := opts.Width, opts.Height
+		if width <= 0 {
+			width = 1280
+		}
+		if height <= 0 {
+			height = 720
+		}
+		attrs = append(attrs, fmt.Sprintf(`width="%d" height="%d"`, width, height))
+	}
+
+	return fmt.Sprintf(`<video id="cfstream-player" %s></video>
+<script src="https://cdn.jsdelivr.net/npm/hls.js@1"></script>
+<script>
+  (function () {
+    var video = document.getElementById("cfstream-player");
+    var src = %s;
+    if (video.canPlayType("application/vnd.apple.mpegurl")) {
+      video.src = src;
+    } else if (window.Hls && Hls.isSupported()) {
+      var hls = new Hls();
+      hls.loadSource(src);
+      hls.attachMedia(video);
+    }
+  })();
+</script>`, strings.Join(attrs, " "), strconv.Quote(manifest))
+}
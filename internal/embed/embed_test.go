@@ -0,0 +1,43 @@
+package embed
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"cfstream/internal/api"
+)
+
+var testVideo = Video{CustomerCode: "abc", UID: "vid"}
+
+func TestRender_StreamElement(t *testing.T) {
+	out, err := Render("stream-element", testVideo, &api.EmbedOptions{Controls: true, Muted: true, PrimaryColor: "#f48120"})
+	require.NoError(t, err)
+	assert.Contains(t, out, `<stream src="vid" controls muted primary-color="#f48120"></stream>`)
+	assert.Contains(t, out, `src="https://customer-abc.cloudflarestream.com/embed/sdk.latest.js"`)
+}
+
+func TestRender_React(t *testing.T) {
+	out, err := Render("react", testVideo, &api.EmbedOptions{Controls: true, SignedToken: "tok", StartTime: "30s"})
+	require.NoError(t, err)
+	assert.Contains(t, out, `import { Stream } from "@cloudflare/stream-react";`)
+	assert.Contains(t, out, `src="tok"`)
+	assert.Contains(t, out, `customerCode="abc"`)
+	assert.Contains(t, out, `startTime="30s"`)
+}
+
+func TestRender_HLSJS(t *testing.T) {
+	out, err := Render("hls-js", testVideo, &api.EmbedOptions{Controls: true, SignedToken: "tok"})
+	require.NoError(t, err)
+	assert.Contains(t, out, `"https://customer-abc.cloudflarestream.com/vid/manifest/video.m3u8?token=tok"`)
+	assert.Contains(t, out, `width="1280" height="720"`)
+	assert.Contains(t, out, "hls.js@1")
+}
+
+func TestRender_Unsupported(t *testing.T) {
+	_, err := Render("iframe", testVideo, nil)
+	assert.Error(t, err)
+	_, err = Render("flash", testVideo, nil)
+	assert.Error(t, err)
+}
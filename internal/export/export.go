@@ -0,0 +1,132 @@
+// Package export builds a catalog of the video library for backups and
+// version control.
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"cfstream/internal/api"
+)
+
+// Catalog is an export of the video library. It is also a valid manifest
+// for the diff command.
+type Catalog struct {
+	Videos []Video `json:"videos" yaml:"videos"`
+}
+
+// Video is the exported state of one video.
+type Video struct {
+	UID               string                 `json:"uid" yaml:"uid"`
+	Name              string                 `json:"name" yaml:"name"`
+	Creator           string                 `json:"creator,omitempty" yaml:"creator,omitempty"`
+	Created           time.Time              `json:"created" yaml:"created"`
+	Modified          time.Time              `json:"modified" yaml:"modified"`
+	Status            string                 `json:"status" yaml:"status"`
+	Duration          float64                `json:"duration" yaml:"duration"`
+	Size              int64                  `json:"size" yaml:"size"`
+	RequireSignedURLs bool                   `json:"requireSignedURLs" yaml:"requireSignedURLs"`
+	AllowedOrigins    []string               `json:"allowedOrigins" yaml:"allowedOrigins"`
+	Meta              map[string]interface{} `json:"meta" yaml:"meta"`
+	Captions          []api.Caption          `json:"captions" yaml:"captions"`
+}
+
+// Row is one video flattened for CSV, with lists joined by spaces and the
+// metadata as JSON.
+type Row struct {
+	UID               string
+	Name              string
+	Creator           string
+	Created           string
+	Modified          string
+	Status            string
+	Duration          float64
+	Size              int64
+	RequireSignedURLs bool
+	AllowedOrigins    string
+	Meta              string
+	Captions          string
+}
+
+// RowHeaders are the CSV columns of Rows.
+var RowHeaders = []string{
+	"UID", "Name", "Creator", "Created", "Modified", "Status", "Duration", "Size",
+	"Require_Signed_URLs", "Allowed_Origins", "Meta", "Captions",
+}
+
+// New builds a catalog of videos with the caption tracks of each, keyed by
+// video UID. Videos are sorted by UID and captions by language, so exports
+// of an unchanged library are identical.
+func New(videos []api.Video, captions map[string][]api.Caption) *Catalog {
+	catalog := &Catalog{Videos: make([]Video, len(videos))}
+	for i, v := range videos {
+		tracks := append([]api.Caption{}, captions[v.UID]...)
+		sort.Slice(tracks, func(a, b int) bool { return tracks[a].Language < tracks[b].Language })
+
+		origins := v.AllowedOrigins
+		if origins == nil {
+			origins = []string{}
+		}
+		meta := v.Meta
+		if meta == nil {
+			meta = map[string]interface{}{}
+		}
+
+		catalog.Videos[i] = Video{
+			UID:               v.UID,
+			Name:              v.Name,
+			Creator:           v.Creator,
+			Created:           v.Created.UTC(),
+			Modified:          v.Modified.UTC(),
+			Status:            v.Status,
+			Duration:          v.Duration,
+			Size:              v.Size,
+			RequireSignedURLs: v.RequireSignedURLs,
+			AllowedOrigins:    origins,
+			Meta:              meta,
+			Captions:          tracks,
+		}
+	}
+
+	sort.Slice(catalog.Videos, func(a, b int) bool { return catalog.Videos[a].UID < catalog.Videos[b].UID })
+	return catalog
+}
+
+// Rows flattens the catalog for CSV output. Captions are listed as their
+// languages, with generated ones marked, e.g. "de en(generated)".
+func (c *Catalog) Rows() ([]Row, error) {
+	rows := make([]Row, len(c.Videos))
+	for i, v := range c.Videos {
+		meta, err := json.Marshal(v.Meta)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode metadata of %s: %w", v.UID, err)
+		}
+
+		languages := make([]string, len(v.Captions))
+		for j, caption := range v.Captions {
+			languages[j] = caption.Language
+			if caption.Generated {
+				languages[j] += "(generated)"
+			}
+		}
+
+		rows[i] = Row{
+			UID:               v.UID,
+			Name:              v.Name,
+			Creator:           v.Creator,
+			Created:           v.Created.Format(time.RFC3339),
+			Modified:          v.Modified.Format(time.RFC3339),
+			Status:            v.Status,
+			Duration:          v.Duration,
+			Size:              v.Size,
+			RequireSignedURLs: v.RequireSignedURLs,
+			AllowedOrigins:    strings.Join(v.AllowedOrigins, " "),
+			Meta:              string(meta),
+			Captions:          strings.Join(languages, " "),
+		}
+	}
+	return rows, nil
+}
@@ -0,0 +1,60 @@
+package export
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"cfstream/internal/api"
+)
+
+func TestNew(t *testing.T) {
+	created := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	videos := []api.Video{
+		{UID: "b", Name: "Second", Created: created, RequireSignedURLs: true, AllowedOrigins: []string{"example.com"}, Meta: map[string]interface{}{"name": "Second"}},
+		{UID: "a", Name: "First", Created: created},
+	}
+	captions := map[string][]api.Caption{
+		"b": {{Language: "en", Generated: true}, {Language: "de"}},
+	}
+
+	catalog := New(videos, captions)
+	require.Len(t, catalog.Videos, 2)
+
+	assert.Equal(t, "a", catalog.Videos[0].UID)
+	assert.Equal(t, []string{}, catalog.Videos[0].AllowedOrigins)
+	assert.Equal(t, map[string]interface{}{}, catalog.Videos[0].Meta)
+	assert.Empty(t, catalog.Videos[0].Captions)
+
+	b := catalog.Videos[1]
+	assert.True(t, b.RequireSignedURLs)
+	assert.Equal(t, []string{"example.com"}, b.AllowedOrigins)
+	require.Len(t, b.Captions, 2)
+	assert.Equal(t, "de", b.Captions[0].Language)
+	assert.Equal(t, "en", b.Captions[1].Language)
+
+	// The caption inventory given to New is not reordered
+	assert.Equal(t, "en", captions["b"][0].Language)
+}
+
+func TestRows(t *testing.T) {
+	created := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	catalog := New([]api.Video{{
+		UID:            "a",
+		Name:           "Intro",
+		Created:        created,
+		AllowedOrigins: []string{"a.example", "b.example"},
+		Meta:           map[string]interface{}{"category": "training"},
+	}}, map[string][]api.Caption{"a": {{Language: "en", Generated: true}, {Language: "de"}}})
+
+	rows, err := catalog.Rows()
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+
+	assert.Equal(t, "2024-03-01T12:00:00Z", rows[0].Created)
+	assert.Equal(t, "a.example b.example", rows[0].AllowedOrigins)
+	assert.Equal(t, `{"category":"training"}`, rows[0].Meta)
+	assert.Equal(t, "de en(generated)", rows[0].Captions)
+}
@@ -0,0 +1,204 @@
+// Package fetch downloads files over HTTP, resuming interrupted downloads
+// with range requests.
+package fetch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// PartialSuffix is appended to the destination path while a download is
+// incomplete. A partial file left by an interrupted run is resumed.
+const PartialSuffix = ".part"
+
+// Options configures a download.
+type Options struct {
+	Client  *http.Client // Defaults to http.DefaultClient
+	Retries int          // Times an interrupted transfer is resumed before giving up
+
+	// OnStart is called once the size of the file is known, with -1 when the
+	// server doesn't say.
+	OnStart func(total int64)
+	// OnProgress is called with the number of bytes on disk so far,
+	// including those of a resumed partial file.
+	OnProgress func(written int64)
+}
+
+// File downloads url to path and returns the size of the file. The data is
+// written to path+PartialSuffix first and renamed when complete; an existing
+// partial file is continued with a range request when the server supports
+// it and restarted otherwise.
+func File(ctx context.Context, url, path string, opts Options) (int64, error) {
+	if opts.Client == nil {
+		opts.Client = http.DefaultClient
+	}
+	part := path + PartialSuffix
+
+	started := false
+	for attempt := 0; ; attempt++ {
+		size, err := transfer(ctx, url, part, &opts, &started)
+		if err == nil {
+			if err := os.Rename(part, path); err != nil {
+				return 0, fmt.Errorf("failed to rename %s: %w", part, err)
+			}
+			return size, nil
+		}
+
+		var interrupted *interruptedError
+		if !errors.As(err, &interrupted) || ctx.Err() != nil || attempt >= opts.Retries {
+			return 0, err
+		}
+	}
+}
+
+// interruptedError is a transfer that failed after the response began and
+// can be resumed.
+type interruptedError struct {
+	err error
+}
+
+func (e *interruptedError) Error() string {
+	return fmt.Sprintf("download interrupted (rerun to resume): %v", e.err)
+}
+
+func (e *interruptedError) Unwrap() error {
+	return e.err
+}
+
+// transfer makes one request for the missing part of the file and appends
+// the response to part, returning the size of part when complete.
+func transfer(ctx context.Context, url, part string, opts *Options, started *bool) (int64, error) {
+	var offset int64
+	if info, err := os.Stat(part); err == nil {
+		offset = info.Size()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	resp, err := opts.Client.Do(req)
+	if err != nil {
+		return 0, &interruptedError{err: err}
+	}
+	defer resp.Body.Close()
+
+	total := int64(-1)
+	flags := os.O_CREATE | os.O_WRONLY
+	switch {
+	case resp.StatusCode == http.StatusPartialContent && offset > 0:
+		start, size, ok := parseContentRange(resp.Header.Get("Content-Range"))
+		if !ok || start != offset {
+			return 0, fmt.Errorf("unexpected Content-Range %q resuming at byte %d", resp.Header.Get("Content-Range"), offset)
+		}
+		total = size
+		flags |= os.O_APPEND
+	case resp.StatusCode == http.StatusRequestedRangeNotSatisfiable && offset > 0:
+		// The partial file is already complete, or longer than the file now
+		// on the server, in which case it is started again
+		if _, size, ok := parseContentRange(resp.Header.Get("Content-Range")); ok && size == offset {
+			return offset, nil
+		}
+		if err := os.Remove(part); err != nil {
+			return 0, fmt.Errorf("failed to remove %s: %w", part, err)
+		}
+		return 0, &interruptedError{err: fmt.Errorf("partial file does not match the server's")}
+	case resp.StatusCode == http.StatusOK:
+		// The server ignored the range, so the file is downloaded from the start
+		offset = 0
+		total = resp.ContentLength
+		flags |= os.O_TRUNC
+	default:
+		return 0, fmt.Errorf("download failed with status %s", resp.Status)
+	}
+
+	if !*started {
+		*started = true
+		if opts.OnStart != nil {
+			opts.OnStart(total)
+		}
+	}
+	if opts.OnProgress != nil {
+		opts.OnProgress(offset)
+	}
+
+	f, err := os.OpenFile(part, flags, 0o644) //nolint:gosec // Downloads are ordinary user files
+	if err != nil {
+		return 0, fmt.Errorf("failed to open %s: %w", part, err)
+	}
+	defer f.Close()
+
+	w := &progressWriter{w: f, written: offset, onProgress: opts.OnProgress}
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return 0, &interruptedError{err: err}
+	}
+	if err := f.Close(); err != nil {
+		return 0, fmt.Errorf("failed to write %s: %w", part, err)
+	}
+	if total >= 0 && w.written != total {
+		return 0, &interruptedError{err: fmt.Errorf("received %d of %d bytes", w.written, total)}
+	}
+	return w.written, nil
+}
+
+// parseContentRange parses a Content-Range header such as
+// "bytes 100-199/200" or "bytes */200" into the first byte and the total
+// size, which is -1 when given as *.
+func parseContentRange(value string) (start, total int64, ok bool) {
+	spec, found := strings.CutPrefix(value, "bytes ")
+	if !found {
+		return 0, 0, false
+	}
+	rng, size, found := strings.Cut(spec, "/")
+	if !found {
+		return 0, 0, false
+	}
+
+	total = -1
+	if size != "*" {
+		n, err := strconv.ParseInt(size, 10, 64)
+		if err != nil {
+			return 0, 0, false
+		}
+		total = n
+	}
+
+	if rng == "*" {
+		return 0, total, true
+	}
+	first, _, found := strings.Cut(rng, "-")
+	if !found {
+		return 0, 0, false
+	}
+	start, err := strconv.ParseInt(first, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return start, total, true
+}
+
+// progressWriter counts the bytes written through it.
+type progressWriter struct {
+	w          io.Writer
+	written    int64
+	onProgress func(int64)
+}
+
+func (p *progressWriter) Write(b []byte) (int, error) {
+	n, err := p.w.Write(b)
+	p.written += int64(n)
+	if p.onProgress != nil {
+		p.onProgress(p.written)
+	}
+	return n, err
+}
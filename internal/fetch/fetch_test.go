@@ -0,0 +1,194 @@
+package fetch
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"cfstream/internal/api"
+)
+
+var content = bytes.Repeat([]byte("0123456789"), 1000)
+
+func serveContent(w http.ResponseWriter, r *http.Request) {
+	http.ServeContent(w, r, "video.mp4", time.Time{}, bytes.NewReader(content))
+}
+
+func TestFile(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(serveContent))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "video.mp4")
+	var total, last int64
+	size, err := File(context.Background(), srv.URL, path, Options{
+		OnStart:    func(n int64) { total = n },
+		OnProgress: func(n int64) { last = n },
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(len(content)), size)
+	assert.Equal(t, int64(len(content)), total)
+	assert.Equal(t, int64(len(content)), last)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, content, data)
+	assert.NoFileExists(t, path+PartialSuffix)
+}
+
+func TestFile_ResumesPartialFile(t *testing.T) {
+	var ranges []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ranges = append(ranges, r.Header.Get("Range"))
+		serveContent(w, r)
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "video.mp4")
+	require.NoError(t, os.WriteFile(path+PartialSuffix, content[:4000], 0o644))
+
+	size, err := File(context.Background(), srv.URL, path, Options{})
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(len(content)), size)
+	assert.Equal(t, []string{"bytes=4000-"}, ranges)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, content, data)
+}
+
+func TestFile_CompletePartialFile(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(serveContent))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "video.mp4")
+	require.NoError(t, os.WriteFile(path+PartialSuffix, content, 0o644))
+
+	size, err := File(context.Background(), srv.URL, path, Options{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(content)), size)
+	assert.FileExists(t, path)
+}
+
+func TestFile_RangeIgnored(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(content) //nolint:errcheck // Test server
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "video.mp4")
+	require.NoError(t, os.WriteFile(path+PartialSuffix, []byte("stale partial data"), 0o644))
+
+	_, err := File(context.Background(), srv.URL, path, Options{})
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, content, data)
+}
+
+func TestFile_RetriesInterruptedTransfer(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			// Promise the whole file but drop the connection halfway
+			w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+			_, _ = w.Write(content[:len(content)/2]) //nolint:errcheck // Test server
+			return
+		}
+		serveContent(w, r)
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "video.mp4")
+
+	_, err := File(context.Background(), srv.URL, path, Options{})
+	require.Error(t, err)
+	assert.FileExists(t, path+PartialSuffix)
+
+	requests.Store(0)
+	size, err := File(context.Background(), srv.URL, path, Options{Retries: 1})
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(content)), size)
+	assert.Equal(t, int32(2), requests.Load())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, content, data)
+}
+
+func TestFile_HTTPError(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	_, err := File(context.Background(), srv.URL, filepath.Join(t.TempDir(), "video.mp4"), Options{Retries: 3})
+	assert.ErrorContains(t, err, "404")
+}
+
+func TestParseContentRange(t *testing.T) {
+	tests := []struct {
+		value        string
+		start, total int64
+		ok           bool
+	}{
+		{"bytes 100-199/200", 100, 200, true},
+		{"bytes 0-99/*", 0, -1, true},
+		{"bytes */200", 0, 200, true},
+		{"items 0-1/2", 0, 0, false},
+		{"bytes 100-199", 0, 0, false},
+		{"bytes x-1/2", 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		start, total, ok := parseContentRange(tt.value)
+		assert.Equal(t, tt.ok, ok, tt.value)
+		if tt.ok {
+			assert.Equal(t, tt.start, start, tt.value)
+			assert.Equal(t, tt.total, total, tt.value)
+		}
+	}
+}
+
+func TestName(t *testing.T) {
+	video := &api.Video{
+		UID:     "abc123",
+		Name:    "Q3 Review: Final.mov",
+		Created: time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC),
+		Meta:    map[string]interface{}{"team": "sales/emea", "year": float64(2024)},
+	}
+
+	tests := []struct {
+		template string
+		want     string
+	}{
+		{DefaultTemplate, "abc123.mp4"},
+		{"{name}.mp4", "Q3 Review_ Final.mp4"},
+		{"{meta.team}/{created}-{uid}.mp4", "sales_emea/2024-06-01-abc123.mp4"},
+		{"{meta.year}-{meta.missing}{uid}.mp4", "2024-abc123.mp4"},
+	}
+	for _, tt := range tests {
+		name, err := Name(tt.template, video)
+		require.NoError(t, err, tt.template)
+		assert.Equal(t, tt.want, name, tt.template)
+	}
+
+	for _, template := range []string{"{title}.mp4", "{uid.mp4", "{meta.missing}.mp4", "{meta.}.mp4"} {
+		_, err := Name(template, video)
+		assert.Error(t, err, template)
+	}
+}
+
+func TestName_NoDotDot(t *testing.T) {
+	name, err := Name("{name}.mp4", &api.Video{UID: "abc", Name: "../../etc/passwd"})
+	require.NoError(t, err)
+	assert.Equal(t, "_.._etc_passwd.mp4", name)
+}
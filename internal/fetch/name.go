@@ -0,0 +1,85 @@
+package fetch
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"cfstream/internal/api"
+)
+
+// DefaultTemplate names downloaded files after the video UID.
+const DefaultTemplate = "{uid}.mp4"
+
+// Name expands a file name template for a video. The placeholders are
+// {uid}, {name} (the video name without its extension), {created} (the
+// upload date as YYYY-MM-DD), and {meta.KEY} for a metadata field. Values
+// are made safe for file names; a field the video lacks expands to nothing.
+func Name(template string, v *api.Video) (string, error) {
+	var b strings.Builder
+	rest := template
+	for rest != "" {
+		i := strings.Index(rest, "{")
+		if i < 0 {
+			b.WriteString(rest)
+			break
+		}
+		b.WriteString(rest[:i])
+		rest = rest[i:]
+
+		end := strings.Index(rest, "}")
+		if end < 0 {
+			return "", fmt.Errorf("template %q: unclosed {", template)
+		}
+		value, err := placeholder(rest[1:end], v)
+		if err != nil {
+			return "", fmt.Errorf("template %q: %w", template, err)
+		}
+		b.WriteString(sanitize(value))
+		rest = rest[end+1:]
+	}
+
+	name := b.String()
+	if strings.TrimSuffix(name, path.Ext(name)) == "" {
+		return "", fmt.Errorf("template %q gives an empty file name for video %s", template, v.UID)
+	}
+	return name, nil
+}
+
+// placeholder returns the value of a template placeholder for v.
+func placeholder(key string, v *api.Video) (string, error) {
+	switch key {
+	case "uid":
+		return v.UID, nil
+	case "name":
+		return strings.TrimSuffix(v.Name, path.Ext(v.Name)), nil
+	case "created":
+		if v.Created.IsZero() {
+			return "", nil
+		}
+		return v.Created.Format("2006-01-02"), nil
+	}
+
+	if field, ok := strings.CutPrefix(key, "meta."); ok && field != "" {
+		if value, ok := v.Meta[field]; ok && value != nil {
+			return fmt.Sprint(value), nil
+		}
+		return "", nil
+	}
+	return "", fmt.Errorf("unknown placeholder {%s} (use {uid}, {name}, {created}, or {meta.KEY})", key)
+}
+
+// sanitize replaces characters that are not safe in file names on common
+// filesystems, and trims leading dots so a value can't hide or climb out of
+// the output directory.
+func sanitize(value string) string {
+	value = strings.Map(func(r rune) rune {
+		switch {
+		case r < 0x20, strings.ContainsRune(`/\:*?"<>|`, r):
+			return '_'
+		default:
+			return r
+		}
+	}, value)
+	return strings.TrimLeft(strings.TrimSpace(value), ".")
+}
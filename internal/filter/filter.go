@@ -0,0 +1,151 @@
+// Package filter selects videos using simple field expressions such as
+// "meta.category=training,status!=error".
+package filter
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"cfstream/internal/api"
+	"cfstream/internal/tags"
+)
+
+// condition is a single field comparison.
+type condition struct {
+	field  string
+	value  string
+	negate bool
+}
+
+// Filter is a conjunction of field conditions. The zero value matches every video.
+type Filter struct {
+	conditions []condition
+}
+
+// Parse parses a comma-separated list of field=value or field!=value conditions.
+// Values may contain * and ? wildcards. Supported fields are uid, name, status,
+// creator, requiresignedurls, tag, and meta.<key>. A tag condition matches a
+// video with any matching tag. An empty expression matches everything.
+func Parse(expr string) (*Filter, error) {
+	f := &Filter{}
+	if strings.TrimSpace(expr) == "" {
+		return f, nil
+	}
+
+	for _, part := range strings.Split(expr, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		c := condition{}
+		var found bool
+		if c.field, c.value, found = strings.Cut(part, "!="); found {
+			c.negate = true
+		} else if c.field, c.value, found = strings.Cut(part, "="); !found {
+			return nil, fmt.Errorf("invalid filter %q: expected field=value or field!=value", part)
+		}
+
+		c.field = strings.ToLower(strings.TrimSpace(c.field))
+		c.value = strings.TrimSpace(c.value)
+		if !validField(c.field) {
+			return nil, fmt.Errorf("invalid filter %q: unknown field %q", part, c.field)
+		}
+		if _, err := path.Match(c.value, ""); err != nil {
+			return nil, fmt.Errorf("invalid filter %q: %w", part, err)
+		}
+
+		f.conditions = append(f.conditions, c)
+	}
+
+	return f, nil
+}
+
+// Match reports whether v satisfies every condition.
+func (f *Filter) Match(v *api.Video) bool {
+	for _, c := range f.conditions {
+		var matched bool
+		if c.field == "tag" {
+			matched = matchTag(c.value, v)
+		} else {
+			value, ok := Field(v, c.field)
+			matched = ok && matchValue(c.value, value)
+		}
+		if matched == c.negate {
+			return false
+		}
+	}
+	return true
+}
+
+// Apply returns the videos that match f.
+func (f *Filter) Apply(videos []api.Video) []api.Video {
+	if len(f.conditions) == 0 {
+		return videos
+	}
+
+	matched := make([]api.Video, 0, len(videos))
+	for i := range videos {
+		if f.Match(&videos[i]) {
+			matched = append(matched, videos[i])
+		}
+	}
+	return matched
+}
+
+// Field returns the string value of a named field of v.
+// The second result is false when a meta key is not present.
+func Field(v *api.Video, field string) (string, bool) {
+	switch field {
+	case "uid":
+		return v.UID, true
+	case "name":
+		return v.Name, true
+	case "status":
+		return v.Status, true
+	case "creator":
+		return v.Creator, true
+	case "requiresignedurls":
+		return strconv.FormatBool(v.RequireSignedURLs), true
+	}
+
+	if key, ok := strings.CutPrefix(field, "meta."); ok {
+		value, exists := v.Meta[key]
+		if !exists || value == nil {
+			return "", false
+		}
+		return fmt.Sprintf("%v", value), true
+	}
+
+	return "", false
+}
+
+func validField(field string) bool {
+	switch field {
+	case "uid", "name", "status", "creator", "requiresignedurls", "tag":
+		return true
+	}
+	return strings.HasPrefix(field, "meta.") && len(field) > len("meta.")
+}
+
+// matchTag reports whether any tag of v matches pattern.
+func matchTag(pattern string, v *api.Video) bool {
+	for _, tag := range tags.Get(v.Meta) {
+		if matchValue(pattern, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchValue compares case-insensitively, honoring wildcards.
+func matchValue(pattern, value string) bool {
+	pattern, value = strings.ToLower(pattern), strings.ToLower(value)
+	if strings.ContainsAny(pattern, "*?[") {
+		matched, _ := path.Match(pattern, value) //nolint:errcheck // Pattern validated in Parse
+		return matched
+	}
+	return pattern == value
+}
@@ -0,0 +1,55 @@
+package filter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"cfstream/internal/api"
+)
+
+func TestParse_Errors(t *testing.T) {
+	for _, expr := range []string{"category", "color=red", "meta.=x", "name=[abc"} {
+		_, err := Parse(expr)
+		assert.Error(t, err, expr)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	videos := []api.Video{
+		{UID: "a", Name: "Onboarding 101", Status: "ready", Meta: map[string]interface{}{"category": "training"}},
+		{UID: "b", Name: "Launch", Status: "ready", Meta: map[string]interface{}{"category": "marketing"}},
+		{UID: "c", Name: "Onboarding 102", Status: "error", Meta: map[string]interface{}{"category": "Training"}},
+		{UID: "d", Name: "Untagged", Status: "ready"},
+		{UID: "e", Name: "Q&A", Status: "ready", Meta: map[string]interface{}{"tags": "webinar,2024"}},
+	}
+
+	tests := []struct {
+		expr string
+		want []string
+	}{
+		{expr: "", want: []string{"a", "b", "c", "d", "e"}},
+		{expr: "meta.category=training", want: []string{"a", "c"}},
+		{expr: "meta.category=training,status!=error", want: []string{"a"}},
+		{expr: "meta.category!=training", want: []string{"b", "d", "e"}},
+		{expr: "name=onboarding*", want: []string{"a", "c"}},
+		{expr: "requiresignedurls=false,uid=d", want: []string{"d"}},
+		{expr: "tag=Webinar", want: []string{"e"}},
+		{expr: "tag=20*", want: []string{"e"}},
+		{expr: "tag!=webinar,status=ready", want: []string{"a", "b", "d"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			f, err := Parse(tt.expr)
+			require.NoError(t, err)
+
+			var got []string
+			for _, v := range f.Apply(videos) {
+				got = append(got, v.UID)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,160 @@
+// Package hls parses the subset of HLS playlists needed to verify playback.
+package hls
+
+import (
+	"bufio"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Variant is a rendition listed in a master playlist.
+type Variant struct {
+	URI        string
+	Bandwidth  int
+	Resolution string
+	Codecs     string
+}
+
+// Media is an alternative rendition (audio or subtitles) in a master playlist.
+type Media struct {
+	Type     string
+	Name     string
+	Language string
+	URI      string
+}
+
+// Master is a parsed master playlist.
+type Master struct {
+	Variants []Variant
+	Media    []Media
+}
+
+// ParseMaster parses a master playlist. Relative URIs are resolved against base.
+func ParseMaster(body, base string) (*Master, error) {
+	baseURL, err := url.Parse(base)
+	if err != nil {
+		return nil, fmt.Errorf("invalid playlist URL: %w", err)
+	}
+
+	lines, err := playlistLines(body)
+	if err != nil {
+		return nil, err
+	}
+
+	master := &Master{}
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		switch {
+		case strings.HasPrefix(line, "#EXT-X-STREAM-INF:"):
+			attrs := parseAttributes(strings.TrimPrefix(line, "#EXT-X-STREAM-INF:"))
+			// The variant URI is the next non-tag line
+			if i+1 >= len(lines) || strings.HasPrefix(lines[i+1], "#") {
+				return nil, fmt.Errorf("missing URI after #EXT-X-STREAM-INF")
+			}
+			i++
+			bandwidth, _ := strconv.Atoi(attrs["BANDWIDTH"]) //nolint:errcheck // Missing bandwidth is reported as zero
+			master.Variants = append(master.Variants, Variant{
+				URI:        resolve(baseURL, lines[i]),
+				Bandwidth:  bandwidth,
+				Resolution: attrs["RESOLUTION"],
+				Codecs:     attrs["CODECS"],
+			})
+		case strings.HasPrefix(line, "#EXT-X-MEDIA:"):
+			attrs := parseAttributes(strings.TrimPrefix(line, "#EXT-X-MEDIA:"))
+			media := Media{
+				Type:     attrs["TYPE"],
+				Name:     attrs["NAME"],
+				Language: attrs["LANGUAGE"],
+			}
+			if uri := attrs["URI"]; uri != "" {
+				media.URI = resolve(baseURL, uri)
+			}
+			master.Media = append(master.Media, media)
+		}
+	}
+
+	if len(master.Variants) == 0 {
+		return nil, fmt.Errorf("master playlist has no variants")
+	}
+	return master, nil
+}
+
+// ParseSegments returns the segment URIs of a media playlist, resolved against base.
+func ParseSegments(body, base string) ([]string, error) {
+	baseURL, err := url.Parse(base)
+	if err != nil {
+		return nil, fmt.Errorf("invalid playlist URL: %w", err)
+	}
+
+	lines, err := playlistLines(body)
+	if err != nil {
+		return nil, err
+	}
+
+	var segments []string
+	for _, line := range lines {
+		if !strings.HasPrefix(line, "#") {
+			segments = append(segments, resolve(baseURL, line))
+		}
+	}
+	return segments, nil
+}
+
+// playlistLines returns the non-empty lines of a playlist after checking its header.
+func playlistLines(body string) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(strings.NewReader(body))
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read playlist: %w", err)
+	}
+
+	if len(lines) == 0 || lines[0] != "#EXTM3U" {
+		return nil, fmt.Errorf("not an HLS playlist: missing #EXTM3U header")
+	}
+	return lines, nil
+}
+
+// parseAttributes parses an HLS attribute list such as BANDWIDTH=1,CODECS="a,b".
+func parseAttributes(list string) map[string]string {
+	attrs := make(map[string]string)
+	for list != "" {
+		key, rest, ok := strings.Cut(list, "=")
+		if !ok {
+			break
+		}
+
+		var value string
+		if strings.HasPrefix(rest, `"`) {
+			// Quoted values may contain commas
+			end := strings.Index(rest[1:], `"`)
+			if end < 0 {
+				value, rest = rest[1:], ""
+			} else {
+				value, rest = rest[1:end+1], rest[end+2:]
+			}
+			rest = strings.TrimPrefix(rest, ",")
+		} else {
+			value, rest, _ = strings.Cut(rest, ",")
+		}
+
+		attrs[strings.TrimSpace(key)] = value
+		list = rest
+	}
+	return attrs
+}
+
+// resolve resolves a playlist URI against the playlist URL.
+func resolve(base *url.URL, uri string) string {
+	ref, err := url.Parse(uri)
+	if err != nil {
+		return uri
+	}
+	return base.ResolveReference(ref).String()
+}
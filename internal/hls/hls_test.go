@@ -0,0 +1,58 @@
+package hls
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const masterPlaylist = `#EXTM3U
+#EXT-X-VERSION:6
+#EXT-X-INDEPENDENT-SEGMENTS
+#EXT-X-MEDIA:TYPE=AUDIO,GROUP-ID="aac",NAME="original",LANGUAGE="en",DEFAULT=YES,URI="stream_a.m3u8"
+#EXT-X-STREAM-INF:BANDWIDTH=4500000,RESOLUTION=1920x1080,CODECS="avc1.640028,mp4a.40.2",AUDIO="aac"
+stream_1080.m3u8?p=1
+#EXT-X-STREAM-INF:BANDWIDTH=800000,RESOLUTION=640x360,CODECS="avc1.4d401e,mp4a.40.2",AUDIO="aac"
+https://cdn.example.com/abc/stream_360.m3u8
+`
+
+func TestParseMaster(t *testing.T) {
+	master, err := ParseMaster(masterPlaylist, "https://customer-x.cloudflarestream.com/abc/manifest/video.m3u8?token=t")
+	require.NoError(t, err)
+
+	require.Len(t, master.Variants, 2)
+	assert.Equal(t, Variant{
+		URI:        "https://customer-x.cloudflarestream.com/abc/manifest/stream_1080.m3u8?p=1",
+		Bandwidth:  4500000,
+		Resolution: "1920x1080",
+		Codecs:     "avc1.640028,mp4a.40.2",
+	}, master.Variants[0])
+	assert.Equal(t, "https://cdn.example.com/abc/stream_360.m3u8", master.Variants[1].URI)
+
+	require.Len(t, master.Media, 1)
+	assert.Equal(t, Media{
+		Type:     "AUDIO",
+		Name:     "original",
+		Language: "en",
+		URI:      "https://customer-x.cloudflarestream.com/abc/manifest/stream_a.m3u8",
+	}, master.Media[0])
+}
+
+func TestParseMaster_Errors(t *testing.T) {
+	_, err := ParseMaster("<html>", "https://example.com/")
+	assert.ErrorContains(t, err, "missing #EXTM3U")
+
+	_, err = ParseMaster("#EXTM3U\n#EXT-X-VERSION:6\n", "https://example.com/")
+	assert.ErrorContains(t, err, "no variants")
+
+	_, err = ParseMaster("#EXTM3U\n#EXT-X-STREAM-INF:BANDWIDTH=1\n", "https://example.com/")
+	assert.ErrorContains(t, err, "missing URI")
+}
+
+func TestParseSegments(t *testing.T) {
+	playlist := "#EXTM3U\n#EXT-X-TARGETDURATION:4\n#EXTINF:4.0,\nseg_0.ts\n#EXTINF:4.0,\nseg_1.ts\n#EXT-X-ENDLIST\n"
+	segments, err := ParseSegments(playlist, "https://example.com/abc/stream_1080.m3u8")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://example.com/abc/seg_0.ts", "https://example.com/abc/seg_1.ts"}, segments)
+}
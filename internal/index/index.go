@@ -0,0 +1,124 @@
+// Package index maintains a local cache of known videos for fast lookups and completion.
+package index
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/adrg/xdg"
+
+	"cfstream/internal/api"
+)
+
+// Entry is a cached summary of a single video.
+type Entry struct {
+	UID      string    `json:"uid"`
+	Name     string    `json:"name"`
+	Status   string    `json:"status"`
+	Modified time.Time `json:"modified"`
+}
+
+// Index is the on-disk cache of videos seen by cfstream.
+type Index struct {
+	Updated time.Time        `json:"updated"`
+	Videos  map[string]Entry `json:"videos"`
+}
+
+// Load reads the index from disk.
+// Returns an empty index if no index file exists.
+func Load() (*Index, error) {
+	idx := &Index{Videos: make(map[string]Entry)}
+
+	data, err := os.ReadFile(Path())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return idx, nil
+		}
+		return nil, fmt.Errorf("failed to read index: %w", err)
+	}
+
+	if err := json.Unmarshal(data, idx); err != nil {
+		return nil, fmt.Errorf("failed to parse index: %w", err)
+	}
+	if idx.Videos == nil {
+		idx.Videos = make(map[string]Entry)
+	}
+
+	return idx, nil
+}
+
+// Save writes the index to disk.
+func Save(idx *Index) error {
+	if idx == nil {
+		return fmt.Errorf("index cannot be nil")
+	}
+
+	indexPath := Path()
+	if err := os.MkdirAll(filepath.Dir(indexPath), 0o755); err != nil {
+		return fmt.Errorf("failed to create index directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(idx, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode index: %w", err)
+	}
+
+	if err := os.WriteFile(indexPath, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write index: %w", err)
+	}
+
+	return nil
+}
+
+// Path returns the full path to the index file.
+func Path() string {
+	return filepath.Join(xdg.CacheHome, "cfstream", "index.json")
+}
+
+// Merge adds or refreshes entries for the given videos.
+func (idx *Index) Merge(videos []api.Video) {
+	for _, v := range videos {
+		idx.Videos[v.UID] = Entry{
+			UID:      v.UID,
+			Name:     v.Name,
+			Status:   v.Status,
+			Modified: v.Modified,
+		}
+	}
+	idx.Updated = time.Now()
+}
+
+// Remove drops the entries of deleted videos.
+func (idx *Index) Remove(uids ...string) {
+	for _, uid := range uids {
+		delete(idx.Videos, uid)
+	}
+}
+
+// Complete returns the sorted UIDs that start with the given prefix.
+func (idx *Index) Complete(prefix string) []string {
+	matches := make([]string, 0)
+	for uid := range idx.Videos {
+		if strings.HasPrefix(uid, prefix) {
+			matches = append(matches, uid)
+		}
+	}
+	sort.Strings(matches)
+	return matches
+}
+
+// Update merges videos into the on-disk index.
+// It is a convenience wrapper around Load, Merge, and Save.
+func Update(videos []api.Video) error {
+	idx, err := Load()
+	if err != nil {
+		return err
+	}
+	idx.Merge(videos)
+	return Save(idx)
+}
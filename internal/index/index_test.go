@@ -0,0 +1,85 @@
+package index
+
+import (
+	"os"
+	"testing"
+
+	"github.com/adrg/xdg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"cfstream/internal/api"
+)
+
+func TestLoad_Empty(t *testing.T) {
+	useTempCache(t)
+
+	idx, err := Load()
+	require.NoError(t, err)
+	require.NotNil(t, idx)
+	assert.Empty(t, idx.Videos)
+}
+
+func TestSaveAndLoad(t *testing.T) {
+	useTempCache(t)
+
+	err := Update([]api.Video{
+		{UID: "abc123", Name: "Intro", Status: "ready"},
+		{UID: "def456", Name: "Outro", Status: "queued"},
+	})
+	require.NoError(t, err)
+
+	idx, err := Load()
+	require.NoError(t, err)
+	assert.Len(t, idx.Videos, 2)
+	assert.Equal(t, "Intro", idx.Videos["abc123"].Name)
+	assert.False(t, idx.Updated.IsZero())
+}
+
+func TestSave_NilIndex(t *testing.T) {
+	err := Save(nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "index cannot be nil")
+}
+
+func TestMerge_Refreshes(t *testing.T) {
+	idx := &Index{Videos: make(map[string]Entry)}
+	idx.Merge([]api.Video{{UID: "abc123", Status: "queued"}})
+	idx.Merge([]api.Video{{UID: "abc123", Status: "ready"}})
+
+	assert.Len(t, idx.Videos, 1)
+	assert.Equal(t, "ready", idx.Videos["abc123"].Status)
+}
+
+func TestRemove(t *testing.T) {
+	idx := &Index{Videos: make(map[string]Entry)}
+	idx.Merge([]api.Video{{UID: "abc123"}, {UID: "def456"}})
+	idx.Remove("abc123", "missing")
+
+	assert.Equal(t, []string{"def456"}, idx.Complete(""))
+}
+
+func TestComplete(t *testing.T) {
+	idx := &Index{Videos: make(map[string]Entry)}
+	idx.Merge([]api.Video{{UID: "abc123"}, {UID: "abd456"}, {UID: "xyz789"}})
+
+	assert.Equal(t, []string{"abc123", "abd456"}, idx.Complete("ab"))
+	assert.Equal(t, []string{"xyz789"}, idx.Complete("x"))
+	assert.Empty(t, idx.Complete("q"))
+}
+
+// useTempCache points XDG_CACHE_HOME at a temporary directory for the test.
+func useTempCache(t *testing.T) {
+	t.Helper()
+	oldXDGCache := os.Getenv("XDG_CACHE_HOME")
+	t.Cleanup(func() {
+		if oldXDGCache != "" {
+			os.Setenv("XDG_CACHE_HOME", oldXDGCache)
+		} else {
+			os.Unsetenv("XDG_CACHE_HOME")
+		}
+		xdg.Reload()
+	})
+	os.Setenv("XDG_CACHE_HOME", t.TempDir())
+	xdg.Reload()
+}
@@ -0,0 +1,131 @@
+// Package lifecycle evaluates retention rules from the configuration against
+// the video library.
+package lifecycle
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"cfstream/internal/api"
+	"cfstream/internal/config"
+	"cfstream/internal/filter"
+	"cfstream/internal/reltime"
+)
+
+// Rule actions.
+const (
+	ActionDelete  = "delete"
+	ActionProtect = "protect"
+)
+
+// ActionKeep is the planned action for a video a delete rule matched but a
+// protect rule saved.
+const ActionKeep = "keep"
+
+// Rule is a validated lifecycle rule.
+type Rule struct {
+	Name      string
+	Action    string
+	OlderThan time.Duration // Zero matches videos of any age
+	filter    *filter.Filter
+}
+
+// Decision is the planned outcome for a video matched by a delete rule.
+type Decision struct {
+	Video  api.Video
+	Action string // delete or keep
+	Rule   string // Name of the rule that decided the action
+}
+
+// Compile validates the configured rules. Unnamed rules are named after
+// their position. Delete rules need a filter or an age, so a rule can't
+// select the whole library by accident.
+func Compile(rules []config.LifecycleRule) ([]Rule, error) {
+	compiled := make([]Rule, 0, len(rules))
+	for i, r := range rules {
+		rule := Rule{Name: r.Name, Action: strings.ToLower(strings.TrimSpace(r.Action))}
+		if rule.Name == "" {
+			rule.Name = fmt.Sprintf("rule %d", i+1)
+		}
+
+		if rule.Action != ActionDelete && rule.Action != ActionProtect {
+			return nil, fmt.Errorf("lifecycle rule %q: invalid action %q (use delete or protect)", rule.Name, r.Action)
+		}
+
+		f, err := filter.Parse(r.Filter)
+		if err != nil {
+			return nil, fmt.Errorf("lifecycle rule %q: %w", rule.Name, err)
+		}
+		rule.filter = f
+
+		if r.OlderThan != "" {
+			rule.OlderThan, err = ParseAge(r.OlderThan)
+			if err != nil {
+				return nil, fmt.Errorf("lifecycle rule %q: %w", rule.Name, err)
+			}
+		}
+
+		if rule.Action == ActionDelete && strings.TrimSpace(r.Filter) == "" && rule.OlderThan == 0 {
+			return nil, fmt.Errorf("lifecycle rule %q: delete rules need a filter or older_than", rule.Name)
+		}
+
+		compiled = append(compiled, rule)
+	}
+	return compiled, nil
+}
+
+// Match reports whether the rule applies to v at time now.
+func (r *Rule) Match(v *api.Video, now time.Time) bool {
+	if r.OlderThan > 0 && now.Sub(v.Created) < r.OlderThan {
+		return false
+	}
+	return r.filter.Match(v)
+}
+
+// Plan returns a decision for every video matched by a delete rule, in
+// library order. Protect rules take precedence over delete rules regardless
+// of their order in the configuration.
+func Plan(rules []Rule, videos []api.Video, now time.Time) []Decision {
+	var decisions []Decision
+	for i := range videos {
+		v := &videos[i]
+
+		deleteRule, protectRule := "", ""
+		for j := range rules {
+			if !rules[j].Match(v, now) {
+				continue
+			}
+			switch rules[j].Action {
+			case ActionDelete:
+				if deleteRule == "" {
+					deleteRule = rules[j].Name
+				}
+			case ActionProtect:
+				if protectRule == "" {
+					protectRule = rules[j].Name
+				}
+			}
+		}
+
+		switch {
+		case deleteRule == "":
+			continue
+		case protectRule != "":
+			decisions = append(decisions, Decision{Video: *v, Action: ActionKeep, Rule: protectRule})
+		default:
+			decisions = append(decisions, Decision{Video: *v, Action: ActionDelete, Rule: deleteRule})
+		}
+	}
+	return decisions
+}
+
+// ParseAge parses a minimum age given as a day count such as 30d or a Go
+// duration such as 12h.
+func ParseAge(value string) (time.Duration, error) {
+	d, err := reltime.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid older_than value: %w", err)
+	}
+	return d, nil
+}
@@ -0,0 +1,71 @@
+package lifecycle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"cfstream/internal/api"
+	"cfstream/internal/config"
+)
+
+func TestCompile_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		rule config.LifecycleRule
+	}{
+		{name: "unknown action", rule: config.LifecycleRule{Filter: "meta.temp=true", Action: "archive"}},
+		{name: "invalid filter", rule: config.LifecycleRule{Filter: "color=red", Action: "delete"}},
+		{name: "invalid age", rule: config.LifecycleRule{Filter: "meta.temp=true", OlderThan: "soon", Action: "delete"}},
+		{name: "delete everything", rule: config.LifecycleRule{Action: "delete"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Compile([]config.LifecycleRule{tt.rule})
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestPlan(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	videos := []api.Video{
+		{UID: "old-temp", Created: now.AddDate(0, 0, -45), Meta: map[string]interface{}{"temp": true}},
+		{UID: "new-temp", Created: now.AddDate(0, 0, -5), Meta: map[string]interface{}{"temp": true}},
+		{UID: "ceo-temp", Created: now.AddDate(0, 0, -90), Creator: "ceo", Meta: map[string]interface{}{"temp": true}},
+		{UID: "keeper", Created: now.AddDate(0, 0, -90)},
+	}
+
+	rules, err := Compile([]config.LifecycleRule{
+		{Name: "temp", Filter: "meta.temp=true", OlderThan: "30d", Action: "delete"},
+		{Name: "ceo", Filter: "creator=ceo", Action: "protect"},
+	})
+	require.NoError(t, err)
+
+	decisions := Plan(rules, videos, now)
+	require.Len(t, decisions, 2)
+	assert.Equal(t, "old-temp", decisions[0].Video.UID)
+	assert.Equal(t, ActionDelete, decisions[0].Action)
+	assert.Equal(t, "temp", decisions[0].Rule)
+	assert.Equal(t, "ceo-temp", decisions[1].Video.UID)
+	assert.Equal(t, ActionKeep, decisions[1].Action)
+	assert.Equal(t, "ceo", decisions[1].Rule)
+}
+
+func TestParseAge(t *testing.T) {
+	d, err := ParseAge("30d")
+	require.NoError(t, err)
+	assert.Equal(t, 30*24*time.Hour, d)
+
+	d, err = ParseAge("12h")
+	require.NoError(t, err)
+	assert.Equal(t, 12*time.Hour, d)
+
+	for _, value := range []string{"0d", "-1d", "xd", "-5h", "soon"} {
+		_, err := ParseAge(value)
+		assert.Error(t, err, value)
+	}
+}
@@ -0,0 +1,291 @@
+// Package manifest reads declarative descriptions of an account's videos and
+// compares them with the live library.
+package manifest
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"cfstream/internal/api"
+)
+
+// Entry is the desired state of one video. Nil fields are not managed by
+// the manifest and are not compared.
+type Entry struct {
+	UID               string
+	Name              *string
+	RequireSignedURLs *bool
+	AllowedOrigins    []string // Nil when not managed
+	Meta              map[string]interface{}
+}
+
+// Change kinds.
+const (
+	Added   = "added"   // Only in the account
+	Removed = "removed" // Only in the manifest
+	Changed = "changed" // A field differs
+)
+
+// Change is one difference between the manifest and the account.
+type Change struct {
+	Kind     string
+	UID      string
+	Name     string
+	Field    string // Changed only
+	Manifest string // Changed only: value in the manifest
+	Account  string // Changed only: value in the account
+}
+
+// Load reads a manifest file. It accepts YAML or JSON, either as a list of
+// videos or as a mapping with a videos key, so both hand-written manifests
+// and the output of "video list -o json|yaml" can be used. Keys are matched
+// case-insensitively.
+func Load(path string) ([]Entry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+	entries, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid manifest %s: %w", path, err)
+	}
+	return entries, nil
+}
+
+// Parse parses manifest data; see Load.
+func Parse(data []byte) ([]Entry, error) {
+	var doc interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	items, ok := doc.([]interface{})
+	if m, isMap := doc.(map[string]interface{}); isMap {
+		items, ok = lookup(m, "videos").([]interface{})
+	}
+	if !ok {
+		return nil, fmt.Errorf("expected a list of videos or a videos key")
+	}
+
+	entries := make([]Entry, 0, len(items))
+	seen := make(map[string]bool, len(items))
+	for i, item := range items {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("video %d: expected a mapping", i+1)
+		}
+		entry, err := parseEntry(m)
+		if err != nil {
+			return nil, fmt.Errorf("video %d: %w", i+1, err)
+		}
+		if seen[entry.UID] {
+			return nil, fmt.Errorf("video %d: duplicate uid %s", i+1, entry.UID)
+		}
+		seen[entry.UID] = true
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func parseEntry(m map[string]interface{}) (Entry, error) {
+	var entry Entry
+
+	uid, _ := lookup(m, "uid").(string) //nolint:errcheck // A missing uid is reported below
+	if uid == "" {
+		return entry, fmt.Errorf("missing uid")
+	}
+	entry.UID = uid
+
+	if v := lookup(m, "name"); v != nil {
+		name := fmt.Sprint(v)
+		entry.Name = &name
+	}
+
+	if v := lookup(m, "requiresignedurls"); v != nil {
+		b, ok := v.(bool)
+		if !ok {
+			return entry, fmt.Errorf("requireSignedURLs must be true or false")
+		}
+		entry.RequireSignedURLs = &b
+	}
+
+	if v, present := lookupOK(m, "allowedorigins"); present {
+		list, _ := v.([]interface{}) //nolint:errcheck // Null means no origins
+		entry.AllowedOrigins = make([]string, 0, len(list))
+		for _, origin := range list {
+			entry.AllowedOrigins = append(entry.AllowedOrigins, fmt.Sprint(origin))
+		}
+	}
+
+	if v, present := lookupOK(m, "meta"); present {
+		meta, _ := v.(map[string]interface{}) //nolint:errcheck // Null means no meta
+		entry.Meta = make(map[string]interface{}, len(meta))
+		for key, value := range meta {
+			entry.Meta[key] = value
+		}
+	}
+
+	return entry, nil
+}
+
+// Diff compares the manifest with the live videos and returns the changes
+// from the manifest to the account, ordered by UID. The name meta key is
+// compared as the name field only.
+func Diff(entries []Entry, videos []api.Video) []Change {
+	live := make(map[string]*api.Video, len(videos))
+	for i := range videos {
+		live[videos[i].UID] = &videos[i]
+	}
+
+	var changes []Change
+	inManifest := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		inManifest[entry.UID] = true
+		video, ok := live[entry.UID]
+		if !ok {
+			name := ""
+			if entry.Name != nil {
+				name = *entry.Name
+			}
+			changes = append(changes, Change{Kind: Removed, UID: entry.UID, Name: name})
+			continue
+		}
+		changes = append(changes, diffVideo(&entry, video)...)
+	}
+
+	for i := range videos {
+		if !inManifest[videos[i].UID] {
+			changes = append(changes, Change{Kind: Added, UID: videos[i].UID, Name: videos[i].Name})
+		}
+	}
+
+	sort.SliceStable(changes, func(a, b int) bool {
+		return changes[a].UID < changes[b].UID
+	})
+	return changes
+}
+
+// diffVideo compares the managed fields of entry with the live video.
+func diffVideo(entry *Entry, video *api.Video) []Change {
+	var changes []Change
+	add := func(field, manifest, account string) {
+		if manifest != account {
+			changes = append(changes, Change{
+				Kind: Changed, UID: video.UID, Name: video.Name,
+				Field: field, Manifest: manifest, Account: account,
+			})
+		}
+	}
+
+	if entry.Name != nil {
+		add("name", *entry.Name, video.Name)
+	}
+	if entry.RequireSignedURLs != nil {
+		add("requireSignedURLs", fmt.Sprint(*entry.RequireSignedURLs), fmt.Sprint(video.RequireSignedURLs))
+	}
+	if entry.AllowedOrigins != nil {
+		add("allowedOrigins", joinSorted(entry.AllowedOrigins), joinSorted(video.AllowedOrigins))
+	}
+	if entry.Meta != nil {
+		keys := make([]string, 0, len(entry.Meta)+len(video.Meta))
+		for key := range entry.Meta {
+			keys = append(keys, key)
+		}
+		for key := range video.Meta {
+			if _, ok := entry.Meta[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if key == "name" {
+				continue
+			}
+			add("meta."+key, metaValue(entry.Meta, key), metaValue(video.Meta, key))
+		}
+	}
+	return changes
+}
+
+// Update returns the update that makes the managed fields of video match
+// entry, and false when they already match. Metadata is replaced as a
+// whole, keeping the video's name unless entry sets one.
+func Update(entry *Entry, video *api.Video) (*api.UpdateOptions, bool) {
+	changes := diffVideo(entry, video)
+	if len(changes) == 0 {
+		return nil, false
+	}
+
+	opts := &api.UpdateOptions{}
+	for _, c := range changes {
+		switch {
+		case c.Field == "requireSignedURLs":
+			signed := *entry.RequireSignedURLs
+			opts.RequireSignedURLs = &signed
+		case c.Field == "allowedOrigins":
+			opts.AllowedOrigins = slices.Clone(entry.AllowedOrigins)
+		case c.Field == "name" || strings.HasPrefix(c.Field, "meta."):
+			if opts.Meta == nil {
+				opts.Meta = updatedMeta(entry, video)
+			}
+		}
+	}
+	return opts, true
+}
+
+// updatedMeta returns the metadata of video after applying entry.
+func updatedMeta(entry *Entry, video *api.Video) map[string]interface{} {
+	source := video.Meta
+	if entry.Meta != nil {
+		source = entry.Meta
+	}
+	meta := make(map[string]interface{}, len(source)+1)
+	for key, value := range source {
+		meta[key] = value
+	}
+
+	switch {
+	case entry.Name != nil:
+		meta["name"] = *entry.Name
+	case video.Name != "":
+		meta["name"] = video.Name
+	default:
+		delete(meta, "name")
+	}
+	return meta
+}
+
+// lookup returns the value of a key matched case-insensitively.
+func lookup(m map[string]interface{}, key string) interface{} {
+	v, _ := lookupOK(m, key)
+	return v
+}
+
+// lookupOK is lookup that also reports whether the key is present.
+func lookupOK(m map[string]interface{}, key string) (interface{}, bool) {
+	for k, v := range m {
+		if strings.EqualFold(k, key) {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+func metaValue(meta map[string]interface{}, key string) string {
+	v, ok := meta[key]
+	if !ok || v == nil {
+		return ""
+	}
+	return fmt.Sprint(v)
+}
+
+func joinSorted(values []string) string {
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	return strings.Join(sorted, ",")
+}
@@ -0,0 +1,115 @@
+package manifest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"cfstream/internal/api"
+)
+
+func TestParse_Manifest(t *testing.T) {
+	entries, err := Parse([]byte(`
+videos:
+  - uid: abc
+    name: Intro
+    requireSignedURLs: true
+    meta:
+      category: training
+  - uid: def
+    allowedOrigins: [example.com]
+`))
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	assert.Equal(t, "abc", entries[0].UID)
+	require.NotNil(t, entries[0].Name)
+	assert.Equal(t, "Intro", *entries[0].Name)
+	require.NotNil(t, entries[0].RequireSignedURLs)
+	assert.True(t, *entries[0].RequireSignedURLs)
+	assert.Equal(t, map[string]interface{}{"category": "training"}, entries[0].Meta)
+	assert.Nil(t, entries[0].AllowedOrigins)
+
+	assert.Nil(t, entries[1].Name)
+	assert.Nil(t, entries[1].Meta)
+	assert.Equal(t, []string{"example.com"}, entries[1].AllowedOrigins)
+}
+
+func TestParse_Export(t *testing.T) {
+	entries, err := Parse([]byte(`[{"UID": "abc", "Name": "Intro", "RequireSignedURLs": false, "AllowedOrigins": null, "Meta": {"name": "Intro"}}]`))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "abc", entries[0].UID)
+	assert.Equal(t, []string{}, entries[0].AllowedOrigins)
+	assert.Equal(t, map[string]interface{}{"name": "Intro"}, entries[0].Meta)
+}
+
+func TestParse_Errors(t *testing.T) {
+	for name, data := range map[string]string{
+		"not a list":     "videos: abc",
+		"missing uid":    "- name: Intro",
+		"duplicate uid":  "- uid: abc\n- uid: abc",
+		"invalid signed": "- uid: abc\n  requireSignedURLs: maybe",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := Parse([]byte(data))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestDiff(t *testing.T) {
+	name := "Intro"
+	signed := true
+	entries := []Entry{
+		{UID: "abc", Name: &name, RequireSignedURLs: &signed, Meta: map[string]interface{}{"category": "training"}},
+		{UID: "gone", Name: &name},
+		{UID: "same", AllowedOrigins: []string{"b.com", "a.com"}},
+	}
+	videos := []api.Video{
+		{UID: "abc", Name: "Intro v2", Meta: map[string]interface{}{"name": "Intro v2", "category": "training", "owner": "ann"}},
+		{UID: "new", Name: "Fresh"},
+		{UID: "same", AllowedOrigins: []string{"a.com", "b.com"}},
+	}
+
+	changes := Diff(entries, videos)
+	assert.Equal(t, []Change{
+		{Kind: Changed, UID: "abc", Name: "Intro v2", Field: "name", Manifest: "Intro", Account: "Intro v2"},
+		{Kind: Changed, UID: "abc", Name: "Intro v2", Field: "requireSignedURLs", Manifest: "true", Account: "false"},
+		{Kind: Changed, UID: "abc", Name: "Intro v2", Field: "meta.owner", Manifest: "", Account: "ann"},
+		{Kind: Removed, UID: "gone", Name: "Intro"},
+		{Kind: Added, UID: "new", Name: "Fresh"},
+	}, changes)
+}
+
+func TestUpdate(t *testing.T) {
+	name := "Intro"
+	signed := true
+	video := api.Video{
+		UID:  "abc",
+		Name: "Intro v2",
+		Meta: map[string]interface{}{"name": "Intro v2", "category": "training", "owner": "ann"},
+	}
+
+	opts, ok := Update(&Entry{UID: "abc", Name: &name, RequireSignedURLs: &signed}, &video)
+	require.True(t, ok)
+	require.NotNil(t, opts.RequireSignedURLs)
+	assert.True(t, *opts.RequireSignedURLs)
+	assert.Nil(t, opts.AllowedOrigins)
+	assert.Equal(t, map[string]interface{}{"name": "Intro", "category": "training", "owner": "ann"}, opts.Meta)
+
+	// Manifest metadata replaces the video's, keeping its name
+	opts, ok = Update(&Entry{UID: "abc", Meta: map[string]interface{}{"category": "training"}}, &video)
+	require.True(t, ok)
+	assert.Nil(t, opts.RequireSignedURLs)
+	assert.Equal(t, map[string]interface{}{"name": "Intro v2", "category": "training"}, opts.Meta)
+
+	opts, ok = Update(&Entry{UID: "abc", AllowedOrigins: []string{"example.com"}}, &video)
+	require.True(t, ok)
+	assert.Nil(t, opts.Meta)
+	assert.Equal(t, []string{"example.com"}, opts.AllowedOrigins)
+
+	_, ok = Update(&Entry{UID: "abc", Meta: video.Meta}, &video)
+	assert.False(t, ok)
+}
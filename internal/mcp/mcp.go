@@ -0,0 +1,203 @@
+// Package mcp exposes the Stream client as Model Context Protocol tools over stdio.
+//
+// Messages are newline-delimited JSON-RPC 2.0. Only tools that cannot destroy
+// data are offered: listing and inspecting videos, building playback links,
+// and creating direct upload URLs.
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"cfstream/internal/api"
+)
+
+// protocolVersion is the MCP revision implemented by this server.
+const protocolVersion = "2024-11-05"
+
+// JSON-RPC error codes.
+const (
+	codeParseError     = -32700
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+)
+
+// Minter returns a signed token granting access to videoID until exp.
+type Minter func(ctx context.Context, videoID string, exp time.Time) (string, error)
+
+// Server answers MCP requests using an API client.
+type Server struct {
+	client  api.Client
+	version string
+	mint    Minter
+	tools   []tool
+
+	mu  sync.Mutex
+	out io.Writer
+}
+
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// NewServer creates an MCP server backed by client. version is reported to the
+// assistant. Signed links use tokens from mint, or from the API when mint is nil.
+func NewServer(client api.Client, version string, mint Minter) *Server {
+	if mint == nil {
+		mint = func(ctx context.Context, videoID string, exp time.Time) (string, error) {
+			return client.GetSignedToken(ctx, videoID, exp.Unix())
+		}
+	}
+	s := &Server{client: client, version: version, mint: mint}
+	s.tools = s.registerTools()
+	return s
+}
+
+// Serve reads requests from r and writes responses to w until r is exhausted
+// or ctx is cancelled.
+func (s *Server) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	s.out = w
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var req request
+		if err := json.Unmarshal([]byte(line), &req); err != nil {
+			s.write(response{JSONRPC: "2.0", ID: json.RawMessage("null"), Error: &rpcError{Code: codeParseError, Message: err.Error()}})
+			continue
+		}
+
+		result, rpcErr := s.handle(ctx, &req)
+
+		// Notifications carry no ID and get no response
+		if len(req.ID) == 0 {
+			continue
+		}
+		s.write(response{JSONRPC: "2.0", ID: req.ID, Result: result, Error: rpcErr})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read request: %w", err)
+	}
+	return nil
+}
+
+func (s *Server) handle(ctx context.Context, req *request) (interface{}, *rpcError) {
+	switch req.Method {
+	case "initialize":
+		return map[string]interface{}{
+			"protocolVersion": protocolVersion,
+			"capabilities": map[string]interface{}{
+				"tools": map[string]interface{}{},
+			},
+			"serverInfo": map[string]string{
+				"name":    "cfstream",
+				"version": s.version,
+			},
+		}, nil
+	case "ping":
+		return map[string]interface{}{}, nil
+	case "tools/list":
+		list := make([]map[string]interface{}, 0, len(s.tools))
+		for _, t := range s.tools {
+			list = append(list, map[string]interface{}{
+				"name":        t.name,
+				"description": t.description,
+				"inputSchema": t.schema,
+			})
+		}
+		return map[string]interface{}{"tools": list}, nil
+	case "tools/call":
+		return s.callTool(ctx, req.Params)
+	default:
+		if strings.HasPrefix(req.Method, "notifications/") {
+			return nil, nil
+		}
+		return nil, &rpcError{Code: codeMethodNotFound, Message: fmt.Sprintf("method not found: %s", req.Method)}
+	}
+}
+
+func (s *Server) callTool(ctx context.Context, raw json.RawMessage) (interface{}, *rpcError) {
+	var params struct {
+		Name      string          `json:"name"`
+		Arguments json.RawMessage `json:"arguments"`
+	}
+	if err := json.Unmarshal(raw, &params); err != nil {
+		return nil, &rpcError{Code: codeInvalidParams, Message: err.Error()}
+	}
+
+	for _, t := range s.tools {
+		if t.name != params.Name {
+			continue
+		}
+
+		args := params.Arguments
+		if len(args) == 0 {
+			args = json.RawMessage("{}")
+		}
+
+		callCtx, cancel := context.WithTimeout(ctx, 60*time.Second)
+		defer cancel()
+
+		// Tool failures are reported in the result so the assistant can react to them
+		result, err := t.run(callCtx, args)
+		if err != nil {
+			return toolResult(err.Error(), true), nil
+		}
+		text, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return toolResult(err.Error(), true), nil
+		}
+		return toolResult(string(text), false), nil
+	}
+
+	return nil, &rpcError{Code: codeInvalidParams, Message: fmt.Sprintf("unknown tool: %s", params.Name)}
+}
+
+func toolResult(text string, isError bool) map[string]interface{} {
+	return map[string]interface{}{
+		"content": []map[string]string{{"type": "text", "text": text}},
+		"isError": isError,
+	}
+}
+
+func (s *Server) write(resp response) {
+	data, err := json.Marshal(resp)
+	if err != nil {
+		data, _ = json.Marshal(response{JSONRPC: "2.0", ID: resp.ID, Error: &rpcError{Code: -32603, Message: err.Error()}}) //nolint:errcheck // Fallback encoding cannot fail
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fmt.Fprintf(s.out, "%s\n", data)
+}
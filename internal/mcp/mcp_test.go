@@ -0,0 +1,139 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"cfstream/internal/api"
+)
+
+// fakeClient is a minimal api.Client for tool tests.
+type fakeClient struct {
+	api.Client
+	videos []api.Video
+}
+
+func (f *fakeClient) ListVideos(_ context.Context, opts *api.ListOptions) ([]api.Video, error) {
+	return f.videos, nil
+}
+
+func (f *fakeClient) GetVideo(_ context.Context, videoID string) (*api.Video, error) {
+	for i := range f.videos {
+		if f.videos[i].UID == videoID {
+			return &f.videos[i], nil
+		}
+	}
+	return nil, api.ErrNotFound
+}
+
+func (f *fakeClient) GetSignedToken(_ context.Context, videoID string, exp int64) (string, error) {
+	return "tok-" + videoID, nil
+}
+
+// roundTrip sends requests to a fresh server and returns the decoded responses.
+func roundTrip(t *testing.T, client api.Client, requests ...string) []map[string]interface{} {
+	t.Helper()
+	return roundTripServer(t, NewServer(client, "test", nil), requests...)
+}
+
+// roundTripServer sends requests to s and returns the decoded responses.
+func roundTripServer(t *testing.T, s *Server, requests ...string) []map[string]interface{} {
+	t.Helper()
+	var out bytes.Buffer
+	require.NoError(t, s.Serve(context.Background(), strings.NewReader(strings.Join(requests, "\n")), &out))
+
+	var responses []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var resp map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &resp))
+		responses = append(responses, resp)
+	}
+	return responses
+}
+
+func toolText(t *testing.T, resp map[string]interface{}) (string, bool) {
+	t.Helper()
+	result := resp["result"].(map[string]interface{})
+	content := result["content"].([]interface{})[0].(map[string]interface{})
+	return content["text"].(string), result["isError"].(bool)
+}
+
+func TestHandshake(t *testing.T) {
+	responses := roundTrip(t, &fakeClient{},
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{}}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/list"}`,
+		`{"jsonrpc":"2.0","id":3,"method":"nope"}`,
+	)
+	require.Len(t, responses, 3)
+
+	info := responses[0]["result"].(map[string]interface{})
+	assert.Equal(t, protocolVersion, info["protocolVersion"])
+
+	tools := responses[1]["result"].(map[string]interface{})["tools"].([]interface{})
+	var names []string
+	for _, tl := range tools {
+		names = append(names, tl.(map[string]interface{})["name"].(string))
+	}
+	assert.Equal(t, []string{"list_videos", "get_video", "get_links", "create_direct_upload"}, names)
+
+	assert.Equal(t, float64(codeMethodNotFound), responses[2]["error"].(map[string]interface{})["code"])
+}
+
+func TestTools(t *testing.T) {
+	client := &fakeClient{videos: []api.Video{
+		{UID: "pub", Name: "Public", Preview: "https://customer-x.cloudflarestream.com/pub/manifest/video.m3u8"},
+		{UID: "priv", Name: "Private", RequireSignedURLs: true, Preview: "https://customer-x.cloudflarestream.com/priv/manifest/video.m3u8"},
+	}}
+
+	responses := roundTrip(t, client,
+		`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"list_videos","arguments":{"limit":1}}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"get_links","arguments":{"video_id":"pub"}}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"get_links","arguments":{"video_id":"priv"}}}`,
+		`{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"get_video","arguments":{"video_id":"missing"}}}`,
+	)
+	require.Len(t, responses, 4)
+
+	text, isError := toolText(t, responses[0])
+	assert.False(t, isError)
+	assert.Contains(t, text, "Public")
+	assert.NotContains(t, text, "Private")
+
+	text, _ = toolText(t, responses[1])
+	assert.Contains(t, text, "https://customer-x.cloudflarestream.com/pub/manifest/video.mpd")
+
+	text, _ = toolText(t, responses[2])
+	assert.Contains(t, text, "https://customer-x.cloudflarestream.com/priv/watch?token=tok-priv")
+	assert.NotContains(t, text, `"hls"`)
+
+	_, isError = toolText(t, responses[3])
+	assert.True(t, isError)
+}
+
+func TestGetLinks_Minter(t *testing.T) {
+	client := &fakeClient{videos: []api.Video{
+		{UID: "priv", RequireSignedURLs: true, Preview: "https://customer-x.cloudflarestream.com/priv/manifest/video.m3u8"},
+	}}
+	mint := func(_ context.Context, videoID string, _ time.Time) (string, error) {
+		return "local-" + videoID, nil
+	}
+
+	responses := roundTripServer(t, NewServer(client, "test", mint),
+		`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"get_links","arguments":{"video_id":"priv"}}}`,
+	)
+	require.Len(t, responses, 1)
+
+	text, isError := toolText(t, responses[0])
+	assert.False(t, isError)
+	assert.Contains(t, text, "https://customer-x.cloudflarestream.com/priv/watch?token=local-priv")
+}
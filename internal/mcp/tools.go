@@ -0,0 +1,185 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"cfstream/internal/api"
+)
+
+// tool is a single MCP tool backed by the API client.
+type tool struct {
+	name        string
+	description string
+	schema      map[string]interface{}
+	run         func(ctx context.Context, args json.RawMessage) (interface{}, error)
+}
+
+// objectSchema builds a JSON schema for an object with the given properties.
+func objectSchema(properties map[string]interface{}, required ...string) map[string]interface{} {
+	schema := map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+func prop(typ, description string) map[string]interface{} {
+	return map[string]interface{}{"type": typ, "description": description}
+}
+
+func (s *Server) registerTools() []tool {
+	return []tool{
+		{
+			name:        "list_videos",
+			description: "List or search videos in the Cloudflare Stream library.",
+			schema: objectSchema(map[string]interface{}{
+				"search":  prop("string", "Filter by video name"),
+				"creator": prop("string", "Filter by creator ID"),
+				"limit":   prop("integer", "Maximum number of videos to return (default 50)"),
+			}),
+			run: s.listVideos,
+		},
+		{
+			name:        "get_video",
+			description: "Get details for a video by ID.",
+			schema: objectSchema(map[string]interface{}{
+				"video_id": prop("string", "Video ID"),
+			}, "video_id"),
+			run: s.getVideo,
+		},
+		{
+			name:        "get_links",
+			description: "Get playback, thumbnail, and embed links for a video. Private videos get a signed watch URL.",
+			schema: objectSchema(map[string]interface{}{
+				"video_id": prop("string", "Video ID"),
+				"duration": prop("string", "Signed token lifetime for private videos, e.g. 1h (default 1h)"),
+			}, "video_id"),
+			run: s.getLinks,
+		},
+		{
+			name:        "create_direct_upload",
+			description: "Create a one-time URL that an end user can upload a video to.",
+			schema: objectSchema(map[string]interface{}{
+				"max_duration_seconds": prop("integer", "Maximum video duration in seconds"),
+				"expires":              prop("string", "URL lifetime, e.g. 30m (default 30m)"),
+			}),
+			run: s.createDirectUpload,
+		},
+	}
+}
+
+func (s *Server) listVideos(ctx context.Context, raw json.RawMessage) (interface{}, error) {
+	var args struct {
+		Search  string `json:"search"`
+		Creator string `json:"creator"`
+		Limit   int    `json:"limit"`
+	}
+	if err := json.Unmarshal(raw, &args); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+	if args.Limit <= 0 {
+		args.Limit = 50
+	}
+
+	videos, err := s.client.ListVideos(ctx, &api.ListOptions{Search: args.Search, Creator: args.Creator})
+	if err != nil {
+		return nil, err
+	}
+	if len(videos) > args.Limit {
+		videos = videos[:args.Limit]
+	}
+	return videos, nil
+}
+
+func (s *Server) getVideo(ctx context.Context, raw json.RawMessage) (interface{}, error) {
+	var args struct {
+		VideoID string `json:"video_id"`
+	}
+	if err := json.Unmarshal(raw, &args); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	return s.client.GetVideo(ctx, args.VideoID)
+}
+
+func (s *Server) getLinks(ctx context.Context, raw json.RawMessage) (interface{}, error) {
+	var args struct {
+		VideoID  string `json:"video_id"`
+		Duration string `json:"duration"`
+	}
+	if err := json.Unmarshal(raw, &args); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	video, err := s.client.GetVideo(ctx, args.VideoID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Preview URLs look like https://customer-{code}.cloudflarestream.com/{id}/manifest/video.m3u8
+	base, _, found := strings.Cut(video.Preview, "/manifest/")
+	if !found {
+		return nil, fmt.Errorf("unexpected preview URL format: %s", video.Preview)
+	}
+
+	links := map[string]string{
+		"thumbnail": video.Thumbnail,
+	}
+
+	if !video.RequireSignedURLs {
+		links["hls"] = video.Preview
+		links["dash"] = base + "/manifest/video.mpd"
+		links["watch"] = base + "/watch"
+		links["iframe"] = base + "/iframe"
+		return links, nil
+	}
+
+	duration := time.Hour
+	if args.Duration != "" {
+		duration, err = time.ParseDuration(args.Duration)
+		if err != nil {
+			return nil, fmt.Errorf("invalid duration: %w", err)
+		}
+	}
+
+	token, err := s.mint(ctx, video.UID, time.Now().Add(duration))
+	if err != nil {
+		return nil, err
+	}
+	links["signed_watch"] = base + "/watch?token=" + token
+	links["token"] = token
+	return links, nil
+}
+
+func (s *Server) createDirectUpload(ctx context.Context, raw json.RawMessage) (interface{}, error) {
+	var args struct {
+		MaxDurationSeconds int    `json:"max_duration_seconds"`
+		Expires            string `json:"expires"`
+	}
+	if err := json.Unmarshal(raw, &args); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	duration := 30 * time.Minute
+	if args.Expires != "" {
+		var err error
+		duration, err = time.ParseDuration(args.Expires)
+		if err != nil {
+			return nil, fmt.Errorf("invalid expires: %w", err)
+		}
+	}
+	expiry := time.Now().Add(duration)
+
+	return s.client.CreateDirectUploadURL(ctx, &api.DirectUploadOptions{
+		MaxDurationSeconds: args.MaxDurationSeconds,
+		Expiry:             &expiry,
+		RequireSignedURLs:  true,
+	})
+}
@@ -0,0 +1,57 @@
+package media
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ffprobeOutput is the part of `ffprobe -print_format json` output Probe
+// reads.
+type ffprobeOutput struct {
+	Format struct {
+		FormatName string `json:"format_name"`
+		Duration   string `json:"duration"`
+	} `json:"format"`
+	Streams []struct {
+		CodecType string `json:"codec_type"`
+		CodecName string `json:"codec_name"`
+	} `json:"streams"`
+}
+
+// probeFFprobe inspects path by running the ffprobe binary.
+func probeFFprobe(ctx context.Context, ffprobe, path string) (*Info, error) {
+	cmd := exec.CommandContext(ctx, ffprobe, "-v", "error", "-print_format", "json", "-show_format", "-show_streams", path) //nolint:gosec // Path is provided by the user
+	out, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && len(exitErr.Stderr) > 0 {
+			return nil, fmt.Errorf("ffprobe failed for %s: %s", path, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, fmt.Errorf("ffprobe failed for %s: %w", path, err)
+	}
+	return parseFFprobe(out)
+}
+
+// parseFFprobe converts ffprobe JSON output to Info.
+func parseFFprobe(data []byte) (*Info, error) {
+	var out ffprobeOutput
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, fmt.Errorf("failed to parse ffprobe output: %w", err)
+	}
+
+	info := &Info{Prober: "ffprobe", Container: out.Format.FormatName}
+	if seconds, err := strconv.ParseFloat(out.Format.Duration, 64); err == nil {
+		info.Duration = time.Duration(seconds * float64(time.Second))
+	}
+	for _, s := range out.Streams {
+		if s.CodecType == "video" {
+			info.VideoCodec = s.CodecName
+			break
+		}
+	}
+	return info, nil
+}
@@ -0,0 +1,96 @@
+// Package media inspects video files before upload and checks them against
+// Stream's limits.
+package media
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// Stream limits checked by Validate.
+const (
+	// MaxFileSize is the largest file Stream accepts.
+	MaxFileSize = 30 << 30 // 30 GB
+
+	// MaxDuration is the longest video the CLI uploads, matching the
+	// maxDurationSeconds of file uploads.
+	MaxDuration = 6 * time.Hour
+)
+
+// supportedCodecs are the video codecs Stream encodes from, by ffprobe name.
+var supportedCodecs = map[string]bool{
+	"h264": true,
+	"hevc": true,
+	"vp8":  true,
+	"vp9":  true,
+	"av1":  true,
+}
+
+// ErrUnknownFormat is returned when a file can't be inspected without
+// ffprobe.
+var ErrUnknownFormat = errors.New("unknown container format (install ffprobe to inspect it)")
+
+// Info describes a media file.
+type Info struct {
+	Path       string
+	Size       int64
+	Container  string        // e.g. mp4 or matroska
+	Duration   time.Duration // Zero when unknown
+	VideoCodec string        // ffprobe codec name, e.g. h264; empty without a video stream
+	Prober     string        // ffprobe or headers
+}
+
+// Probe inspects the file at path with ffprobe when it is on the PATH, and
+// otherwise by parsing the container headers, which supports MP4 and
+// QuickTime files.
+func Probe(ctx context.Context, path string) (*Info, error) {
+	stat, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get file info: %w", err)
+	}
+
+	var info *Info
+	if ffprobe, lookErr := exec.LookPath("ffprobe"); lookErr == nil {
+		info, err = probeFFprobe(ctx, ffprobe, path)
+	} else {
+		info, err = probeHeaders(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	info.Path = path
+	info.Size = stat.Size()
+	return info, nil
+}
+
+// Validate checks the file against Stream's limits and returns an error
+// describing every problem found.
+func (i *Info) Validate() error {
+	var problems []string
+	if i.Size > MaxFileSize {
+		problems = append(problems, fmt.Sprintf("size %d bytes exceeds the 30 GB limit", i.Size))
+	}
+	if i.Size == 0 {
+		problems = append(problems, "file is empty")
+	}
+	if i.Duration > MaxDuration {
+		problems = append(problems, fmt.Sprintf("duration %s exceeds the %s limit", i.Duration.Round(time.Second), MaxDuration))
+	}
+	switch {
+	case i.VideoCodec == "":
+		problems = append(problems, "no video stream found")
+	case !supportedCodecs[i.VideoCodec]:
+		problems = append(problems, fmt.Sprintf("video codec %s is not supported (h264, hevc, vp8, vp9, av1)", i.VideoCodec))
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%s: %s", i.Path, strings.Join(problems, "; "))
+}
@@ -0,0 +1,85 @@
+package media
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// box builds an MP4 box of the given type around payloads.
+func box(boxType string, payloads ...[]byte) []byte {
+	size := 8
+	for _, p := range payloads {
+		size += len(p)
+	}
+	b := make([]byte, 8, size)
+	binary.BigEndian.PutUint32(b, uint32(size))
+	copy(b[4:], boxType)
+	for _, p := range payloads {
+		b = append(b, p...)
+	}
+	return b
+}
+
+// testMP4 returns a minimal MP4 file with a video track.
+func testMP4(codec string, timescale, duration uint32) []byte {
+	mvhd := make([]byte, 20)
+	binary.BigEndian.PutUint32(mvhd[12:], timescale)
+	binary.BigEndian.PutUint32(mvhd[16:], duration)
+
+	hdlr := make([]byte, 12)
+	copy(hdlr[8:], "vide")
+
+	stsd := make([]byte, 8)
+	binary.BigEndian.PutUint32(stsd[4:], 1)
+	entry := box(codec, make([]byte, 8))
+
+	trak := box("trak", box("mdia", box("hdlr", hdlr), box("minf", box("stbl", box("stsd", stsd, entry)))))
+	return append(append(box("ftyp", []byte("isom")), box("mdat", make([]byte, 64))...), box("moov", box("mvhd", mvhd), trak)...)
+}
+
+func TestProbeHeaders(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "video.mp4")
+	require.NoError(t, os.WriteFile(path, testMP4("avc1", 1000, 90500), 0o600))
+
+	info, err := probeHeaders(path)
+	require.NoError(t, err)
+	assert.Equal(t, "mp4", info.Container)
+	assert.Equal(t, "h264", info.VideoCodec)
+	assert.Equal(t, 90500*time.Millisecond, info.Duration)
+}
+
+func TestProbeHeaders_Unknown(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "video.mkv")
+	require.NoError(t, os.WriteFile(path, []byte("\x1a\x45\xdf\xa3 matroska header"), 0o600))
+
+	_, err := probeHeaders(path)
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+}
+
+func TestParseFFprobe(t *testing.T) {
+	info, err := parseFFprobe([]byte(`{"streams":[{"codec_type":"audio","codec_name":"aac"},{"codec_type":"video","codec_name":"vp9"}],"format":{"format_name":"matroska,webm","duration":"12.500000"}}`))
+	require.NoError(t, err)
+	assert.Equal(t, "vp9", info.VideoCodec)
+	assert.Equal(t, "matroska,webm", info.Container)
+	assert.Equal(t, 12500*time.Millisecond, info.Duration)
+}
+
+func TestValidate(t *testing.T) {
+	ok := &Info{Path: "a.mp4", Size: 1024, Duration: time.Minute, VideoCodec: "h264"}
+	assert.NoError(t, ok.Validate())
+
+	bad := &Info{Path: "b.mp4", Size: MaxFileSize + 1, Duration: 7 * time.Hour, VideoCodec: "prores"}
+	err := bad.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "b.mp4: size")
+	assert.Contains(t, err.Error(), "duration 7h0m0s exceeds the 6h0m0s limit")
+	assert.Contains(t, err.Error(), "video codec prores is not supported")
+
+	assert.ErrorContains(t, (&Info{Path: "c.mp4", Size: 1}).Validate(), "no video stream found")
+}
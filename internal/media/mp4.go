@@ -0,0 +1,208 @@
+package media
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// maxMoovSize bounds the metadata box read into memory.
+const maxMoovSize = 64 << 20
+
+// mp4Codecs maps MP4 sample entry types to ffprobe codec names.
+var mp4Codecs = map[string]string{
+	"avc1": "h264",
+	"avc3": "h264",
+	"hvc1": "hevc",
+	"hev1": "hevc",
+	"vp08": "vp8",
+	"vp09": "vp9",
+	"av01": "av1",
+	"mp4v": "mpeg4",
+	"apch": "prores",
+	"apcn": "prores",
+	"apcs": "prores",
+	"apco": "prores",
+	"ap4h": "prores",
+}
+
+// probeHeaders inspects path by parsing its container headers.
+func probeHeaders(path string) (*Info, error) {
+	f, err := os.Open(path) //nolint:gosec // Path is provided by the user
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer f.Close()
+
+	var magic [12]byte
+	if _, err := io.ReadFull(f, magic[:]); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, ErrUnknownFormat)
+	}
+	switch string(magic[4:8]) {
+	case "ftyp", "moov", "mdat", "wide", "free", "skip":
+	default:
+		return nil, fmt.Errorf("%s: %w", path, ErrUnknownFormat)
+	}
+
+	moov, err := findBox(f, "moov")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	info, err := parseMoov(moov)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return info, nil
+}
+
+// findBox scans the top-level boxes of r and returns the payload of the
+// first box of type name.
+func findBox(r io.ReadSeeker, name string) ([]byte, error) {
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("failed to seek: %w", err)
+	}
+
+	for {
+		size, boxType, headerSize, err := readBoxHeader(r)
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("no %s box found", name)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if boxType == name {
+			if size == 0 || size-headerSize > maxMoovSize {
+				return nil, fmt.Errorf("%s box is too large", name)
+			}
+			payload := make([]byte, size-headerSize)
+			if _, err := io.ReadFull(r, payload); err != nil {
+				return nil, fmt.Errorf("failed to read %s box: %w", name, err)
+			}
+			return payload, nil
+		}
+		if size == 0 {
+			return nil, fmt.Errorf("no %s box found", name)
+		}
+		if _, err := r.Seek(int64(size-headerSize), io.SeekCurrent); err != nil { //nolint:gosec // Box sizes fit in int64 for seekable files
+			return nil, fmt.Errorf("failed to seek: %w", err)
+		}
+	}
+}
+
+// readBoxHeader reads a box header. A size of 0 means the box extends to
+// the end of the file.
+func readBoxHeader(r io.Reader) (size uint64, boxType string, headerSize uint64, err error) {
+	var header [8]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return 0, "", 0, io.EOF
+		}
+		return 0, "", 0, err
+	}
+	size = uint64(binary.BigEndian.Uint32(header[:4]))
+	boxType = string(header[4:8])
+	headerSize = 8
+
+	if size == 1 {
+		var large [8]byte
+		if _, err := io.ReadFull(r, large[:]); err != nil {
+			return 0, "", 0, fmt.Errorf("failed to read box size: %w", err)
+		}
+		size = binary.BigEndian.Uint64(large[:])
+		headerSize = 16
+	}
+	if size != 0 && size < headerSize {
+		return 0, "", 0, fmt.Errorf("invalid %s box size %d", boxType, size)
+	}
+	return size, boxType, headerSize, nil
+}
+
+// children returns the payloads of the boxes directly inside data, keyed by
+// type. Repeated types keep every payload in order.
+func children(data []byte) map[string][][]byte {
+	boxes := make(map[string][][]byte)
+	r := bytes.NewReader(data)
+	for {
+		offset := int64(len(data)) - int64(r.Len())
+		size, boxType, headerSize, err := readBoxHeader(r)
+		if err != nil {
+			return boxes
+		}
+		end := uint64(offset) + size //nolint:gosec // Offsets are within data
+		if size == 0 || end > uint64(len(data)) {
+			end = uint64(len(data))
+		}
+		start := uint64(offset) + headerSize //nolint:gosec // Offsets are within data
+		boxes[boxType] = append(boxes[boxType], data[start:end])
+		if _, err := r.Seek(int64(end), io.SeekStart); err != nil { //nolint:gosec // end is at most len(data)
+			return boxes
+		}
+	}
+}
+
+// parseMoov reads the duration and the video codec from a moov payload.
+func parseMoov(moov []byte) (*Info, error) {
+	boxes := children(moov)
+	info := &Info{Prober: "headers", Container: "mp4"}
+
+	if mvhd := boxes["mvhd"]; len(mvhd) > 0 {
+		info.Duration = mvhdDuration(mvhd[0])
+	}
+
+	for _, trak := range boxes["trak"] {
+		mdia := first(children(trak)["mdia"])
+		if mdia == nil {
+			continue
+		}
+		mdiaBoxes := children(mdia)
+		hdlr := first(mdiaBoxes["hdlr"])
+		if len(hdlr) < 12 || string(hdlr[8:12]) != "vide" {
+			continue
+		}
+		minf := first(mdiaBoxes["minf"])
+		stbl := first(children(minf)["stbl"])
+		stsd := first(children(stbl)["stsd"])
+		// Version, flags, and entry count precede the first sample entry
+		if len(stsd) < 16 {
+			continue
+		}
+		format := string(stsd[12:16])
+		if codec, ok := mp4Codecs[format]; ok {
+			info.VideoCodec = codec
+		} else {
+			info.VideoCodec = format
+		}
+		break
+	}
+
+	return info, nil
+}
+
+// mvhdDuration returns the movie duration from an mvhd payload.
+func mvhdDuration(mvhd []byte) time.Duration {
+	var timescale, duration uint64
+	switch {
+	case len(mvhd) >= 32 && mvhd[0] == 1:
+		timescale = uint64(binary.BigEndian.Uint32(mvhd[20:24]))
+		duration = binary.BigEndian.Uint64(mvhd[24:32])
+	case len(mvhd) >= 20:
+		timescale = uint64(binary.BigEndian.Uint32(mvhd[12:16]))
+		duration = uint64(binary.BigEndian.Uint32(mvhd[16:20]))
+	}
+	if timescale == 0 {
+		return 0
+	}
+	return time.Duration(float64(duration) / float64(timescale) * float64(time.Second))
+}
+
+func first(boxes [][]byte) []byte {
+	if len(boxes) == 0 {
+		return nil
+	}
+	return boxes[0]
+}
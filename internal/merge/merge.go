@@ -0,0 +1,98 @@
+// Package merge combines the output of one command run against several
+// profiles into a single result with a profile column.
+package merge
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"slices"
+)
+
+// Column names the profile in merged CSV rows and JSON objects.
+const (
+	Column    = "Profile"
+	JSONField = "profile"
+)
+
+// Output is the standard output of a command run against one profile.
+type Output struct {
+	Profile string
+	Data    []byte
+}
+
+// JSON merges JSON outputs into one list. Arrays contribute their elements
+// and anything else contributes itself; every object gets a profile field and
+// other values are wrapped as {"profile": ..., "value": ...}. Empty outputs
+// are skipped.
+func JSON(outputs []Output) ([]interface{}, error) {
+	var merged []interface{}
+	for _, out := range outputs {
+		if len(bytes.TrimSpace(out.Data)) == 0 {
+			continue
+		}
+
+		var value interface{}
+		if err := json.Unmarshal(out.Data, &value); err != nil {
+			return nil, fmt.Errorf("profile %s: output is not JSON: %w", out.Profile, err)
+		}
+
+		items, ok := value.([]interface{})
+		if !ok {
+			items = []interface{}{value}
+		}
+		for _, item := range items {
+			object, ok := item.(map[string]interface{})
+			if !ok {
+				object = map[string]interface{}{"value": item}
+			}
+			object[JSONField] = out.Profile
+			merged = append(merged, object)
+		}
+	}
+	return merged, nil
+}
+
+// CSV merges CSV outputs that start with a header row. The merged headers
+// are Profile followed by the headers in order of first appearance; cells a
+// profile's output has no column for are left empty. Empty outputs are
+// skipped. Output with fewer than two columns is rejected, since that is
+// almost always plain text rather than a table.
+func CSV(outputs []Output) ([]string, []map[string]string, error) {
+	headers := []string{Column}
+	var rows []map[string]string
+	for _, out := range outputs {
+		if len(bytes.TrimSpace(out.Data)) == 0 {
+			continue
+		}
+
+		records, err := csv.NewReader(bytes.NewReader(out.Data)).ReadAll()
+		if err != nil {
+			return nil, nil, fmt.Errorf("profile %s: output is not CSV: %w", out.Profile, err)
+		}
+		if len(records) == 0 {
+			continue
+		}
+
+		header := records[0]
+		if len(header) < 2 {
+			return nil, nil, fmt.Errorf("profile %s: output is not a table", out.Profile)
+		}
+		for _, h := range header {
+			if !slices.Contains(headers, h) {
+				headers = append(headers, h)
+			}
+		}
+		for _, record := range records[1:] {
+			row := map[string]string{Column: out.Profile}
+			for i, cell := range record {
+				if i < len(header) {
+					row[header[i]] = cell
+				}
+			}
+			rows = append(rows, row)
+		}
+	}
+	return headers, rows, nil
+}
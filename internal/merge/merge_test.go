@@ -0,0 +1,48 @@
+package merge
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSON(t *testing.T) {
+	merged, err := JSON([]Output{
+		{Profile: "acme", Data: []byte(`[{"UID":"a"},{"UID":"b"}]`)},
+		{Profile: "empty", Data: []byte("\n")},
+		{Profile: "globex", Data: []byte(`{"UID":"c"}`)},
+		{Profile: "initech", Data: []byte(`["x"]`)},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"UID": "a", "profile": "acme"},
+		map[string]interface{}{"UID": "b", "profile": "acme"},
+		map[string]interface{}{"UID": "c", "profile": "globex"},
+		map[string]interface{}{"value": "x", "profile": "initech"},
+	}, merged)
+
+	_, err = JSON([]Output{{Profile: "acme", Data: []byte("No videos found")}})
+	assert.Error(t, err)
+}
+
+func TestCSV(t *testing.T) {
+	headers, rows, err := CSV([]Output{
+		{Profile: "acme", Data: []byte("UID,Name\na,Intro\n")},
+		{Profile: "globex", Data: []byte("UID,Name,Status\nb,Launch,ready\n")},
+		{Profile: "empty", Data: nil},
+	})
+	require.NoError(t, err)
+
+	for _, text := range []string{"[dry-run] DELETE https://example.com\n", "Configuration:\n  Account ID: x\n"} {
+		_, _, err := CSV([]Output{{Profile: "acme", Data: []byte(text)}})
+		assert.Error(t, err, text)
+	}
+
+	assert.Equal(t, []string{"Profile", "UID", "Name", "Status"}, headers)
+	assert.Equal(t, []map[string]string{
+		{"Profile": "acme", "UID": "a", "Name": "Intro"},
+		{"Profile": "globex", "UID": "b", "Name": "Launch", "Status": "ready"},
+	}, rows)
+}
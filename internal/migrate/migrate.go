@@ -0,0 +1,140 @@
+// Package migrate reads video listings exported from other hosts and maps
+// their columns onto Stream upload fields.
+package migrate
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Item is a video to copy into Stream.
+type Item struct {
+	URL  string
+	Name string
+	Meta map[string]interface{}
+}
+
+// Mapping maps export columns to Stream fields: "url", "name", or
+// "meta.<key>" for a metadata key.
+type Mapping map[string]string
+
+// ParseMapping parses a comma-separated list of column=field pairs, such as
+// "title=name,desc=meta.description".
+func ParseMapping(spec string) (Mapping, error) {
+	m := Mapping{}
+	if strings.TrimSpace(spec) == "" {
+		return m, nil
+	}
+
+	for _, pair := range strings.Split(spec, ",") {
+		column, field, ok := strings.Cut(pair, "=")
+		column, field = strings.TrimSpace(column), strings.TrimSpace(field)
+		if !ok || column == "" || field == "" {
+			return nil, fmt.Errorf("invalid mapping %q (use column=field)", pair)
+		}
+		valid := field == "url" || field == "name" || strings.HasPrefix(field, "meta.") && field != "meta."
+		if !valid {
+			return nil, fmt.Errorf("invalid field %q for column %q (use url, name, or meta.<key>)", field, column)
+		}
+		m[column] = field
+	}
+	return m, nil
+}
+
+// LoadCSV reads a CSV export with a header row and returns one item per row.
+// Columns that are not mapped are ignored, except that a column named "url"
+// is used for the source URL when no column is mapped to url.
+func LoadCSV(path string, mapping Mapping) ([]Item, error) {
+	f, err := os.Open(path) //nolint:gosec // Path is provided by the user
+	if err != nil {
+		return nil, fmt.Errorf("failed to open export: %w", err)
+	}
+	defer f.Close()
+
+	return ReadCSV(f, mapping)
+}
+
+// ReadCSV is like LoadCSV but reads from r.
+func ReadCSV(r io.Reader, mapping Mapping) ([]Item, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read export header: %w", err)
+	}
+
+	fields := make([]string, len(header))
+	hasURL := false
+	for i, name := range header {
+		name = strings.TrimSpace(name)
+		fields[i] = mapping[name]
+		if fields[i] == "url" {
+			hasURL = true
+		}
+	}
+	if !hasURL {
+		for i, name := range header {
+			if strings.EqualFold(strings.TrimSpace(name), "url") && fields[i] == "" {
+				fields[i] = "url"
+				hasURL = true
+			}
+		}
+	}
+	if !hasURL {
+		return nil, fmt.Errorf("export has no url column (map one with e.g. --map 'download_link=url')")
+	}
+	for column := range mapping {
+		if !containsColumn(header, column) {
+			return nil, fmt.Errorf("mapped column %q is not in the export", column)
+		}
+	}
+
+	var items []Item
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read export: %w", err)
+		}
+
+		item := Item{Meta: map[string]interface{}{}}
+		for i, value := range record {
+			if i >= len(fields) || fields[i] == "" {
+				continue
+			}
+			value = strings.TrimSpace(value)
+			switch field := fields[i]; field {
+			case "url":
+				item.URL = value
+			case "name":
+				item.Name = value
+			default:
+				if value != "" {
+					item.Meta[strings.TrimPrefix(field, "meta.")] = value
+				}
+			}
+		}
+		if item.URL == "" {
+			return nil, fmt.Errorf("line %d: missing source URL", line)
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func containsColumn(header []string, column string) bool {
+	for _, name := range header {
+		if strings.TrimSpace(name) == column {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,48 @@
+package migrate
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMapping(t *testing.T) {
+	m, err := ParseMapping("title=name, desc=meta.description,link=url")
+	require.NoError(t, err)
+	assert.Equal(t, Mapping{"title": "name", "desc": "meta.description", "link": "url"}, m)
+
+	for _, spec := range []string{"title", "title=", "title=duration", "desc=meta."} {
+		_, err := ParseMapping(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestReadCSV(t *testing.T) {
+	export := `url,title,desc,views
+https://example.com/a.mp4,First,"About, things",10
+https://example.com/b.mp4,Second,,20
+`
+	items, err := ReadCSV(strings.NewReader(export), Mapping{"title": "name", "desc": "meta.description"})
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+
+	assert.Equal(t, Item{
+		URL:  "https://example.com/a.mp4",
+		Name: "First",
+		Meta: map[string]interface{}{"description": "About, things"},
+	}, items[0])
+	assert.Empty(t, items[1].Meta, "empty values are not mapped")
+}
+
+func TestReadCSV_Errors(t *testing.T) {
+	_, err := ReadCSV(strings.NewReader("link,title\nhttps://example.com/a.mp4,A\n"), Mapping{})
+	assert.ErrorContains(t, err, "no url column")
+
+	_, err = ReadCSV(strings.NewReader("url,title\nhttps://example.com/a.mp4,A\n"), Mapping{"name": "name"})
+	assert.ErrorContains(t, err, `mapped column "name" is not in the export`)
+
+	_, err = ReadCSV(strings.NewReader("url,title\n,A\n"), Mapping{})
+	assert.ErrorContains(t, err, "line 2: missing source URL")
+}
@@ -0,0 +1,62 @@
+// Package notify delivers completion notifications for long-running operations.
+package notify
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// Desktop shows a native desktop notification.
+// It uses osascript on macOS, notify-send on Linux, and PowerShell on Windows.
+func Desktop(title, message string) error {
+	name, args := desktopCommand(runtime.GOOS, title, message)
+	if name == "" {
+		return fmt.Errorf("desktop notifications are not supported on %s", runtime.GOOS)
+	}
+
+	if _, err := exec.LookPath(name); err != nil {
+		return fmt.Errorf("%s not found: %w", name, err)
+	}
+
+	if out, err := exec.Command(name, args...).CombinedOutput(); err != nil { //nolint:gosec // Command names come from a fixed list
+		return fmt.Errorf("%s failed: %w: %s", name, err, strings.TrimSpace(string(out)))
+	}
+
+	return nil
+}
+
+// desktopCommand returns the command and arguments that display a notification on goos.
+func desktopCommand(goos, title, message string) (string, []string) {
+	switch goos {
+	case "darwin":
+		script := fmt.Sprintf("display notification %s with title %s", appleScriptString(message), appleScriptString(title))
+		return "osascript", []string{"-e", script}
+	case "windows":
+		script := fmt.Sprintf(`Add-Type -AssemblyName System.Windows.Forms
+$n = New-Object System.Windows.Forms.NotifyIcon
+$n.Icon = [System.Drawing.SystemIcons]::Information
+$n.Visible = $true
+$n.ShowBalloonTip(10000, %s, %s, 'Info')
+Start-Sleep -Seconds 5
+$n.Dispose()`, powerShellString(title), powerShellString(message))
+		return "powershell", []string{"-NoProfile", "-NonInteractive", "-Command", script}
+	case "linux", "freebsd", "openbsd", "netbsd":
+		return "notify-send", []string{"--app-name=cfstream", title, message}
+	default:
+		return "", nil
+	}
+}
+
+// appleScriptString quotes s as an AppleScript string literal.
+func appleScriptString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + s + `"`
+}
+
+// powerShellString quotes s as a single-quoted PowerShell string literal.
+func powerShellString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
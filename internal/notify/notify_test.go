@@ -0,0 +1,60 @@
+package notify
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDesktopCommand(t *testing.T) {
+	tests := []struct {
+		name     string
+		goos     string
+		wantName string
+		check    func(t *testing.T, args []string)
+	}{
+		{
+			name:     "macOS escapes quotes",
+			goos:     "darwin",
+			wantName: "osascript",
+			check: func(t *testing.T, args []string) {
+				assert.Equal(t, []string{"-e", `display notification "say \"hi\"" with title "Done"`}, args)
+			},
+		},
+		{
+			name:     "linux uses notify-send",
+			goos:     "linux",
+			wantName: "notify-send",
+			check: func(t *testing.T, args []string) {
+				assert.Equal(t, []string{"--app-name=cfstream", "Done", `say "hi"`}, args)
+			},
+		},
+		{
+			name:     "windows uses powershell",
+			goos:     "windows",
+			wantName: "powershell",
+			check: func(t *testing.T, args []string) {
+				assert.Contains(t, args[len(args)-1], `'Done', 'say "hi"'`)
+			},
+		},
+		{
+			name:     "unsupported platform",
+			goos:     "plan9",
+			wantName: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, args := desktopCommand(tt.goos, "Done", `say "hi"`)
+			assert.Equal(t, tt.wantName, name)
+			if tt.check != nil {
+				tt.check(t, args)
+			}
+		})
+	}
+}
+
+func TestPowerShellString(t *testing.T) {
+	assert.Equal(t, "'it''s done'", powerShellString("it's done"))
+}
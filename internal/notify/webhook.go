@@ -0,0 +1,53 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Webhook posts a message to a Slack- or Discord-compatible incoming webhook.
+func Webhook(ctx context.Context, webhookURL, message string) error {
+	if webhookURL == "" {
+		return fmt.Errorf("webhook URL is empty")
+	}
+
+	jsonBody, err := json.Marshal(webhookPayload(webhookURL, message))
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(jsonBody))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body) //nolint:errcheck // Error message, best effort read
+		return fmt.Errorf("webhook failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// webhookPayload builds the JSON body for the webhook flavor implied by its URL.
+// Discord expects "content"; Slack and most compatible services expect "text".
+func webhookPayload(webhookURL, message string) map[string]string {
+	if strings.Contains(webhookURL, "discord.com/api/webhooks") || strings.Contains(webhookURL, "discordapp.com/api/webhooks") {
+		return map[string]string{"content": message}
+	}
+	return map[string]string{"text": message}
+}
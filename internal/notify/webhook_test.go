@@ -0,0 +1,48 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhook_Success(t *testing.T) {
+	var received map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	err := Webhook(context.Background(), server.URL, "upload finished")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"text": "upload finished"}, received)
+}
+
+func TestWebhook_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "invalid_token", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	err := Webhook(context.Background(), server.URL, "upload finished")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status 403")
+}
+
+func TestWebhook_EmptyURL(t *testing.T) {
+	err := Webhook(context.Background(), "", "message")
+	require.Error(t, err)
+}
+
+func TestWebhookPayload(t *testing.T) {
+	assert.Equal(t, map[string]string{"content": "hi"}, webhookPayload("https://discord.com/api/webhooks/1/abc", "hi"))
+	assert.Equal(t, map[string]string{"text": "hi"}, webhookPayload("https://hooks.slack.com/services/T/B/X", "hi"))
+}
@@ -0,0 +1,66 @@
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// CSVFormatter formats output as comma-separated values.
+type CSVFormatter struct{}
+
+// FormatList formats a slice of items as CSV with a header row.
+func (f *CSVFormatter) FormatList(w io.Writer, headers []string, items interface{}) error {
+	v := reflect.ValueOf(items)
+	if v.Kind() != reflect.Slice {
+		return fmt.Errorf("items must be a slice, got %T", items)
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(headers); err != nil {
+		return err
+	}
+
+	for i := 0; i < v.Len(); i++ {
+		row, err := extractRow(v.Index(i), headers)
+		if err != nil {
+			return err
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// FormatSingle formats a single item as key,value rows.
+func (f *CSVFormatter) FormatSingle(w io.Writer, item interface{}) error {
+	v := reflect.ValueOf(item)
+
+	// Dereference pointers
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return fmt.Errorf("item is nil")
+		}
+		v = v.Elem()
+	}
+
+	var pairs [][]string
+	switch v.Kind() {
+	case reflect.Struct:
+		pairs = extractStructPairs(v)
+	case reflect.Map:
+		pairs = extractMapPairs(v)
+	default:
+		return fmt.Errorf("unsupported type for single item: %T", item)
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.WriteAll(pairs); err != nil {
+		return err
+	}
+	return writer.Error()
+}
@@ -4,6 +4,7 @@ package output
 import (
 	"fmt"
 	"io"
+	"strings"
 )
 
 // Formatter defines the interface for formatting output data.
@@ -19,16 +20,29 @@ type Formatter interface {
 }
 
 // NewFormatter creates a new formatter based on the specified format type.
-// Supported formats: "table", "json", "yaml".
+// Supported formats: "table", "json", "ndjson", "yaml", "csv", and
+// "go-template=" followed by a template.
 func NewFormatter(format string) (Formatter, error) {
+	if text, ok := strings.CutPrefix(format, TemplatePrefix); ok {
+		formatter, err := NewTemplateFormatter(text)
+		if err != nil {
+			return nil, err
+		}
+		return formatter, nil
+	}
+
 	switch format {
 	case "table":
 		return &TableFormatter{}, nil
 	case "json":
 		return &JSONFormatter{}, nil
+	case "ndjson":
+		return &NDJSONFormatter{}, nil
 	case "yaml":
 		return &YAMLFormatter{}, nil
+	case "csv":
+		return &CSVFormatter{}, nil
 	default:
-		return nil, fmt.Errorf("unsupported output format: %s (supported: table, json, yaml)", format)
+		return nil, fmt.Errorf("unsupported output format: %s (supported: table, json, ndjson, yaml, csv, go-template=...)", format)
 	}
 }
@@ -46,12 +46,35 @@ func TestNewFormatter(t *testing.T) {
 			wantErr: false,
 			wantTyp: &JSONFormatter{},
 		},
+		{
+			name:    "ndjson formatter",
+			format:  "ndjson",
+			wantErr: false,
+			wantTyp: &NDJSONFormatter{},
+		},
 		{
 			name:    "yaml formatter",
 			format:  "yaml",
 			wantErr: false,
 			wantTyp: &YAMLFormatter{},
 		},
+		{
+			name:    "csv formatter",
+			format:  "csv",
+			wantErr: false,
+			wantTyp: &CSVFormatter{},
+		},
+		{
+			name:    "go-template formatter",
+			format:  "go-template={{.ID}}",
+			wantErr: false,
+			wantTyp: &TemplateFormatter{},
+		},
+		{
+			name:    "invalid go-template",
+			format:  "go-template={{.ID",
+			wantErr: true,
+		},
 		{
 			name:    "invalid formatter",
 			format:  "xml",
@@ -610,3 +633,73 @@ func TestTableFormatter_WithNilPointerFields(t *testing.T) {
 	// Should have at least 3 lines (id, name, status fields)
 	assert.GreaterOrEqual(t, len(lines), 3)
 }
+
+func TestNDJSONFormatter(t *testing.T) {
+	formatter := &NDJSONFormatter{}
+
+	var buf bytes.Buffer
+	videos := []testVideo{
+		{ID: "vid1", Name: "Video 1", Status: "ready", Duration: 120},
+		{ID: "vid2", Name: "Video 2", Status: "processing", Duration: 300},
+	}
+	require.NoError(t, formatter.FormatList(&buf, []string{"ID"}, videos))
+	assert.Equal(t, `{"id":"vid1","name":"Video 1","status":"ready","duration":120}
+{"id":"vid2","name":"Video 2","status":"processing","duration":300}
+`, buf.String())
+
+	buf.Reset()
+	require.NoError(t, formatter.FormatList(&buf, nil, []testVideo{}))
+	assert.Empty(t, buf.String())
+
+	buf.Reset()
+	require.NoError(t, formatter.FormatSingle(&buf, &videos[0]))
+	assert.Equal(t, `{"id":"vid1","name":"Video 1","status":"ready","duration":120}`+"\n", buf.String())
+
+	assert.Error(t, formatter.FormatList(&buf, nil, videos[0]))
+	assert.Error(t, formatter.FormatSingle(&buf, nil))
+}
+
+func TestTemplateFormatter(t *testing.T) {
+	formatter, err := NewFormatter(`go-template={{range .}}{{.ID}} {{.Name}}{{"\n"}}{{end}}`)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	videos := []testVideo{
+		{ID: "vid1", Name: "Video 1"},
+		{ID: "vid2", Name: "Video 2"},
+	}
+	require.NoError(t, formatter.FormatList(&buf, []string{"ID"}, videos))
+	assert.Equal(t, "vid1 Video 1\nvid2 Video 2\n", buf.String())
+
+	single, err := NewFormatter("go-template={{.Name}} is {{.Status}}")
+	require.NoError(t, err)
+	buf.Reset()
+	require.NoError(t, single.FormatSingle(&buf, &testVideo{Name: "Intro", Status: "ready"}))
+	assert.Equal(t, "Intro is ready", buf.String())
+
+	assert.Error(t, single.FormatSingle(&buf, nil))
+
+	// Unknown fields and map keys fail instead of printing nothing
+	missing, err := NewFormatter("go-template={{.Missing}}")
+	require.NoError(t, err)
+	assert.Error(t, missing.FormatSingle(&buf, testVideo{}))
+	assert.Error(t, missing.FormatSingle(&buf, map[string]interface{}{"name": "Intro"}))
+}
+
+func TestCSVFormatter(t *testing.T) {
+	formatter := &CSVFormatter{}
+
+	var buf bytes.Buffer
+	items := []testVideo{
+		{ID: "vid1", Name: "Video, with comma", Status: "ready", Duration: 120},
+		{ID: "vid2", Name: "Video 2", Status: "processing", Duration: 300},
+	}
+	require.NoError(t, formatter.FormatList(&buf, []string{"ID", "Name", "Duration"}, items))
+	assert.Equal(t, "ID,Name,Duration\nvid1,\"Video, with comma\",120\nvid2,Video 2,300\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, formatter.FormatSingle(&buf, items[1]))
+	assert.Equal(t, "id,vid2\nname,Video 2\nstatus,processing\nduration,300\n", buf.String())
+
+	assert.Error(t, formatter.FormatList(&buf, []string{"ID"}, "not a slice"))
+}
@@ -0,0 +1,36 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// NDJSONFormatter formats output as newline-delimited JSON, one compact
+// object per line, for jq -c, log shippers, and streaming pipelines.
+type NDJSONFormatter struct{}
+
+// FormatList writes each item of a slice as a JSON object on its own line.
+func (f *NDJSONFormatter) FormatList(w io.Writer, headers []string, items interface{}) error {
+	v := reflect.ValueOf(items)
+	if v.Kind() != reflect.Slice {
+		return fmt.Errorf("items must be a slice, got %T", items)
+	}
+
+	encoder := json.NewEncoder(w)
+	for i := 0; i < v.Len(); i++ {
+		if err := encoder.Encode(v.Index(i).Interface()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FormatSingle writes a single item as one JSON line.
+func (f *NDJSONFormatter) FormatSingle(w io.Writer, item interface{}) error {
+	if item == nil {
+		return fmt.Errorf("item is nil")
+	}
+	return json.NewEncoder(w).Encode(item)
+}
@@ -0,0 +1,149 @@
+package output
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// Sort orders a slice of structs in place by the field named by key. Keys
+// follow the header convention, so "created" sorts by Created and
+// "video_id" by VideoID, ignoring case. Strings compare case-insensitively,
+// times chronologically, and numbers and booleans by value. The sort is
+// stable, so items with equal keys keep their order.
+func Sort(items interface{}, key string, desc bool) error {
+	v := reflect.ValueOf(items)
+	if v.Kind() != reflect.Slice {
+		return fmt.Errorf("items must be a slice, got %T", items)
+	}
+
+	elem := v.Type().Elem()
+	if elem.Kind() == reflect.Ptr {
+		elem = elem.Elem()
+	}
+	if elem.Kind() != reflect.Struct {
+		return fmt.Errorf("items must be a slice of structs, got %T", items)
+	}
+
+	field, ok := sortField(elem, key)
+	if !ok || !field.IsExported() {
+		return fmt.Errorf("cannot sort by %q: no such field", key)
+	}
+	if !sortable(field.Type) {
+		return fmt.Errorf("cannot sort by %q: unsupported field type %s", key, field.Type)
+	}
+
+	sort.Stable(&sorter{items: v, index: field.Index, swap: reflect.Swapper(items), desc: desc})
+	return nil
+}
+
+// sortField finds the struct field for a sort key.
+func sortField(t reflect.Type, key string) (reflect.StructField, bool) {
+	name := headerToFieldName(key)
+	return t.FieldByNameFunc(func(field string) bool {
+		return strings.EqualFold(field, name)
+	})
+}
+
+// sortable reports whether compareValues supports fields of type t.
+func sortable(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == timeType {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// sorter sorts a slice of structs by the field at index.
+type sorter struct {
+	items reflect.Value
+	index []int
+	swap  func(i, j int)
+	desc  bool
+}
+
+func (s *sorter) Len() int { return s.items.Len() }
+
+func (s *sorter) Swap(i, j int) { s.swap(i, j) }
+
+func (s *sorter) Less(i, j int) bool {
+	if s.desc {
+		i, j = j, i
+	}
+	return compareValues(s.field(i), s.field(j)) < 0
+}
+
+// field returns the sort field of item i, or the zero Value for a nil item.
+func (s *sorter) field(i int) reflect.Value {
+	item := deref(s.items.Index(i))
+	if !item.IsValid() {
+		return item
+	}
+	return item.FieldByIndex(s.index)
+}
+
+// compareValues compares two field values of the same type. Missing values
+// and nil pointers sort first.
+func compareValues(a, b reflect.Value) int {
+	a, b = deref(a), deref(b)
+	switch {
+	case !a.IsValid() && !b.IsValid():
+		return 0
+	case !a.IsValid():
+		return -1
+	case !b.IsValid():
+		return 1
+	}
+
+	if a.Type() == timeType {
+		return a.Interface().(time.Time).Compare(b.Interface().(time.Time))
+	}
+
+	switch a.Kind() {
+	case reflect.String:
+		return strings.Compare(strings.ToLower(a.String()), strings.ToLower(b.String()))
+	case reflect.Bool:
+		switch {
+		case a.Bool() == b.Bool():
+			return 0
+		case b.Bool():
+			return -1
+		default:
+			return 1
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(a.Int(), b.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return cmp.Compare(a.Uint(), b.Uint())
+	case reflect.Float32, reflect.Float64:
+		return cmp.Compare(a.Float(), b.Float())
+	default:
+		return 0
+	}
+}
+
+// deref follows a pointer, returning the zero Value for nil.
+func deref(v reflect.Value) reflect.Value {
+	if v.IsValid() && v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		return v.Elem()
+	}
+	return v
+}
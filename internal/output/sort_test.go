@@ -0,0 +1,74 @@
+package output
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sortItem struct {
+	VideoID  string
+	Name     string
+	Duration float64
+	Created  time.Time
+	Ready    bool
+	Size     *int
+}
+
+func sortedIDs(items []sortItem) []string {
+	ids := make([]string, len(items))
+	for i, item := range items {
+		ids[i] = item.VideoID
+	}
+	return ids
+}
+
+func TestSort(t *testing.T) {
+	day := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	size := 10
+	items := func() []sortItem {
+		return []sortItem{
+			{VideoID: "a", Name: "beta", Duration: 30, Created: day.AddDate(0, 0, 2), Ready: true, Size: &size},
+			{VideoID: "b", Name: "Alpha", Duration: 90, Created: day, Ready: false},
+			{VideoID: "c", Name: "gamma", Duration: 30, Created: day.AddDate(0, 0, 1), Ready: true},
+		}
+	}
+
+	tests := []struct {
+		key  string
+		desc bool
+		want []string
+	}{
+		{key: "name", want: []string{"b", "a", "c"}},
+		{key: "name", desc: true, want: []string{"c", "a", "b"}},
+		{key: "created", want: []string{"b", "c", "a"}},
+		{key: "created", desc: true, want: []string{"a", "c", "b"}},
+		{key: "duration", want: []string{"a", "c", "b"}},
+		{key: "duration", desc: true, want: []string{"b", "a", "c"}},
+		{key: "ready", want: []string{"b", "a", "c"}},
+		{key: "size", want: []string{"b", "c", "a"}},
+		{key: "video_id", desc: true, want: []string{"c", "b", "a"}},
+		{key: "Name", want: []string{"b", "a", "c"}},
+	}
+
+	for _, tt := range tests {
+		got := items()
+		require.NoError(t, Sort(got, tt.key, tt.desc), tt.key)
+		assert.Equal(t, tt.want, sortedIDs(got), "key %s desc %v", tt.key, tt.desc)
+	}
+}
+
+func TestSort_Pointers(t *testing.T) {
+	items := []*sortItem{{VideoID: "a", Name: "b"}, {VideoID: "b", Name: "a"}}
+	require.NoError(t, Sort(items, "name", false))
+	assert.Equal(t, "b", items[0].VideoID)
+}
+
+func TestSort_Errors(t *testing.T) {
+	assert.Error(t, Sort(sortItem{}, "name", false))
+	assert.Error(t, Sort([]string{"a"}, "name", false))
+	assert.Error(t, Sort([]sortItem{}, "missing", false))
+	assert.Error(t, Sort([]struct{ Tags []string }{}, "tags", false))
+}
@@ -0,0 +1,47 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"text/template"
+)
+
+// TemplatePrefix starts an output format that is a Go template, e.g.
+// go-template={{range .}}{{.UID}}{{"\n"}}{{end}}.
+const TemplatePrefix = "go-template="
+
+// TemplateFormatter formats output with a Go text/template. Lists are passed
+// to the template as the slice and single items as the item, so fields are
+// referenced by their Go names, e.g. {{.UID}}.
+type TemplateFormatter struct {
+	tmpl *template.Template
+}
+
+// NewTemplateFormatter parses text as a Go template.
+func NewTemplateFormatter(text string) (*TemplateFormatter, error) {
+	tmpl, err := template.New("output").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid go-template: %w", err)
+	}
+	return &TemplateFormatter{tmpl: tmpl}, nil
+}
+
+// FormatList executes the template with the slice of items.
+func (f *TemplateFormatter) FormatList(w io.Writer, headers []string, items interface{}) error {
+	return f.execute(w, items)
+}
+
+// FormatSingle executes the template with the item.
+func (f *TemplateFormatter) FormatSingle(w io.Writer, item interface{}) error {
+	if item == nil {
+		return fmt.Errorf("item is nil")
+	}
+	return f.execute(w, item)
+}
+
+func (f *TemplateFormatter) execute(w io.Writer, data interface{}) error {
+	if err := f.tmpl.Execute(w, data); err != nil {
+		return fmt.Errorf("failed to execute go-template: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package progress
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sync"
+	"time"
+)
+
+// Event types of the JSON progress stream.
+const (
+	EventStarted      = "started"
+	EventProgress     = "progress"
+	EventItemComplete = "item-complete"
+	EventWarning      = "warning"
+	EventDone         = "done"
+)
+
+// Event is one line of the JSON progress stream. Every operation emits a
+// started event, then progress, item-complete, and warning events, and
+// finally a done event. Current and Total count items, or bytes when Bytes
+// is set; Total is -1 when unknown.
+//
+// Progress events also carry the percentage when the total is known, and
+// for bytes the average speed since the first update and the estimated
+// seconds remaining once a speed is known.
+type Event struct {
+	Event     string    `json:"event"`
+	Time      time.Time `json:"time"`
+	Operation string    `json:"operation"`
+	Phase     string    `json:"phase,omitempty"`
+	Current   int64     `json:"current"`
+	Total     int64     `json:"total"`
+	Bytes     bool      `json:"bytes,omitempty"`
+	Percent   *float64  `json:"percent,omitempty"` // progress only
+	Speed     float64   `json:"speed,omitempty"`   // progress only: bytes per second
+	ETA       *float64  `json:"eta,omitempty"`     // progress only: seconds
+	Item      string    `json:"item,omitempty"`    // item-complete only
+	Error     string    `json:"error,omitempty"`   // Failed item-complete only
+	Message   string    `json:"message,omitempty"`
+	Failed    int64     `json:"failed,omitempty"` // done only: failed items
+}
+
+// eventReporter writes the progress of an operation as NDJSON events.
+// Progress events are throttled; all other events are written immediately.
+type eventReporter struct {
+	w        io.Writer
+	opts     Options
+	interval time.Duration
+	now      func() time.Time
+
+	mu       sync.Mutex
+	current  int64
+	failed   int64
+	last     time.Time
+	finished bool
+
+	// The first update is the baseline of the speed, so bytes skipped when
+	// resuming an upload don't count
+	first      time.Time
+	firstCount int64
+}
+
+func newEvents(w io.Writer, opts Options) *eventReporter {
+	e := &eventReporter{w: w, opts: opts, interval: lineInterval, now: time.Now}
+	e.emit(Event{Event: EventStarted})
+	return e
+}
+
+func (e *eventReporter) Add(n int64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.current += n
+	e.maybeProgress()
+}
+
+func (e *eventReporter) Set(n int64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.current = n
+	e.maybeProgress()
+}
+
+func (e *eventReporter) Item(id string, err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.current++
+	event := Event{Event: EventItemComplete, Item: id}
+	if err != nil {
+		e.failed++
+		event.Error = err.Error()
+	}
+	e.emit(event)
+}
+
+func (e *eventReporter) Warn(message string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.emit(Event{Event: EventWarning, Message: message})
+}
+
+func (e *eventReporter) Finish() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.finished {
+		return
+	}
+	e.finished = true
+	e.emit(Event{Event: EventDone, Failed: e.failed})
+}
+
+// maybeProgress emits a progress event unless one was emitted within the
+// interval. Reaching the total is always emitted. Callers hold e.mu.
+func (e *eventReporter) maybeProgress() {
+	now := e.now()
+	if e.first.IsZero() {
+		e.first, e.firstCount = now, e.current
+	}
+	if now.Sub(e.last) < e.interval && e.current != e.opts.Total {
+		return
+	}
+	e.last = now
+
+	event := Event{Event: EventProgress}
+	if e.opts.Total > 0 {
+		pct := math.Round(float64(e.current)*1000/float64(e.opts.Total)) / 10
+		event.Percent = &pct
+	}
+	if elapsed := now.Sub(e.first).Seconds(); e.opts.Bytes && elapsed > 0 && e.current > e.firstCount {
+		speed := float64(e.current-e.firstCount) / elapsed
+		event.Speed = math.Round(speed)
+		if e.opts.Total > 0 {
+			eta := math.Round(float64(e.opts.Total-e.current) / speed)
+			event.ETA = &eta
+		}
+	}
+	e.emit(event)
+}
+
+// emit fills in the operation state and writes event as one line.
+func (e *eventReporter) emit(event Event) {
+	event.Time = e.now().UTC()
+	event.Operation = e.opts.Description
+	event.Phase = e.opts.Phase
+	event.Current = e.current
+	event.Total = e.opts.Total
+	event.Bytes = e.opts.Bytes
+
+	line, _ := json.Marshal(event) //nolint:errcheck // Marshaling a flat struct cannot fail
+	fmt.Fprintf(e.w, "%s\n", line)
+}
@@ -0,0 +1,90 @@
+package progress
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// plainReporter writes a line per update, at most once per interval, for logs.
+type plainReporter struct {
+	w        io.Writer
+	opts     Options
+	interval time.Duration
+	now      func() time.Time
+
+	mu       sync.Mutex
+	current  int64
+	last     time.Time
+	written  int64 // Count of the last line written, -1 before the first
+	finished bool
+}
+
+func newPlain(w io.Writer, opts Options) *plainReporter {
+	return &plainReporter{w: w, opts: opts, interval: lineInterval, now: time.Now, written: -1}
+}
+
+func (p *plainReporter) Add(n int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.current += n
+	p.maybeWrite()
+}
+
+func (p *plainReporter) Set(n int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.current = n
+	p.maybeWrite()
+}
+
+// Item counts the item; failures are summarized by the command afterwards.
+func (p *plainReporter) Item(string, error) {
+	p.Add(1)
+}
+
+func (p *plainReporter) Warn(message string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	fmt.Fprintf(p.w, "Warning: %s\n", message)
+}
+
+// Finish writes the final count unless it was the last line written.
+func (p *plainReporter) Finish() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.finished {
+		return
+	}
+	p.finished = true
+	if p.current != p.written {
+		p.write()
+	}
+}
+
+// maybeWrite writes the current count unless a line was written within the
+// interval. Reaching the total is always written. Callers hold p.mu.
+func (p *plainReporter) maybeWrite() {
+	now := p.now()
+	if now.Sub(p.last) < p.interval && p.current != p.opts.Total {
+		return
+	}
+	p.last = now
+	p.write()
+}
+
+// write writes the current count. Callers hold p.mu.
+func (p *plainReporter) write() {
+	p.written = p.current
+
+	current, total := fmt.Sprint(p.current), fmt.Sprint(p.opts.Total)
+	if p.opts.Bytes {
+		current, total = FormatBytes(p.current), FormatBytes(p.opts.Total)
+	}
+	if p.opts.Total <= 0 {
+		fmt.Fprintf(p.w, "%s: %s\n", p.opts.Description, current)
+		return
+	}
+	fmt.Fprintf(p.w, "%s: %s/%s (%d%%)\n", p.opts.Description, current, total, p.current*100/p.opts.Total)
+}
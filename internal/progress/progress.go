@@ -0,0 +1,145 @@
+// Package progress reports the progress of long-running commands as a
+// progress bar, plain text lines, or JSON lines.
+package progress
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/schollz/progressbar/v3"
+)
+
+// Progress output modes.
+const (
+	ModeBar   = "bar"
+	ModePlain = "plain"
+	ModeJSON  = "json"
+	ModeNone  = "none"
+)
+
+// lineInterval is the minimum time between two plain progress lines or
+// JSON progress events.
+const lineInterval = time.Second
+
+// Reporter receives the progress of one operation. Implementations are safe
+// for concurrent use.
+type Reporter interface {
+	// Add advances the progress by n.
+	Add(n int64)
+	// Set sets the absolute progress.
+	Set(n int64)
+	// Item reports that one item of a batch finished, with its error if it
+	// failed, and advances the progress by one.
+	Item(id string, err error)
+	// Warn reports a problem that doesn't stop the operation.
+	Warn(message string)
+	// Finish reports that the operation is complete.
+	Finish()
+}
+
+// Options describes the operation a Reporter tracks.
+type Options struct {
+	Total       int64  // Expected final count; -1 if unknown
+	Description string // Shown before the count, e.g. "Deleting"
+	Bytes       bool   // Counts are bytes
+	Phase       string // Stage named in JSON events, e.g. "uploading"
+}
+
+// Validate checks that mode is a supported progress mode.
+func Validate(mode string) error {
+	switch mode {
+	case ModeBar, ModePlain, ModeJSON, ModeNone:
+		return nil
+	default:
+		return fmt.Errorf("unsupported progress mode: %s (supported: bar, plain, json, none)", mode)
+	}
+}
+
+// New returns a Reporter writing to w in the given mode.
+func New(w io.Writer, mode string, opts Options) (Reporter, error) {
+	if err := Validate(mode); err != nil {
+		return nil, err
+	}
+
+	switch mode {
+	case ModeBar:
+		return newBar(w, opts), nil
+	case ModePlain:
+		return newPlain(w, opts), nil
+	case ModeJSON:
+		return newEvents(w, opts), nil
+	default:
+		return None(), nil
+	}
+}
+
+// None returns a Reporter that discards all progress.
+func None() Reporter {
+	return noneReporter{}
+}
+
+type noneReporter struct{}
+
+func (noneReporter) Add(int64)          {}
+func (noneReporter) Set(int64)          {}
+func (noneReporter) Item(string, error) {}
+func (noneReporter) Warn(string)        {}
+func (noneReporter) Finish()            {}
+
+// barReporter renders an interactive progress bar.
+type barReporter struct {
+	w   io.Writer
+	bar *progressbar.ProgressBar
+}
+
+func newBar(w io.Writer, opts Options) *barReporter {
+	options := []progressbar.Option{
+		progressbar.OptionSetDescription(opts.Description),
+		progressbar.OptionSetWriter(w),
+		progressbar.OptionShowCount(),
+		progressbar.OptionSetWidth(40),
+		progressbar.OptionThrottle(65 * time.Millisecond),
+		progressbar.OptionClearOnFinish(),
+	}
+	if opts.Bytes {
+		options = append(options, progressbar.OptionShowBytes(true))
+	}
+	return &barReporter{w: w, bar: progressbar.NewOptions64(opts.Total, options...)}
+}
+
+func (b *barReporter) Add(n int64) {
+	_ = b.bar.Add64(n) //nolint:errcheck // Progress bar errors are not critical
+}
+
+func (b *barReporter) Set(n int64) {
+	_ = b.bar.Set64(n) //nolint:errcheck // Progress bar errors are not critical
+}
+
+func (b *barReporter) Item(string, error) {
+	b.Add(1)
+}
+
+// Warn prints the message above the bar, which is redrawn on the next update.
+func (b *barReporter) Warn(message string) {
+	_ = b.bar.Clear() //nolint:errcheck // Progress bar errors are not critical
+	fmt.Fprintf(b.w, "Warning: %s\n", message)
+}
+
+func (b *barReporter) Finish() {
+	_ = b.bar.Finish() //nolint:errcheck // Progress bar errors are not critical
+}
+
+// FormatBytes formats a byte count in human-readable format.
+func FormatBytes(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	div, exp := int64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
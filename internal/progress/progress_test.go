@@ -0,0 +1,143 @@
+package progress
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	for _, mode := range []string{ModeBar, ModePlain, ModeJSON, ModeNone} {
+		assert.NoError(t, Validate(mode), mode)
+	}
+	assert.Error(t, Validate("fancy"))
+
+	_, err := New(&bytes.Buffer{}, "fancy", Options{})
+	assert.Error(t, err)
+}
+
+func TestPlain(t *testing.T) {
+	var out bytes.Buffer
+	r, err := New(&out, ModePlain, Options{Total: 4, Description: "Deleting"})
+	require.NoError(t, err)
+
+	// Updates within the interval are coalesced, except for reaching the total
+	now := time.Unix(0, 0)
+	r.(*plainReporter).now = func() time.Time { return now }
+	r.Add(1)
+	r.Add(1)
+	now = now.Add(2 * time.Second)
+	r.Add(1)
+	r.Add(1)
+	r.Finish()
+
+	assert.Equal(t, "Deleting: 1/4 (25%)\nDeleting: 3/4 (75%)\nDeleting: 4/4 (100%)\n", out.String())
+}
+
+func TestPlain_Bytes(t *testing.T) {
+	var out bytes.Buffer
+	r, err := New(&out, ModePlain, Options{Total: 2048, Description: "Uploading a.mp4", Bytes: true})
+	require.NoError(t, err)
+
+	r.Set(1024)
+	r.Finish()
+
+	assert.Equal(t, "Uploading a.mp4: 1.0 KB/2.0 KB (50%)\n", out.String())
+}
+
+func TestEvents(t *testing.T) {
+	var out bytes.Buffer
+	r, err := New(&out, ModeJSON, Options{Total: 2, Description: "Deleting"})
+	require.NoError(t, err)
+
+	r.Item("a", nil)
+	r.Warn("rate limited")
+	r.Item("b", errors.New("not found"))
+	r.Finish()
+	r.Finish()
+
+	var events []Event
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		var e Event
+		require.NoError(t, json.Unmarshal([]byte(line), &e))
+		assert.Equal(t, "Deleting", e.Operation)
+		assert.Equal(t, int64(2), e.Total)
+		assert.False(t, e.Time.IsZero())
+		e.Time, e.Operation, e.Total = time.Time{}, "", 0
+		events = append(events, e)
+	}
+
+	assert.Equal(t, []Event{
+		{Event: EventStarted},
+		{Event: EventItemComplete, Current: 1, Item: "a"},
+		{Event: EventWarning, Current: 1, Message: "rate limited"},
+		{Event: EventItemComplete, Current: 2, Item: "b", Error: "not found"},
+		{Event: EventDone, Current: 2, Failed: 1},
+	}, events)
+}
+
+func TestEvents_Progress(t *testing.T) {
+	var out bytes.Buffer
+	r, err := New(&out, ModeJSON, Options{Total: 2048, Description: "Uploading a.mp4", Bytes: true})
+	require.NoError(t, err)
+
+	r.Set(1024)
+	r.Set(2048)
+	r.Finish()
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 4)
+
+	var e Event
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &e))
+	assert.Equal(t, EventProgress, e.Event)
+	assert.Equal(t, int64(1024), e.Current)
+	assert.True(t, e.Bytes)
+}
+
+func TestEvents_SpeedAndETA(t *testing.T) {
+	var out bytes.Buffer
+	r, err := New(&out, ModeJSON, Options{Total: 4096, Description: "Uploading a.mp4", Bytes: true, Phase: "uploading"})
+	require.NoError(t, err)
+
+	// The speed is measured from the first update, e.g. a resumed offset
+	now := time.Unix(0, 0)
+	r.(*eventReporter).now = func() time.Time { return now }
+	r.Set(1024)
+	now = now.Add(2 * time.Second)
+	r.Set(3072)
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 3)
+
+	var first, second Event
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &first))
+	assert.Equal(t, "uploading", first.Phase)
+	require.NotNil(t, first.Percent)
+	assert.Equal(t, 25.0, *first.Percent)
+	assert.Zero(t, first.Speed)
+	assert.Nil(t, first.ETA)
+
+	require.NoError(t, json.Unmarshal([]byte(lines[2]), &second))
+	require.NotNil(t, second.Percent)
+	assert.Equal(t, 75.0, *second.Percent)
+	assert.Equal(t, 1024.0, second.Speed)
+	require.NotNil(t, second.ETA)
+	assert.Equal(t, 1.0, *second.ETA)
+}
+
+func TestNone(t *testing.T) {
+	var out bytes.Buffer
+	r, err := New(&out, ModeNone, Options{Total: 1})
+	require.NoError(t, err)
+
+	r.Add(1)
+	r.Finish()
+	assert.Empty(t, out.String())
+}
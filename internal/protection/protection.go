@@ -0,0 +1,118 @@
+// Package protection decides which videos the protected_videos list in the
+// configuration shields from deletion.
+package protection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"cfstream/internal/api"
+	"cfstream/internal/filter"
+)
+
+// List is a compiled protected_videos list. The zero value protects nothing.
+type List struct {
+	uids    map[string]bool
+	filters []*filter.Filter
+}
+
+// Compile parses protected_videos entries. Entries containing "=" are filter
+// expressions such as meta.keep=true; all others are video UIDs or aliases,
+// which resolve maps to UIDs.
+func Compile(entries []string, resolve func(string) string) (*List, error) {
+	l := &List{uids: make(map[string]bool)}
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if !strings.Contains(entry, "=") {
+			l.uids[resolve(entry)] = true
+			continue
+		}
+
+		f, err := filter.Parse(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid protected_videos entry: %w", err)
+		}
+		l.filters = append(l.filters, f)
+	}
+	return l, nil
+}
+
+// Empty reports whether the list protects nothing.
+func (l *List) Empty() bool {
+	return len(l.uids) == 0 && len(l.filters) == 0
+}
+
+// NeedsDetails reports whether matching needs more than the video UID, i.e.
+// whether the list contains filter expressions.
+func (l *List) NeedsDetails() bool {
+	return len(l.filters) > 0
+}
+
+// Protected reports whether v is protected. Filter entries only match when
+// v carries the fields they test.
+func (l *List) Protected(v *api.Video) bool {
+	if l.uids[v.UID] {
+		return true
+	}
+	for _, f := range l.filters {
+		if f.Match(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// Find returns the videos among videoIDs that l protects, with their details
+// so names can be shown. When l has filter entries the whole library is paged
+// through, since a filter can only match a video whose fields are known; IDs
+// the listing doesn't contain are fetched one by one. A failed lookup fails
+// Find rather than let a protected video through.
+func (l *List) Find(ctx context.Context, client api.Client, videoIDs []string) ([]api.Video, error) {
+	byID := make(map[string]api.Video)
+	if l.NeedsDetails() && len(videoIDs) > 1 {
+		wanted := make(map[string]bool, len(videoIDs))
+		for _, id := range videoIDs {
+			wanted[id] = true
+		}
+		pager := client.ListVideosPager(&api.ListOptions{})
+		for pager.More() && len(byID) < len(wanted) {
+			page, err := pager.Next(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list videos: %w", err)
+			}
+			for _, v := range page {
+				if wanted[v.UID] {
+					byID[v.UID] = v
+				}
+			}
+		}
+	}
+
+	var protected []api.Video
+	for _, id := range videoIDs {
+		video, ok := byID[id]
+		if !ok {
+			video = api.Video{UID: id}
+			if l.uids[id] || l.NeedsDetails() {
+				details, err := client.GetVideo(ctx, id)
+				switch {
+				case err == nil:
+					video = *details
+				case l.uids[id] || errors.Is(err, api.ErrNotFound):
+					// Protected by UID either way, or there is no video to match
+				default:
+					return nil, fmt.Errorf("failed to get video %s: %w", id, err)
+				}
+			}
+		}
+		if l.Protected(&video) {
+			protected = append(protected, video)
+		}
+	}
+	return protected, nil
+}
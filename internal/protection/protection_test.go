@@ -0,0 +1,121 @@
+package protection
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"cfstream/internal/api"
+)
+
+func TestList(t *testing.T) {
+	aliases := map[string]string{"keynote": "abc123"}
+	resolve := func(id string) string {
+		if uid, ok := aliases[id]; ok {
+			return uid
+		}
+		return id
+	}
+
+	l, err := Compile([]string{"keynote", "def456", "meta.keep=true", " "}, resolve)
+	require.NoError(t, err)
+	assert.False(t, l.Empty())
+	assert.True(t, l.NeedsDetails())
+
+	assert.True(t, l.Protected(&api.Video{UID: "abc123"}))
+	assert.True(t, l.Protected(&api.Video{UID: "def456"}))
+	assert.True(t, l.Protected(&api.Video{UID: "ghi789", Meta: map[string]interface{}{"keep": true}}))
+	assert.False(t, l.Protected(&api.Video{UID: "ghi789"}))
+	assert.False(t, l.Protected(&api.Video{UID: "keynote"}))
+}
+
+func TestCompile_Empty(t *testing.T) {
+	l, err := Compile(nil, func(id string) string { return id })
+	require.NoError(t, err)
+	assert.True(t, l.Empty())
+	assert.False(t, l.NeedsDetails())
+	assert.False(t, l.Protected(&api.Video{UID: "abc123"}))
+}
+
+func TestCompile_InvalidFilter(t *testing.T) {
+	_, err := Compile([]string{"color=red"}, func(id string) string { return id })
+	assert.Error(t, err)
+}
+
+// library serves n videos, newest first in pages of 1000 like the list API,
+// where every tenth video has meta.keep=true.
+func library(t *testing.T, n int) api.Client {
+	t.Helper()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	video := func(i int) map[string]interface{} {
+		v := map[string]interface{}{
+			"uid":     fmt.Sprintf("v%d", i),
+			"created": start.Add(time.Duration(i) * time.Second).Format(time.RFC3339),
+		}
+		if i%10 == 0 {
+			v["meta"] = map[string]interface{}{"keep": "true"}
+		}
+		return v
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var result interface{}
+		if uid := strings.TrimPrefix(r.URL.Path, "/accounts/acct/stream/"); uid != r.URL.Path {
+			var i int
+			if _, err := fmt.Sscanf(uid, "v%d", &i); err != nil || i >= n {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"success":false,"errors":[{"code":10005,"message":"not found"}],"result":null}`)) //nolint:errcheck // Test server
+				return
+			}
+			result = video(i)
+		} else {
+			end := time.Date(9999, 1, 1, 0, 0, 0, 0, time.UTC)
+			if value := r.URL.Query().Get("end"); value != "" {
+				var err error
+				end, err = time.Parse(time.RFC3339, value)
+				assert.NoError(t, err)
+			}
+			var page []map[string]interface{}
+			for i := n - 1; i >= 0 && len(page) < 1000; i-- {
+				if start.Add(time.Duration(i) * time.Second).Before(end) {
+					page = append(page, video(i))
+				}
+			}
+			result = page
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"success": true, "result": result}) //nolint:errcheck // Test server
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := api.NewClient("acct", "token", api.WithBaseURL(srv.URL), api.WithHTTPClient(srv.Client()))
+	require.NoError(t, err)
+	return client
+}
+
+func TestFind(t *testing.T) {
+	client := library(t, 1500)
+	l, err := Compile([]string{"v1499", "meta.keep=true"}, func(id string) string { return id })
+	require.NoError(t, err)
+
+	// v20 is on the second page of the listing; v7 and v1499 aren't protected
+	// by a filter, and v9999 doesn't exist
+	protected, err := l.Find(context.Background(), client, []string{"v1499", "v1498", "v20", "v7", "v9999"})
+	require.NoError(t, err)
+	require.Len(t, protected, 2)
+	assert.Equal(t, "v1499", protected[0].UID)
+	assert.Equal(t, "v20", protected[1].UID)
+
+	// A single video is fetched directly
+	protected, err = l.Find(context.Background(), client, []string{"v30"})
+	require.NoError(t, err)
+	require.Len(t, protected, 1)
+}
@@ -0,0 +1,62 @@
+// Package reltime parses times given relative to now, as used by flags such
+// as --since, --older-than, and --delete-after and by lifecycle rules.
+package reltime
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Direction says on which side of now a relative time lies.
+type Direction int
+
+const (
+	// Past times lie before now, e.g. --since 30d.
+	Past Direction = iota
+	// Future times lie after now, e.g. --delete-after 30d.
+	Future
+)
+
+// dateLayout is the layout of absolute dates.
+const dateLayout = "2006-01-02"
+
+// Parse parses value as a time relative to now in direction dir. It accepts a
+// day count such as 30d, a Go duration such as 12h, or a date (2006-01-02).
+// Dates must lie after now when dir is Future.
+func Parse(value string, now time.Time, dir Direction) (time.Time, error) {
+	if t, err := time.Parse(dateLayout, value); err == nil {
+		if dir == Future && !t.After(now) {
+			return time.Time{}, fmt.Errorf("date must be in the future: %s", value)
+		}
+		return t, nil
+	}
+
+	d, err := ParseDuration(value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s is not a day count, duration, or date (e.g., 30d, 12h, or 2006-01-02)", value)
+	}
+	if dir == Past {
+		d = -d
+	}
+	return now.Add(d), nil
+}
+
+// ParseDuration parses a length of time given as a day count such as 30d or
+// a Go duration such as 12h. Dates are not accepted.
+func ParseDuration(value string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("%s is not a positive day count", value)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("%s is not a day count or duration (e.g., 30d or 12h)", value)
+	}
+	return d, nil
+}
@@ -0,0 +1,59 @@
+package reltime
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	now := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value string
+		dir   Direction
+		want  time.Time
+	}{
+		{"30d", Past, now.Add(-30 * 24 * time.Hour)},
+		{"30d", Future, now.Add(30 * 24 * time.Hour)},
+		{"12h", Past, now.Add(-12 * time.Hour)},
+		{"720h", Future, now.Add(720 * time.Hour)},
+		{"2024-01-01", Past, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"2024-07-01", Future, time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.value, now, tt.dir)
+		require.NoError(t, err, tt.value)
+		assert.Equal(t, tt.want, got, tt.value)
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	now := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+
+	for _, value := range []string{"", "0d", "-1d", "xd", "-5h", "soon", "2024-13-01"} {
+		_, err := Parse(value, now, Past)
+		assert.Error(t, err, value)
+	}
+
+	// Future dates must lie after now
+	_, err := Parse("2024-01-01", now, Future)
+	assert.ErrorContains(t, err, "must be in the future")
+}
+
+func TestParseDuration(t *testing.T) {
+	d, err := ParseDuration("30d")
+	require.NoError(t, err)
+	assert.Equal(t, 30*24*time.Hour, d)
+
+	d, err = ParseDuration("90m")
+	require.NoError(t, err)
+	assert.Equal(t, 90*time.Minute, d)
+
+	for _, value := range []string{"0d", "-1d", "xd", "-5h", "2024-01-01"} {
+		_, err := ParseDuration(value)
+		assert.Error(t, err, value)
+	}
+}
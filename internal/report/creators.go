@@ -0,0 +1,59 @@
+// Package report aggregates the video library for usage reports.
+package report
+
+import (
+	"math"
+	"sort"
+
+	"cfstream/internal/api"
+)
+
+// Unassigned is the creator name used for videos without a creator.
+const Unassigned = "(none)"
+
+// CreatorTotal is the storage a single creator accounts for.
+type CreatorTotal struct {
+	Creator string  `json:"creator"`
+	Videos  int     `json:"videos"`
+	Minutes float64 `json:"minutes"`
+	Bytes   int64   `json:"bytes"`
+}
+
+// ByCreator groups videos by their creator field and totals count, minutes,
+// and bytes per creator, largest storage first. Minutes are rounded to a
+// tenth; videos with an unknown duration don't add to them.
+func ByCreator(videos []api.Video) []CreatorTotal {
+	index := make(map[string]int)
+	var totals []CreatorTotal
+	for _, v := range videos {
+		creator := v.Creator
+		if creator == "" {
+			creator = Unassigned
+		}
+
+		i, ok := index[creator]
+		if !ok {
+			i = len(totals)
+			index[creator] = i
+			totals = append(totals, CreatorTotal{Creator: creator})
+		}
+
+		totals[i].Videos++
+		totals[i].Bytes += v.Size
+		if v.Duration > 0 {
+			totals[i].Minutes += v.Duration / 60
+		}
+	}
+
+	for i := range totals {
+		totals[i].Minutes = math.Round(totals[i].Minutes*10) / 10
+	}
+
+	sort.SliceStable(totals, func(a, b int) bool {
+		if totals[a].Bytes != totals[b].Bytes {
+			return totals[a].Bytes > totals[b].Bytes
+		}
+		return totals[a].Creator < totals[b].Creator
+	})
+	return totals
+}
@@ -0,0 +1,24 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"cfstream/internal/api"
+)
+
+func TestByCreator(t *testing.T) {
+	videos := []api.Video{
+		{UID: "a", Creator: "alice", Duration: 120, Size: 100},
+		{UID: "b", Creator: "bob", Duration: 60, Size: 500},
+		{UID: "c", Creator: "alice", Duration: 30, Size: 50},
+		{UID: "d", Duration: -1, Size: 10},
+	}
+
+	assert.Equal(t, []CreatorTotal{
+		{Creator: "bob", Videos: 1, Minutes: 1, Bytes: 500},
+		{Creator: "alice", Videos: 2, Minutes: 2.5, Bytes: 150},
+		{Creator: Unassigned, Videos: 1, Minutes: 0, Bytes: 10},
+	}, ByCreator(videos))
+}
@@ -0,0 +1,78 @@
+// Package seo builds structured data for search engines.
+package seo
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// VideoObject is a schema.org VideoObject.
+type VideoObject struct {
+	Context      string `json:"@context" yaml:"@context"`
+	Type         string `json:"@type" yaml:"@type"`
+	Name         string `json:"name" yaml:"name"`
+	Description  string `json:"description" yaml:"description"`
+	ThumbnailURL string `json:"thumbnailUrl,omitempty" yaml:"thumbnailUrl,omitempty"`
+	UploadDate   string `json:"uploadDate,omitempty" yaml:"uploadDate,omitempty"`
+	Duration     string `json:"duration,omitempty" yaml:"duration,omitempty"`
+	EmbedURL     string `json:"embedUrl,omitempty" yaml:"embedUrl,omitempty"`
+	ContentURL   string `json:"contentUrl,omitempty" yaml:"contentUrl,omitempty"`
+}
+
+// NewVideoObject creates a VideoObject with the schema.org context filled in.
+// Description falls back to the name, since search engines require one.
+func NewVideoObject(name, description string, duration float64, uploaded time.Time) *VideoObject {
+	if description == "" {
+		description = name
+	}
+
+	obj := &VideoObject{
+		Context:     "https://schema.org",
+		Type:        "VideoObject",
+		Name:        name,
+		Description: description,
+		Duration:    ISODuration(duration),
+	}
+	if !uploaded.IsZero() {
+		obj.UploadDate = uploaded.UTC().Format(time.RFC3339)
+	}
+	return obj
+}
+
+// ScriptTag renders obj as a <script type="application/ld+json"> block.
+func (obj *VideoObject) ScriptTag() (string, error) {
+	// encoding/json escapes <, >, and &, so values cannot close the script element
+	data, err := json.MarshalIndent(obj, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode JSON-LD: %w", err)
+	}
+
+	return fmt.Sprintf("<script type=\"application/ld+json\">\n%s\n</script>", data), nil
+}
+
+// ISODuration formats seconds as an ISO 8601 duration such as PT1M35S.
+func ISODuration(seconds float64) string {
+	if seconds <= 0 {
+		return ""
+	}
+
+	d := time.Duration(seconds * float64(time.Second)).Round(time.Second)
+	h := int(d.Hours())
+	m := int(d.Minutes()) % 60
+	s := int(d.Seconds()) % 60
+
+	var b strings.Builder
+	b.WriteString("PT")
+	if h > 0 {
+		fmt.Fprintf(&b, "%dH", h)
+	}
+	if m > 0 {
+		fmt.Fprintf(&b, "%dM", m)
+	}
+	if s > 0 || (h == 0 && m == 0) {
+		fmt.Fprintf(&b, "%dS", s)
+	}
+	return b.String()
+}
@@ -0,0 +1,45 @@
+package seo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestISODuration(t *testing.T) {
+	tests := []struct {
+		seconds float64
+		want    string
+	}{
+		{0, ""},
+		{0.4, "PT0S"},
+		{5, "PT5S"},
+		{95.2, "PT1M35S"},
+		{3600, "PT1H"},
+		{3723, "PT1H2M3S"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, ISODuration(tt.seconds), tt.seconds)
+	}
+}
+
+func TestVideoObject(t *testing.T) {
+	obj := NewVideoObject("Intro", "", 95, time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC))
+	obj.EmbedURL = "https://example.com/abc/iframe"
+
+	assert.Equal(t, "Intro", obj.Description)
+	assert.Equal(t, "2024-03-01T12:00:00Z", obj.UploadDate)
+
+	tag, err := obj.ScriptTag()
+	require.NoError(t, err)
+	assert.Contains(t, tag, `<script type="application/ld+json">`)
+	assert.Contains(t, tag, `"@type": "VideoObject"`)
+	assert.Contains(t, tag, `"duration": "PT1M35S"`)
+
+	obj.Name = "</script><b>"
+	tag, err = obj.ScriptTag()
+	require.NoError(t, err)
+	assert.NotContains(t, tag, "</script><b>")
+}
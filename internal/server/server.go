@@ -0,0 +1,202 @@
+// Package server implements a minimal authenticated HTTP API in front of the
+// Stream client, so browser prototypes never see the Cloudflare API token.
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"cfstream/internal/api"
+)
+
+// Options configures the HTTP API.
+type Options struct {
+	// AuthToken is the bearer token clients must present. Required.
+	AuthToken string
+
+	// AllowOrigin is sent as Access-Control-Allow-Origin when non-empty.
+	AllowOrigin string
+
+	// SignedDuration is the default lifetime of minted signed tokens.
+	SignedDuration time.Duration
+
+	// MaxSignedDuration caps the lifetime clients may request for signed tokens.
+	MaxSignedDuration time.Duration
+
+	// Mint mints signed tokens. Defaults to minting them through the client.
+	Mint Minter
+}
+
+// Server serves the HTTP API.
+type Server struct {
+	client api.Client
+	opts   Options
+	mux    *http.ServeMux
+}
+
+// New creates a Server backed by client.
+func New(client api.Client, opts Options) (*Server, error) {
+	if opts.AuthToken == "" {
+		return nil, fmt.Errorf("auth token is required")
+	}
+	if opts.SignedDuration <= 0 {
+		opts.SignedDuration = time.Hour
+	}
+	if opts.MaxSignedDuration <= 0 {
+		opts.MaxSignedDuration = 24 * time.Hour
+	}
+	if opts.Mint == nil {
+		opts.Mint = func(ctx context.Context, videoID string, exp time.Time) (string, error) {
+			return client.GetSignedToken(ctx, videoID, exp.Unix())
+		}
+	}
+
+	s := &Server{client: client, opts: opts, mux: http.NewServeMux()}
+	s.mux.HandleFunc("GET /healthz", s.handleHealth)
+	s.mux.Handle("GET /videos", s.authenticated(s.handleListVideos))
+	s.mux.Handle("POST /uploads", s.authenticated(s.handleCreateUpload))
+	s.mux.Handle("POST /videos/{id}/token", s.authenticated(s.handleSignedToken))
+	return s, nil
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.opts.AllowOrigin != "" {
+		w.Header().Set("Access-Control-Allow-Origin", s.opts.AllowOrigin)
+		w.Header().Set("Access-Control-Allow-Headers", "Authorization, Content-Type")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+		if r.Method == http.MethodOptions {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+// authenticated rejects requests that don't carry the configured bearer token.
+func (s *Server) authenticated(next http.HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(s.opts.AuthToken)) != 1 {
+			writeError(w, http.StatusUnauthorized, "missing or invalid bearer token")
+			return
+		}
+		next(w, r)
+	})
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+func (s *Server) handleListVideos(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	opts := &api.ListOptions{
+		Search:  query.Get("search"),
+		Creator: query.Get("creator"),
+	}
+
+	videos, err := s.client.ListVideos(r.Context(), opts)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, videos)
+}
+
+// uploadRequest is the optional body of POST /uploads.
+type uploadRequest struct {
+	MaxDurationSeconds int    `json:"maxDurationSeconds"`
+	Expires            string `json:"expires"`
+}
+
+func (s *Server) handleCreateUpload(w http.ResponseWriter, r *http.Request) {
+	var req uploadRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+			return
+		}
+	}
+
+	opts := &api.DirectUploadOptions{
+		MaxDurationSeconds: req.MaxDurationSeconds,
+		RequireSignedURLs:  true,
+	}
+	if req.Expires != "" {
+		duration, err := time.ParseDuration(req.Expires)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid expires duration: %v", err))
+			return
+		}
+		expiry := time.Now().Add(duration)
+		opts.Expiry = &expiry
+	}
+
+	result, err := s.client.CreateDirectUploadURL(r.Context(), opts)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, result)
+}
+
+func (s *Server) handleSignedToken(w http.ResponseWriter, r *http.Request) {
+	duration := s.opts.SignedDuration
+	if value := r.URL.Query().Get("duration"); value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil || parsed <= 0 {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid duration: %s", value))
+			return
+		}
+		if parsed > s.opts.MaxSignedDuration {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("duration exceeds maximum of %s", s.opts.MaxSignedDuration))
+			return
+		}
+		duration = parsed
+	}
+
+	expires := time.Now().Add(duration)
+	token, err := s.opts.Mint(r.Context(), r.PathValue("id"), expires)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"token":   token,
+		"expires": expires.UTC().Format(time.RFC3339),
+	})
+}
+
+// writeAPIError maps client errors to HTTP status codes.
+func writeAPIError(w http.ResponseWriter, err error) {
+	status := http.StatusBadGateway
+	switch {
+	case errors.Is(err, api.ErrNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, api.ErrInvalidInput):
+		status = http.StatusBadRequest
+	case errors.Is(err, api.ErrRateLimit):
+		status = http.StatusTooManyRequests
+	}
+	writeError(w, status, err.Error())
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v) //nolint:errcheck // Client disconnects are not actionable
+}
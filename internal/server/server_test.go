@@ -0,0 +1,165 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"cfstream/internal/api"
+)
+
+// fakeClient is a minimal api.Client for handler tests.
+type fakeClient struct {
+	api.Client
+	videos     []api.Video
+	lastList   *api.ListOptions
+	lastExp    int64
+	lastUpload *api.DirectUploadOptions
+}
+
+func (f *fakeClient) ListVideos(_ context.Context, opts *api.ListOptions) ([]api.Video, error) {
+	f.lastList = opts
+	return f.videos, nil
+}
+
+func (f *fakeClient) GetSignedToken(_ context.Context, videoID string, exp int64) (string, error) {
+	if videoID == "missing" {
+		return "", api.ErrNotFound
+	}
+	f.lastExp = exp
+	return "tok-" + videoID, nil
+}
+
+func (f *fakeClient) CreateDirectUploadURL(_ context.Context, opts *api.DirectUploadOptions) (*api.DirectUploadResult, error) {
+	f.lastUpload = opts
+	return &api.DirectUploadResult{UploadURL: "https://upload.example/abc", UID: "abc"}, nil
+}
+
+func newTestServer(t *testing.T, client *fakeClient) *Server {
+	t.Helper()
+	s, err := New(client, Options{AuthToken: "secret", AllowOrigin: "*"})
+	require.NoError(t, err)
+	return s
+}
+
+func do(s *Server, method, target, body string, auth bool) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	if auth {
+		req.Header.Set("Authorization", "Bearer secret")
+	}
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestNew_RequiresToken(t *testing.T) {
+	_, err := New(&fakeClient{}, Options{})
+	assert.Error(t, err)
+}
+
+func TestAuth(t *testing.T) {
+	s := newTestServer(t, &fakeClient{})
+
+	assert.Equal(t, http.StatusOK, do(s, http.MethodGet, "/healthz", "", false).Code)
+	assert.Equal(t, http.StatusUnauthorized, do(s, http.MethodGet, "/videos", "", false).Code)
+
+	req := httptest.NewRequest(http.MethodGet, "/videos", nil)
+	req.Header.Set("Authorization", "Bearer wrong")
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	// CORS preflight is answered without credentials
+	rec = do(s, http.MethodOptions, "/videos", "", false)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, "*", rec.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestListVideos(t *testing.T) {
+	client := &fakeClient{videos: []api.Video{{UID: "abc", Name: "Demo"}}}
+	s := newTestServer(t, client)
+
+	rec := do(s, http.MethodGet, "/videos?search=demo", "", true)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "demo", client.lastList.Search)
+
+	var videos []api.Video
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &videos))
+	assert.Equal(t, "Demo", videos[0].Name)
+}
+
+func TestCreateUpload(t *testing.T) {
+	client := &fakeClient{}
+	s := newTestServer(t, client)
+
+	rec := do(s, http.MethodPost, "/uploads", `{"maxDurationSeconds":600,"expires":"30m"}`, true)
+	require.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, 600, client.lastUpload.MaxDurationSeconds)
+	assert.NotNil(t, client.lastUpload.Expiry)
+	assert.Contains(t, rec.Body.String(), "https://upload.example/abc")
+
+	rec = do(s, http.MethodPost, "/uploads", `{"expires":"soon"}`, true)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestSignedToken(t *testing.T) {
+	client := &fakeClient{}
+	s := newTestServer(t, client)
+
+	rec := do(s, http.MethodPost, "/videos/abc/token?duration=10m", "", true)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"token":"tok-abc"`)
+	assert.InDelta(t, time.Now().Add(10*time.Minute).Unix(), client.lastExp, 5)
+
+	assert.Equal(t, http.StatusBadRequest, do(s, http.MethodPost, "/videos/abc/token?duration=48h", "", true).Code)
+	assert.Equal(t, http.StatusNotFound, do(s, http.MethodPost, "/videos/missing/token", "", true).Code)
+}
+
+func TestSignedToken_Minter(t *testing.T) {
+	client := &fakeClient{}
+	s, err := New(client, Options{
+		AuthToken: "secret",
+		Mint: func(_ context.Context, videoID string, _ time.Time) (string, error) {
+			return "local-" + videoID, nil
+		},
+	})
+	require.NoError(t, err)
+
+	rec := do(s, http.MethodPost, "/videos/abc/token", "", true)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"token":"local-abc"`)
+	assert.Zero(t, client.lastExp, "the API is not asked for a token")
+}
+
+func TestTokenServer(t *testing.T) {
+	var gotExp time.Time
+	mint := func(_ context.Context, videoID string, exp time.Time) (string, error) {
+		if videoID == "missing" {
+			return "", api.ErrNotFound
+		}
+		gotExp = exp
+		return "tok-" + videoID, nil
+	}
+	s := NewTokenServer(mint, 30*time.Minute, "*")
+
+	req := httptest.NewRequest(http.MethodGet, "/token/abc", nil)
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"token":"tok-abc"`)
+	assert.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+	assert.Equal(t, "*", rec.Header().Get("Access-Control-Allow-Origin"))
+	assert.WithinDuration(t, time.Now().Add(30*time.Minute), gotExp, 5*time.Second)
+
+	req = httptest.NewRequest(http.MethodGet, "/token/missing", nil)
+	rec = httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
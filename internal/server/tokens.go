@@ -0,0 +1,53 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Minter returns a signed token granting access to videoID until exp.
+type Minter func(ctx context.Context, videoID string, exp time.Time) (string, error)
+
+// TokenServer serves fresh signed tokens for local player development.
+// It is unauthenticated and meant to be bound to localhost only.
+type TokenServer struct {
+	mint        Minter
+	duration    time.Duration
+	allowOrigin string
+	mux         *http.ServeMux
+}
+
+// NewTokenServer creates a TokenServer that mints tokens valid for duration.
+func NewTokenServer(mint Minter, duration time.Duration, allowOrigin string) *TokenServer {
+	if duration <= 0 {
+		duration = time.Hour
+	}
+
+	s := &TokenServer{mint: mint, duration: duration, allowOrigin: allowOrigin, mux: http.NewServeMux()}
+	s.mux.HandleFunc("GET /token/{videoID}", s.handleToken)
+	return s
+}
+
+// ServeHTTP implements http.Handler.
+func (s *TokenServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.allowOrigin != "" {
+		w.Header().Set("Access-Control-Allow-Origin", s.allowOrigin)
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *TokenServer) handleToken(w http.ResponseWriter, r *http.Request) {
+	expires := time.Now().Add(s.duration)
+	token, err := s.mint(r.Context(), r.PathValue("videoID"), expires)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"token":   token,
+		"expires": expires.UTC().Format(time.RFC3339),
+	})
+}
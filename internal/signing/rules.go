@@ -0,0 +1,118 @@
+package signing
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+
+	"cfstream/internal/api"
+)
+
+// ParseRule parses an access rule written as ACTION:TYPE[=VALUES], where
+// ACTION is allow or block, TYPE is ip, country, or any, and VALUES is a
+// comma-separated list of CIDR ranges or country codes, e.g.
+// allow:country=US,CA, block:ip=203.0.113.0/24, or block:any.
+func ParseRule(value string) (api.AccessRule, error) {
+	action, spec, ok := strings.Cut(strings.TrimSpace(value), ":")
+	if !ok {
+		return api.AccessRule{}, fmt.Errorf("invalid access rule %q (e.g., allow:country=US,CA or block:any)", value)
+	}
+	kind, list, _ := strings.Cut(spec, "=")
+
+	rule := api.AccessRule{Action: strings.ToLower(action)}
+	var values []string
+	for _, v := range strings.Split(list, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+
+	switch strings.ToLower(kind) {
+	case "any":
+		rule.Type = api.AccessRuleAny
+		if len(values) > 0 {
+			return api.AccessRule{}, fmt.Errorf("invalid access rule %q: any takes no values", value)
+		}
+	case "ip":
+		rule.Type = api.AccessRuleIP
+		rule.IP = values
+	case "country":
+		rule.Type = api.AccessRuleCountry
+		rule.Country = values
+	default:
+		return api.AccessRule{}, fmt.Errorf("invalid access rule %q: unknown type %q (use ip, country, or any)", value, kind)
+	}
+
+	if err := normalizeRule(&rule); err != nil {
+		return api.AccessRule{}, fmt.Errorf("invalid access rule %q: %w", value, err)
+	}
+	return rule, nil
+}
+
+// LoadRules reads access rules in the API's JSON format from a file: a list
+// of objects with type, action, and ip or country.
+func LoadRules(path string) ([]api.AccessRule, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // Path is provided by the user
+	if err != nil {
+		return nil, fmt.Errorf("failed to read access rules: %w", err)
+	}
+
+	var rules []api.AccessRule
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("failed to parse access rules in %s: %w", path, err)
+	}
+	for i := range rules {
+		if err := normalizeRule(&rules[i]); err != nil {
+			return nil, fmt.Errorf("access rule %d in %s: %w", i+1, path, err)
+		}
+	}
+	return rules, nil
+}
+
+// normalizeRule validates a rule, turns bare IP addresses into single-host
+// ranges, and upper-cases country codes.
+func normalizeRule(rule *api.AccessRule) error {
+	if rule.Action != api.AccessAllow && rule.Action != api.AccessBlock {
+		return fmt.Errorf("invalid action %q (use allow or block)", rule.Action)
+	}
+
+	switch rule.Type {
+	case api.AccessRuleAny:
+		if len(rule.IP) > 0 || len(rule.Country) > 0 {
+			return fmt.Errorf("rules of type any take no ip or country")
+		}
+	case api.AccessRuleIP:
+		if len(rule.IP) == 0 || len(rule.Country) > 0 {
+			return fmt.Errorf("rules of type %s need ip ranges and no country", rule.Type)
+		}
+		for i, cidr := range rule.IP {
+			if ip := net.ParseIP(cidr); ip != nil {
+				if ip.To4() != nil {
+					cidr += "/32"
+				} else {
+					cidr += "/128"
+				}
+			}
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				return fmt.Errorf("invalid IP range %q", rule.IP[i])
+			}
+			rule.IP[i] = cidr
+		}
+	case api.AccessRuleCountry:
+		if len(rule.Country) == 0 || len(rule.IP) > 0 {
+			return fmt.Errorf("rules of type %s need countries and no ip", rule.Type)
+		}
+		for i, country := range rule.Country {
+			country = strings.ToUpper(country)
+			if len(country) != 2 || country[0] < 'A' || country[0] > 'Z' || country[1] < 'A' || country[1] > 'Z' {
+				return fmt.Errorf("invalid country code %q (use two letters, e.g. US)", rule.Country[i])
+			}
+			rule.Country[i] = country
+		}
+	default:
+		return fmt.Errorf("invalid type %q (use %s, %s, or %s)", rule.Type, api.AccessRuleAny, api.AccessRuleIP, api.AccessRuleCountry)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package signing
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"cfstream/internal/api"
+)
+
+func TestParseRule(t *testing.T) {
+	tests := []struct {
+		value string
+		want  api.AccessRule
+	}{
+		{"allow:country=us,CA", api.AccessRule{Type: api.AccessRuleCountry, Action: api.AccessAllow, Country: []string{"US", "CA"}}},
+		{"block:ip=203.0.113.0/24, 198.51.100.7", api.AccessRule{Type: api.AccessRuleIP, Action: api.AccessBlock, IP: []string{"203.0.113.0/24", "198.51.100.7/32"}}},
+		{"allow:ip=2001:db8::1", api.AccessRule{Type: api.AccessRuleIP, Action: api.AccessAllow, IP: []string{"2001:db8::1/128"}}},
+		{"block:any", api.AccessRule{Type: api.AccessRuleAny, Action: api.AccessBlock}},
+	}
+	for _, tt := range tests {
+		rule, err := ParseRule(tt.value)
+		require.NoError(t, err, tt.value)
+		assert.Equal(t, tt.want, rule, tt.value)
+	}
+
+	for _, value := range []string{"country=US", "deny:any", "allow:city=Paris", "allow:country=USA", "allow:country", "block:ip=10.0.0.0/33", "block:any=US"} {
+		_, err := ParseRule(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestLoadRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.json")
+	require.NoError(t, os.WriteFile(path, []byte(`[
+		{"type": "ip.src", "action": "allow", "ip": ["10.0.0.0/8"]},
+		{"type": "ip.geoip.country", "action": "allow", "country": ["gb"]},
+		{"type": "any", "action": "block"}
+	]`), 0o600))
+
+	rules, err := LoadRules(path)
+	require.NoError(t, err)
+	require.Len(t, rules, 3)
+	assert.Equal(t, []string{"GB"}, rules[1].Country)
+	assert.Equal(t, api.AccessRuleAny, rules[2].Type)
+
+	require.NoError(t, os.WriteFile(path, []byte(`[{"type": "ip.src", "action": "allow"}]`), 0o600))
+	_, err = LoadRules(path)
+	assert.Error(t, err)
+}
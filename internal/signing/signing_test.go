@@ -0,0 +1,111 @@
+package signing
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testKey(t *testing.T) (*rsa.PrivateKey, []byte) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	der := x509.MarshalPKCS1PrivateKey(key)
+	return key, pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: der})
+}
+
+func TestSign(t *testing.T) {
+	key, pemData := testKey(t)
+	signer, err := NewSigner("key123", pemData)
+	require.NoError(t, err)
+
+	exp := time.Now().Add(time.Hour)
+	token, err := signer.Sign("video1", exp)
+	require.NoError(t, err)
+
+	parts := strings.Split(token, ".")
+	require.Len(t, parts, 3)
+
+	var header map[string]string
+	decodeSegment(t, parts[0], &header)
+	assert.Equal(t, map[string]string{"alg": "RS256", "kid": "key123"}, header)
+
+	var claims map[string]interface{}
+	decodeSegment(t, parts[1], &claims)
+	assert.Equal(t, "video1", claims["sub"])
+	assert.Equal(t, "key123", claims["kid"])
+	assert.Equal(t, float64(exp.Unix()), claims["exp"])
+	assert.Less(t, claims["nbf"].(float64), float64(time.Now().Unix()))
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	require.NoError(t, err)
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	assert.NoError(t, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], signature))
+}
+
+func TestSignWithClaims(t *testing.T) {
+	_, pemData := testKey(t)
+	signer, err := NewSigner("key123", pemData)
+	require.NoError(t, err)
+
+	exp := time.Now().Add(time.Hour)
+	token, err := signer.SignWithClaims("video1", exp, map[string]interface{}{"downloadable": true, "sub": "other"})
+	require.NoError(t, err)
+
+	var claims map[string]interface{}
+	decodeSegment(t, strings.Split(token, ".")[1], &claims)
+	assert.Equal(t, true, claims["downloadable"])
+	assert.Equal(t, "video1", claims["sub"], "extra claims can't override the standard ones")
+}
+
+func TestNewSigner_Formats(t *testing.T) {
+	key, pemData := testKey(t)
+
+	// Base64-encoded PEM, as returned by the signing keys API
+	_, err := NewSigner("key123", []byte(base64.StdEncoding.EncodeToString(pemData)))
+	assert.NoError(t, err)
+
+	// PKCS#8
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	_, err = NewSigner("key123", pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+	assert.NoError(t, err)
+
+	_, err = NewSigner("", pemData)
+	assert.Error(t, err)
+	_, err = NewSigner("key123", []byte("not a key"))
+	assert.Error(t, err)
+}
+
+func TestLoadSigner(t *testing.T) {
+	_, pemData := testKey(t)
+	path := filepath.Join(t.TempDir(), "key.pem")
+	require.NoError(t, os.WriteFile(path, pemData, 0o600))
+
+	signer, err := LoadSigner("key123", path)
+	require.NoError(t, err)
+	assert.Equal(t, "key123", signer.KeyID())
+
+	_, err = LoadSigner("key123", filepath.Join(t.TempDir(), "missing.pem"))
+	assert.Error(t, err)
+}
+
+func decodeSegment(t *testing.T, segment string, v interface{}) {
+	t.Helper()
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, v))
+}
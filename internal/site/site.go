@@ -0,0 +1,122 @@
+// Package site renders a static HTML gallery of Stream videos.
+package site
+
+import (
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Item is a video to include in the gallery.
+type Item struct {
+	UID       string
+	Name      string
+	Duration  float64
+	Created   time.Time
+	Thumbnail string
+	PlayerURL string
+}
+
+// Page is the data rendered into every page of the site.
+type Page struct {
+	Title     string
+	Generated time.Time
+	Items     []Item
+	Item      *Item
+}
+
+// Generate writes index.html and one player page per item into dir.
+func Generate(dir, title string, items []Item) error {
+	if err := os.MkdirAll(filepath.Join(dir, "videos"), 0o755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	page := Page{Title: title, Generated: time.Now().UTC(), Items: items}
+	if err := render(filepath.Join(dir, "index.html"), indexTemplate, page); err != nil {
+		return err
+	}
+
+	for i := range items {
+		page.Item = &items[i]
+		if err := render(filepath.Join(dir, "videos", items[i].UID+".html"), videoTemplate, page); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func render(path string, tmpl *template.Template, data Page) error {
+	f, err := os.Create(path) //nolint:gosec // Path is built from the user's output directory
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	defer f.Close()
+
+	if err := tmpl.Execute(f, data); err != nil {
+		return fmt.Errorf("failed to render %s: %w", path, err)
+	}
+	return nil
+}
+
+// formatDuration renders seconds as m:ss or h:mm:ss.
+func formatDuration(seconds float64) string {
+	if seconds <= 0 {
+		return ""
+	}
+	d := time.Duration(seconds) * time.Second
+	h, m, s := int(d.Hours()), int(d.Minutes())%60, int(d.Seconds())%60
+	if h > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", h, m, s)
+	}
+	return fmt.Sprintf("%d:%02d", m, s)
+}
+
+var funcs = template.FuncMap{"duration": formatDuration}
+
+const layout = `{{define "head"}}<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{if .Item}}{{.Item.Name}} · {{end}}{{.Title}}</title>
+<style>
+body { font-family: system-ui, sans-serif; margin: 0 auto; max-width: 1200px; padding: 1.5rem; color: #222; }
+a { color: inherit; text-decoration: none; }
+.grid { display: grid; grid-template-columns: repeat(auto-fill, minmax(260px, 1fr)); gap: 1.25rem; }
+.card img { width: 100%; aspect-ratio: 16 / 9; object-fit: cover; border-radius: 6px; background: #ddd; }
+.card h2 { font-size: 1rem; margin: .5rem 0 .25rem; }
+.meta { color: #666; font-size: .85rem; }
+.player { position: relative; padding-top: 56.25%; }
+.player iframe { border: none; position: absolute; top: 0; left: 0; width: 100%; height: 100%; }
+footer { margin-top: 2rem; color: #888; font-size: .8rem; }
+</style>
+</head>
+<body>
+{{end}}{{define "foot"}}<footer>Generated {{.Generated.Format "2006-01-02 15:04 MST"}}</footer>
+</body>
+</html>
+{{end}}`
+
+var indexTemplate = template.Must(template.Must(template.New("layout").Funcs(funcs).Parse(layout)).New("index").Parse(
+	`{{template "head" .}}<h1>{{.Title}}</h1>
+<div class="grid">
+{{range .Items}}<a class="card" href="videos/{{.UID}}.html">
+  <img src="{{.Thumbnail}}" alt="" loading="lazy">
+  <h2>{{.Name}}</h2>
+  <div class="meta">{{duration .Duration}}{{if not .Created.IsZero}} · {{.Created.Format "Jan 2, 2006"}}{{end}}</div>
+</a>
+{{else}}<p>No videos.</p>
+{{end}}</div>
+{{template "foot" .}}`))
+
+var videoTemplate = template.Must(template.Must(template.New("layout").Funcs(funcs).Parse(layout)).New("video").Parse(
+	`{{template "head" .}}<p><a href="../index.html">← {{.Title}}</a></p>
+<h1>{{.Item.Name}}</h1>
+<div class="player">
+  <iframe src="{{.Item.PlayerURL}}" allow="accelerometer; gyroscope; autoplay; encrypted-media; picture-in-picture;" allowfullscreen="true"></iframe>
+</div>
+<p class="meta">{{duration .Item.Duration}}{{if not .Item.Created.IsZero}} · {{.Item.Created.Format "Jan 2, 2006"}}{{end}}</p>
+{{template "foot" .}}`))
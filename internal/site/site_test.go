@@ -0,0 +1,36 @@
+package site
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	dir := t.TempDir()
+	items := []Item{
+		{UID: "abc", Name: "Intro <1>", Duration: 95, Thumbnail: "https://example.com/abc.jpg", PlayerURL: "https://example.com/abc/iframe?token=t"},
+	}
+
+	require.NoError(t, Generate(dir, "Training", items))
+
+	index, err := os.ReadFile(filepath.Join(dir, "index.html"))
+	require.NoError(t, err)
+	assert.Contains(t, string(index), `<h1>Training</h1>`)
+	assert.Contains(t, string(index), `href="videos/abc.html"`)
+	assert.Contains(t, string(index), `Intro &lt;1&gt;`)
+	assert.Contains(t, string(index), `1:35`)
+
+	page, err := os.ReadFile(filepath.Join(dir, "videos", "abc.html"))
+	require.NoError(t, err)
+	assert.Contains(t, string(page), `src="https://example.com/abc/iframe?token=t"`)
+}
+
+func TestFormatDuration(t *testing.T) {
+	assert.Equal(t, "", formatDuration(0))
+	assert.Equal(t, "0:05", formatDuration(5))
+	assert.Equal(t, "1:01:01", formatDuration(3661))
+}
@@ -0,0 +1,92 @@
+// Package tags stores lightweight video tags in the conventional tags
+// metadata key, as a comma-separated string such as "webinar,2024".
+package tags
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Key is the metadata key holding the tags of a video.
+const Key = "tags"
+
+// Get returns the tags stored in video metadata. A JSON array written by
+// other tools is accepted as well as the comma-separated string.
+func Get(meta map[string]interface{}) []string {
+	var values []string
+	switch v := meta[Key].(type) {
+	case string:
+		values = strings.Split(v, ",")
+	case []interface{}:
+		for _, item := range v {
+			values = append(values, fmt.Sprint(item))
+		}
+	}
+
+	var tags []string
+	for _, value := range values {
+		if tag := strings.TrimSpace(value); tag != "" && !Has(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// Set returns a copy of meta with the tags key set to tags, or removed when
+// there are none.
+func Set(meta map[string]interface{}, tags []string) map[string]interface{} {
+	updated := make(map[string]interface{}, len(meta)+1)
+	for key, value := range meta {
+		updated[key] = value
+	}
+	if len(tags) == 0 {
+		delete(updated, Key)
+	} else {
+		updated[Key] = strings.Join(tags, ",")
+	}
+	return updated
+}
+
+// Validate checks that tag can be stored.
+func Validate(tag string) error {
+	if strings.TrimSpace(tag) == "" {
+		return fmt.Errorf("tag cannot be empty")
+	}
+	if strings.Contains(tag, ",") {
+		return fmt.Errorf("tag cannot contain a comma: %s", tag)
+	}
+	return nil
+}
+
+// Has reports whether tags contains tag, ignoring case.
+func Has(tags []string, tag string) bool {
+	for _, t := range tags {
+		if strings.EqualFold(t, strings.TrimSpace(tag)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Add returns tags with add appended, skipping the ones already present,
+// and whether anything was added.
+func Add(tags []string, add ...string) ([]string, bool) {
+	updated := append([]string{}, tags...)
+	for _, tag := range add {
+		if !Has(updated, tag) {
+			updated = append(updated, strings.TrimSpace(tag))
+		}
+	}
+	return updated, len(updated) != len(tags)
+}
+
+// Remove returns tags without remove, and whether anything was removed.
+func Remove(tags []string, remove ...string) ([]string, bool) {
+	updated := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if !Has(remove, tag) {
+			updated = append(updated, tag)
+		}
+	}
+	return updated, len(updated) != len(tags)
+}
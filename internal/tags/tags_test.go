@@ -0,0 +1,45 @@
+package tags
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGet(t *testing.T) {
+	assert.Nil(t, Get(nil))
+	assert.Equal(t, []string{"webinar", "2024"}, Get(map[string]interface{}{"tags": " webinar, 2024,,Webinar"}))
+	assert.Equal(t, []string{"a", "b"}, Get(map[string]interface{}{"tags": []interface{}{"a", "b"}}))
+}
+
+func TestSet(t *testing.T) {
+	meta := map[string]interface{}{"name": "Intro", "tags": "old"}
+
+	updated := Set(meta, []string{"webinar", "2024"})
+	assert.Equal(t, map[string]interface{}{"name": "Intro", "tags": "webinar,2024"}, updated)
+	assert.Equal(t, "old", meta["tags"])
+
+	assert.Equal(t, map[string]interface{}{"name": "Intro"}, Set(meta, nil))
+}
+
+func TestAddRemove(t *testing.T) {
+	tags, changed := Add([]string{"webinar"}, "Webinar", "intro")
+	assert.True(t, changed)
+	assert.Equal(t, []string{"webinar", "intro"}, tags)
+
+	_, changed = Add(tags, "INTRO")
+	assert.False(t, changed)
+
+	tags, changed = Remove(tags, "WEBINAR")
+	assert.True(t, changed)
+	assert.Equal(t, []string{"intro"}, tags)
+
+	_, changed = Remove(tags, "missing")
+	assert.False(t, changed)
+}
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, Validate("webinar"))
+	assert.Error(t, Validate(" "))
+	assert.Error(t, Validate("a,b"))
+}
@@ -0,0 +1,76 @@
+package upload
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ChecksumKey is the metadata key recording the SHA-256 of an uploaded file,
+// so a file can be recognized after it was renamed.
+const ChecksumKey = "sha256"
+
+// Find returns the files in dir whose base name matches the glob pattern,
+// sorted by path. With recursive, subdirectories are searched too. Hidden
+// files and directories are skipped.
+func Find(dir, pattern string, recursive bool) ([]string, error) {
+	if _, err := filepath.Match(pattern, ""); err != nil {
+		return nil, fmt.Errorf("invalid glob %q: %w", pattern, err)
+	}
+
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == dir {
+			return nil
+		}
+		if strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			if !recursive {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		if ok, _ := filepath.Match(pattern, d.Name()); ok { //nolint:errcheck // Pattern is validated above
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory: %w", err)
+	}
+
+	sort.Strings(files)
+	return files, nil
+}
+
+// Checksum returns the hex-encoded SHA-256 of the file at path.
+func Checksum(path string) (string, error) {
+	file, err := os.Open(path) //nolint:gosec // Path is provided by the user
+	if err != nil {
+		return "", fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -0,0 +1,39 @@
+package upload
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFind(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"b.mp4", "a.mp4", "notes.txt", ".hidden.mp4", "sub/c.mp4", ".cache/d.mp4"} {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(name), 0o600))
+	}
+
+	files, err := Find(dir, "*.mp4", false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "a.mp4"), filepath.Join(dir, "b.mp4")}, files)
+
+	files, err = Find(dir, "*.mp4", true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "a.mp4"), filepath.Join(dir, "b.mp4"), filepath.Join(dir, "sub", "c.mp4")}, files)
+
+	_, err = Find(dir, "[", false)
+	assert.Error(t, err)
+}
+
+func TestChecksum(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "video.mp4")
+	require.NoError(t, os.WriteFile(path, []byte("abc"), 0o600))
+
+	sum, err := Checksum(path)
+	require.NoError(t, err)
+	assert.Equal(t, "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad", sum)
+}
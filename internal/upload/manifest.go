@@ -0,0 +1,148 @@
+package upload
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Entry is one row of a batch upload manifest.
+type Entry struct {
+	Line              int // Line of the row in the manifest
+	Source            string
+	Name              string
+	Meta              map[string]interface{}
+	Creator           string
+	RequireSignedURLs bool
+}
+
+// IsURL reports whether the entry is copied from a URL rather than uploaded
+// from a local file.
+func (e *Entry) IsURL() bool {
+	return strings.HasPrefix(e.Source, "http://") || strings.HasPrefix(e.Source, "https://")
+}
+
+// LoadManifest reads a batch upload manifest. Relative file paths are
+// resolved against the directory of the manifest.
+func LoadManifest(path string) ([]Entry, error) {
+	f, err := os.Open(path) //nolint:gosec // Path is provided by the user
+	if err != nil {
+		return nil, fmt.Errorf("failed to open manifest: %w", err)
+	}
+	defer f.Close()
+
+	entries, err := ReadManifest(f)
+	if err != nil {
+		return nil, err
+	}
+
+	base := filepath.Dir(path)
+	for i := range entries {
+		if !entries[i].IsURL() && !filepath.IsAbs(entries[i].Source) {
+			entries[i].Source = filepath.Join(base, entries[i].Source)
+		}
+	}
+	return entries, nil
+}
+
+// ReadManifest reads a CSV batch upload manifest with a header row. The
+// columns are:
+//
+//	source               local file path or http(s) URL (also path or url)
+//	name                 video name; defaults to the file name
+//	metadata             JSON object of metadata
+//	meta.<key>           a single metadata value
+//	creator              creator ID
+//	require_signed_urls  true or false; defaults to true
+//
+// Only source is required; unknown columns are an error, so a typo doesn't
+// silently drop a setting.
+func ReadManifest(r io.Reader) ([]Entry, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest header: %w", err)
+	}
+
+	columns := make([]string, len(header))
+	hasSource := false
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		switch {
+		case name == "source" || name == "path" || name == "url":
+			name = "source"
+			hasSource = true
+		case name == "name" || name == "metadata" || name == "creator" || name == "require_signed_urls":
+		case strings.HasPrefix(name, "meta.") && name != "meta.":
+		default:
+			return nil, fmt.Errorf("unknown manifest column %q (use source, name, metadata, meta.<key>, creator, or require_signed_urls)", header[i])
+		}
+		columns[i] = name
+	}
+	if !hasSource {
+		return nil, fmt.Errorf("manifest has no source column")
+	}
+
+	var entries []Entry
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read manifest: %w", err)
+		}
+
+		entry := Entry{Line: line, Meta: map[string]interface{}{}, RequireSignedURLs: true}
+		for i, value := range record {
+			if i >= len(columns) {
+				continue
+			}
+			value = strings.TrimSpace(value)
+			if value == "" {
+				continue
+			}
+			switch column := columns[i]; column {
+			case "source":
+				entry.Source = value
+			case "name":
+				entry.Name = value
+			case "creator":
+				entry.Creator = value
+			case "require_signed_urls":
+				signed, err := strconv.ParseBool(value)
+				if err != nil {
+					return nil, fmt.Errorf("line %d: invalid require_signed_urls value %q", line, value)
+				}
+				entry.RequireSignedURLs = signed
+			case "metadata":
+				var meta map[string]interface{}
+				if err := json.Unmarshal([]byte(value), &meta); err != nil {
+					return nil, fmt.Errorf("line %d: invalid metadata JSON: %w", line, err)
+				}
+				for k, v := range meta {
+					entry.Meta[k] = v
+				}
+			default:
+				entry.Meta[strings.TrimPrefix(column, "meta.")] = value
+			}
+		}
+		if entry.Source == "" {
+			return nil, fmt.Errorf("line %d: missing source", line)
+		}
+		if entry.Name == "" && !entry.IsURL() {
+			entry.Name = filepath.Base(entry.Source)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
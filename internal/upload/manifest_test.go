@@ -0,0 +1,61 @@
+package upload
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadManifest(t *testing.T) {
+	manifest := `source,name,metadata,meta.course,creator,require_signed_urls
+videos/intro.mp4,,"{""level"": 1}",go101,alice,
+https://example.com/b.mp4,Second,,,,false
+`
+	entries, err := ReadManifest(strings.NewReader(manifest))
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	assert.Equal(t, Entry{
+		Line:              2,
+		Source:            "videos/intro.mp4",
+		Name:              "intro.mp4",
+		Meta:              map[string]interface{}{"level": float64(1), "course": "go101"},
+		Creator:           "alice",
+		RequireSignedURLs: true,
+	}, entries[0])
+	assert.False(t, entries[0].IsURL())
+
+	assert.True(t, entries[1].IsURL())
+	assert.Equal(t, "Second", entries[1].Name)
+	assert.False(t, entries[1].RequireSignedURLs)
+}
+
+func TestReadManifest_Errors(t *testing.T) {
+	for manifest, want := range map[string]string{
+		"name\nA\n":                          "no source column",
+		"source,title\na.mp4,A\n":            `unknown manifest column "title"`,
+		"source,name\n,A\n":                  "line 2: missing source",
+		"path,metadata\na.mp4,{\n":           "line 2: invalid metadata JSON",
+		"url,require_signed_urls\na,maybe\n": `line 2: invalid require_signed_urls value "maybe"`,
+	} {
+		_, err := ReadManifest(strings.NewReader(manifest))
+		assert.ErrorContains(t, err, want, manifest)
+	}
+}
+
+func TestLoadManifest(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "manifest.csv")
+	require.NoError(t, os.WriteFile(path, []byte("path\nintro.mp4\n/abs/outro.mp4\nhttps://example.com/c.mp4\n"), 0o600))
+
+	entries, err := LoadManifest(path)
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	assert.Equal(t, filepath.Join(dir, "intro.mp4"), entries[0].Source)
+	assert.Equal(t, "/abs/outro.mp4", entries[1].Source)
+	assert.Equal(t, "https://example.com/c.mp4", entries[2].Source)
+}
@@ -2,73 +2,45 @@ package upload
 
 import (
 	"fmt"
-	"io"
 	"time"
 
-	"github.com/schollz/progressbar/v3"
-
 	"cfstream/internal/api"
+	"cfstream/internal/progress"
 )
 
-// ProgressTracker wraps a progress bar and handles upload progress updates.
+// ProgressTracker reports upload progress updates.
 type ProgressTracker struct {
-	bar       *progressbar.ProgressBar
+	reporter  progress.Reporter
 	startTime time.Time
-	quiet     bool
 }
 
-// NewProgressTracker creates a new progress tracker for file uploads.
-func NewProgressTracker(fileSize int64, filename string, quiet bool) *ProgressTracker {
-	if quiet {
-		return &ProgressTracker{
-			quiet:     true,
-			startTime: time.Now(),
-		}
-	}
-
-	bar := progressbar.NewOptions64(
-		fileSize,
-		progressbar.OptionSetDescription(fmt.Sprintf("Uploading %s", filename)),
-		progressbar.OptionSetWriter(io.Discard),
-		progressbar.OptionShowBytes(true),
-		progressbar.OptionSetWidth(40),
-		progressbar.OptionThrottle(65*time.Millisecond),
-		progressbar.OptionShowCount(),
-		progressbar.OptionOnCompletion(func() {
-			fmt.Println()
-		}),
-		progressbar.OptionSpinnerType(14),
-		progressbar.OptionFullWidth(),
-		progressbar.OptionSetRenderBlankState(true),
-	)
-
+// NewProgressTracker creates a new progress tracker for a file upload that
+// reports byte counts to reporter.
+func NewProgressTracker(reporter progress.Reporter) *ProgressTracker {
 	return &ProgressTracker{
-		bar:       bar,
+		reporter:  reporter,
 		startTime: time.Now(),
-		quiet:     quiet,
 	}
 }
 
-// Update updates the progress bar with the current upload progress.
-func (pt *ProgressTracker) Update(progress api.UploadProgress) {
-	if pt.quiet {
-		return
+// ProgressOptions returns the progress options for uploading a file.
+func ProgressOptions(fileSize int64, filename string) progress.Options {
+	return progress.Options{
+		Total:       fileSize,
+		Description: fmt.Sprintf("Uploading %s", filename),
+		Bytes:       true,
+		Phase:       "uploading",
 	}
+}
 
-	if pt.bar != nil {
-		_ = pt.bar.Set64(progress.BytesSent) //nolint:errcheck // Progress bar errors are not critical
-	}
+// Update reports the current upload progress.
+func (pt *ProgressTracker) Update(p api.UploadProgress) {
+	pt.reporter.Set(p.BytesSent)
 }
 
 // Finish marks the upload as complete.
 func (pt *ProgressTracker) Finish() {
-	if pt.quiet {
-		return
-	}
-
-	if pt.bar != nil {
-		_ = pt.bar.Finish() //nolint:errcheck // Progress bar errors are not critical
-	}
+	pt.reporter.Finish()
 }
 
 // Duration returns the time elapsed since the tracker was created.
@@ -78,16 +50,7 @@ func (pt *ProgressTracker) Duration() time.Duration {
 
 // FormatBytes formats a byte count in human-readable format.
 func FormatBytes(bytes int64) string {
-	const unit = 1024
-	if bytes < unit {
-		return fmt.Sprintf("%d B", bytes)
-	}
-	div, exp := int64(unit), 0
-	for n := bytes / unit; n >= unit; n /= unit {
-		div *= unit
-		exp++
-	}
-	return fmt.Sprintf("%.1f %cB", float64(bytes)/float64(div), "KMGTPE"[exp])
+	return progress.FormatBytes(bytes)
 }
 
 // FormatSpeed formats upload speed in human-readable format.
@@ -0,0 +1,171 @@
+package upload
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/adrg/xdg"
+)
+
+// Queued is a file staged for a later upload with 'upload queue add'.
+type Queued struct {
+	Path     string                 `json:"path"` // Absolute path of the file
+	Name     string                 `json:"name"`
+	Meta     map[string]interface{} `json:"meta,omitempty"`
+	Size     int64                  `json:"size"`
+	Added    time.Time              `json:"added"`
+	Attempts int                    `json:"attempts,omitempty"`
+	Error    string                 `json:"error,omitempty"` // Error of the last failed attempt
+}
+
+// Queue is the on-disk list of staged uploads, in the order they were added.
+type Queue struct {
+	Files []Queued `json:"files"`
+}
+
+// NewQueued describes the file at path, named name, for the queue.
+func NewQueued(path, name string, meta map[string]interface{}) (Queued, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return Queued{}, fmt.Errorf("failed to resolve path: %w", err)
+	}
+	info, err := os.Stat(abs)
+	if err != nil {
+		return Queued{}, fmt.Errorf("failed to get file info: %w", err)
+	}
+	if info.IsDir() {
+		return Queued{}, fmt.Errorf("%s is a directory", path)
+	}
+
+	return Queued{
+		Path:  abs,
+		Name:  name,
+		Meta:  meta,
+		Size:  info.Size(),
+		Added: time.Now(),
+	}, nil
+}
+
+// LoadQueue reads the staged uploads from disk.
+// Returns an empty queue if no queue file exists.
+func LoadQueue() (*Queue, error) {
+	queue := &Queue{}
+
+	data, err := os.ReadFile(QueuePath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return queue, nil
+		}
+		return nil, fmt.Errorf("failed to read upload queue: %w", err)
+	}
+
+	if err := json.Unmarshal(data, queue); err != nil {
+		return nil, fmt.Errorf("failed to parse upload queue: %w", err)
+	}
+
+	return queue, nil
+}
+
+// SaveQueue writes the staged uploads to disk.
+func SaveQueue(queue *Queue) error {
+	if queue == nil {
+		return fmt.Errorf("upload queue cannot be nil")
+	}
+
+	queuePath := QueuePath()
+	if err := os.MkdirAll(filepath.Dir(queuePath), 0o755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(queue, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode upload queue: %w", err)
+	}
+
+	if err := os.WriteFile(queuePath, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write upload queue: %w", err)
+	}
+
+	return nil
+}
+
+// QueuePath returns the full path to the upload queue file.
+func QueuePath() string {
+	return filepath.Join(xdg.StateHome, "cfstream", "queue.json")
+}
+
+// queueMu serializes updates of the queue file by concurrent uploads.
+var queueMu sync.Mutex
+
+// updateQueue applies change to the on-disk queue and saves it.
+func updateQueue(change func(q *Queue)) error {
+	queueMu.Lock()
+	defer queueMu.Unlock()
+
+	queue, err := LoadQueue()
+	if err != nil {
+		return err
+	}
+	change(queue)
+	return SaveQueue(queue)
+}
+
+// Enqueue appends files to the on-disk queue and returns how many were
+// added. A file already in the queue is updated in place.
+func Enqueue(files ...Queued) (int, error) {
+	added := 0
+	err := updateQueue(func(q *Queue) {
+		for _, f := range files {
+			if i := q.index(f.Path); i >= 0 {
+				q.Files[i] = f
+				continue
+			}
+			q.Files = append(q.Files, f)
+			added++
+		}
+	})
+	return added, err
+}
+
+// Dequeue removes an uploaded or unwanted file from the on-disk queue and
+// reports whether it was queued.
+func Dequeue(path string) (bool, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return false, fmt.Errorf("failed to resolve path: %w", err)
+	}
+
+	found := false
+	err = updateQueue(func(q *Queue) {
+		if i := q.index(abs); i >= 0 {
+			q.Files = append(q.Files[:i], q.Files[i+1:]...)
+			found = true
+		}
+	})
+	return found, err
+}
+
+// MarkFailed records a failed upload attempt of a queued file, which stays
+// in the queue for the next run.
+func MarkFailed(path string, uploadErr error) error {
+	return updateQueue(func(q *Queue) {
+		if i := q.index(path); i >= 0 {
+			q.Files[i].Attempts++
+			q.Files[i].Error = uploadErr.Error()
+		}
+	})
+}
+
+// index returns the position of the file at path in the queue, or -1.
+func (q *Queue) index(path string) int {
+	for i := range q.Files {
+		if q.Files[i].Path == path {
+			return i
+		}
+	}
+	return -1
+}
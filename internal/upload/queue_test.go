@@ -0,0 +1,69 @@
+package upload
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueue(t *testing.T) {
+	useTempState(t)
+	dir := t.TempDir()
+
+	queue, err := LoadQueue()
+	require.NoError(t, err)
+	assert.Empty(t, queue.Files)
+
+	var files []Queued
+	for _, name := range []string{"a.mp4", "b.mp4"} {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte("data"), 0o600))
+		f, err := NewQueued(path, name, map[string]interface{}{"tags": "webinar"})
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), f.Size)
+		files = append(files, f)
+	}
+
+	added, err := Enqueue(files...)
+	require.NoError(t, err)
+	assert.Equal(t, 2, added)
+
+	// Adding a queued file again updates it
+	files[0].Name = "renamed.mp4"
+	added, err = Enqueue(files[0])
+	require.NoError(t, err)
+	assert.Zero(t, added)
+
+	require.NoError(t, MarkFailed(files[1].Path, errors.New("connection reset")))
+
+	queue, err = LoadQueue()
+	require.NoError(t, err)
+	require.Len(t, queue.Files, 2)
+	assert.Equal(t, "renamed.mp4", queue.Files[0].Name)
+	assert.Equal(t, 1, queue.Files[1].Attempts)
+	assert.Equal(t, "connection reset", queue.Files[1].Error)
+
+	found, err := Dequeue(files[0].Path)
+	require.NoError(t, err)
+	assert.True(t, found)
+	found, err = Dequeue(filepath.Join(dir, "missing.mp4"))
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	queue, err = LoadQueue()
+	require.NoError(t, err)
+	require.Len(t, queue.Files, 1)
+	assert.Equal(t, files[1].Path, queue.Files[0].Path)
+}
+
+func TestNewQueued_Directory(t *testing.T) {
+	_, err := NewQueued(t.TempDir(), "dir", nil)
+	assert.Error(t, err)
+
+	_, err = NewQueued(filepath.Join(t.TempDir(), "missing.mp4"), "missing.mp4", nil)
+	assert.Error(t, err)
+}
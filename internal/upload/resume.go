@@ -0,0 +1,169 @@
+package upload
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/adrg/xdg"
+
+	"cfstream/internal/api"
+)
+
+// Pending is a TUS upload that was started but not finished, recorded so a
+// later invocation can resume it.
+type Pending struct {
+	UID      string    `json:"uid"`
+	Location string    `json:"location"`
+	Path     string    `json:"path"` // Absolute path of the file being uploaded
+	Name     string    `json:"name"`
+	Size     int64     `json:"size"`
+	ModTime  time.Time `json:"modTime"`
+	Started  time.Time `json:"started"`
+}
+
+// State is the on-disk list of pending uploads, keyed by video UID.
+type State struct {
+	Uploads map[string]Pending `json:"uploads"`
+}
+
+// NewPending describes the upload of the file at path to upload.
+func NewPending(upload *api.ResumableUpload, path, name string) (Pending, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return Pending{}, fmt.Errorf("failed to resolve path: %w", err)
+	}
+	info, err := os.Stat(abs)
+	if err != nil {
+		return Pending{}, fmt.Errorf("failed to get file info: %w", err)
+	}
+
+	return Pending{
+		UID:      upload.UID,
+		Location: upload.Location,
+		Path:     abs,
+		Name:     name,
+		Size:     info.Size(),
+		ModTime:  info.ModTime(),
+		Started:  time.Now(),
+	}, nil
+}
+
+// Resumable returns the API handle of the upload.
+func (p *Pending) Resumable() *api.ResumableUpload {
+	return &api.ResumableUpload{UID: p.UID, Location: p.Location}
+}
+
+// CheckFile returns an error when the file changed since the upload started,
+// as resuming would then mix the bytes of two files.
+func (p *Pending) CheckFile() error {
+	info, err := os.Stat(p.Path)
+	if err != nil {
+		return fmt.Errorf("failed to get file info: %w", err)
+	}
+	if info.Size() != p.Size || !info.ModTime().Equal(p.ModTime) {
+		return fmt.Errorf("%s changed since the upload started; upload it again", p.Path)
+	}
+	return nil
+}
+
+// LoadState reads the pending uploads from disk.
+// Returns an empty state if no state file exists.
+func LoadState() (*State, error) {
+	state := &State{Uploads: make(map[string]Pending)}
+
+	data, err := os.ReadFile(StatePath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return state, nil
+		}
+		return nil, fmt.Errorf("failed to read upload state: %w", err)
+	}
+
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("failed to parse upload state: %w", err)
+	}
+	if state.Uploads == nil {
+		state.Uploads = make(map[string]Pending)
+	}
+
+	return state, nil
+}
+
+// SaveState writes the pending uploads to disk.
+func SaveState(state *State) error {
+	if state == nil {
+		return fmt.Errorf("upload state cannot be nil")
+	}
+
+	statePath := StatePath()
+	if err := os.MkdirAll(filepath.Dir(statePath), 0o755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode upload state: %w", err)
+	}
+
+	// Upload locations accept bytes without further checks, so the file is
+	// private like the config
+	if err := os.WriteFile(statePath, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write upload state: %w", err)
+	}
+
+	return nil
+}
+
+// StatePath returns the full path to the upload state file.
+func StatePath() string {
+	return filepath.Join(xdg.StateHome, "cfstream", "uploads.json")
+}
+
+// List returns the pending uploads, most recently started first.
+func (s *State) List() []Pending {
+	pending := make([]Pending, 0, len(s.Uploads))
+	for _, p := range s.Uploads {
+		pending = append(pending, p)
+	}
+	sort.Slice(pending, func(i, j int) bool {
+		return pending[i].Started.After(pending[j].Started)
+	})
+	return pending
+}
+
+// stateMu serializes updates of the state file by concurrent uploads.
+var stateMu sync.Mutex
+
+// Record adds a pending upload to the on-disk state.
+func Record(p Pending) error {
+	stateMu.Lock()
+	defer stateMu.Unlock()
+
+	state, err := LoadState()
+	if err != nil {
+		return err
+	}
+	state.Uploads[p.UID] = p
+	return SaveState(state)
+}
+
+// Forget removes a finished or abandoned upload from the on-disk state.
+func Forget(uid string) error {
+	stateMu.Lock()
+	defer stateMu.Unlock()
+
+	state, err := LoadState()
+	if err != nil {
+		return err
+	}
+	if _, ok := state.Uploads[uid]; !ok {
+		return nil
+	}
+	delete(state.Uploads, uid)
+	return SaveState(state)
+}
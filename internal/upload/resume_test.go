@@ -0,0 +1,68 @@
+package upload
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/adrg/xdg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"cfstream/internal/api"
+)
+
+func TestRecordAndForget(t *testing.T) {
+	useTempState(t)
+
+	state, err := LoadState()
+	require.NoError(t, err)
+	assert.Empty(t, state.Uploads)
+
+	now := time.Now()
+	require.NoError(t, Record(Pending{UID: "old", Location: "https://example.com/tus/old", Started: now.Add(-time.Hour)}))
+	require.NoError(t, Record(Pending{UID: "new", Location: "https://example.com/tus/new", Started: now}))
+
+	state, err = LoadState()
+	require.NoError(t, err)
+	pending := state.List()
+	require.Len(t, pending, 2)
+	assert.Equal(t, "new", pending[0].UID)
+	assert.Equal(t, &api.ResumableUpload{UID: "old", Location: "https://example.com/tus/old"}, pending[1].Resumable())
+
+	require.NoError(t, Forget("new"))
+	require.NoError(t, Forget("missing"))
+	state, err = LoadState()
+	require.NoError(t, err)
+	assert.Len(t, state.Uploads, 1)
+	assert.Contains(t, state.Uploads, "old")
+}
+
+func TestPending_CheckFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "video.mp4")
+	require.NoError(t, os.WriteFile(path, []byte("data"), 0o600))
+
+	p, err := NewPending(&api.ResumableUpload{UID: "abc", Location: "https://example.com/tus/abc"}, path, "video.mp4")
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), p.Size)
+	assert.NoError(t, p.CheckFile())
+
+	require.NoError(t, os.WriteFile(path, []byte("other data"), 0o600))
+	assert.Error(t, p.CheckFile())
+}
+
+func useTempState(t *testing.T) {
+	t.Helper()
+	oldXDGState := os.Getenv("XDG_STATE_HOME")
+	t.Cleanup(func() {
+		if oldXDGState != "" {
+			os.Setenv("XDG_STATE_HOME", oldXDGState)
+		} else {
+			os.Unsetenv("XDG_STATE_HOME")
+		}
+		xdg.Reload()
+	})
+	os.Setenv("XDG_STATE_HOME", t.TempDir())
+	xdg.Reload()
+}
@@ -0,0 +1,104 @@
+package upload
+
+import (
+	"fmt"
+	"html/template"
+	"os"
+	"time"
+)
+
+// Widget describes a one-time direct upload URL for the HTML upload page.
+type Widget struct {
+	UploadURL string
+	UID       string
+	Expiry    time.Time
+}
+
+// WriteWidget writes a self-contained HTML page with a drag-and-drop uploader
+// that POSTs a file to the direct upload URL.
+func WriteWidget(path string, w Widget) error {
+	f, err := os.Create(path) //nolint:gosec // Path is provided by the user
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	defer f.Close()
+
+	if err := widgetTemplate.Execute(f, w); err != nil {
+		return fmt.Errorf("failed to write upload page: %w", err)
+	}
+	return nil
+}
+
+var widgetTemplate = template.Must(template.New("widget").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Upload a video</title>
+<style>
+body { font-family: system-ui, sans-serif; max-width: 640px; margin: 3rem auto; padding: 0 1rem; color: #222; }
+#drop { border: 2px dashed #aaa; border-radius: 8px; padding: 3rem 1rem; text-align: center; cursor: pointer; }
+#drop.over { border-color: #f48120; background: #fff7f0; }
+progress { width: 100%; margin-top: 1rem; }
+.meta { color: #666; font-size: .85rem; }
+</style>
+</head>
+<body>
+<h1>Upload a video</h1>
+<p class="meta">Video ID {{.UID}}{{if not .Expiry.IsZero}} · link expires {{.Expiry.UTC.Format "2006-01-02 15:04 MST"}}{{end}} · one upload only</p>
+<div id="drop">Drop a video file here, or click to choose one.
+  <input id="file" type="file" accept="video/*" hidden>
+</div>
+<progress id="progress" value="0" max="100" hidden></progress>
+<p id="status"></p>
+<script>
+(function () {
+  var uploadURL = {{.UploadURL}};
+  var drop = document.getElementById("drop");
+  var input = document.getElementById("file");
+  var progress = document.getElementById("progress");
+  var status = document.getElementById("status");
+  var started = false;
+
+  function upload(file) {
+    if (!file || started) return;
+    started = true;
+    var form = new FormData();
+    form.append("file", file);
+
+    var xhr = new XMLHttpRequest();
+    xhr.open("POST", uploadURL);
+    xhr.upload.onprogress = function (e) {
+      if (e.lengthComputable) progress.value = Math.round(e.loaded / e.total * 100);
+    };
+    xhr.onload = function () {
+      if (xhr.status >= 200 && xhr.status < 300) {
+        status.textContent = "Upload complete. Thank you!";
+      } else {
+        status.textContent = "Upload failed (HTTP " + xhr.status + "). The link may have expired or already been used.";
+        started = false;
+      }
+    };
+    xhr.onerror = function () {
+      status.textContent = "Upload failed: network error.";
+      started = false;
+    };
+    progress.hidden = false;
+    status.textContent = "Uploading " + file.name + "...";
+    xhr.send(form);
+  }
+
+  drop.addEventListener("click", function () { input.click(); });
+  input.addEventListener("change", function () { upload(input.files[0]); });
+  drop.addEventListener("dragover", function (e) { e.preventDefault(); drop.classList.add("over"); });
+  drop.addEventListener("dragleave", function () { drop.classList.remove("over"); });
+  drop.addEventListener("drop", function (e) {
+    e.preventDefault();
+    drop.classList.remove("over");
+    upload(e.dataTransfer.files[0]);
+  });
+})();
+</script>
+</body>
+</html>
+`))
@@ -0,0 +1,30 @@
+package upload
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteWidget(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "widget.html")
+	err := WriteWidget(path, Widget{
+		UploadURL: "https://upload.videodelivery.net/abc?x=1&y=2",
+		UID:       "abc",
+		Expiry:    time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	out := string(data)
+
+	// The URL is emitted as a JavaScript string literal
+	assert.Contains(t, out, `var uploadURL = "https://upload.videodelivery.net/abc?x=1\u0026y=2";`)
+	assert.Contains(t, out, "Video ID abc")
+	assert.Contains(t, out, "2024-01-01 12:00 UTC")
+}
@@ -0,0 +1,143 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"cfstream/internal/api"
+)
+
+// Fixture event types.
+const (
+	EventReady = "ready"
+	EventError = "error"
+)
+
+// Fixture builds a notification body for event in the shape Stream sends:
+// the video object with its processing status. Fields of v are used where
+// set; a nil v yields a sample video with a random UID.
+func Fixture(event string, v *api.Video) ([]byte, error) {
+	if event != EventReady && event != EventError {
+		return nil, fmt.Errorf("invalid event %q (use ready or error)", event)
+	}
+
+	if v == nil {
+		uid, err := randomUID()
+		if err != nil {
+			return nil, err
+		}
+		now := time.Now().UTC().Truncate(time.Second)
+		v = &api.Video{
+			UID:      uid,
+			Name:     "webhook-test.mp4",
+			Duration: 42.5,
+			Created:  now.Add(-time.Minute),
+			Modified: now,
+			Uploaded: now.Add(-time.Minute),
+			Width:    1920,
+			Height:   1080,
+			Size:     8421376,
+		}
+	}
+
+	meta := make(map[string]interface{}, len(v.Meta)+1)
+	for k, val := range v.Meta {
+		meta[k] = val
+	}
+	if v.Name != "" {
+		meta["name"] = v.Name
+	}
+
+	status := map[string]interface{}{
+		"state":           "ready",
+		"pctComplete":     "100.000000",
+		"errorReasonCode": "",
+		"errorReasonText": "",
+	}
+	duration := v.Duration
+	if event == EventError {
+		status = map[string]interface{}{
+			"state":           "error",
+			"pctComplete":     "",
+			"errorReasonCode": "ERR_NON_VIDEO",
+			"errorReasonText": "The file was not recognized as a valid video file.",
+		}
+		duration = -1
+	}
+
+	body := map[string]interface{}{
+		"uid":               v.UID,
+		"creator":           nilIfEmpty(v.Creator),
+		"thumbnail":         v.Thumbnail,
+		"preview":           v.Preview,
+		"readyToStream":     event == EventReady,
+		"requireSignedURLs": v.RequireSignedURLs,
+		"allowedOrigins":    nonNil(v.AllowedOrigins),
+		"status":            status,
+		"meta":              meta,
+		"created":           v.Created.UTC().Format(time.RFC3339Nano),
+		"modified":          v.Modified.UTC().Format(time.RFC3339Nano),
+		"uploaded":          v.Uploaded.UTC().Format(time.RFC3339Nano),
+		"size":              v.Size,
+		"input":             map[string]int64{"width": v.Width, "height": v.Height},
+		"duration":          duration,
+		"playback":          map[string]string{"hls": v.HLS, "dash": v.DASH},
+		"liveInput":         v.LiveInput,
+	}
+	return json.Marshal(body)
+}
+
+// Send posts payload to target with a Webhook-Signature header computed
+// from secret, as Stream does, and returns the response status and body.
+func Send(ctx context.Context, client *http.Client, target, secret string, payload []byte) (int, []byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(payload))
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	now := time.Now()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, "time="+strconv.FormatInt(now.Unix(), 10)+",sig1="+Sign(secret, now, payload))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to send webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxPayload))
+	if err != nil {
+		return resp.StatusCode, nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	return resp.StatusCode, body, nil
+}
+
+// randomUID returns a random 32-character hex ID like Stream video UIDs.
+func randomUID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate UID: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+func nilIfEmpty(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
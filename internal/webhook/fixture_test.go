@@ -0,0 +1,55 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"cfstream/internal/api"
+)
+
+func TestFixture(t *testing.T) {
+	data, err := Fixture(EventReady, &api.Video{UID: "abc", Name: "Intro", Meta: map[string]interface{}{"team": "a"}})
+	require.NoError(t, err)
+
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &body))
+	assert.Equal(t, "abc", body["uid"])
+	assert.Equal(t, true, body["readyToStream"])
+	assert.Equal(t, map[string]interface{}{"name": "Intro", "team": "a"}, body["meta"])
+	assert.Equal(t, "ready", body["status"].(map[string]interface{})["state"])
+
+	data, err = Fixture(EventError, nil)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, &body))
+	assert.Len(t, body["uid"], 32)
+	assert.Equal(t, false, body["readyToStream"])
+	assert.Equal(t, "ERR_NON_VIDEO", body["status"].(map[string]interface{})["errorReasonCode"])
+
+	_, err = Fixture("deleted", nil)
+	assert.Error(t, err)
+}
+
+func TestSend(t *testing.T) {
+	payload := []byte(`{"uid":"abc"}`)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		result, err := Verify("secret", r.Header.Get(SignatureHeader), body)
+		assert.NoError(t, err)
+		assert.True(t, result.Valid)
+		w.Write([]byte("ok")) //nolint:errcheck // Test server
+	}))
+	defer srv.Close()
+
+	status, body, err := Send(context.Background(), srv.Client(), srv.URL, "secret", payload)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "ok", string(body))
+}
@@ -0,0 +1,122 @@
+package webhook
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// maxPayload bounds the size of an accepted notification body.
+const maxPayload = 1 << 20
+
+// ListenerOptions configures a Listener.
+type ListenerOptions struct {
+	// Forward, when set, is a URL that verified notifications are POSTed to
+	// unchanged, including the signature header. The sender gets the
+	// forward target's status code.
+	Forward string
+
+	// OnEvent is called with the body of each verified notification.
+	OnEvent func(payload []byte)
+
+	// OnReject is called when a request is rejected or forwarding fails.
+	OnReject func(err error)
+}
+
+// Listener is an http.Handler that receives Stream webhook notifications and
+// rejects those whose Webhook-Signature doesn't match the secret.
+type Listener struct {
+	secret string
+	opts   ListenerOptions
+	client *http.Client
+}
+
+// NewListener creates a Listener that verifies notifications with secret.
+func NewListener(secret string, opts ListenerOptions) *Listener {
+	return &Listener{secret: secret, opts: opts, client: &http.Client{Timeout: 30 * time.Second}}
+}
+
+// ServeHTTP implements http.Handler.
+func (l *Listener) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	payload, err := io.ReadAll(io.LimitReader(r.Body, maxPayload+1))
+	if err != nil {
+		l.reject(w, http.StatusBadRequest, fmt.Errorf("failed to read body: %w", err))
+		return
+	}
+	if len(payload) > maxPayload {
+		l.reject(w, http.StatusRequestEntityTooLarge, fmt.Errorf("body exceeds %d bytes", maxPayload))
+		return
+	}
+
+	header := r.Header.Get(SignatureHeader)
+	if header == "" {
+		l.reject(w, http.StatusUnauthorized, fmt.Errorf("missing %s header", SignatureHeader))
+		return
+	}
+	result, err := Verify(l.secret, header, payload)
+	if err != nil {
+		l.reject(w, http.StatusUnauthorized, err)
+		return
+	}
+	if !result.Valid {
+		l.reject(w, http.StatusUnauthorized, fmt.Errorf("signature does not match"))
+		return
+	}
+
+	if l.opts.OnEvent != nil {
+		l.opts.OnEvent(payload)
+	}
+
+	if l.opts.Forward == "" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	status, err := l.forward(r, payload, header)
+	if err != nil {
+		l.reject(w, http.StatusBadGateway, err)
+		return
+	}
+	w.WriteHeader(status)
+}
+
+// forward posts a verified notification to the forward URL and returns the
+// response status.
+func (l *Listener) forward(r *http.Request, payload []byte, signature string) (int, error) {
+	req, err := http.NewRequestWithContext(r.Context(), http.MethodPost, l.opts.Forward, bytes.NewReader(payload))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create forward request: %w", err)
+	}
+	req.Header.Set("Content-Type", r.Header.Get("Content-Type"))
+	req.Header.Set(SignatureHeader, signature)
+
+	resp, err := l.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to forward notification: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body) //nolint:errcheck // Draining lets the connection be reused
+
+	if resp.StatusCode >= 300 {
+		l.report(fmt.Errorf("forward target returned %s", resp.Status))
+	}
+	return resp.StatusCode, nil
+}
+
+func (l *Listener) reject(w http.ResponseWriter, status int, err error) {
+	l.report(err)
+	http.Error(w, err.Error(), status)
+}
+
+func (l *Listener) report(err error) {
+	if l.opts.OnReject != nil {
+		l.opts.OnReject(err)
+	}
+}
@@ -0,0 +1,78 @@
+package webhook
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// signature returns a Webhook-Signature header for payload signed now.
+func signature(secret, payload string) string {
+	now := time.Now()
+	return "time=" + strconv.FormatInt(now.Unix(), 10) + ",sig1=" + Sign(secret, now, []byte(payload))
+}
+
+func TestListener(t *testing.T) {
+	var events []string
+	var rejected []error
+	listener := NewListener("secret", ListenerOptions{
+		OnEvent:  func(payload []byte) { events = append(events, string(payload)) },
+		OnReject: func(err error) { rejected = append(rejected, err) },
+	})
+
+	payload := `{"uid":"abc","readyToStream":true}`
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(payload))
+	req.Header.Set(SignatureHeader, signature("secret", payload))
+	rec := httptest.NewRecorder()
+	listener.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, []string{payload}, events)
+
+	req = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(payload))
+	req.Header.Set(SignatureHeader, signature("wrong", payload))
+	rec = httptest.NewRecorder()
+	listener.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	req = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(payload))
+	rec = httptest.NewRecorder()
+	listener.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = httptest.NewRecorder()
+	listener.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+
+	assert.Len(t, events, 1)
+	assert.Len(t, rejected, 2)
+}
+
+func TestListener_Forward(t *testing.T) {
+	payload := `{"uid":"abc"}`
+	header := signature("secret", payload)
+
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, payload, string(body))
+		assert.Equal(t, header, r.Header.Get(SignatureHeader))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer target.Close()
+
+	listener := NewListener("secret", ListenerOptions{Forward: target.URL})
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(payload))
+	req.Header.Set(SignatureHeader, header)
+	rec := httptest.NewRecorder()
+	listener.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusAccepted, rec.Code)
+}
@@ -0,0 +1,109 @@
+// Package webhook verifies the signatures Cloudflare Stream attaches to
+// webhook notifications.
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SignatureHeader is the HTTP header carrying the signature.
+const SignatureHeader = "Webhook-Signature"
+
+// Header is a parsed Webhook-Signature header.
+type Header struct {
+	Time      time.Time
+	Signature string // Hex-encoded HMAC-SHA256 from the sig1 field
+}
+
+// Result is the outcome of verifying a webhook.
+type Result struct {
+	Valid    bool
+	Time     time.Time // Signing time from the header
+	Received string    // Signature from the header
+	Computed string    // Signature computed from the secret and payload
+	// TrimmedMatch is set when the signature only matches once trailing
+	// newlines are removed from the payload, a common artifact of saving
+	// the payload to a file.
+	TrimmedMatch bool
+}
+
+// ParseHeader parses a header of the form "time=1230811200,sig1=60493ec9...".
+// A leading "Webhook-Signature:" is accepted so the header can be pasted as is.
+func ParseHeader(value string) (*Header, error) {
+	value = strings.TrimSpace(value)
+	if name, rest, ok := strings.Cut(value, ":"); ok && strings.EqualFold(strings.TrimSpace(name), SignatureHeader) {
+		value = strings.TrimSpace(rest)
+	}
+
+	var h Header
+	var haveTime bool
+	for _, field := range strings.Split(value, ",") {
+		key, val, ok := strings.Cut(strings.TrimSpace(field), "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid signature header field %q", field)
+		}
+		switch key {
+		case "time":
+			secs, err := strconv.ParseInt(val, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid signature time %q", val)
+			}
+			h.Time = time.Unix(secs, 0)
+			haveTime = true
+		case "sig1":
+			h.Signature = strings.ToLower(val)
+		}
+	}
+
+	if !haveTime {
+		return nil, fmt.Errorf("signature header has no time field")
+	}
+	if h.Signature == "" {
+		return nil, fmt.Errorf("signature header has no sig1 field")
+	}
+	return &h, nil
+}
+
+// Sign returns the hex-encoded signature of payload sent at t: an
+// HMAC-SHA256 of "<unix time>.<payload>" keyed with the webhook secret.
+func Sign(secret string, t time.Time, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%d.", t.Unix())
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the signature header of a received webhook against its
+// payload. A mismatch is reported in the result; errors are returned only
+// for malformed headers.
+func Verify(secret, header string, payload []byte) (*Result, error) {
+	if secret == "" {
+		return nil, fmt.Errorf("webhook secret is required")
+	}
+	h, err := ParseHeader(header)
+	if err != nil {
+		return nil, err
+	}
+
+	computed := Sign(secret, h.Time, payload)
+	result := &Result{
+		Valid:    hmac.Equal([]byte(computed), []byte(h.Signature)),
+		Time:     h.Time,
+		Received: h.Signature,
+		Computed: computed,
+	}
+
+	if !result.Valid {
+		if trimmed := bytes.TrimRight(payload, "\r\n"); len(trimmed) < len(payload) {
+			result.TrimmedMatch = hmac.Equal([]byte(Sign(secret, h.Time, trimmed)), []byte(h.Signature))
+		}
+	}
+	return result, nil
+}
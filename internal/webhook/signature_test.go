@@ -0,0 +1,45 @@
+package webhook
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseHeader(t *testing.T) {
+	h, err := ParseHeader("Webhook-Signature: time=1230811200,sig1=ABCDEF")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1230811200), h.Time.Unix())
+	assert.Equal(t, "abcdef", h.Signature)
+
+	for _, value := range []string{"", "sig1=abc", "time=1230811200", "time=soon,sig1=abc", "garbage"} {
+		_, err := ParseHeader(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	payload := []byte(`{"uid":"abc","readyToStream":true}`)
+	sent := time.Unix(1230811200, 0)
+	header := "time=1230811200,sig1=" + Sign("secret", sent, payload)
+
+	result, err := Verify("secret", header, payload)
+	require.NoError(t, err)
+	assert.True(t, result.Valid)
+	assert.Equal(t, sent, result.Time)
+
+	result, err = Verify("wrong", header, payload)
+	require.NoError(t, err)
+	assert.False(t, result.Valid)
+	assert.False(t, result.TrimmedMatch)
+
+	result, err = Verify("secret", header, append(payload, '\n'))
+	require.NoError(t, err)
+	assert.False(t, result.Valid)
+	assert.True(t, result.TrimmedMatch)
+
+	_, err = Verify("", header, payload)
+	assert.Error(t, err)
+}